@@ -10,6 +10,14 @@
 //
 //	-data <path>  外部データディレクトリのパス（省略時は埋め込みデータを使用）
 //	-debug        デバッグモードを有効化（全コア・モジュール・チェイン効果を選択可能）
+//
+// サブコマンド:
+//
+//	simulate      合成タイピストで多数のバトルを実行し、勝率・撃破時間・被ダメージ・
+//	              モジュール使用回数を集計します（バランス調整用、TUIは起動しません）
+//
+//	例: BlitzTypingOperator simulate -agent all_rounder:5:physical_strike_lv1,heal_lv1 \
+//	        -enemy slime -level 5 -wpm 60 -accuracy 0.95 -reaction 500ms -battles 1000 -json
 package main
 
 import (
@@ -24,6 +32,12 @@ import (
 )
 
 func main() {
+	// バランス調整用のシミュレーション（TUIを起動しない）
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		exitOnSimulateError(runSimulate(os.Args[2:], os.Stdout))
+		return
+	}
+
 	// コマンドライン引数を解析
 	dataDir := flag.String("data", "", "外部データディレクトリのパス（省略時は埋め込みデータを使用）")
 	debugMode := flag.Bool("debug", false, "デバッグモードを有効化（全コア・モジュール・チェイン効果を選択可能）")
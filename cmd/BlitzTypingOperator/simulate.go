@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"hirorocky/type-battle/internal/app"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/infra/masterdata"
+	"hirorocky/type-battle/internal/usecase/simulation"
+)
+
+// agentFlags は繰り返し指定できる -agent 引数です。
+type agentFlags []string
+
+// String はflag.Valueインターフェースの実装です。
+func (f *agentFlags) String() string {
+	return strings.Join(*f, " ")
+}
+
+// Set はflag.Valueインターフェースの実装です。
+func (f *agentFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runSimulate は simulate サブコマンドを実行します。
+// 合成タイピストで指定の編成・敵と多数のバトルを行い、勝率などを集計して出力します。
+func runSimulate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	var agents agentFlags
+	dataDir := fs.String("data", "", "外部データディレクトリのパス（省略時は埋め込みデータを使用）")
+	fs.Var(&agents, "agent", "エージェント編成 <コアタイプID>:<コアレベル>:<モジュールID>,<モジュールID>,...（最大3回指定可能）")
+	enemyID := fs.String("enemy", "", "敵タイプID")
+	level := fs.Int("level", 1, "敵レベル")
+	wpm := fs.Float64("wpm", 60, "タイピストの平均WPM")
+	accuracy := fs.Float64("accuracy", 0.95, "タイピストの1打ごとの正確性（0.0〜1.0）")
+	reaction := fs.Duration("reaction", 500*time.Millisecond, "タイピング終了から次のモジュール選択までの反応時間")
+	battles := fs.Int("battles", 1000, "実行するバトル数")
+	seed := fs.Int64("seed", 1, "乱数シード")
+	asJSON := fs.Bool("json", false, "結果をJSONで出力")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(agents) == 0 {
+		return fmt.Errorf("-agent を1つ以上指定してください")
+	}
+	if len(agents) > 3 {
+		return fmt.Errorf("-agent は最大3つまでです（指定: %d）", len(agents))
+	}
+	if *enemyID == "" {
+		return fmt.Errorf("-enemy を指定してください")
+	}
+
+	// マスタデータをロード
+	var dataLoader *masterdata.DataLoader
+	if *dataDir != "" {
+		dataLoader = masterdata.NewDataLoader(*dataDir)
+	} else {
+		dataLoader = masterdata.NewEmbeddedDataLoader(masterdata.EmbeddedData, "data")
+	}
+	externalData, err := dataLoader.LoadAllExternalData()
+	if err != nil {
+		return fmt.Errorf("マスタデータの読み込みに失敗しました: %w", err)
+	}
+
+	enemyTypes, _, _ := app.ConvertExternalDataToDomain(externalData)
+	app.ResolveEnemyTypeActions(enemyTypes, app.ConvertEnemyActions(externalData.EnemyActions))
+	var enemyType *domain.EnemyType
+	for i := range enemyTypes {
+		if enemyTypes[i].ID == *enemyID {
+			enemyType = &enemyTypes[i]
+			break
+		}
+	}
+	if enemyType == nil {
+		return fmt.Errorf("敵タイプが見つかりません: %s", *enemyID)
+	}
+
+	passiveSkills := app.ConvertPassiveSkills(externalData.PassiveSkills)
+	equipped := make([]*domain.AgentModel, 0, len(agents))
+	for i, spec := range agents {
+		agent, err := parseAgent(fmt.Sprintf("sim_agent_%d", i+1), spec, externalData, passiveSkills)
+		if err != nil {
+			return err
+		}
+		equipped = append(equipped, agent)
+	}
+
+	cfg := simulation.Config{
+		EnemyType:  *enemyType,
+		EnemyLevel: *level,
+		Agents:     equipped,
+		Typist: simulation.TypistProfile{
+			MeanWPM:       *wpm,
+			Accuracy:      *accuracy,
+			ReactionDelay: *reaction,
+		},
+		Battles:       *battles,
+		Seed:          *seed,
+		PassiveSkills: passiveSkills,
+	}
+	if externalData.TypingDictionary != nil {
+		cfg.Dictionary = app.ConvertTypingDictionary(externalData.TypingDictionary)
+	}
+
+	report, err := simulation.Run(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printReport(stdout, report)
+	return nil
+}
+
+// parseAgent は <コアタイプID>:<コアレベル>:<モジュールID>,... 形式の指定からエージェントを作成します。
+func parseAgent(id, spec string, externalData *masterdata.ExternalData, passiveSkills map[string]domain.PassiveSkill) (*domain.AgentModel, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("エージェント指定の形式が不正です: %s（<コアタイプID>:<コアレベル>:<モジュールID>,...）", spec)
+	}
+
+	coreLevel, err := strconv.Atoi(parts[1])
+	if err != nil || coreLevel < 1 {
+		return nil, fmt.Errorf("コアレベルが不正です: %s", parts[1])
+	}
+
+	var core *domain.CoreModel
+	for _, ct := range externalData.CoreTypes {
+		if ct.ID == parts[0] {
+			coreType := ct.ToDomain()
+			core = domain.NewCoreWithTypeID(ct.ID, coreLevel, coreType, passiveSkills[coreType.PassiveSkillID])
+			break
+		}
+	}
+	if core == nil {
+		return nil, fmt.Errorf("コアタイプが見つかりません: %s", parts[0])
+	}
+
+	moduleIDs := strings.Split(parts[2], ",")
+	if len(moduleIDs) < domain.MinModuleSlotCount || len(moduleIDs) > domain.MaxModuleSlotCount {
+		return nil, fmt.Errorf("モジュールは%d〜%d個必要です（現在: %d個）", domain.MinModuleSlotCount, domain.MaxModuleSlotCount, len(moduleIDs))
+	}
+	modules := make([]*domain.ModuleModel, 0, len(moduleIDs))
+	for _, moduleID := range moduleIDs {
+		var module *domain.ModuleModel
+		for i := range externalData.ModuleDefinitions {
+			if externalData.ModuleDefinitions[i].ID == moduleID {
+				module = externalData.ModuleDefinitions[i].ToDomain()
+				break
+			}
+		}
+		if module == nil {
+			return nil, fmt.Errorf("モジュールが見つかりません: %s", moduleID)
+		}
+		modules = append(modules, module)
+	}
+
+	return domain.NewAgent(id, core, modules), nil
+}
+
+// printReport はシミュレーション結果を表形式で出力します。
+func printReport(w io.Writer, report *simulation.Report) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "バトル数\t%d\n", report.Battles)
+	fmt.Fprintf(tw, "勝利数\t%d\n", report.Wins)
+	fmt.Fprintf(tw, "敗北数\t%d（うち時間切れ %d）\n", report.Battles-report.Wins, report.TimedOut)
+	fmt.Fprintf(tw, "勝率\t%.1f%%\n", report.WinRate*100)
+	fmt.Fprintf(tw, "撃破時間（秒）\t平均 %.1f / 中央値 %.1f / p90 %.1f / 最大 %.1f\n",
+		report.TimeToKill.Mean, report.TimeToKill.Median, report.TimeToKill.P90, report.TimeToKill.Max)
+	fmt.Fprintf(tw, "被ダメージ\t平均 %.1f / 中央値 %.0f / p90 %.0f / 最大 %.0f\n",
+		report.DamageTaken.Mean, report.DamageTaken.Median, report.DamageTaken.P90, report.DamageTaken.Max)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "エージェント\tモジュール\t名前\t使用回数\t1戦あたり")
+	for _, usage := range report.ModuleUsage {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%.2f\n", usage.AgentIndex+1, usage.ModuleID, usage.Name, usage.Uses, usage.PerBattle)
+	}
+	tw.Flush()
+}
+
+// exitOnSimulateError は simulate サブコマンドのエラーを表示して終了します。
+func exitOnSimulateError(err error) {
+	if err == nil {
+		return
+	}
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	fmt.Fprintf(os.Stderr, "simulate: %v\n", err)
+	os.Exit(2)
+}
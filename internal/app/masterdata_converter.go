@@ -6,6 +6,7 @@ import (
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/infra/masterdata"
 	"hirorocky/type-battle/internal/usecase/rewarding"
+	"hirorocky/type-battle/internal/usecase/typing"
 )
 
 // ConvertEnemyTypes はmasterdata.EnemyTypeDataのスライスをdomain.EnemyTypeのスライスに変換します。
@@ -49,6 +50,20 @@ func ConvertEnemyTypesWithPassives(
 				result[i].EnhancedPassive = passive
 			}
 		}
+		for j := range result[i].Phases {
+			if passive, ok := passiveMap[result[i].Phases[j].PassiveID]; ok {
+				result[i].Phases[j].Passive = passive
+			}
+		}
+	}
+	return result
+}
+
+// ConvertEncounters はmasterdata.EncounterDataのスライスをdomain.Encounterのスライスに変換します。
+func ConvertEncounters(encounters []masterdata.EncounterData) []domain.Encounter {
+	result := make([]domain.Encounter, len(encounters))
+	for i, e := range encounters {
+		result[i] = e.ToDomain()
 	}
 	return result
 }
@@ -62,7 +77,8 @@ func ConvertEnemyActions(actions []masterdata.EnemyActionData) []domain.EnemyAct
 	return result
 }
 
-// ResolveEnemyTypeActions は敵タイプの行動パターンIDを実際のEnemyActionに解決します。
+// ResolveEnemyTypeActions は敵タイプの行動パターンID・行動ルールの行動ID・フェーズの行動ID・激昂時行動IDを
+// 実際のEnemyActionに解決します。解決できない行動IDの行動ルールは除外されます。
 func ResolveEnemyTypeActions(enemyTypes []domain.EnemyType, actions []domain.EnemyAction) {
 	actionMap := make(map[string]domain.EnemyAction)
 	for _, action := range actions {
@@ -85,6 +101,35 @@ func ResolveEnemyTypeActions(enemyTypes []domain.EnemyType, actions []domain.Ene
 		}
 
 		enemyTypes[i].SetResolvedActions(normalActions, enhancedActions)
+
+		var rules []domain.EnemyActionRule
+		for _, rule := range enemyTypes[i].ActionRules {
+			if action, ok := actionMap[rule.ActionID]; ok {
+				rule.Action = action
+				rules = append(rules, rule)
+			}
+		}
+		enemyTypes[i].ActionRules = rules
+
+		for j := range enemyTypes[i].Phases {
+			phase := &enemyTypes[i].Phases[j]
+			phase.ResolvedActions = nil
+			for _, id := range phase.ActionPatternIDs {
+				if action, ok := actionMap[id]; ok {
+					phase.ResolvedActions = append(phase.ResolvedActions, action)
+				}
+			}
+			if action, ok := actionMap[phase.TransitionActionID]; ok {
+				phase.TransitionAction = &action
+			}
+		}
+
+		for j := range enemyTypes[i].VoltageThresholds {
+			threshold := &enemyTypes[i].VoltageThresholds[j]
+			if action, ok := actionMap[threshold.EnrageActionID]; ok {
+				threshold.EnrageAction = &action
+			}
+		}
 	}
 }
 
@@ -142,13 +187,14 @@ func ConvertChainEffects(effects []masterdata.ChainEffectData) []rewarding.Chain
 	result := make([]rewarding.ChainEffectDefinition, len(effects))
 	for i, e := range effects {
 		result[i] = rewarding.ChainEffectDefinition{
-			ID:           e.ID,
-			Name:         e.Name,
-			Category:     e.Category,
-			EffectType:   e.ToDomainEffectType(),
-			MinValue:     e.MinValue,
-			MaxValue:     e.MaxValue,
-			MinDropLevel: e.MinDropLevel,
+			ID:             e.ID,
+			Name:           e.Name,
+			Category:       e.Category,
+			EffectType:     e.ToDomainEffectType(),
+			MinValue:       e.MinValue,
+			MaxValue:       e.MaxValue,
+			MinDropLevel:   e.MinDropLevel,
+			MinChainLength: e.MinChainLength,
 		}
 	}
 	return result
@@ -163,3 +209,30 @@ func ConvertPassiveSkills(skills []masterdata.PassiveSkillData) map[string]domai
 	}
 	return result
 }
+
+// ConvertTypingDictionary はmasterdata.TypingDictionaryをtyping.Dictionaryに変換します。
+func ConvertTypingDictionary(dict *masterdata.TypingDictionary) *typing.Dictionary {
+	result := &typing.Dictionary{
+		Easy:   dict.Easy,
+		Medium: dict.Medium,
+		Hard:   dict.Hard,
+		Parry:  dict.Parry,
+	}
+	if dict.Japanese != nil {
+		result.Japanese = &typing.JapaneseDictionary{
+			Easy:   convertJapaneseWords(dict.Japanese.Easy),
+			Medium: convertJapaneseWords(dict.Japanese.Medium),
+			Hard:   convertJapaneseWords(dict.Japanese.Hard),
+		}
+	}
+	return result
+}
+
+// convertJapaneseWords はmasterdata.JapaneseWordDataのスライスをtyping.JapaneseWordのスライスに変換します。
+func convertJapaneseWords(words []masterdata.JapaneseWordData) []typing.JapaneseWord {
+	result := make([]typing.JapaneseWord, len(words))
+	for i, w := range words {
+		result[i] = typing.JapaneseWord{Text: w.Text, Reading: w.Reading}
+	}
+	return result
+}
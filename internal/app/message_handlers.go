@@ -61,8 +61,16 @@ func (mh *MessageHandlers) Handle(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return mh.handleBattleTickMsg(msg)
 	case screens.BattleResultMsg:
 		return mh.handleBattleResultMsg(msg)
+	case screens.TowerAdvanceMsg:
+		return mh.handleTowerAdvanceMsg(msg)
 	case screens.SaveRequestMsg:
 		return mh.handleSaveRequestMsg(msg)
+	case screens.TypingModeChangedMsg:
+		return mh.handleTypingModeChangedMsg(msg)
+	case screens.AdaptiveWordsChangedMsg:
+		return mh.handleAdaptiveWordsChangedMsg(msg)
+	case screens.BattleLogExportMsg:
+		return mh.handleBattleLogExportMsg(msg)
 	}
 	return mh.model, nil
 }
@@ -87,7 +95,8 @@ func (mh *MessageHandlers) handleKeyMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return mh.model, tea.Quit
 	case "esc":
 		// ホーム画面以外ならホームに戻る
-		if mh.model.currentScene != SceneHome {
+		// バトル中のEscはタイピングのキャンセルと撤退の確認に使うため、バトル画面に転送する
+		if mh.model.currentScene != SceneHome && mh.model.currentScene != SceneBattle {
 			mh.model.homeScreen.RefreshMenuState()
 			mh.model.currentScene = SceneHome
 			return mh.model, nil
@@ -130,7 +139,7 @@ func (mh *MessageHandlers) handleSceneChangeMsg(msg tea.Msg) (tea.Model, tea.Cmd
 // handleStartBattleMsg はバトル開始メッセージを処理します。
 func (mh *MessageHandlers) handleStartBattleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	startMsg := msg.(screens.StartBattleMsg)
-	cmd := mh.model.startBattle(startMsg.Level, startMsg.EnemyTypeID)
+	cmd := mh.model.startBattle(startMsg.Level, startMsg.EnemyTypeID, startMsg.EncounterID)
 	return mh.model, cmd
 }
 
@@ -140,6 +149,10 @@ func (mh *MessageHandlers) handleBattleTickMsg(msg tea.Msg) (tea.Model, tea.Cmd)
 		_, cmd := mh.model.battleScreen.Update(msg)
 		return mh.model, cmd
 	}
+	if mh.model.currentScene == SceneReplay && mh.model.replayScreen != nil {
+		_, cmd := mh.model.replayScreen.Update(msg)
+		return mh.model, cmd
+	}
 	return mh.model, nil
 }
 
@@ -150,12 +163,39 @@ func (mh *MessageHandlers) handleBattleResultMsg(msg tea.Msg) (tea.Model, tea.Cm
 	return mh.model, nil
 }
 
+// handleTowerAdvanceMsg はエンドレスタワーの階へ進むメッセージを処理します。
+func (mh *MessageHandlers) handleTowerAdvanceMsg(_ tea.Msg) (tea.Model, tea.Cmd) {
+	cmd := mh.model.advanceTower()
+	return mh.model, cmd
+}
+
 // handleSaveRequestMsg はセーブ要求メッセージを処理します。
 func (mh *MessageHandlers) handleSaveRequestMsg(_ tea.Msg) (tea.Model, tea.Cmd) {
 	mh.model.handleSaveRequest()
 	return mh.model, nil
 }
 
+// handleTypingModeChangedMsg はタイピング入力モード変更メッセージを処理します。
+func (mh *MessageHandlers) handleTypingModeChangedMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	modeMsg := msg.(screens.TypingModeChangedMsg)
+	mh.model.handleTypingModeChanged(modeMsg.Mode)
+	return mh.model, nil
+}
+
+// handleAdaptiveWordsChangedMsg は苦手キー優先出題の切り替えメッセージを処理します。
+func (mh *MessageHandlers) handleAdaptiveWordsChangedMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	adaptiveMsg := msg.(screens.AdaptiveWordsChangedMsg)
+	mh.model.handleAdaptiveWordsChanged(adaptiveMsg.Enabled)
+	return mh.model, nil
+}
+
+// handleBattleLogExportMsg はバトルログの書き出し要求を処理し、結果を現在の画面に通知します。
+func (mh *MessageHandlers) handleBattleLogExportMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	exportMsg := msg.(screens.BattleLogExportMsg)
+	path, err := mh.model.exportBattleLog(exportMsg.Events)
+	return mh.forwardToCurrentScene(screens.BattleLogExportedMsg{Path: path, Err: err})
+}
+
 // handleBattleMsg はバトル関連のメッセージを統合処理します。
 func (mh *MessageHandlers) handleBattleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m := msg.(type) {
@@ -165,6 +205,8 @@ func (mh *MessageHandlers) handleBattleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return mh.handleBattleTickMsg(m)
 	case screens.BattleResultMsg:
 		return mh.handleBattleResultMsg(m)
+	case screens.TowerAdvanceMsg:
+		return mh.handleTowerAdvanceMsg(m)
 	}
 	return mh.model, nil
 }
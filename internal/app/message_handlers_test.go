@@ -6,6 +6,7 @@ import (
 
 	"hirorocky/type-battle/internal/infra/masterdata"
 	"hirorocky/type-battle/internal/tui/screens"
+	"hirorocky/type-battle/internal/usecase/combat"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -65,6 +66,18 @@ func TestMessageHandlers_HandleScreensChangeSceneMsg(t *testing.T) {
 	}
 }
 
+// TestMessageHandlers_HandleTypingModeChangedMsg はタイピング入力モード変更が設定に反映されることを検証します
+func TestMessageHandlers_HandleTypingModeChangedMsg(t *testing.T) {
+	model := NewRootModel("", masterdata.EmbeddedData, false)
+	handlers := NewMessageHandlers(model)
+
+	_, _ = handlers.Handle(screens.TypingModeChangedMsg{Mode: "romaji"})
+
+	if model.gameState.Settings().TypingMode() != "romaji" {
+		t.Errorf("TypingMode should be romaji, got %s", model.gameState.Settings().TypingMode())
+	}
+}
+
 // TestMessageHandlers_HandleCtrlC はCtrl+Cでtea.Quitが返されることを検証します
 func TestMessageHandlers_HandleCtrlC(t *testing.T) {
 	model := NewRootModel("", masterdata.EmbeddedData, false)
@@ -246,3 +259,103 @@ func TestScreenMap_MapCount(t *testing.T) {
 		t.Errorf("MapCount should be at least 6, got %d", count)
 	}
 }
+
+// TestMessageHandlers_TowerFlow はエンドレスタワーの開始・戦闘・勝利後の階の進行を検証します
+func TestMessageHandlers_TowerFlow(t *testing.T) {
+	// オートセーブが実際のホームディレクトリに書き込まれないようにする
+	t.Setenv("HOME", t.TempDir())
+	model := NewRootModel("", masterdata.EmbeddedData, false)
+	handlers := NewMessageHandlers(model)
+
+	_, _ = handlers.Handle(screens.ChangeSceneMsg{Scene: "tower"})
+	if model.CurrentScene() != SceneTower || model.towerRun == nil {
+		t.Fatalf("エンドレスタワーが開始されていない: %v", model.CurrentScene())
+	}
+	if model.gameState.TowerBestFloor() != 1 {
+		t.Errorf("開始時の最高到達階: 期待 1, 実際 %d", model.gameState.TowerBestFloor())
+	}
+
+	// 1階は戦闘階のためバトルが開始される
+	_, _ = handlers.Handle(screens.TowerAdvanceMsg{})
+	if model.CurrentScene() != SceneBattle || !model.towerBattle {
+		t.Fatalf("タワーの階のバトルが開始されていない: %v", model.CurrentScene())
+	}
+
+	// 勝利時のHPが次の階へ持ち越される
+	carried := model.gameState.Player().MaxHP / 2
+	model.gameState.Player().HP = carried
+	model.handleBattleResult(screens.BattleResultMsg{Victory: true, Level: model.towerRun.Floor().Level})
+
+	if model.CurrentScene() != SceneTower || model.towerBattle {
+		t.Fatalf("勝利後にタワー画面へ戻っていない: %v", model.CurrentScene())
+	}
+	if model.towerRun.Floor().Number != 2 || model.towerRun.PlayerHP() != carried {
+		t.Errorf("階の進行とHPの持ち越し: 期待 2階/%d, 実際 %d階/%d", carried, model.towerRun.Floor().Number, model.towerRun.PlayerHP())
+	}
+	if model.gameState.TowerBestFloor() != 2 {
+		t.Errorf("最高到達階: 期待 2, 実際 %d", model.gameState.TowerBestFloor())
+	}
+	if model.gameState.MaxLevelReached != 0 {
+		t.Errorf("タワーの勝利で最高到達レベルが更新された: %d", model.gameState.MaxLevelReached)
+	}
+}
+
+// TestMessageHandlers_BattleRetreat は撤退したバトルが敗北とは別に記録され、タイピング統計が残ることを検証します
+func TestMessageHandlers_BattleRetreat(t *testing.T) {
+	// オートセーブが実際のホームディレクトリに書き込まれないようにする
+	t.Setenv("HOME", t.TempDir())
+	model := NewRootModel("", masterdata.EmbeddedData, false)
+	handlers := NewMessageHandlers(model)
+
+	stats := &combat.BattleStatistics{TotalWPM: 120, TotalAccuracy: 190, TotalTypingCount: 2, TotalDamageDealt: 30}
+	_, _ = handlers.Handle(screens.BattleResultMsg{Retreated: true, Level: 3, Stats: stats, EnemyID: "slime"})
+
+	if model.CurrentScene() != SceneHome {
+		t.Errorf("撤退後にホームへ戻っていない: %v", model.CurrentScene())
+	}
+	battle := model.gameState.Statistics().Battle()
+	if battle.Retreats != 1 || battle.Losses != 0 || battle.Wins != 0 {
+		t.Errorf("撤退・敗北・勝利数: 期待 1/0/0, 実際 %d/%d/%d", battle.Retreats, battle.Losses, battle.Wins)
+	}
+	if battle.TotalDamageDealt != 30 {
+		t.Errorf("撤退までの与ダメージ: 期待 30, 実際 %d", battle.TotalDamageDealt)
+	}
+	if typing := model.gameState.Statistics().Typing(); typing.TotalSessions != 1 || typing.MaxWPM != 60 {
+		t.Errorf("撤退までのタイピング統計: 実際 %+v", *typing)
+	}
+}
+
+// TestMessageHandlers_EscInBattleOpensRetreatDialog はバトル中のEscでホームに戻らず撤退の確認ダイアログが開くことを検証します
+func TestMessageHandlers_EscInBattleOpensRetreatDialog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	model := NewRootModel("", masterdata.EmbeddedData, false)
+	handlers := NewMessageHandlers(model)
+
+	_, _ = handlers.Handle(screens.ChangeSceneMsg{Scene: "tower"})
+	_, _ = handlers.Handle(screens.TowerAdvanceMsg{})
+	if model.CurrentScene() != SceneBattle || model.battleScreen == nil {
+		t.Fatalf("バトルが開始されていない: %v", model.CurrentScene())
+	}
+
+	_, _ = handlers.Handle(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.CurrentScene() != SceneBattle {
+		t.Fatalf("バトル中のEscでホームに戻ってしまった: %v", model.CurrentScene())
+	}
+	if !model.battleScreen.IsConfirmingRetreat() {
+		t.Fatal("バトル中のEscで撤退の確認ダイアログが開くべき")
+	}
+
+	// 撤退を確定するとタワーの挑戦が終了し、撤退として記録される
+	_, _ = handlers.Handle(tea.KeyMsg{Type: tea.KeyLeft})
+	_, cmd := handlers.Handle(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("撤退の確定でバトル結果が返されていない")
+	}
+	_, _ = handlers.Handle(cmd())
+	if model.CurrentScene() != SceneTower || !model.towerRun.IsOver() {
+		t.Errorf("撤退後にタワーの挑戦が終了していない: %v", model.CurrentScene())
+	}
+	if battle := model.gameState.Statistics().Battle(); battle.Retreats != 1 || battle.Losses != 0 {
+		t.Errorf("撤退・敗北数: 期待 1/0, 実際 %d/%d", battle.Retreats, battle.Losses)
+	}
+}
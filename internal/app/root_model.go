@@ -3,10 +3,13 @@
 package app
 
 import (
+	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/infra/masterdata"
@@ -16,6 +19,8 @@ import (
 	"hirorocky/type-battle/internal/tui/presenter"
 	"hirorocky/type-battle/internal/tui/screens"
 	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/climbing"
+	"hirorocky/type-battle/internal/usecase/combat"
 	"hirorocky/type-battle/internal/usecase/rewarding"
 	gamestate "hirorocky/type-battle/internal/usecase/session"
 	"hirorocky/type-battle/internal/usecase/typing"
@@ -54,6 +59,12 @@ type RootModel struct {
 	// saveDataIO はセーブデータの読み書きを担当します
 	saveDataIO *savedata.SaveDataIO
 
+	// replayIO はバトルリプレイの読み書きを担当します
+	replayIO *savedata.ReplayIO
+
+	// battleLogIO はバトルログの書き出しを担当します
+	battleLogIO *savedata.BattleLogIO
+
 	// statusMessage はステータスメッセージ（セーブ/ロード結果など）です
 	statusMessage string
 
@@ -78,10 +89,21 @@ type RootModel struct {
 	statsAchievementsScreen *screens.StatsAchievementsScreen
 	settingsScreen          *screens.SettingsScreen
 	rewardScreen            *screens.RewardScreen
+	replayScreen            *screens.ReplayScreen
+	towerScreen             *screens.TowerScreen
+
+	// towerRun は挑戦中のエンドレスタワーです（nilの場合は挑戦していない）
+	towerRun *climbing.TowerRun
+
+	// towerBattle は現在のバトルがエンドレスタワーの階のバトルかどうかです
+	towerBattle bool
 
 	// パッシブスキル定義（バトル開始時に BattleEngine へ渡す）
 	passiveSkills map[string]domain.PassiveSkill
 
+	// マスタデータ（リプレイ復元時に敵タイプ・エージェントを再構築するために使用）
+	domainSources *gamestate.DomainDataSources
+
 	// タイピング辞書（words.jsonからロード）
 	typingDictionary *typing.Dictionary
 
@@ -111,6 +133,8 @@ func NewRootModel(dataDir string, embeddedFS fs.FS, debugMode bool) *RootModel {
 	homeDir, _ := os.UserHomeDir()
 	saveDir := filepath.Join(homeDir, ".BlitzTypingOperator")
 	saveDataIO := savedata.NewSaveDataIO(saveDir, debugMode)
+	replayIO := savedata.NewReplayIO(saveDir)
+	battleLogIO := savedata.NewBattleLogIO(saveDir)
 
 	// 外部データをロード
 	var dataLoader *masterdata.DataLoader
@@ -141,16 +165,13 @@ func NewRootModel(dataDir string, embeddedFS fs.FS, debugMode bool) *RootModel {
 			CoreTypes:              coreTypes,
 			ModuleTypes:            moduleTypes,
 			EnemyTypes:             enemyTypes,
+			Encounters:             ConvertEncounters(externalData.Encounters),
 			PassiveSkills:          passiveSkills,
 			ChainEffectDefinitions: chainEffectDefs,
 		}
 		// タイピング辞書を変換
 		if externalData.TypingDictionary != nil {
-			typingDict = &typing.Dictionary{
-				Easy:   externalData.TypingDictionary.Easy,
-				Medium: externalData.TypingDictionary.Medium,
-				Hard:   externalData.TypingDictionary.Hard,
-			}
+			typingDict = ConvertTypingDictionary(externalData.TypingDictionary)
 		}
 	}
 
@@ -180,7 +201,7 @@ func NewRootModel(dataDir string, embeddedFS fs.FS, debugMode bool) *RootModel {
 
 	// 外部データで敵生成器と報酬計算器を更新（ドメイン型を使用）
 	if domainSources != nil {
-		gs.UpdateEnemyGenerator(domainSources.EnemyTypes)
+		gs.UpdateEnemyGenerator(domainSources.EnemyTypes, domainSources.Encounters)
 		gs.UpdateRewardCalculator(domainSources.CoreTypes, domainSources.ModuleTypes, domainSources.PassiveSkills)
 
 		// チェイン効果プールを設定（UpdateRewardCalculatorで新しいRewardCalculatorが作成されるため再設定が必要）
@@ -252,6 +273,8 @@ func NewRootModel(dataDir string, embeddedFS fs.FS, debugMode bool) *RootModel {
 		gameState:               gs,
 		styles:                  styles.NewGameStyles(),
 		saveDataIO:              saveDataIO,
+		replayIO:                replayIO,
+		battleLogIO:             battleLogIO,
 		statusMessage:           statusMessage,
 		sceneRouter:             NewSceneRouter(),
 		screenFactory:           screenFactory,
@@ -262,6 +285,7 @@ func NewRootModel(dataDir string, embeddedFS fs.FS, debugMode bool) *RootModel {
 		statsAchievementsScreen: statsAchievementsScreen,
 		settingsScreen:          settingsScreen,
 		passiveSkills:           passiveSkills,
+		domainSources:           domainSources,
 		typingDictionary:        typingDict,
 		debugMode:               debugMode,
 		invProvider:             invProvider,
@@ -313,6 +337,7 @@ func (m *RootModel) handleBattleResult(result screens.BattleResultMsg) {
 		stats.RecordDamageDealt(result.Stats.TotalDamageDealt)
 		stats.RecordDamageTaken(result.Stats.TotalDamageTaken)
 		stats.RecordHealing(result.Stats.TotalHealAmount)
+		m.gameState.RecordCriticalHits(result.Stats.CriticalHits)
 
 		// タイピング統計を記録（平均値を計算）
 		if result.Stats.TotalTypingCount > 0 {
@@ -322,10 +347,37 @@ func (m *RootModel) handleBattleResult(result screens.BattleResultMsg) {
 		}
 	}
 
-	// 敵図鑑を更新
+	// 敵図鑑を更新（複数敵バトルでは出現した全ての敵タイプ）
+	encounteredIDs := make([]string, 0, len(result.EncounteredEnemies))
+	for enemyID := range result.EncounteredEnemies {
+		encounteredIDs = append(encounteredIDs, enemyID)
+	}
+	sort.Strings(encounteredIDs)
 	m.gameState.AddEncounteredEnemy(result.EnemyID)
+	for _, enemyID := range encounteredIDs {
+		m.gameState.AddEncounteredEnemy(enemyID)
+	}
+	for enemyID, elements := range result.DiscoveredWeaknesses {
+		m.gameState.RecordDiscoveredWeaknesses(enemyID, elements)
+	}
 
-	if result.Victory {
+	// リプレイを保存（勝敗に関わらず記録）
+	m.saveReplay(result.Replay)
+
+	// エンドレスタワーの階のバトルは報酬画面を経由せずタワー画面に戻る
+	if m.towerBattle {
+		m.handleTowerBattleResult(result)
+		m.performAutoSave()
+		m.battleScreen = nil
+		return
+	}
+
+	if result.Retreated {
+		// 撤退時：敗北とは別に記録してホームに戻る（報酬なし）
+		m.gameState.RecordBattleRetreat()
+		m.homeScreen.SetMaxLevelReached(m.gameState.MaxLevelReached)
+		m.currentScene = SceneHome
+	} else if result.Victory {
 		// 敵のデフォルトレベルを取得
 		defaultLevel := 1
 		if result.EnemyType != nil && result.EnemyType.DefaultLevel > 0 {
@@ -336,7 +388,10 @@ func (m *RootModel) handleBattleResult(result screens.BattleResultMsg) {
 		m.gameState.RecordBattleVictory(result.Level, defaultLevel)
 
 		// 撃破済み敵情報を記録（敵選択UIで使用）
-		m.gameState.RecordEnemyDefeat(result.EnemyID, result.Level)
+		m.gameState.RecordEnemyDefeat(result.EnemyID, result.EnemyLevel)
+		for _, enemyID := range encounteredIDs {
+			m.gameState.RecordEnemyDefeat(enemyID, result.EncounteredEnemies[enemyID])
+		}
 
 		// ノーダメージ判定付きで実績チェック
 		noDamage := result.Stats != nil && result.Stats.TotalDamageTaken == 0
@@ -350,6 +405,8 @@ func (m *RootModel) handleBattleResult(result screens.BattleResultMsg) {
 			TotalDamageDealt: result.Stats.TotalDamageDealt,
 			TotalDamageTaken: result.Stats.TotalDamageTaken,
 			TotalHealAmount:  result.Stats.TotalHealAmount,
+			CriticalHits:     result.Stats.CriticalHits,
+			PeakVoltage:      result.Stats.PeakVoltage,
 		}
 
 		// 確定報酬を計算（敵タイプのドロップ設定に基づく）
@@ -376,12 +433,112 @@ func (m *RootModel) handleBattleResult(result screens.BattleResultMsg) {
 		m.currentScene = SceneHome
 	}
 
-	// オートセーブ（勝敗に関わらず実行）
+	// オートセーブ（勝敗・撤退に関わらず実行）
 	m.performAutoSave()
 
 	m.battleScreen = nil
 }
 
+// handleTowerBattleResult はエンドレスタワーの階のバトル結果を処理します。
+// タワーの階は敵選択画面のレベル解放に影響させないため、最高レベルと撃破記録は更新しません。
+func (m *RootModel) handleTowerBattleResult(result screens.BattleResultMsg) {
+	m.towerBattle = false
+	floor := m.towerRun.Floor().Number
+
+	if result.Retreated {
+		m.gameState.RecordBattleRetreat()
+		m.towerRun.RecordRetreat()
+		m.towerScreen.SetMilestoneReward(nil)
+		m.towerScreen.SetMessage(fmt.Sprintf("第%d階から撤退  最高到達: %d階", floor, m.gameState.TowerBestFloor()))
+	} else if result.Victory {
+		m.gameState.RecordBattleVictory(result.Level, 0)
+		noDamage := result.Stats != nil && result.Stats.TotalDamageTaken == 0
+		m.gameState.CheckBattleAchievementsWithNoDamage(noDamage)
+
+		// バトル終了時のHPを次の階へ持ち越す
+		milestone := m.towerRun.RecordVictory(m.gameState.Player().HP)
+		m.towerScreen.SetMessage(fmt.Sprintf("第%d階を突破！", floor))
+		m.completeTowerFloor(floor, milestone)
+	} else {
+		m.gameState.RecordBattleDefeat(result.Level)
+		m.towerRun.RecordDefeat()
+		m.towerScreen.SetMilestoneReward(nil)
+		m.towerScreen.SetMessage(fmt.Sprintf("挑戦終了  最高到達: %d階", m.gameState.TowerBestFloor()))
+	}
+
+	m.currentScene = SceneTower
+}
+
+// advanceTower はエンドレスタワーの現在の階へ進みます。
+// 休憩階ではHPを部分回復して次の階へ進み、戦闘階では持ち越しHPでバトルを開始します。
+func (m *RootModel) advanceTower() tea.Cmd {
+	if m.towerRun == nil || m.towerRun.IsOver() {
+		return nil
+	}
+
+	floor := m.towerRun.Floor()
+	if floor.Rest {
+		healed, milestone := m.towerRun.Rest()
+		m.towerScreen.SetMessage(fmt.Sprintf("第%d階で休憩し、HPが%d回復した", floor.Number, healed))
+		m.completeTowerFloor(floor.Number, milestone)
+		m.performAutoSave()
+		return nil
+	}
+
+	enemy := m.towerRun.GenerateEnemy()
+	m.gameState.PreparePlayerForBattle()
+	m.gameState.Player().HP = m.towerRun.PlayerHP()
+	m.towerBattle = true
+	return m.openBattleScreen(enemy, nil)
+}
+
+// completeTowerFloor はエンドレスタワーの階の突破を記録し、マイルストーン報酬を付与します。
+func (m *RootModel) completeTowerFloor(cleared int, milestone bool) {
+	m.gameState.RecordTowerFloor(m.towerRun.Floor().Number)
+	m.towerScreen.SetBestFloor(m.gameState.TowerBestFloor())
+	m.towerScreen.SetMilestoneReward(nil)
+
+	if milestone {
+		level := cleared
+		if maxLevel := m.gameState.EnemyGenerator().GetMaxLevel(); level > maxLevel {
+			level = maxLevel
+		}
+		reward := m.gameState.RewardCalculator().CalculateMilestoneReward(level)
+		m.gameState.AddRewardsToInventory(reward)
+		m.towerScreen.SetMilestoneReward(reward)
+	}
+}
+
+// saveReplay はバトルリプレイをファイルに保存します。
+// 保存に失敗してもバトル結果の処理は継続します。
+func (m *RootModel) saveReplay(replay *combat.Replay) {
+	if m.replayIO == nil || replay == nil {
+		return
+	}
+
+	if _, err := m.replayIO.SaveReplay(gamestate.ReplayToSaveData(replay)); err != nil {
+		slog.Error("リプレイの保存に失敗",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// exportBattleLog はバトルログをJSON Lines形式でファイルに書き出し、書き出し先のパスを返します。
+func (m *RootModel) exportBattleLog(events []combat.BattleEvent) (string, error) {
+	if m.battleLogIO == nil {
+		return "", fmt.Errorf("バトルログの書き出し先が設定されていません")
+	}
+
+	path, err := m.battleLogIO.SaveBattleLog(time.Now(), gamestate.BattleLogToSaveData(events))
+	if err != nil {
+		slog.Error("バトルログの書き出しに失敗",
+			slog.Any("error", err),
+		)
+		return "", err
+	}
+	return path, nil
+}
+
 // performAutoSave はオートセーブを実行します。
 func (m *RootModel) performAutoSave() {
 	if m.saveDataIO == nil {
@@ -400,6 +557,16 @@ func (m *RootModel) performAutoSave() {
 	m.homeScreen.SetStatusMessage(m.statusMessage)
 }
 
+// handleTypingModeChanged は設定画面からのタイピング入力モード変更を反映します。
+func (m *RootModel) handleTypingModeChanged(mode string) {
+	m.gameState.Settings().SetTypingMode(gamestate.TypingMode(mode))
+}
+
+// handleAdaptiveWordsChanged は設定画面からの苦手キー優先出題の切り替えを反映します。
+func (m *RootModel) handleAdaptiveWordsChanged(enabled bool) {
+	m.gameState.Settings().SetAdaptiveWords(enabled)
+}
+
 // handleSaveRequest はメニューからのセーブ要求を処理します。
 func (m *RootModel) handleSaveRequest() {
 	if m.saveDataIO == nil {
@@ -467,28 +634,57 @@ func (m *RootModel) handleSaveRequest() {
 
 // startBattle はバトルを開始します。
 // enemyTypeID が空でない場合は指定された敵タイプで生成し、空の場合はランダム生成します。
-func (m *RootModel) startBattle(level int, enemyTypeID string) tea.Cmd {
+func (m *RootModel) startBattle(level int, enemyTypeID string, encounterID string) tea.Cmd {
+	// エンカウントが指定されている場合はウェーブごとの敵を生成
+	var waves [][]*domain.EnemyModel
+	if encounterID != "" {
+		waves = m.gameState.EnemyGenerator().GenerateEncounter(encounterID)
+	}
+
 	// 敵を生成（タイプが指定されている場合はそのタイプで、なければランダム）
 	var enemy *domain.EnemyModel
-	if enemyTypeID != "" {
-		enemy = m.gameState.EnemyGenerator().GenerateWithType(level, enemyTypeID)
-	} else {
-		enemy = m.gameState.EnemyGenerator().Generate(level)
+	if waves == nil {
+		if enemyTypeID != "" {
+			enemy = m.gameState.EnemyGenerator().GenerateWithType(level, enemyTypeID)
+		} else {
+			enemy = m.gameState.EnemyGenerator().Generate(level)
+		}
 	}
 
-	// プレイヤーを準備し、インベントリプロバイダーから装備エージェントを取得
+	m.towerBattle = false
 	m.gameState.PreparePlayerForBattle()
+	return m.openBattleScreen(enemy, waves)
+}
+
+// openBattleScreen はバトル画面を作成してバトルシーンに切り替えます。
+// wavesがnilでない場合は複数敵バトル、nilの場合はenemyとの1対1バトルになります。
+// プレイヤーはPreparePlayerForBattleで準備済みである必要があります。
+func (m *RootModel) openBattleScreen(enemy *domain.EnemyModel, waves [][]*domain.EnemyModel) tea.Cmd {
+	// インベントリプロバイダーから装備エージェントを取得
 	player := m.gameState.Player()
 	agents := m.invProvider.GetEquippedAgents()
 
 	// バトル画面を作成（JSONからロードした辞書を渡す）
-	m.battleScreen = screens.NewBattleScreen(enemy, player, agents, m.typingDictionary)
+	if waves != nil {
+		m.battleScreen = screens.NewEncounterBattleScreen(waves, player, agents, m.typingDictionary)
+	} else {
+		m.battleScreen = screens.NewBattleScreen(enemy, player, agents, m.typingDictionary)
+	}
 
 	// パッシブスキル定義を設定（EffectTable登録に使用）
 	if m.passiveSkills != nil {
 		m.battleScreen.SetPassiveSkills(m.passiveSkills)
 	}
 
+	// タイピング入力モードを設定
+	if m.gameState.Settings().TypingMode() == gamestate.TypingModeRomaji {
+		m.battleScreen.SetTypingMode(typing.InputModeRomaji)
+	}
+
+	// 苦手キー統計を設定（入力記録の蓄積とアダプティブ出題に使用）
+	m.battleScreen.SetKeyStats(m.gameState.KeyStats())
+	m.battleScreen.SetAdaptiveWords(m.gameState.Settings().AdaptiveWords())
+
 	// シーンを切り替え
 	m.currentScene = SceneBattle
 
@@ -528,6 +724,19 @@ func (m *RootModel) prepareSceneTransition(sceneName string) {
 	case "stats_achievements":
 		// 最新の統計データで画面を再初期化
 		m.statsAchievementsScreen = m.screenFactory.CreateStatsAchievementsScreen()
+	case "tower":
+		// 新しい挑戦を1階から開始（装備エージェントから算出した最大HPで開始）
+		m.gameState.PreparePlayerForBattle()
+		m.towerRun = climbing.NewTowerRun(m.gameState.EnemyGenerator(), m.gameState.Player().MaxHP)
+		m.gameState.RecordTowerFloor(m.towerRun.Floor().Number)
+		m.towerScreen = m.screenFactory.CreateTowerScreen(m.towerRun, m.invProvider)
+	case "replay":
+		// 最新のリプレイ一覧で画面を再初期化
+		m.replayScreen = m.screenFactory.CreateReplayScreen(
+			presenter.NewReplayProviderAdapter(m.replayIO, m.domainSources),
+			m.passiveSkills,
+			m.typingDictionary,
+		)
 	}
 }
 
@@ -37,6 +37,14 @@ const (
 	// SceneReward は報酬画面を表します。
 	// バトル勝利後のドロップアイテムとバトル統計を表示します。
 	SceneReward
+
+	// SceneReplay はリプレイ画面を表します。
+	// 保存済みバトルリプレイの一覧と再生を行います。
+	SceneReplay
+
+	// SceneTower はエンドレスタワー画面を表します。
+	// 階の間に現在の階の情報と持ち越しHPを表示し、次の階への挑戦・休憩を行います。
+	SceneTower
 )
 
 // String はシーンの文字列表現を返します。
@@ -58,6 +66,10 @@ func (s Scene) String() string {
 		return "Settings"
 	case SceneReward:
 		return "Reward"
+	case SceneReplay:
+		return "Replay"
+	case SceneTower:
+		return "Tower"
 	default:
 		return "Unknown"
 	}
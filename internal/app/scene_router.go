@@ -21,6 +21,8 @@ func NewSceneRouter() *SceneRouter {
 			"stats_achievements": SceneAchievement,
 			"settings":           SceneSettings,
 			"reward":             SceneReward,
+			"replay":             SceneReplay,
+			"tower":              SceneTower,
 		},
 	}
 }
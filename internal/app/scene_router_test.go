@@ -131,3 +131,21 @@ func TestSceneRouter_AllRoutes(t *testing.T) {
 		})
 	}
 }
+
+// TestSceneRouter_RouteToReplay はリプレイシーンへのルーティングを検証します
+func TestSceneRouter_RouteToReplay(t *testing.T) {
+	router := NewSceneRouter()
+	scene := router.Route("replay")
+	if scene != SceneReplay {
+		t.Errorf("Route(\"replay\") should return SceneReplay, got %v", scene)
+	}
+}
+
+// TestSceneRouter_RouteToTower はエンドレスタワーシーンへのルーティングを検証します
+func TestSceneRouter_RouteToTower(t *testing.T) {
+	router := NewSceneRouter()
+	scene := router.Route("tower")
+	if scene != SceneTower {
+		t.Errorf("Route(\"tower\") should return SceneTower, got %v", scene)
+	}
+}
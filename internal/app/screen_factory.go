@@ -5,8 +5,10 @@ import (
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/tui/presenter"
 	"hirorocky/type-battle/internal/tui/screens"
+	"hirorocky/type-battle/internal/usecase/climbing"
 	gamestate "hirorocky/type-battle/internal/usecase/session"
 	"hirorocky/type-battle/internal/usecase/spawning"
+	"hirorocky/type-battle/internal/usecase/typing"
 )
 
 // InventoryProvider は画面に必要なインベントリ操作を提供するインターフェースです。
@@ -76,6 +78,20 @@ func (f *ScreenFactory) CreateStatsAchievementsScreen() *screens.StatsAchievemen
 	return screens.NewStatsAchievementsScreen(statsData)
 }
 
+// CreateReplayScreen はリプレイ画面を作成します。
+func (f *ScreenFactory) CreateReplayScreen(
+	replayProvider screens.ReplayProvider,
+	passiveSkills map[string]domain.PassiveSkill,
+	dictionary *typing.Dictionary,
+) *screens.ReplayScreen {
+	return screens.NewReplayScreen(replayProvider, passiveSkills, dictionary)
+}
+
+// CreateTowerScreen はエンドレスタワー画面を作成します。
+func (f *ScreenFactory) CreateTowerScreen(run *climbing.TowerRun, invProvider InventoryProvider) *screens.TowerScreen {
+	return screens.NewTowerScreen(run, invProvider, f.gameState.TowerBestFloor())
+}
+
 // CreateSettingsScreen は設定画面を作成します。
 func (f *ScreenFactory) CreateSettingsScreen() *screens.SettingsScreen {
 	settingsData := presenter.CreateSettingsData(f.gameState)
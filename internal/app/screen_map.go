@@ -55,6 +55,12 @@ func (sm *ScreenMap) registerScreens() {
 	sm.screens[SceneReward] = func() ScreenGetter {
 		return sm.model.rewardScreen
 	}
+	sm.screens[SceneReplay] = func() ScreenGetter {
+		return sm.model.replayScreen
+	}
+	sm.screens[SceneTower] = func() ScreenGetter {
+		return sm.model.towerScreen
+	}
 }
 
 // GetScreen は指定されたシーンの画面を返します。
@@ -25,6 +25,59 @@ const (
 	MinEnemyAttackInterval = 500 * time.Millisecond
 )
 
+// クリティカル設定定数
+const (
+	// CritRatePerLUK はLUK1あたりのクリティカル率です。
+	// LUK50でクリティカル率10%になります。
+	CritRatePerLUK = 0.002
+
+	// CriticalDamageMultiplier はクリティカルヒットの基本ダメージ倍率です。
+	// crit_damage列の値が加算されます。
+	CriticalDamageMultiplier = 1.5
+)
+
+// パリィ設定定数
+const (
+	// ParryPartialDamageRate はミスありでパリィを完了した場合の被ダメージ倍率です。
+	ParryPartialDamageRate = 0.5
+
+	// ParryFailedDamageRate はパリィに失敗した場合の被ダメージ倍率です。
+	// 構えを崩された無防備な状態で攻撃を受けます。
+	ParryFailedDamageRate = 1.5
+)
+
+// ボルテージ設定定数
+const (
+	// PerfectAccuracyVoltageDrop は正確性100%でモジュールを使用したときに対象の敵のボルテージが下がる量です（10.0 = 10%）。
+	PerfectAccuracyVoltageDrop = 10.0
+
+	// VoltageRewardStep はピークボルテージがこの値だけ100%を上回るごとに報酬のドロップレベルが1上がる幅です。
+	VoltageRewardStep = 50.0
+
+	// MaxVoltageRewardBonus はピークボルテージによるドロップレベル加算の上限です。
+	MaxVoltageRewardBonus = 5
+)
+
+// チェイン設定定数
+const (
+	// ChainWindowSeconds はチェインを継続できる猶予時間（秒）です。
+	// 直前のモジュール使用からこの時間内に別のエージェントがモジュールを使用するとチェイン数が増えます。
+	ChainWindowSeconds = 8.0
+
+	// ChainBonusPerLink は3チェイン目以降、1チェインごとに加算されるチェイン効果の倍率です。
+	ChainBonusPerLink = 0.25
+
+	// MaxChainBonusMultiplier はチェイン数によるチェイン効果の倍率の上限です。
+	MaxChainBonusMultiplier = 2.0
+)
+
+// バトルログ設定定数
+const (
+	// BattleLogCapacity はバトルログパネル用に保持するイベントの最大件数です。
+	// 超えた分は古いイベントから破棄されます（JSON Linesの書き出しは全イベントが対象です）。
+	BattleLogCapacity = 200
+)
+
 // 効果持続時間定数
 const (
 	// BuffDuration はバフのデフォルト持続時間（秒）です。
@@ -42,3 +95,25 @@ const (
 	// ModulesPerAgent はエージェントあたりのモジュール数です。
 	ModulesPerAgent = 4
 )
+
+// エンドレスタワー設定定数
+const (
+	// TowerLevelVariance は敵レベルの階数からの最大上振れ幅です。
+	// 敵レベルは「階数〜階数+TowerLevelVariance」の範囲でランダムに決まります。
+	TowerLevelVariance = 2
+
+	// TowerRestFloorInterval は休憩階の間隔です（この倍数の階が休憩階になります）。
+	TowerRestFloorInterval = 5
+
+	// TowerRestHealRatio は休憩階で回復する最大HPに対する割合です。
+	TowerRestHealRatio = 0.3
+
+	// TowerMilestoneInterval はマイルストーン報酬の間隔です（この倍数の階を突破すると報酬を獲得）。
+	TowerMilestoneInterval = 10
+
+	// TowerModifierFloorStep は敵補正の最大数が1つ増える階数の間隔です。
+	TowerModifierFloorStep = 10
+
+	// TowerMaxFloorModifiers は1つの階に付く敵補正の最大数です。
+	TowerMaxFloorModifiers = 3
+)
@@ -0,0 +1,149 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+// ========== 状態異常 ==========
+
+// ailmentInfo は状態異常の表示情報です。
+type ailmentInfo struct {
+	name string
+	icon string
+}
+
+// ailmentInfos は状態異常の効果列ごとの表示情報です。
+var ailmentInfos = map[EffectColumn]ailmentInfo{
+	ColPoison:  {name: "毒", icon: "☠"},
+	ColBurn:    {name: "火傷", icon: "🔥"},
+	ColStun:    {name: "スタン", icon: "💫"},
+	ColSilence: {name: "沈黙", icon: "🔇"},
+}
+
+// IsAilmentColumn は状態異常の効果列かどうかを判定します。
+func IsAilmentColumn(col EffectColumn) bool {
+	_, ok := ailmentInfos[col]
+	return ok
+}
+
+// IsDamageOverTimeColumn は継続ダメージ（毒・火傷）の効果列かどうかを判定します。
+func IsDamageOverTimeColumn(col EffectColumn) bool {
+	return col == ColPoison || col == ColBurn
+}
+
+// AilmentName は状態異常の表示名を返します。
+func AilmentName(col EffectColumn) string {
+	return ailmentInfos[col].name
+}
+
+// AilmentIcon は状態異常の表示用アイコンを返します。
+func AilmentIcon(col EffectColumn) string {
+	return ailmentInfos[col].icon
+}
+
+// AilmentColumn はエントリの状態異常の効果列を返します。状態異常でない場合は空文字を返します。
+func (e *EffectEntry) AilmentColumn() EffectColumn {
+	for col := range e.Values {
+		if IsAilmentColumn(col) {
+			return col
+		}
+	}
+	return ""
+}
+
+// AddAilment は状態異常を追加します。
+// valueは継続ダメージ（/秒）で、スタン・沈黙では使用しません。
+// agentIndexはスタン・沈黙の対象エージェント番号です（敵への状態異常では使用しません）。
+// 同じ対象に同じ状態異常が付与されている場合は上書きします。
+func (t *EffectTable) AddAilment(col EffectColumn, value, duration float64, agentIndex int) {
+	if !IsAilmentColumn(col) {
+		return
+	}
+	if value <= 0 {
+		value = 1
+	}
+
+	remaining := make([]EffectEntry, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		if e.SourceType == SourceAilment && e.SourceIndex == agentIndex && e.Values[col] > 0 {
+			continue
+		}
+		remaining = append(remaining, e)
+	}
+	t.Entries = remaining
+
+	values := map[EffectColumn]float64{col: value}
+	d := duration
+	t.AddEntry(EffectEntry{
+		SourceType:  SourceAilment,
+		SourceID:    string(col),
+		SourceIndex: agentIndex,
+		Name:        DescribeEffectValues(values),
+		Duration:    &d,
+		Values:      values,
+	})
+}
+
+// GetAilments は状態異常のリストを取得します。
+func (t *EffectTable) GetAilments() []EffectEntry {
+	return t.FindBySourceType(SourceAilment)
+}
+
+// HasAilment は指定した状態異常が付与されているかを判定します（対象エージェントは問いません）。
+func (t *EffectTable) HasAilment(col EffectColumn) bool {
+	for _, e := range t.Entries {
+		if e.SourceType == SourceAilment && e.Values[col] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAgentAilment は指定エージェントに指定した状態異常が付与されているかを判定します。
+func (t *EffectTable) HasAgentAilment(col EffectColumn, agentIndex int) bool {
+	for _, e := range t.Entries {
+		if e.SourceType == SourceAilment && e.SourceIndex == agentIndex && e.Values[col] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAilments は全ての状態異常を解除し、解除した数を返します。
+func (t *EffectTable) RemoveAilments() int {
+	return t.RemoveBySourceType(SourceAilment)
+}
+
+// tickDamageOverTime は経過時間分の継続ダメージ（毒・火傷）を計算します。
+// 持続時間が経過時間より短い状態異常は残り時間分だけダメージを与えます。
+// 1未満の端数は次回に持ち越し、継続ダメージがなくなった時点で破棄します。
+func (t *EffectTable) tickDamageOverTime(deltaSeconds float64) int {
+	if deltaSeconds <= 0 {
+		return 0
+	}
+	hasDamageOverTime := false
+	for _, e := range t.Entries {
+		if e.SourceType != SourceAilment {
+			continue
+		}
+		active := deltaSeconds
+		if e.Duration != nil && *e.Duration < active {
+			active = *e.Duration
+		}
+		if active <= 0 {
+			continue
+		}
+		for col, val := range e.Values {
+			if IsDamageOverTimeColumn(col) {
+				t.dotCarry += val * active
+				hasDamageOverTime = true
+			}
+		}
+	}
+	if !hasDamageOverTime {
+		// 継続ダメージがなくなったら端数を破棄
+		t.dotCarry = 0
+		return 0
+	}
+
+	damage := int(t.dotCarry)
+	t.dotCarry -= float64(damage)
+	return damage
+}
@@ -0,0 +1,82 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+import (
+	"testing"
+)
+
+// TestEffectTable_AilmentDamageOverTime は毒・火傷の継続ダメージがTickで発生し、端数が持ち越されることをテストします。
+func TestEffectTable_AilmentDamageOverTime(t *testing.T) {
+	table := NewEffectTable()
+	table.AddAilment(ColPoison, 3.0, 2.0, -1)
+	table.AddAilment(ColBurn, 1.5, 10.0, -1)
+
+	// 0.5秒: (3.0 + 1.5) × 0.5 = 2.25 → 2ダメージ、0.25を持ち越し
+	if damage := table.Tick(0.5); damage != 2 {
+		t.Errorf("0.5秒の継続ダメージ: 期待 2, 実際 %d", damage)
+	}
+	// 0.5秒: 2.25 + 0.25 = 2.5 → 2ダメージ
+	if damage := table.Tick(0.5); damage != 2 {
+		t.Errorf("1.0秒時点の継続ダメージ: 期待 2, 実際 %d", damage)
+	}
+
+	// 毒は残り1秒のため、2秒経過しても1秒分だけダメージを与える: 3.0 + 1.5×2 + 0.5 = 6.5 → 6
+	if damage := table.Tick(2.0); damage != 6 {
+		t.Errorf("持続時間切れを含む継続ダメージ: 期待 6, 実際 %d", damage)
+	}
+	if table.HasAilment(ColPoison) {
+		t.Error("持続時間が切れた毒は解除されるべき")
+	}
+	if !table.HasAilment(ColBurn) {
+		t.Error("持続時間が残っている火傷は継続すべき")
+	}
+}
+
+// TestEffectTable_AddAilmentReplace は同じ対象への同じ状態異常が上書きされることをテストします。
+func TestEffectTable_AddAilmentReplace(t *testing.T) {
+	table := NewEffectTable()
+	table.AddAilment(ColStun, 0, 3.0, 0)
+	table.AddAilment(ColStun, 0, 5.0, 0)
+	table.AddAilment(ColStun, 0, 2.0, 1)
+	table.AddAilment(ColSilence, 0, 4.0, 0)
+
+	ailments := table.GetAilments()
+	if len(ailments) != 3 {
+		t.Fatalf("状態異常の数: 期待 3, 実際 %d", len(ailments))
+	}
+	if !table.HasAgentAilment(ColStun, 0) || !table.HasAgentAilment(ColStun, 1) || !table.HasAgentAilment(ColSilence, 0) {
+		t.Error("エージェントごとの状態異常が判定できません")
+	}
+	if table.HasAgentAilment(ColSilence, 1) {
+		t.Error("沈黙していないエージェントが沈黙と判定されました")
+	}
+	for _, ailment := range ailments {
+		if ailment.AilmentColumn() == ColStun && ailment.SourceIndex == 0 && *ailment.Duration != 5.0 {
+			t.Errorf("上書き後の持続時間: 期待 5.0, 実際 %.1f", *ailment.Duration)
+		}
+	}
+
+	// 状態異常以外の効果列は付与されない
+	table.AddAilment(ColDamageCut, 0.5, 5.0, -1)
+	if len(table.GetAilments()) != 3 {
+		t.Error("状態異常でない効果列が状態異常として追加されました")
+	}
+}
+
+// TestEffectTable_RemoveAilments は状態異常のみが解除されることをテストします。
+func TestEffectTable_RemoveAilments(t *testing.T) {
+	table := NewEffectTable()
+	table.AddDebuff("被ダメ増加", 5.0, map[EffectColumn]float64{ColDamageCut: -0.1})
+	table.AddAilment(ColPoison, 2.0, 5.0, -1)
+	table.AddAilment(ColSilence, 0, 5.0, 2)
+
+	if removed := table.RemoveAilments(); removed != 2 {
+		t.Errorf("解除数: 期待 2, 実際 %d", removed)
+	}
+	if len(table.GetAilments()) != 0 {
+		t.Error("状態異常が残っています")
+	}
+	if len(table.FindBySourceType(SourceDebuff)) != 1 {
+		t.Error("デバフは解除されるべきではありません")
+	}
+}
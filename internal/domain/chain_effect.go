@@ -139,6 +139,10 @@ type ChainEffect struct {
 
 	// ShortDescription は効果の短い説明文です（16文字程度）。
 	ShortDescription string
+
+	// MinChainLength は発動に必要な最低チェイン数です（0または1は制限なし）。
+	// チェイン数はエージェントを切り替えながら連続でモジュールを使用した回数です。
+	MinChainLength int
 }
 
 // NewChainEffectWithTemplate は説明文テンプレートから新しいChainEffectを作成します。
@@ -164,6 +168,17 @@ func formatIfHasPlaceholder(template string, value float64) string {
 	return template
 }
 
+// WithMinChainLength は発動に必要な最低チェイン数を設定したコピーを返します。
+func (c ChainEffect) WithMinChainLength(length int) ChainEffect {
+	c.MinChainLength = length
+	return c
+}
+
+// RequiresChain は発動に2以上のチェイン数が必要かを返します。
+func (c ChainEffect) RequiresChain() bool {
+	return c.MinChainLength > 1
+}
+
 // NewChainEffect は説明文なしでChainEffectを作成する簡易コンストラクタです。
 // テスト用途または説明文が不要な場合に使用します。
 func NewChainEffect(effectType ChainEffectType, value float64) ChainEffect {
@@ -214,6 +229,7 @@ func (t ChainEffectType) Category() ChainEffectCategory {
 // agentIndex は効果を登録したエージェントのインデックスです。
 func (c ChainEffect) ToEntry(agentIndex int) EffectEntry {
 	idx := agentIndex
+	minChain := c.MinChainLength
 	return EffectEntry{
 		SourceType:  SourceChain,
 		SourceID:    string(c.Type),
@@ -224,7 +240,7 @@ func (c ChainEffect) ToEntry(agentIndex int) EffectEntry {
 			if ctx.EventType != EventOnModuleUse {
 				return false
 			}
-			return ctx.LastModuleAgent != idx && ctx.LastModuleAgent >= 0
+			return ctx.LastModuleAgent != idx && ctx.LastModuleAgent >= 0 && ctx.ChainLength >= minChain
 		},
 		Values:  c.buildValues(),
 		Flags:   c.buildFlags(),
@@ -58,6 +58,9 @@ type CoreType struct {
 	// MinDropLevel はこのコア特性がドロップする最低敵レベルです。
 	// このレベル未満の敵からはこの特性のコアはドロップしません。
 	MinDropLevel int
+
+	// ElementAffinity は敵の属性攻撃に対する被ダメージ倍率（弱点・耐性）です。
+	ElementAffinity ElementAffinity
 }
 
 // CoreModel はゲーム内のコアエンティティを表す構造体です。
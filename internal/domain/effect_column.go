@@ -34,6 +34,26 @@ const (
 	// ColRegen は継続回復（/秒）を表します。
 	ColRegen EffectColumn = "regen"
 
+	// ========== 属性防御系 ==========
+
+	// ColFireCut は炎属性の被ダメ軽減（%）を表します。
+	ColFireCut EffectColumn = "fire_cut"
+
+	// ColIceCut は氷属性の被ダメ軽減（%）を表します。
+	ColIceCut EffectColumn = "ice_cut"
+
+	// ColThunderCut は雷属性の被ダメ軽減（%）を表します。
+	ColThunderCut EffectColumn = "thunder_cut"
+
+	// ColPoisonCut は毒属性の被ダメ軽減（%）を表します。
+	ColPoisonCut EffectColumn = "poison_cut"
+
+	// ColHolyCut は聖属性の被ダメ軽減（%）を表します。
+	ColHolyCut EffectColumn = "holy_cut"
+
+	// ColDarkCut は闇属性の被ダメ軽減（%）を表します。
+	ColDarkCut EffectColumn = "dark_cut"
+
 	// ========== 回復強化系 ==========
 
 	// ColHealBonus は加算回復（固定値）を表します。
@@ -53,6 +73,23 @@ const (
 	// ColAutoCorrect はミス無視回数を表します。
 	ColAutoCorrect EffectColumn = "auto_correct"
 
+	// ========== タイピング妨害系 ==========
+
+	// ColHideLetters は先読み封じ（未入力の文字を先頭から指定文字数しか表示しない）を表します。
+	ColHideLetters EffectColumn = "hide_letters"
+
+	// ColMirrorText は鏡文字（チャレンジテキストを左右反転して表示）を表します。
+	ColMirrorText EffectColumn = "mirror_text"
+
+	// ColShuffleText は文字シャッフル（チャレンジテキストの文字を並べ替えて出題）を表します。
+	ColShuffleText EffectColumn = "shuffle_text"
+
+	// ColTimeShrink は制限時間縮小（1秒あたりに縮む秒数）を表します。
+	ColTimeShrink EffectColumn = "time_shrink"
+
+	// ColForceCase は大文字小文字の強制（大文字混じりで出題し、厳密に判定）を表します。
+	ColForceCase EffectColumn = "force_case"
+
 	// ========== リキャスト系 ==========
 
 	// ColCooldownReduce はCD短縮率（%）を表します。
@@ -76,6 +113,9 @@ const (
 	// ColCritRate はクリティカル率加算（%）を表します。
 	ColCritRate EffectColumn = "crit_rate"
 
+	// ColCritDamage はクリティカルダメージ倍率加算（%）を表します。
+	ColCritDamage EffectColumn = "crit_damage"
+
 	// ColSTRBonus はSTR加算（固定値）を表します。
 	ColSTRBonus EffectColumn = "str_bonus"
 
@@ -99,6 +139,28 @@ const (
 
 	// ColLUKMultiplier はLUK倍率を表します。
 	ColLUKMultiplier EffectColumn = "luk_mult"
+
+	// ========== 状態異常系 ==========
+
+	// ColPoison は毒（継続ダメージ/秒）を表します。
+	ColPoison EffectColumn = "poison"
+
+	// ColBurn は火傷（継続ダメージ/秒）を表します。
+	ColBurn EffectColumn = "burn"
+
+	// ColStun はスタン（エージェントのリキャスト停止・行動不能、敵のチャージ停止）を表します。
+	ColStun EffectColumn = "stun"
+
+	// ColSilence は沈黙（エージェントのモジュール封印、敵の攻撃以外の行動封印）を表します。
+	ColSilence EffectColumn = "silence"
+
+	// ColCleanse は状態異常の解除（即時効果）を表します。
+	ColCleanse EffectColumn = "cleanse"
+
+	// ========== ボルテージ系 ==========
+
+	// ColVoltageDown は敵のボルテージ低下量（即時効果、10.0 = 10%）を表します。
+	ColVoltageDown EffectColumn = "voltage_down"
 )
 
 // AggregationType は列ごとの集計方法を表します。
@@ -116,8 +178,28 @@ const (
 
 	// AggOr はOR集計を表します: any(values)
 	AggOr
+
+	// AggMin は最小値集計を表します: min(values)（0は未設定として扱う）
+	AggMin
 )
 
+// String は集計方法の表示名を返します。
+func (a AggregationType) String() string {
+	switch a {
+	case AggAdd:
+		return "加算"
+	case AggMult:
+		return "乗算"
+	case AggMax:
+		return "最大"
+	case AggOr:
+		return "いずれか"
+	case AggMin:
+		return "最小"
+	}
+	return "不明"
+}
+
 // ColumnAggregation は各列の集計方法を定義します。
 var ColumnAggregation = map[EffectColumn]AggregationType{
 	// 攻撃強化系
@@ -132,6 +214,14 @@ var ColumnAggregation = map[EffectColumn]AggregationType{
 	ColReflect:   AggMax, // 最大の反射率を採用
 	ColRegen:     AggAdd, // 回復量は加算
 
+	// 属性防御系（最大の軽減率を採用）
+	ColFireCut:    AggMax,
+	ColIceCut:     AggMax,
+	ColThunderCut: AggMax,
+	ColPoisonCut:  AggMax,
+	ColHolyCut:    AggMax,
+	ColDarkCut:    AggMax,
+
 	// 回復強化系
 	ColHealBonus:      AggAdd,  // 固定回復は加算
 	ColHealMultiplier: AggMult, // 倍率は乗算
@@ -141,6 +231,13 @@ var ColumnAggregation = map[EffectColumn]AggregationType{
 	ColTimeExtend:  AggAdd, // 延長時間は加算
 	ColAutoCorrect: AggAdd, // ミス無視回数は加算
 
+	// タイピング妨害系
+	ColHideLetters: AggMin, // 最も少ない表示文字数を採用
+	ColMirrorText:  AggOr,  // 1つでもあれば鏡文字
+	ColShuffleText: AggOr,  // 1つでもあればシャッフル
+	ColTimeShrink:  AggAdd, // 縮小速度は加算
+	ColForceCase:   AggOr,  // 1つでもあれば大文字小文字を強制
+
 	// リキャスト系
 	ColCooldownReduce: AggMax, // 最大の短縮率を採用
 
@@ -153,6 +250,7 @@ var ColumnAggregation = map[EffectColumn]AggregationType{
 
 	// ステータス系
 	ColCritRate:      AggAdd,  // クリティカル率は加算
+	ColCritDamage:    AggAdd,  // クリティカルダメージ倍率は加算
 	ColSTRBonus:      AggAdd,  // STR加算は加算
 	ColINTBonus:      AggAdd,  // INT加算は加算
 	ColWILBonus:      AggAdd,  // WIL加算は加算
@@ -161,6 +259,16 @@ var ColumnAggregation = map[EffectColumn]AggregationType{
 	ColINTMultiplier: AggMult, // INT倍率は乗算
 	ColWILMultiplier: AggMult, // WIL倍率は乗算
 	ColLUKMultiplier: AggMult, // LUK倍率は乗算
+
+	// 状態異常系
+	ColPoison:  AggAdd, // 継続ダメージは加算
+	ColBurn:    AggAdd, // 継続ダメージは加算
+	ColStun:    AggOr,  // 1つでもあればスタン
+	ColSilence: AggOr,  // 1つでもあれば沈黙
+	ColCleanse: AggOr,  // 即時効果
+
+	// ボルテージ系
+	ColVoltageDown: AggAdd, // 即時効果
 }
 
 // ColumnDefault は集計の初期値を返します。
@@ -32,6 +32,9 @@ const (
 
 	// EventOnTimeout は時間切れ時のイベントを表します。
 	EventOnTimeout EffectEventType = "on_timeout"
+
+	// EventOnCritical はクリティカルヒット時のイベントを表します。
+	EventOnCritical EffectEventType = "on_critical"
 )
 
 // EffectContext は EnableCondition の判定に使う状態を表します。
@@ -84,6 +87,9 @@ type EffectContext struct {
 	// CurrentAgent は現在評価中のエージェント番号です。
 	CurrentAgent int
 
+	// ChainLength はエージェントを切り替えながら連続でモジュールを使用した回数です（0: チェインなし）。
+	ChainLength int
+
 	// ========== イベント情報 ==========
 
 	// EventType は現在発生中のイベントです。
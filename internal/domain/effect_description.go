@@ -27,6 +27,9 @@ func DescribeSingleEffect(effectType string, value float64) string {
 		return fmt.Sprintf("CD%.0f%%延長", value*100)
 	case "defense_down":
 		return fmt.Sprintf("被ダメ%.0f%%増加", value*100)
+	case "poison", "burn", "stun", "silence",
+		"hide_letters", "mirror_text", "shuffle_text", "time_shrink", "force_case":
+		return describeColumn(EffectColumn(effectType), value)
 	default:
 		return "効果"
 	}
@@ -93,6 +96,11 @@ func describeColumn(col EffectColumn, value float64) string {
 	case ColRegen:
 		return fmt.Sprintf("HP回復%.0f/s", value)
 
+	// 属性防御系
+	case ColFireCut, ColIceCut, ColThunderCut, ColPoisonCut, ColHolyCut, ColDarkCut:
+		element, _ := elementOfCutColumn(col)
+		return element.String() + formatDamageCutEffect(value)
+
 	// 回復強化系
 	case ColHealBonus:
 		if value >= 0 {
@@ -116,6 +124,18 @@ func describeColumn(col EffectColumn, value float64) string {
 	case ColAutoCorrect:
 		return fmt.Sprintf("ミス無視%.0f回", value)
 
+	// タイピング妨害系
+	case ColHideLetters:
+		return fmt.Sprintf("先読み封じ(%.0f文字)", value)
+	case ColMirrorText:
+		return "鏡文字"
+	case ColShuffleText:
+		return "文字シャッフル"
+	case ColTimeShrink:
+		return fmt.Sprintf("時間縮小%.1f秒/秒", value)
+	case ColForceCase:
+		return "大文字小文字強制"
+
 	// リキャスト系
 	case ColCooldownReduce:
 		return formatCooldownEffect(value)
@@ -133,6 +153,8 @@ func describeColumn(col EffectColumn, value float64) string {
 	// ステータス系
 	case ColCritRate:
 		return fmt.Sprintf("クリ率+%.0f%%", value*100)
+	case ColCritDamage:
+		return fmt.Sprintf("クリダメ+%.0f%%", value*100)
 	case ColSTRBonus:
 		return fmt.Sprintf("STR+%.0f", value)
 	case ColINTBonus:
@@ -150,6 +172,18 @@ func describeColumn(col EffectColumn, value float64) string {
 	case ColLUKMultiplier:
 		return formatIncrementRate("LUK", value)
 
+	// 状態異常系
+	case ColPoison, ColBurn:
+		return fmt.Sprintf("%s%.0f/s", AilmentName(col), value)
+	case ColStun, ColSilence:
+		return AilmentName(col)
+	case ColCleanse:
+		return "状態異常解除"
+
+	// ボルテージ系
+	case ColVoltageDown:
+		return fmt.Sprintf("ボルテージ-%.0f%%", value)
+
 	default:
 		return ""
 	}
@@ -1,6 +1,8 @@
 // Package domain はゲームのドメインモデルを定義します。
 package domain
 
+import "fmt"
+
 // EffectSourceType は効果のソース種別を表します。
 // パッシブスキル、チェイン効果、バフ、デバフを区別します。
 type EffectSourceType string
@@ -17,6 +19,9 @@ const (
 
 	// SourceDebuff はデバフからの効果を表します。
 	SourceDebuff EffectSourceType = "debuff"
+
+	// SourceAilment は状態異常（毒・火傷・スタン・沈黙）を表します。
+	SourceAilment EffectSourceType = "ailment"
 )
 
 // EffectEntry は効果テーブルの1行（効果の1つのソース）を表します。
@@ -145,6 +150,9 @@ type EffectResult struct {
 	// Regen は継続回復量です。
 	Regen float64
 
+	// ElementCut は属性別の被ダメ軽減率です（属性防御系の列を集計したもの）。
+	ElementCut map[Element]float64
+
 	// ========== 回復強化系 ==========
 
 	// HealBonus は加算回復です。
@@ -164,6 +172,23 @@ type EffectResult struct {
 	// AutoCorrect はミス無視回数です。
 	AutoCorrect int
 
+	// ========== タイピング妨害系 ==========
+
+	// VisibleLetters は表示される未入力文字数です（0は制限なし）。
+	VisibleLetters int
+
+	// MirrorText は鏡文字が有効かを表します。
+	MirrorText bool
+
+	// ShuffleText は文字シャッフルが有効かを表します。
+	ShuffleText bool
+
+	// TimeShrink は制限時間の縮小速度（秒/秒）です。
+	TimeShrink float64
+
+	// ForceCase は大文字小文字の強制が有効かを表します。
+	ForceCase bool
+
 	// ========== リキャスト系 ==========
 
 	// CooldownReduce はCD短縮率です。
@@ -211,10 +236,43 @@ type EffectResult struct {
 	// CritRate はクリティカル率加算です。
 	CritRate float64
 
+	// CritDamage はクリティカルダメージ倍率加算です（0.5 = +50%）。
+	CritDamage float64
+
 	// ========== デバッグ用 ==========
 
 	// ActiveSources は有効だったソース名のリストです。
 	ActiveSources []string
+
+	// Contributions は集計に寄与したエントリの効果値です（計算トレース用）。
+	Contributions []EffectContribution
+}
+
+// EffectContribution は1つのエントリが1つの列の集計に寄与した効果値です。
+type EffectContribution struct {
+	// Source は寄与したエントリの表示名です。
+	Source string
+
+	// SourceType は寄与したエントリの種別です。
+	SourceType EffectSourceType
+
+	// Column は寄与した効果列です。
+	Column EffectColumn
+
+	// Value は効果値です（bool型効果は1）。
+	Value float64
+
+	// Aggregation は列の集計方法です。
+	Aggregation AggregationType
+}
+
+// Describe は寄与した効果を「名前: 効果（集計方法）」の形式で返します。
+func (c EffectContribution) Describe() string {
+	desc := describeColumn(c.Column, c.Value)
+	if desc == "" {
+		desc = string(c.Column)
+	}
+	return fmt.Sprintf("%s: %s（%s）", c.Source, desc, c.Aggregation)
 }
 
 // NewEffectResult は初期値を持つ EffectResult を生成します。
@@ -231,6 +289,29 @@ func NewEffectResult() EffectResult {
 	}
 }
 
+// clone は集計結果のコピーを作成します（マップとスライスも複製します）。
+func (r EffectResult) clone() EffectResult {
+	clone := r
+	if r.ElementCut != nil {
+		clone.ElementCut = make(map[Element]float64, len(r.ElementCut))
+		for k, v := range r.ElementCut {
+			clone.ElementCut[k] = v
+		}
+	}
+	clone.ActiveSources = append(make([]string, 0, len(r.ActiveSources)), r.ActiveSources...)
+	clone.Contributions = append([]EffectContribution(nil), r.Contributions...)
+	return clone
+}
+
+// ElementCutFor は指定属性の被ダメ軽減率を返します。
+// 無属性の場合は0を返します。
+func (r *EffectResult) ElementCutFor(element Element) float64 {
+	if element == ElementNone {
+		return 0
+	}
+	return r.ElementCut[element]
+}
+
 // CalculateFinalDamage は基礎ダメージに効果を適用します。
 func (r *EffectResult) CalculateFinalDamage(baseDamage int) int {
 	damage := float64(baseDamage)
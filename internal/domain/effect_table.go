@@ -15,6 +15,12 @@ type EffectTable struct {
 
 	// rng は確率判定用の乱数生成器です。
 	rng *rand.Rand
+
+	// dotCarry は継続ダメージの端数（1未満の蓄積分）です。
+	dotCarry float64
+
+	// lastExpired は直前の Tick で期限切れになったエントリです。
+	lastExpired []EffectEntry
 }
 
 // NewEffectTable は新しい EffectTable を生成します。
@@ -33,6 +39,12 @@ func NewEffectTableWithSeed(seed int64) *EffectTable {
 	}
 }
 
+// SetSeed は確率判定用の乱数生成器を指定されたシードで初期化し直します。
+// リプレイで確率判定を再現するために使用します。
+func (t *EffectTable) SetSeed(seed int64) {
+	t.rng = rand.New(rand.NewSource(seed))
+}
+
 // ========== エントリ追加メソッド ==========
 
 // AddEntry はエントリを追加します。
@@ -71,45 +83,78 @@ func (t *EffectTable) Aggregate(ctx *EffectContext) EffectResult {
 	result := NewEffectResult()
 
 	for i := range t.Entries {
-		entry := &t.Entries[i]
+		t.aggregateEntry(&result, &t.Entries[i], ctx)
+	}
 
-		// 既に発動済みの OneShot はスキップ
-		if entry.OneShot && entry.Triggered {
-			continue
-		}
+	return result
+}
 
-		// 有効条件の判定
-		if !entry.IsEnabled(ctx) {
-			continue
-		}
+// AggregateEvent は base では無効で、イベントを設定した ctx で初めて有効になるエントリのみを集計し、
+// base で集計済みの結果 result に加えた結果を返します（result 自体は変更しません）。
+// base で有効なエントリは再集計しないため、確率判定のやり直しや OneShot の二重発動は起きません。
+func (t *EffectTable) AggregateEvent(result EffectResult, base, ctx *EffectContext) EffectResult {
+	merged := result.clone()
 
-		// 確率判定
-		if entry.Probability > 0 && t.rng.Float64() >= entry.Probability {
+	for i := range t.Entries {
+		entry := &t.Entries[i]
+		if entry.IsEnabled(base) {
 			continue
 		}
+		t.aggregateEntry(&merged, entry, ctx)
+	}
 
-		// OneShot なら発動済みフラグを立てる
-		if entry.OneShot {
-			entry.Triggered = true
-		}
+	return merged
+}
 
-		// 有効なソースとして記録
-		result.ActiveSources = append(result.ActiveSources, entry.Name)
+// aggregateEntry は1つのエントリが有効な場合に、その効果を集計結果に加えます。
+func (t *EffectTable) aggregateEntry(result *EffectResult, entry *EffectEntry, ctx *EffectContext) {
+	// 既に発動済みの OneShot はスキップ
+	if entry.OneShot && entry.Triggered {
+		return
+	}
 
-		// 数値型効果の集計
-		for col, val := range entry.Values {
-			t.aggregateValue(&result, col, val)
-		}
+	// 有効条件の判定
+	if !entry.IsEnabled(ctx) {
+		return
+	}
 
-		// bool型効果の集計
-		for col, flag := range entry.Flags {
-			if flag {
-				t.aggregateFlag(&result, col)
-			}
+	// 確率判定
+	if entry.Probability > 0 && t.rng.Float64() >= entry.Probability {
+		return
+	}
+
+	// OneShot なら発動済みフラグを立てる
+	if entry.OneShot {
+		entry.Triggered = true
+	}
+
+	// 有効なソースとして記録
+	result.ActiveSources = append(result.ActiveSources, entry.Name)
+
+	// 数値型効果の集計
+	for col, val := range entry.Values {
+		t.aggregateValue(result, col, val)
+		result.Contributions = append(result.Contributions, newEffectContribution(entry, col, val))
+	}
+
+	// bool型効果の集計
+	for col, flag := range entry.Flags {
+		if flag {
+			t.aggregateFlag(result, col)
+			result.Contributions = append(result.Contributions, newEffectContribution(entry, col, 1))
 		}
 	}
+}
 
-	return result
+// newEffectContribution はエントリの効果値の寄与を作成します。
+func newEffectContribution(entry *EffectEntry, col EffectColumn, val float64) EffectContribution {
+	return EffectContribution{
+		Source:      entry.Name,
+		SourceType:  entry.SourceType,
+		Column:      col,
+		Value:       val,
+		Aggregation: ColumnAggregation[col],
+	}
 }
 
 // aggregateValue は数値型効果を集計します。
@@ -145,6 +190,18 @@ func (t *EffectTable) aggregateValue(result *EffectResult, col EffectColumn, val
 		result.TimeExtend += val
 	case ColAutoCorrect:
 		result.AutoCorrect += int(val)
+	case ColHideLetters:
+		if val >= 1 && (result.VisibleLetters == 0 || int(val) < result.VisibleLetters) {
+			result.VisibleLetters = int(val)
+		}
+	case ColMirrorText:
+		result.MirrorText = result.MirrorText || val > 0
+	case ColShuffleText:
+		result.ShuffleText = result.ShuffleText || val > 0
+	case ColTimeShrink:
+		result.TimeShrink += val
+	case ColForceCase:
+		result.ForceCase = result.ForceCase || val > 0
 	case ColCooldownReduce:
 		// 加算集計（正=短縮、負=延長）
 		result.CooldownReduce += val
@@ -174,6 +231,16 @@ func (t *EffectTable) aggregateValue(result *EffectResult, col EffectColumn, val
 		result.LUKMultiplier += val // 増加率として加算
 	case ColCritRate:
 		result.CritRate += val
+	case ColCritDamage:
+		result.CritDamage += val
+	case ColFireCut, ColIceCut, ColThunderCut, ColPoisonCut, ColHolyCut, ColDarkCut:
+		element, _ := elementOfCutColumn(col)
+		if result.ElementCut == nil {
+			result.ElementCut = make(map[Element]float64)
+		}
+		if val > result.ElementCut[element] {
+			result.ElementCut[element] = val
+		}
 	}
 }
 
@@ -223,25 +290,36 @@ func (t *EffectTable) FindByAgentIndex(idx int) []EffectEntry {
 
 // ========== 時間経過処理 ==========
 
-// Tick は時間経過を処理（期限切れエントリを削除）します。
-func (t *EffectTable) Tick(deltaSeconds float64) {
+// Tick は時間経過を処理（期限切れエントリを削除）し、
+// その間に状態異常（毒・火傷）で受ける継続ダメージを返します。
+func (t *EffectTable) Tick(deltaSeconds float64) int {
+	damage := t.tickDamageOverTime(deltaSeconds)
+
 	remaining := make([]EffectEntry, 0, len(t.Entries))
+	t.lastExpired = nil
 	for i := range t.Entries {
 		entry := &t.Entries[i]
 		if entry.Duration != nil {
 			*entry.Duration -= deltaSeconds
 			if *entry.Duration <= 0 {
-				continue // 期限切れ
+				t.lastExpired = append(t.lastExpired, *entry) // 期限切れ
+				continue
 			}
 		}
 		remaining = append(remaining, *entry)
 	}
 	t.Entries = remaining
+	return damage
+}
+
+// LastExpired は直前の Tick で期限切れになって削除されたエントリを返します。
+func (t *EffectTable) LastExpired() []EffectEntry {
+	return t.lastExpired
 }
 
 // UpdateDurations は Tick のエイリアスです（既存コード互換性用）。
-func (t *EffectTable) UpdateDurations(deltaSeconds float64) {
-	t.Tick(deltaSeconds)
+func (t *EffectTable) UpdateDurations(deltaSeconds float64) int {
+	return t.Tick(deltaSeconds)
 }
 
 // ========== 削除メソッド ==========
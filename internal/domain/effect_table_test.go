@@ -220,6 +220,15 @@ func TestEffectTable_期限切れ削除(t *testing.T) {
 	if table.Entries[0].SourceID != "buff_001" {
 		t.Errorf("残っているエントリのSourceIDが異なります: got %s, want buff_001", table.Entries[0].SourceID)
 	}
+	if expired := table.LastExpired(); len(expired) != 1 || expired[0].SourceID != "buff_002" {
+		t.Errorf("期限切れエントリが記録されていません: got %v", expired)
+	}
+
+	// 次のTickでは期限切れの記録がリセットされる
+	table.Tick(1.0)
+	if expired := table.LastExpired(); len(expired) != 0 {
+		t.Errorf("期限切れの記録は直前のTickのみであるべきです: got %d件", len(expired))
+	}
 }
 
 // TestEffectTable_Aggregate_加算効果 は加算効果が正しく集計されることを確認します。
@@ -255,6 +264,34 @@ func TestEffectTable_Aggregate_乗算効果(t *testing.T) {
 	}
 }
 
+// TestEffectTable_Aggregate_寄与の記録 は集計に寄与したエントリが集計方法とともに記録されることを確認します。
+func TestEffectTable_Aggregate_寄与の記録(t *testing.T) {
+	table := NewEffectTableWithSeed(42)
+
+	table.AddBuff("攻撃UP", 5.0, map[EffectColumn]float64{ColDamageMultiplier: 1.2})
+	table.AddDebuff("鈍足", 5.0, map[EffectColumn]float64{ColDamageCut: 0.1})
+	table.AddEntry(EffectEntry{SourceType: SourcePassive, Name: "貫通", Flags: map[EffectColumn]bool{ColArmorPierce: true}})
+
+	result := table.Aggregate(NewEffectContext(100, 100, 50, 100))
+
+	if len(result.Contributions) != 3 {
+		t.Fatalf("寄与の件数が期待値と異なります: got %d, want 3 (%+v)", len(result.Contributions), result.Contributions)
+	}
+	want := map[EffectColumn]EffectContribution{
+		ColDamageMultiplier: {Source: "攻撃UP", SourceType: SourceBuff, Column: ColDamageMultiplier, Value: 1.2, Aggregation: AggMult},
+		ColDamageCut:        {Source: "鈍足", SourceType: SourceDebuff, Column: ColDamageCut, Value: 0.1, Aggregation: AggMax},
+		ColArmorPierce:      {Source: "貫通", SourceType: SourcePassive, Column: ColArmorPierce, Value: 1, Aggregation: AggOr},
+	}
+	for _, c := range result.Contributions {
+		if c != want[c.Column] {
+			t.Errorf("寄与が期待値と異なります: got %+v, want %+v", c, want[c.Column])
+		}
+	}
+	if got := want[ColDamageMultiplier].Describe(); got != "攻撃UP: ダメージ20%UP（乗算）" {
+		t.Errorf("寄与の説明が期待値と異なります: got %q", got)
+	}
+}
+
 // TestEffectTable_Aggregate_最大値効果 は最大値効果が正しく集計されることを確認します。
 func TestEffectTable_Aggregate_最大値効果(t *testing.T) {
 	table := NewEffectTableWithSeed(42)
@@ -400,3 +437,51 @@ func abs(x float64) float64 {
 	}
 	return x
 }
+
+// TestEffectTable_AggregateEvent はイベントで初めて有効になるエントリのみが集計済みの結果に加わることをテストします。
+func TestEffectTable_AggregateEvent(t *testing.T) {
+	table := NewEffectTableWithSeed(42)
+	table.AddEntry(EffectEntry{
+		SourceType: SourceChain,
+		Name:       "ワンショット",
+		Values:     map[EffectColumn]float64{ColDamageMultiplier: 2.0},
+		OneShot:    true,
+	})
+	table.AddEntry(EffectEntry{
+		SourceType:  SourcePassive,
+		Name:        "確率発動",
+		Values:      map[EffectColumn]float64{ColDamageBonus: 10},
+		Probability: 0.5,
+	})
+	table.AddEntry(EffectEntry{
+		SourceType:      SourcePassive,
+		Name:            "会心",
+		Values:          map[EffectColumn]float64{ColCritDamage: 0.5},
+		EnableCondition: func(ctx *EffectContext) bool { return ctx.EventType == EventOnCritical },
+	})
+
+	base := NewEffectContext(100, 100, 100, 100)
+	result := table.Aggregate(base)
+	event := *base
+	event.SetEvent(EventOnCritical)
+	merged := table.AggregateEvent(result, base, &event)
+
+	// 集計済みの効果は維持され、確率判定・OneShotはやり直さない
+	if merged.DamageMultiplier != 2.0 {
+		t.Errorf("OneShotの効果が失われています: got %f, want 2.0", merged.DamageMultiplier)
+	}
+	if merged.DamageBonus != result.DamageBonus {
+		t.Errorf("確率発動の効果が再判定されています: got %d, want %d", merged.DamageBonus, result.DamageBonus)
+	}
+	// イベントで有効になるエントリのみが加わる
+	if merged.CritDamage != 0.5 {
+		t.Errorf("イベント条件の効果が加わっていません: got %f, want 0.5", merged.CritDamage)
+	}
+	if len(merged.ActiveSources) != len(result.ActiveSources)+1 {
+		t.Errorf("有効なソース数: got %d, want %d", len(merged.ActiveSources), len(result.ActiveSources)+1)
+	}
+	// 元の集計結果は変更しない
+	if result.CritDamage != 0 || len(result.Contributions) == len(merged.Contributions) {
+		t.Errorf("元の集計結果が変更されています: CritDamage=%f", result.CritDamage)
+	}
+}
@@ -0,0 +1,167 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Element は攻撃の属性を表します。空文字は無属性です。
+type Element string
+
+const (
+	// ElementNone は無属性です。属性倍率・属性軽減の影響を受けません。
+	ElementNone Element = ""
+
+	// ElementFire は炎属性です。
+	ElementFire Element = "fire"
+
+	// ElementIce は氷属性です。
+	ElementIce Element = "ice"
+
+	// ElementThunder は雷属性です。
+	ElementThunder Element = "thunder"
+
+	// ElementPoison は毒属性です。
+	ElementPoison Element = "poison"
+
+	// ElementHoly は聖属性です。
+	ElementHoly Element = "holy"
+
+	// ElementDark は闇属性です。
+	ElementDark Element = "dark"
+)
+
+// AllElements は無属性を除く全属性です（表示順）。
+var AllElements = []Element{
+	ElementFire,
+	ElementIce,
+	ElementThunder,
+	ElementPoison,
+	ElementHoly,
+	ElementDark,
+}
+
+// String は属性の日本語表示名を返します。
+func (e Element) String() string {
+	switch e {
+	case ElementNone:
+		return "無"
+	case ElementFire:
+		return "炎"
+	case ElementIce:
+		return "氷"
+	case ElementThunder:
+		return "雷"
+	case ElementPoison:
+		return "毒"
+	case ElementHoly:
+		return "聖"
+	case ElementDark:
+		return "闇"
+	default:
+		return string(e)
+	}
+}
+
+// ElementAffinity は属性ごとの被ダメージ倍率です。
+// 1.0より大きい値は弱点、1.0より小さい値は耐性を表します。未設定の属性は1.0です。
+type ElementAffinity map[Element]float64
+
+// Multiplier は指定属性の被ダメージ倍率を返します。
+// 無属性または未設定の場合は1.0を返します。
+func (a ElementAffinity) Multiplier(element Element) float64 {
+	if element == ElementNone {
+		return 1.0
+	}
+	if rate, ok := a[element]; ok && rate >= 0 {
+		return rate
+	}
+	return 1.0
+}
+
+// IsWeakTo は指定属性が弱点かを判定します。
+func (a ElementAffinity) IsWeakTo(element Element) bool {
+	return a.Multiplier(element) > 1.0
+}
+
+// Weaknesses は弱点属性をAllElementsの順で返します。
+func (a ElementAffinity) Weaknesses() []Element {
+	var result []Element
+	for _, element := range AllElements {
+		if a.Multiplier(element) > 1.0 {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// Resistances は耐性属性をAllElementsの順で返します。
+func (a ElementAffinity) Resistances() []Element {
+	var result []Element
+	for _, element := range AllElements {
+		if a.Multiplier(element) < 1.0 {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// Describe は弱点・耐性を「炎×1.5 / 毒×0.5」形式で返します。
+// 設定がない場合は空文字を返します。
+func (a ElementAffinity) Describe() string {
+	elements := make([]Element, 0, len(a))
+	for element, rate := range a {
+		if element != ElementNone && rate != 1.0 {
+			elements = append(elements, element)
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return elementOrder(elements[i]) < elementOrder(elements[j])
+	})
+
+	parts := make([]string, len(elements))
+	for i, element := range elements {
+		parts[i] = fmt.Sprintf("%s×%g", element, a[element])
+	}
+	return strings.Join(parts, " / ")
+}
+
+// elementOrder はAllElementsにおける表示順を返します（未知の属性は末尾）。
+func elementOrder(element Element) int {
+	for i, e := range AllElements {
+		if e == element {
+			return i
+		}
+	}
+	return len(AllElements)
+}
+
+// ========== 属性別軽減 ==========
+
+// elementCutColumns は属性と属性別被ダメ軽減列の対応です。
+var elementCutColumns = map[Element]EffectColumn{
+	ElementFire:    ColFireCut,
+	ElementIce:     ColIceCut,
+	ElementThunder: ColThunderCut,
+	ElementPoison:  ColPoisonCut,
+	ElementHoly:    ColHolyCut,
+	ElementDark:    ColDarkCut,
+}
+
+// ElementCutColumn は属性に対応する属性別被ダメ軽減列を返します。
+func ElementCutColumn(element Element) (EffectColumn, bool) {
+	col, ok := elementCutColumns[element]
+	return col, ok
+}
+
+// elementOfCutColumn は属性別被ダメ軽減列に対応する属性を返します。
+func elementOfCutColumn(col EffectColumn) (Element, bool) {
+	for element, c := range elementCutColumns {
+		if c == col {
+			return element, true
+		}
+	}
+	return ElementNone, false
+}
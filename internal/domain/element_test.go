@@ -0,0 +1,64 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+import "testing"
+
+// TestElementAffinity_Multiplier は属性倍率の取得をテストします。
+func TestElementAffinity_Multiplier(t *testing.T) {
+	affinity := ElementAffinity{ElementFire: 1.5, ElementPoison: 0.5}
+
+	tests := []struct {
+		element Element
+		want    float64
+	}{
+		{ElementFire, 1.5},
+		{ElementPoison, 0.5},
+		{ElementIce, 1.0},
+		{ElementNone, 1.0},
+	}
+	for _, tt := range tests {
+		if got := affinity.Multiplier(tt.element); got != tt.want {
+			t.Errorf("Multiplier(%q) = %v, want %v", tt.element, got, tt.want)
+		}
+	}
+
+	var empty ElementAffinity
+	if got := empty.Multiplier(ElementFire); got != 1.0 {
+		t.Errorf("nilの倍率 = %v, want 1.0", got)
+	}
+}
+
+// TestElementAffinity_WeaknessesAndDescribe は弱点・耐性の抽出と表示をテストします。
+func TestElementAffinity_WeaknessesAndDescribe(t *testing.T) {
+	affinity := ElementAffinity{ElementDark: 0.5, ElementHoly: 1.5, ElementFire: 1.25}
+
+	weaknesses := affinity.Weaknesses()
+	if len(weaknesses) != 2 || weaknesses[0] != ElementFire || weaknesses[1] != ElementHoly {
+		t.Errorf("Weaknesses = %v, want [fire holy]", weaknesses)
+	}
+	if resistances := affinity.Resistances(); len(resistances) != 1 || resistances[0] != ElementDark {
+		t.Errorf("Resistances = %v, want [dark]", resistances)
+	}
+	if got := affinity.Describe(); got != "炎×1.25 / 聖×1.5 / 闇×0.5" {
+		t.Errorf("Describe = %q", got)
+	}
+}
+
+// TestEffectTable_ElementCut は属性別軽減列の集計（最大値）をテストします。
+func TestEffectTable_ElementCut(t *testing.T) {
+	table := NewEffectTable()
+	table.AddBuff("炎耐性小", 10.0, map[EffectColumn]float64{ColFireCut: 0.2})
+	table.AddBuff("炎耐性大", 10.0, map[EffectColumn]float64{ColFireCut: 0.5, ColIceCut: 0.1})
+
+	result := table.Aggregate(NewEffectContext(100, 100, 100, 100))
+
+	if got := result.ElementCutFor(ElementFire); got != 0.5 {
+		t.Errorf("炎軽減 = %v, want 0.5", got)
+	}
+	if got := result.ElementCutFor(ElementIce); got != 0.1 {
+		t.Errorf("氷軽減 = %v, want 0.1", got)
+	}
+	if got := result.ElementCutFor(ElementNone); got != 0 {
+		t.Errorf("無属性軽減 = %v, want 0", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+// MaxEncounterMembers は1ウェーブに同時に出現できる敵の最大数です。
+const MaxEncounterMembers = 3
+
+// EncounterMember はエンカウントに登場する敵1体の定義です。
+type EncounterMember struct {
+	// EnemyTypeID は敵タイプIDです。
+	EnemyTypeID string
+
+	// Level は敵のレベルです。
+	Level int
+}
+
+// EncounterWave は同時に出現する敵のグループ（ウェーブ）です。
+// 前のウェーブの敵を全て倒すと次のウェーブが出現します。
+type EncounterWave struct {
+	// Members はこのウェーブに出現する敵です。
+	Members []EncounterMember
+}
+
+// Encounter は複数の敵・複数ウェーブで構成されるバトルの定義です。
+type Encounter struct {
+	// ID はエンカウントの一意識別子です。
+	ID string
+
+	// Name はエンカウントの表示名です。
+	Name string
+
+	// Waves は出現順のウェーブです。
+	Waves []EncounterWave
+}
+
+// Level はエンカウントのレベル（登場する敵の最高レベル）を返します。
+// 報酬計算や到達レベルの記録に使用します。
+func (e Encounter) Level() int {
+	level := 0
+	for _, wave := range e.Waves {
+		for _, member := range wave.Members {
+			if member.Level > level {
+				level = member.Level
+			}
+		}
+	}
+	return level
+}
+
+// MemberTypeIDs は登場する敵タイプIDを重複なく出現順に返します。
+func (e Encounter) MemberTypeIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, wave := range e.Waves {
+		for _, member := range wave.Members {
+			if !seen[member.EnemyTypeID] {
+				seen[member.EnemyTypeID] = true
+				ids = append(ids, member.EnemyTypeID)
+			}
+		}
+	}
+	return ids
+}
+
+// EnemyCount は全ウェーブの敵の総数を返します。
+func (e Encounter) EnemyCount() int {
+	count := 0
+	for _, wave := range e.Waves {
+		count += len(wave.Members)
+	}
+	return count
+}
@@ -0,0 +1,41 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+import "testing"
+
+// newTestEncounter はテスト用のエンカウントを作成するヘルパー関数です。
+func newTestEncounter() Encounter {
+	return Encounter{
+		ID:   "slime_pack",
+		Name: "スライムの群れ",
+		Waves: []EncounterWave{
+			{Members: []EncounterMember{{EnemyTypeID: "slime", Level: 3}, {EnemyTypeID: "slime", Level: 3}}},
+			{Members: []EncounterMember{{EnemyTypeID: "bat", Level: 4}, {EnemyTypeID: "slime", Level: 5}}},
+		},
+	}
+}
+
+// TestEncounter_Level はエンカウントのレベルが登場する敵の最高レベルになることをテストします。
+func TestEncounter_Level(t *testing.T) {
+	if got := newTestEncounter().Level(); got != 5 {
+		t.Errorf("エンカウントのレベル: got %d, want 5", got)
+	}
+	if got := (Encounter{}).Level(); got != 0 {
+		t.Errorf("空のエンカウントのレベル: got %d, want 0", got)
+	}
+}
+
+// TestEncounter_MemberTypeIDs は登場する敵タイプIDが重複なく出現順に返されることをテストします。
+func TestEncounter_MemberTypeIDs(t *testing.T) {
+	ids := newTestEncounter().MemberTypeIDs()
+	if len(ids) != 2 || ids[0] != "slime" || ids[1] != "bat" {
+		t.Errorf("敵タイプIDが不正: %v", ids)
+	}
+}
+
+// TestEncounter_EnemyCount は全ウェーブの敵の総数をテストします。
+func TestEncounter_EnemyCount(t *testing.T) {
+	if got := newTestEncounter().EnemyCount(); got != 4 {
+		t.Errorf("敵の総数: got %d, want 4", got)
+	}
+}
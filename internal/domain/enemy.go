@@ -6,9 +6,11 @@ import (
 )
 
 // EnhanceThreshold は敵が強化フェーズに移行するHP割合の閾値です（50%）。
+// フェーズ定義（Phases）を持たない敵タイプで使用されます。
 const EnhanceThreshold = 0.5
 
 // EnemyPhase は敵のフェーズを表す型です。
+// 値はフェーズ定義（EnemyType.GetPhases）のインデックスです。
 type EnemyPhase int
 
 const (
@@ -60,6 +62,39 @@ func (m EnemyWaitMode) String() string {
 	}
 }
 
+// ParryResult はチャージ中の行動に対するパリィの結果を表す型です。
+type ParryResult int
+
+const (
+	// ParryNone はパリィを試みていないことを示します。
+	ParryNone ParryResult = iota
+
+	// ParryPerfect はミスなしでパリィに成功したことを示します（行動を無効化）。
+	ParryPerfect
+
+	// ParryPartial はミスありでパリィを完了したことを示します（ダメージを軽減）。
+	ParryPartial
+
+	// ParryFailed はパリィに失敗したことを示します（被ダメージが増加）。
+	ParryFailed
+)
+
+// String はParryResultの日本語表示名を返します。
+func (r ParryResult) String() string {
+	switch r {
+	case ParryNone:
+		return "なし"
+	case ParryPerfect:
+		return "完全パリィ"
+	case ParryPartial:
+		return "パリィ"
+	case ParryFailed:
+		return "パリィ失敗"
+	default:
+		return "不明"
+	}
+}
+
 // EnemyType は敵の種類（タイプ）を定義する構造体です。
 // 外部データファイル（enemies.json）から読み込まれます。
 type EnemyType struct {
@@ -98,12 +133,20 @@ type EnemyType struct {
 	// ResolvedEnhancedActions は解決済みの強化行動パターンです（ランタイムで設定）。
 	ResolvedEnhancedActions []EnemyAction
 
+	// ActionRules は条件付き・重み付きの行動選択ルールです。
+	// 現在のフェーズで有効なルールがある場合は行動パターンの代わりにルールで行動を選びます。
+	ActionRules []EnemyActionRule
+
 	// NormalPassive は通常状態で適用されるパッシブスキルです。
 	NormalPassive *EnemyPassiveSkill
 
 	// EnhancedPassive は強化状態で適用されるパッシブスキルです。
 	EnhancedPassive *EnemyPassiveSkill
 
+	// Phases は多段階フェーズの定義です。
+	// 設定されている場合は通常/強化の行動パターン・パッシブの代わりに使用されます。
+	Phases []EnemyPhaseDef
+
 	// DropItemCategory はドロップアイテムのカテゴリ（"core" または "module"）です。
 	DropItemCategory string
 
@@ -115,6 +158,15 @@ type EnemyType struct {
 	// VoltageRisePer10s は10秒間でのボルテージ上昇量です。
 	// 0の場合はボルテージが上昇しません。デフォルト値は10（infra層で設定）。
 	VoltageRisePer10s float64
+
+	// VoltageThresholds はボルテージの到達段階です（ボルテージの昇順）。
+	// 段階に到達すると敵が激昂し、行動が変化します。
+	VoltageThresholds []VoltageThreshold
+
+	// ========== 属性 ==========
+
+	// ElementAffinity はプレイヤーの属性攻撃に対する被ダメージ倍率（弱点・耐性）です。
+	ElementAffinity ElementAffinity
 }
 
 // IsValidDefaultLevel はデフォルトレベルが有効範囲（1〜100）かどうかを判定します。
@@ -124,7 +176,10 @@ func (e EnemyType) IsValidDefaultLevel() bool {
 
 // HasValidNormalActionPattern は通常行動パターンが有効（最低1つの行動を持つ）かどうかを判定します。
 func (e EnemyType) HasValidNormalActionPattern() bool {
-	return len(e.NormalActionPatternIDs) > 0 || len(e.ResolvedNormalActions) > 0
+	if len(e.NormalActionPatternIDs) > 0 || len(e.ResolvedNormalActions) > 0 || len(e.ActionRules) > 0 {
+		return true
+	}
+	return len(e.Phases) > 0 && (len(e.Phases[0].ActionPatternIDs) > 0 || len(e.Phases[0].ResolvedActions) > 0)
 }
 
 // SetResolvedActions は行動IDから解決された行動パターンを設定します。
@@ -195,6 +250,9 @@ type EnemyModel struct {
 	// ActivePassiveID は現在適用中のパッシブスキルIDです（解除時に使用）。
 	ActivePassiveID string
 
+	// ActionCooldowns は行動IDごとの残りクールダウン（行動回数）です。
+	ActionCooldowns map[string]int
+
 	// ========== 待機状態管理フィールド ==========
 
 	// WaitMode は敵の現在の待機状態を示します（チャージ中/ディフェンス中）。
@@ -209,6 +267,9 @@ type EnemyModel struct {
 	// PendingAction はチャージ後に実行する行動です。
 	PendingAction *EnemyAction
 
+	// Parry はチャージ中の行動に対するパリィの結果です（チャージ開始時にリセット）。
+	Parry ParryResult
+
 	// DefenseStartTime はディフェンス開始時刻です。
 	DefenseStartTime time.Time
 
@@ -226,6 +287,9 @@ type EnemyModel struct {
 	// Voltage は現在のボルテージ値です（100.0 = 100%）。
 	// 時間経過で上昇し、プレイヤーのダメージ乗算に使用されます。
 	Voltage float64
+
+	// VoltageStage は到達済みのボルテージ段階の数です（0 = 未到達）。
+	VoltageStage int
 }
 
 // NewEnemy は新しいEnemyModelを作成します。
@@ -262,6 +326,16 @@ func (e *EnemyModel) IsAlive() bool {
 	return e.HP > 0
 }
 
+// Heal はHPを回復します（最大HPを超えません）。実際の回復量を返します。
+func (e *EnemyModel) Heal(amount int) int {
+	before := e.HP
+	e.HP += amount
+	if e.HP > e.MaxHP {
+		e.HP = e.MaxHP
+	}
+	return e.HP - before
+}
+
 // GetHPPercentage はHPの残り割合を0.0〜1.0で返します。
 func (e *EnemyModel) GetHPPercentage() float64 {
 	if e.MaxHP == 0 {
@@ -270,13 +344,10 @@ func (e *EnemyModel) GetHPPercentage() float64 {
 	return float64(e.HP) / float64(e.MaxHP)
 }
 
-// ShouldTransitionToEnhanced は強化フェーズに移行すべきかどうかを判定します。
-// 既にPhaseEnhancedの場合はfalseを返します。
+// ShouldTransitionToEnhanced は次のフェーズに移行すべきかどうかを判定します。
+// 既に最終フェーズの場合はfalseを返します。
 func (e *EnemyModel) ShouldTransitionToEnhanced() bool {
-	if e.Phase == PhaseEnhanced {
-		return false
-	}
-	return e.GetHPPercentage() <= EnhanceThreshold
+	return e.nextPhase() > e.Phase
 }
 
 // TransitionToEnhanced は強化フェーズに移行します。
@@ -285,34 +356,42 @@ func (e *EnemyModel) TransitionToEnhanced() {
 }
 
 // CheckAndTransitionPhase はHPをチェックし、必要に応じてフェーズ移行を実行します。
+// 一度に複数の閾値を下回った場合は最も後のフェーズまで進みます。
 // フェーズ移行した場合はtrueを返します。
 func (e *EnemyModel) CheckAndTransitionPhase() bool {
-	if e.ShouldTransitionToEnhanced() {
-		e.TransitionToEnhanced()
-		return true
+	next := e.nextPhase()
+	if next == e.Phase {
+		return false
 	}
-	return false
+	e.TransitionToPhase(next)
+	return true
 }
 
-// IsEnhanced は現在強化フェーズかどうかを返します。
+// IsEnhanced は最初のフェーズから移行済みかどうかを返します。
 func (e *EnemyModel) IsEnhanced() bool {
-	return e.Phase == PhaseEnhanced
+	return e.Phase > PhaseNormal
 }
 
 // GetPhaseString は現在のフェーズの表示文字列を返します。
 func (e *EnemyModel) GetPhaseString() string {
+	if name := e.GetPhaseDef().Name; name != "" {
+		return name
+	}
 	return e.Phase.String()
 }
 
 // ========== 行動管理メソッド ==========
 
 // GetCurrentPattern は現在のフェーズに対応する行動パターンを返します。
-// 強化フェーズで強化パターンが空の場合は通常パターンを継続します。
+// 現在のフェーズの行動パターンが空の場合は直前のフェーズの行動パターンを継続します。
 func (e *EnemyModel) GetCurrentPattern() []EnemyAction {
-	if e.Phase == PhaseEnhanced && len(e.Type.ResolvedEnhancedActions) > 0 {
-		return e.Type.ResolvedEnhancedActions
+	phases := e.Type.GetPhases()
+	for i := int(e.Phase); i >= 0; i-- {
+		if i < len(phases) && len(phases[i].ResolvedActions) > 0 {
+			return phases[i].ResolvedActions
+		}
 	}
-	return e.Type.ResolvedNormalActions
+	return nil
 }
 
 // GetCurrentAction は現在実行すべき行動を返します。
@@ -361,7 +440,11 @@ func (e *EnemyModel) SetNextAction(action *EnemyAction) {
 // PrepareNextAction は現在の行動パターンから次の行動を取得し、PendingActionに設定します。
 // この関数はチャージ開始前に呼ばれることを想定しています。
 func (e *EnemyModel) PrepareNextAction() {
-	action := e.GetCurrentAction()
+	e.PrepareAction(e.GetCurrentAction())
+}
+
+// PrepareAction は指定した行動を次回行動としてPendingActionに設定します。
+func (e *EnemyModel) PrepareAction(action EnemyAction) {
 	e.PendingAction = &action
 	e.CurrentChargeTime = action.ChargeTime
 }
@@ -374,6 +457,7 @@ func (e *EnemyModel) StartCharging(action EnemyAction, now time.Time) {
 	e.ChargeStartTime = now
 	e.CurrentChargeTime = action.ChargeTime
 	e.PendingAction = &action
+	e.Parry = ParryNone
 }
 
 // GetChargeProgress はチャージ進捗（0.0〜1.0）を返します。
@@ -410,6 +494,30 @@ func (e *EnemyModel) IsChargeComplete(now time.Time) bool {
 	return now.Sub(e.ChargeStartTime) >= e.CurrentChargeTime
 }
 
+// IsInParryWindow はチャージ中の行動がパリィ受付時間内かどうかを返します。
+// 行動にパリィ受付時間が設定されており、チャージ残り時間がその範囲内で、
+// まだパリィを試みていない場合にtrueを返します。
+func (e *EnemyModel) IsInParryWindow(now time.Time) bool {
+	if e.WaitMode != WaitModeCharging || e.PendingAction == nil || e.Parry != ParryNone {
+		return false
+	}
+	window := e.PendingAction.ParryWindow
+	if window <= 0 {
+		return false
+	}
+	remaining := e.GetChargeRemainingTime(now)
+	return remaining > 0 && remaining <= window
+}
+
+// DelayCharge はチャージの進行をdだけ遅らせます（スタン中など）。
+// チャージ中でない場合は何もしません。
+func (e *EnemyModel) DelayCharge(d time.Duration) {
+	if e.WaitMode != WaitModeCharging {
+		return
+	}
+	e.ChargeStartTime = e.ChargeStartTime.Add(d)
+}
+
 // ExecuteChargedAction はチャージ完了した行動を実行可能状態にします。
 // 実行後は行動インデックスを進めます。
 func (e *EnemyModel) ExecuteChargedAction() *EnemyAction {
@@ -500,6 +608,12 @@ const (
 
 	// EnemyActionDefense はディフェンス行動です。
 	EnemyActionDefense
+
+	// EnemyActionHeal は自己回復行動です。
+	EnemyActionHeal
+
+	// EnemyActionDispel はプレイヤーのバフを解除する行動です。
+	EnemyActionDispel
 )
 
 // String はEnemyActionTypeの日本語表示名を返します。
@@ -513,6 +627,10 @@ func (t EnemyActionType) String() string {
 		return "デバフ"
 	case EnemyActionDefense:
 		return "ディフェンス"
+	case EnemyActionHeal:
+		return "回復"
+	case EnemyActionDispel:
+		return "解除"
 	default:
 		return "不明"
 	}
@@ -548,6 +666,9 @@ type EnemyAction struct {
 	// ChargeTime はチャージタイム（行動決定から実行までの時間）です。
 	ChargeTime time.Duration
 
+	// ParryWindow はチャージ終盤のパリィ受付時間です（0はパリィ不可）。
+	ParryWindow time.Duration
+
 	// ========== 攻撃行動用フィールド ==========
 
 	// AttackType は攻撃行動時の攻撃属性（"physical" または "magic"）です。
@@ -559,8 +680,8 @@ type EnemyAction struct {
 	// DamagePerLevel はレベル係数 (b) です。ダメージ = a + Lv * b
 	DamagePerLevel float64
 
-	// Element は攻撃の属性（"fire", "water", "dark"等）です。空文字は無属性。
-	Element string
+	// Element は攻撃の属性（"fire", "poison", "dark"等）です。空文字は無属性。
+	Element Element
 
 	// ========== バフ/デバフ行動用フィールド ==========
 
@@ -568,6 +689,7 @@ type EnemyAction struct {
 	EffectType string
 
 	// EffectValue はバフ/デバフ行動時の効果値です。
+	// 回復行動では最大HPに対する回復割合（0.0〜1.0）です。
 	EffectValue float64
 
 	// Duration はバフ/デバフ/ディフェンスの持続時間（秒）です。
@@ -605,6 +727,16 @@ func (a EnemyAction) IsDefense() bool {
 	return a.ActionType == EnemyActionDefense
 }
 
+// IsHeal は自己回復行動かどうかを判定します。
+func (a EnemyAction) IsHeal() bool {
+	return a.ActionType == EnemyActionHeal
+}
+
+// IsDispel はバフ解除行動かどうかを判定します。
+func (a EnemyAction) IsDispel() bool {
+	return a.ActionType == EnemyActionDispel
+}
+
 // CalculateDamage はレベルに応じたダメージを計算します。
 // ダメージ = DamageBase + Level * DamagePerLevel
 func (a EnemyAction) CalculateDamage(level int) int {
@@ -615,6 +747,21 @@ func (a EnemyAction) CalculateDamage(level int) int {
 	return int(damage)
 }
 
+// CalculateHeal は回復行動の回復量を計算します。
+// 回復量 = maxHP * EffectValue（最低1）
+func (a EnemyAction) CalculateHeal(maxHP int) int {
+	amount := int(float64(maxHP) * a.EffectValue)
+	if amount < 1 {
+		return 1
+	}
+	return amount
+}
+
+// IsParryable はパリィ可能な行動かどうかを判定します。
+func (a EnemyAction) IsParryable() bool {
+	return a.ParryWindow > 0
+}
+
 // GetChargeTimeMs はチャージタイムをミリ秒で返します。
 func (a EnemyAction) GetChargeTimeMs() int64 {
 	return a.ChargeTime.Milliseconds()
@@ -0,0 +1,165 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+// ========== 条件付き行動選択 ==========
+
+// EnemyConditionType は行動ルールの発動条件の種類を表す型です。
+type EnemyConditionType string
+
+const (
+	// ConditionSelfHPBelow は敵自身のHP割合が値（0.0〜1.0）未満の条件です。
+	ConditionSelfHPBelow EnemyConditionType = "self_hp_below"
+
+	// ConditionPlayerHPBelow はプレイヤーのHP割合が値（0.0〜1.0）未満の条件です。
+	ConditionPlayerHPBelow EnemyConditionType = "player_hp_below"
+
+	// ConditionPlayerBuffsAtLeast はプレイヤーのバフ数が値以上の条件です。
+	ConditionPlayerBuffsAtLeast EnemyConditionType = "player_buffs_at_least"
+
+	// ConditionSelfDebuffsAtLeast は敵自身のデバフ数が値以上の条件です。
+	ConditionSelfDebuffsAtLeast EnemyConditionType = "self_debuffs_at_least"
+
+	// ConditionVoltageAbove は敵のボルテージが値（100.0 = 100%）を超える条件です。
+	ConditionVoltageAbove EnemyConditionType = "voltage_above"
+)
+
+// IsValid は既知の条件種別かどうかを判定します。
+func (t EnemyConditionType) IsValid() bool {
+	switch t {
+	case ConditionSelfHPBelow, ConditionPlayerHPBelow, ConditionPlayerBuffsAtLeast,
+		ConditionSelfDebuffsAtLeast, ConditionVoltageAbove:
+		return true
+	default:
+		return false
+	}
+}
+
+// EnemyActionCondition は行動ルールの発動条件です。
+type EnemyActionCondition struct {
+	// Type は条件の種類です。
+	Type EnemyConditionType
+
+	// Value は条件の閾値です（意味は種類ごとに異なります）。
+	Value float64
+}
+
+// EnemyConditionContext は行動ルールの条件判定に使うバトルの状況です。
+type EnemyConditionContext struct {
+	// SelfHPRatio は敵自身のHP割合（0.0〜1.0）です。
+	SelfHPRatio float64
+
+	// PlayerHPRatio はプレイヤーのHP割合（0.0〜1.0）です。
+	PlayerHPRatio float64
+
+	// PlayerBuffCount はプレイヤーに付与されているバフの数です。
+	PlayerBuffCount int
+
+	// SelfDebuffCount は敵自身に付与されているデバフの数です。
+	SelfDebuffCount int
+
+	// Voltage は敵の現在のボルテージです（100.0 = 100%）。
+	Voltage float64
+}
+
+// IsSatisfied は条件を満たしているかどうかを判定します。
+// 未知の条件種別は満たさないものとして扱います。
+func (c EnemyActionCondition) IsSatisfied(ctx EnemyConditionContext) bool {
+	switch c.Type {
+	case ConditionSelfHPBelow:
+		return ctx.SelfHPRatio < c.Value
+	case ConditionPlayerHPBelow:
+		return ctx.PlayerHPRatio < c.Value
+	case ConditionPlayerBuffsAtLeast:
+		return float64(ctx.PlayerBuffCount) >= c.Value
+	case ConditionSelfDebuffsAtLeast:
+		return float64(ctx.SelfDebuffCount) >= c.Value
+	case ConditionVoltageAbove:
+		return ctx.Voltage > c.Value
+	default:
+		return false
+	}
+}
+
+// EnemyActionRule は敵の行動選択ルールです。
+// 条件付きルールは条件を満たした場合に優先して選ばれ、
+// 条件なしルールは重み付きランダムで選ばれます。
+type EnemyActionRule struct {
+	// ActionID は選択する行動のIDです。
+	ActionID string
+
+	// Action は解決済みの行動です（ランタイムで設定）。
+	Action EnemyAction
+
+	// Phases はルールが有効なフェーズです。空の場合は全フェーズで有効です。
+	Phases []EnemyPhase
+
+	// Condition は発動条件です。nilの場合は条件なしルールです。
+	Condition *EnemyActionCondition
+
+	// Weight は同じ優先度の候補から選ぶ際の重みです（0以下の場合は1として扱います）。
+	Weight int
+
+	// CooldownTurns は使用後に再び選ばれるまでに挟む行動回数です。
+	CooldownTurns int
+}
+
+// IsActiveInPhase は指定フェーズでルールが有効かどうかを判定します。
+func (r EnemyActionRule) IsActiveInPhase(phase EnemyPhase) bool {
+	if len(r.Phases) == 0 {
+		return true
+	}
+	for _, p := range r.Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWeight は選択時の重みを返します（0以下の場合は1）。
+func (r EnemyActionRule) GetWeight() int {
+	if r.Weight <= 0 {
+		return 1
+	}
+	return r.Weight
+}
+
+// ========== 行動クールダウン ==========
+
+// IsActionOnCooldown は指定した行動がクールダウン中かどうかを返します。
+func (e *EnemyModel) IsActionOnCooldown(actionID string) bool {
+	return e.ActionCooldowns[actionID] > 0
+}
+
+// SetActionCooldown は指定した行動のクールダウン（行動回数）を設定します。
+func (e *EnemyModel) SetActionCooldown(actionID string, turns int) {
+	if turns <= 0 {
+		return
+	}
+	if e.ActionCooldowns == nil {
+		e.ActionCooldowns = make(map[string]int)
+	}
+	e.ActionCooldowns[actionID] = turns
+}
+
+// TickActionCooldowns は全ての行動のクールダウンを1行動分進めます。
+func (e *EnemyModel) TickActionCooldowns() {
+	for id, turns := range e.ActionCooldowns {
+		if turns <= 1 {
+			delete(e.ActionCooldowns, id)
+			continue
+		}
+		e.ActionCooldowns[id] = turns - 1
+	}
+}
+
+// GetActionRules は現在のフェーズで有効な行動ルールを返します。
+func (e *EnemyModel) GetActionRules() []EnemyActionRule {
+	var rules []EnemyActionRule
+	for _, rule := range e.Type.ActionRules {
+		if rule.IsActiveInPhase(e.Phase) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,121 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+// ========== 多段階フェーズ ==========
+
+// EnemyPhaseDef は敵のフェーズ1段階分の定義です。
+// ボスのように3段階以上のフェーズを持つ敵は、フェーズごとに
+// HP閾値・行動パターン・パッシブ・外観・移行時の行動を定義します。
+type EnemyPhaseDef struct {
+	// ID はフェーズの識別子です（行動ルールのフェーズ指定に使用）。
+	ID string
+
+	// Name はフェーズの表示名です。
+	Name string
+
+	// HPThreshold はこのフェーズに移行するHP割合（0.0〜1.0、以下で移行）です。
+	// 最初のフェーズでは使用しません。
+	HPThreshold float64
+
+	// ActionPatternIDs はこのフェーズの行動パターンIDの配列です。
+	ActionPatternIDs []string
+
+	// ResolvedActions は解決済みの行動パターンです（ランタイムで設定）。
+	// 空の場合は直前のフェーズの行動パターンを継続します。
+	ResolvedActions []EnemyAction
+
+	// PassiveID はこのフェーズで適用されるパッシブスキルIDです。
+	PassiveID string
+
+	// Passive は解決済みのパッシブスキルです（ランタイムで設定）。
+	Passive *EnemyPassiveSkill
+
+	// ASCIIArt はこのフェーズの外観です。空の場合は敵タイプの外観を使用します。
+	ASCIIArt string
+
+	// TransitionActionID はこのフェーズに移行した瞬間に実行する行動IDです（任意）。
+	TransitionActionID string
+
+	// TransitionAction は解決済みの移行時行動です（ランタイムで設定）。
+	TransitionAction *EnemyAction
+}
+
+// GetPhases は敵タイプのフェーズ定義を返します。
+// Phasesが未設定の場合は通常・強化の2フェーズを従来のフィールドから組み立てます。
+func (e EnemyType) GetPhases() []EnemyPhaseDef {
+	if len(e.Phases) > 0 {
+		return e.Phases
+	}
+	return []EnemyPhaseDef{
+		{
+			ID:              "normal",
+			Name:            PhaseNormal.String(),
+			ResolvedActions: e.ResolvedNormalActions,
+			Passive:         e.NormalPassive,
+		},
+		{
+			ID:              "enhanced",
+			Name:            PhaseEnhanced.String(),
+			HPThreshold:     EnhanceThreshold,
+			ResolvedActions: e.ResolvedEnhancedActions,
+			Passive:         e.EnhancedPassive,
+		},
+	}
+}
+
+// GetPhaseCount はフェーズ数を返します。
+func (e EnemyType) GetPhaseCount() int {
+	return len(e.GetPhases())
+}
+
+// GetPhaseDef は現在のフェーズの定義を返します。
+func (e *EnemyModel) GetPhaseDef() EnemyPhaseDef {
+	phases := e.Type.GetPhases()
+	index := int(e.Phase)
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(phases) {
+		index = len(phases) - 1
+	}
+	return phases[index]
+}
+
+// nextPhase は現在のHP割合で移行すべきフェーズを返します。
+// 一度に複数の閾値を下回った場合は最も後のフェーズを返します。
+func (e *EnemyModel) nextPhase() EnemyPhase {
+	phases := e.Type.GetPhases()
+	ratio := e.GetHPPercentage()
+	next := e.Phase
+	for i := int(e.Phase) + 1; i < len(phases); i++ {
+		if ratio > phases[i].HPThreshold {
+			break
+		}
+		next = EnemyPhase(i)
+	}
+	return next
+}
+
+// TransitionToPhase は指定したフェーズに移行します。
+func (e *EnemyModel) TransitionToPhase(phase EnemyPhase) {
+	e.Phase = phase
+}
+
+// IsFinalPhase は最終フェーズかどうかを返します。
+func (e *EnemyModel) IsFinalPhase() bool {
+	return int(e.Phase) >= e.Type.GetPhaseCount()-1
+}
+
+// GetCurrentPassive は現在のフェーズで適用されるパッシブスキルを返します。
+func (e *EnemyModel) GetCurrentPassive() *EnemyPassiveSkill {
+	return e.GetPhaseDef().Passive
+}
+
+// GetCurrentASCIIArt は現在のフェーズの外観を返します。
+// フェーズに外観が設定されていない場合は敵タイプの外観を返します。
+func (e *EnemyModel) GetCurrentASCIIArt() string {
+	if art := e.GetPhaseDef().ASCIIArt; art != "" {
+		return art
+	}
+	return e.Type.ASCIIArt
+}
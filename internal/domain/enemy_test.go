@@ -1471,6 +1471,38 @@ func TestEnemyModel_ExecuteChargedAction(t *testing.T) {
 	}
 }
 
+// TestEnemyModel_IsInParryWindow はチャージ終盤のパリィ受付時間の判定を確認します。
+func TestEnemyModel_IsInParryWindow(t *testing.T) {
+	enemy := NewEnemy("test", "テスト敵", 1, 100, 10, EnemyType{})
+	start := time.Now()
+	enemy.StartCharging(EnemyAction{ID: "slash", ActionType: EnemyActionAttack, ChargeTime: 3 * time.Second, ParryWindow: time.Second}, start)
+
+	if enemy.IsInParryWindow(start.Add(1500 * time.Millisecond)) {
+		t.Error("受付時間より前はパリィできないべきです")
+	}
+	if !enemy.IsInParryWindow(start.Add(2500 * time.Millisecond)) {
+		t.Error("チャージ残り1秒以内はパリィできるべきです")
+	}
+	if enemy.IsInParryWindow(start.Add(3 * time.Second)) {
+		t.Error("チャージ完了後はパリィできないべきです")
+	}
+
+	// パリィを試みた後は受付しない
+	enemy.Parry = ParryPartial
+	if enemy.IsInParryWindow(start.Add(2500 * time.Millisecond)) {
+		t.Error("パリィ済みの行動は再度パリィできないべきです")
+	}
+
+	// 次のチャージ開始でパリィの結果はリセットされる
+	enemy.StartCharging(EnemyAction{ID: "bite", ActionType: EnemyActionAttack, ChargeTime: 2 * time.Second}, start)
+	if enemy.Parry != ParryNone {
+		t.Errorf("チャージ開始時にパリィの結果がリセットされるべきです: got %v", enemy.Parry)
+	}
+	if enemy.IsInParryWindow(start.Add(1900 * time.Millisecond)) {
+		t.Error("パリィ受付時間のない行動はパリィできないべきです")
+	}
+}
+
 // TestEnemyModel_EndDefense はディフェンス終了処理を確認します。
 func TestEnemyModel_EndDefense(t *testing.T) {
 	actions := []EnemyAction{
@@ -1668,3 +1700,185 @@ func TestEnemyType_GetVoltageRisePer10s(t *testing.T) {
 		})
 	}
 }
+
+// TestEnemyActionCondition_IsSatisfied は行動ルールの発動条件の判定をテストします。
+func TestEnemyActionCondition_IsSatisfied(t *testing.T) {
+	ctx := EnemyConditionContext{
+		SelfHPRatio:     0.25,
+		PlayerHPRatio:   0.8,
+		PlayerBuffCount: 2,
+		SelfDebuffCount: 0,
+		Voltage:         210,
+	}
+
+	tests := []struct {
+		name      string
+		condition EnemyActionCondition
+		expected  bool
+	}{
+		{"自身のHP30%未満", EnemyActionCondition{Type: ConditionSelfHPBelow, Value: 0.3}, true},
+		{"自身のHP20%未満", EnemyActionCondition{Type: ConditionSelfHPBelow, Value: 0.2}, false},
+		{"プレイヤーのHP50%未満", EnemyActionCondition{Type: ConditionPlayerHPBelow, Value: 0.5}, false},
+		{"プレイヤーのバフ2つ以上", EnemyActionCondition{Type: ConditionPlayerBuffsAtLeast, Value: 2}, true},
+		{"自身のデバフ1つ以上", EnemyActionCondition{Type: ConditionSelfDebuffsAtLeast, Value: 1}, false},
+		{"ボルテージ200%超", EnemyActionCondition{Type: ConditionVoltageAbove, Value: 200}, true},
+		{"未知の条件", EnemyActionCondition{Type: "unknown", Value: 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.IsSatisfied(ctx); got != tt.expected {
+				t.Errorf("IsSatisfied() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEnemyModel_ActionCooldowns は行動クールダウンの設定と経過をテストします。
+func TestEnemyModel_ActionCooldowns(t *testing.T) {
+	enemy := NewEnemy("enemy_1", "テスト敵", 1, 100, 10, EnemyType{})
+
+	enemy.SetActionCooldown("heal", 2)
+	enemy.SetActionCooldown("attack", 0)
+	if !enemy.IsActionOnCooldown("heal") || enemy.IsActionOnCooldown("attack") {
+		t.Fatal("クールダウン0の行動はクールダウン中にならない")
+	}
+
+	enemy.TickActionCooldowns()
+	if !enemy.IsActionOnCooldown("heal") {
+		t.Error("1行動経過ではまだクールダウン中であるべき")
+	}
+	enemy.TickActionCooldowns()
+	if enemy.IsActionOnCooldown("heal") {
+		t.Error("2行動経過でクールダウンが明けるべき")
+	}
+}
+
+// TestEnemyModel_GetActionRules は現在のフェーズで有効な行動ルールだけが返されることをテストします。
+func TestEnemyModel_GetActionRules(t *testing.T) {
+	enemy := NewEnemy("enemy_1", "テスト敵", 1, 100, 10, EnemyType{
+		ActionRules: []EnemyActionRule{
+			{ActionID: "always"},
+			{ActionID: "enhanced_only", Phases: []EnemyPhase{PhaseEnhanced}},
+		},
+	})
+
+	if rules := enemy.GetActionRules(); len(rules) != 1 || rules[0].ActionID != "always" {
+		t.Errorf("通常フェーズのルール: got %+v", rules)
+	}
+
+	enemy.TransitionToEnhanced()
+	if rules := enemy.GetActionRules(); len(rules) != 2 {
+		t.Errorf("強化フェーズのルール数: got %d, want 2", len(rules))
+	}
+}
+
+// newPhasedTestEnemyType は3段階のフェーズを持つテスト用の敵タイプを作成します。
+func newPhasedTestEnemyType() EnemyType {
+	return EnemyType{
+		ID:       "lich",
+		Name:     "リッチ",
+		ASCIIArt: "base",
+		Phases: []EnemyPhaseDef{
+			{ID: "chant", Name: "詠唱", ResolvedActions: []EnemyAction{{ID: "curse", ActionType: EnemyActionAttack}}, Passive: &EnemyPassiveSkill{ID: "p1"}},
+			{ID: "awakened", Name: "覚醒", HPThreshold: 0.6, Passive: &EnemyPassiveSkill{ID: "p2"}, ASCIIArt: "awakened"},
+			{ID: "collapse", Name: "崩壊", HPThreshold: 0.25, ResolvedActions: []EnemyAction{{ID: "finisher", ActionType: EnemyActionAttack}}},
+		},
+	}
+}
+
+// TestEnemyModel_MultiPhaseTransition はHP閾値に応じて複数のフェーズを順に移行することをテストします。
+func TestEnemyModel_MultiPhaseTransition(t *testing.T) {
+	enemy := NewEnemy("enemy_1", "リッチ", 1, 100, 10, newPhasedTestEnemyType())
+
+	if enemy.GetPhaseString() != "詠唱" || enemy.GetCurrentASCIIArt() != "base" || enemy.GetCurrentPassive().ID != "p1" {
+		t.Errorf("最初のフェーズ: %s, 外観 %q", enemy.GetPhaseString(), enemy.GetCurrentASCIIArt())
+	}
+
+	enemy.HP = 61
+	if enemy.CheckAndTransitionPhase() {
+		t.Error("HP61%ではフェーズ移行しない")
+	}
+
+	enemy.HP = 60
+	if !enemy.CheckAndTransitionPhase() || enemy.Phase != 1 {
+		t.Fatalf("HP60%%で2番目のフェーズに移行すべき: Phase %d", enemy.Phase)
+	}
+	if enemy.GetPhaseString() != "覚醒" || enemy.GetCurrentASCIIArt() != "awakened" || enemy.GetCurrentPassive().ID != "p2" {
+		t.Errorf("2番目のフェーズ: %s, 外観 %q", enemy.GetPhaseString(), enemy.GetCurrentASCIIArt())
+	}
+	// 行動パターンが空のフェーズは直前のフェーズの行動パターンを継続
+	if action := enemy.GetCurrentAction(); action.ID != "curse" {
+		t.Errorf("直前のフェーズの行動を継続すべき: 実際 %s", action.ID)
+	}
+
+	enemy.HP = 10
+	if !enemy.CheckAndTransitionPhase() || enemy.Phase != 2 || !enemy.IsFinalPhase() {
+		t.Fatalf("HP10%%で最終フェーズに移行すべき: Phase %d", enemy.Phase)
+	}
+	if action := enemy.GetCurrentAction(); action.ID != "finisher" {
+		t.Errorf("最終フェーズの行動: 実際 %s", action.ID)
+	}
+	if enemy.ShouldTransitionToEnhanced() {
+		t.Error("最終フェーズからは移行しない")
+	}
+}
+
+// TestEnemyModel_MultiPhaseSkip は一度に複数の閾値を下回った場合に最も後のフェーズまで進むことをテストします。
+func TestEnemyModel_MultiPhaseSkip(t *testing.T) {
+	enemy := NewEnemy("enemy_1", "リッチ", 1, 100, 10, newPhasedTestEnemyType())
+
+	enemy.HP = 20
+	if !enemy.CheckAndTransitionPhase() || enemy.Phase != 2 {
+		t.Errorf("HP20%%では最終フェーズまで進むべき: Phase %d", enemy.Phase)
+	}
+}
+
+// TestEnemyType_GetPhases_Legacy はフェーズ定義がない敵タイプが通常・強化の2フェーズとして扱われることをテストします。
+func TestEnemyType_GetPhases_Legacy(t *testing.T) {
+	enemyType := EnemyType{
+		NormalPassive:   &EnemyPassiveSkill{ID: "normal"},
+		EnhancedPassive: &EnemyPassiveSkill{ID: "enhanced"},
+	}
+
+	phases := enemyType.GetPhases()
+	if len(phases) != 2 {
+		t.Fatalf("フェーズ数: got %d, want 2", len(phases))
+	}
+	if phases[0].Name != "通常" || phases[0].Passive.ID != "normal" {
+		t.Errorf("通常フェーズ: got %+v", phases[0])
+	}
+	if phases[1].Name != "強化" || phases[1].HPThreshold != EnhanceThreshold || phases[1].Passive.ID != "enhanced" {
+		t.Errorf("強化フェーズ: got %+v", phases[1])
+	}
+}
+
+// TestEnemyModel_VoltageStage はボルテージ段階の判定とチャージ時間倍率の適用をテストします。
+func TestEnemyModel_VoltageStage(t *testing.T) {
+	enemyType := EnemyType{VoltageThresholds: []VoltageThreshold{
+		{Voltage: 150, Name: "激昂", ChargeTimeMultiplier: 0.8},
+		{Voltage: 300, Name: "暴走", ChargeTimeMultiplier: 0.5},
+	}}
+	enemy := NewEnemy("enemy_1", "スライム", 1, 100, 10, enemyType)
+
+	cases := []struct {
+		voltage float64
+		stage   int
+	}{{100, 0}, {149.9, 0}, {150, 1}, {299, 1}, {300, 2}, {999, 2}}
+	for _, c := range cases {
+		if got := enemyType.VoltageStageFor(c.voltage); got != c.stage {
+			t.Errorf("ボルテージ%.1f%%の段階: got %d, want %d", c.voltage, got, c.stage)
+		}
+	}
+
+	if enemy.CurrentVoltageThreshold() != nil || enemy.VoltageChargeTime(4*time.Second) != 4*time.Second {
+		t.Error("段階に未到達の場合はチャージ時間が変化しないべき")
+	}
+	enemy.VoltageStage = 2
+	if threshold := enemy.CurrentVoltageThreshold(); threshold == nil || threshold.Name != "暴走" {
+		t.Errorf("到達済みの段階: got %+v", threshold)
+	}
+	if got := enemy.VoltageChargeTime(4 * time.Second); got != 2*time.Second {
+		t.Errorf("暴走中のチャージ時間: got %v, want 2s", got)
+	}
+}
@@ -0,0 +1,54 @@
+// Package domain はゲームのドメインモデルを定義します。
+package domain
+
+import "time"
+
+// ========== ボルテージ段階 ==========
+
+// VoltageThreshold はボルテージの到達段階（激昂）の定義です。
+// ボルテージが閾値に達すると激昂時行動を即座に実行し、
+// 閾値を下回るまで行動のチャージ時間が変化します。
+type VoltageThreshold struct {
+	// Voltage はこの段階に到達するボルテージです（150.0 = 150%、以上で到達）。
+	Voltage float64
+
+	// Name は段階の表示名です（例: 激昂）。
+	Name string
+
+	// ChargeTimeMultiplier はこの段階での行動のチャージ時間倍率です（0の場合は変化なし）。
+	ChargeTimeMultiplier float64
+
+	// EnrageActionID はこの段階に到達した瞬間に実行する行動IDです（任意）。
+	EnrageActionID string
+
+	// EnrageAction は解決済みの激昂時行動です（ランタイムで設定）。
+	EnrageAction *EnemyAction
+}
+
+// VoltageStageFor は指定のボルテージで到達している段階の数を返します。
+func (e EnemyType) VoltageStageFor(voltage float64) int {
+	stage := 0
+	for _, threshold := range e.VoltageThresholds {
+		if voltage >= threshold.Voltage {
+			stage++
+		}
+	}
+	return stage
+}
+
+// CurrentVoltageThreshold は到達済みの最も高いボルテージ段階を返します（未到達の場合はnil）。
+func (e *EnemyModel) CurrentVoltageThreshold() *VoltageThreshold {
+	if e.VoltageStage <= 0 || e.VoltageStage > len(e.Type.VoltageThresholds) {
+		return nil
+	}
+	return &e.Type.VoltageThresholds[e.VoltageStage-1]
+}
+
+// VoltageChargeTime は到達済みのボルテージ段階のチャージ時間倍率を適用したチャージ時間を返します。
+func (e *EnemyModel) VoltageChargeTime(chargeTime time.Duration) time.Duration {
+	threshold := e.CurrentVoltageThreshold()
+	if threshold == nil || threshold.ChargeTimeMultiplier <= 0 {
+		return chargeTime
+	}
+	return time.Duration(float64(chargeTime) * threshold.ChargeTimeMultiplier)
+}
@@ -14,7 +14,12 @@ const (
 	TargetEnemy EffectTarget = "enemy"
 
 	// TargetBoth は自分と敵の両方を対象とする効果です。
+	// HP効果では敵に絶対値のダメージを与え、正の値なら自分を回復（ドレイン）、負の値なら自傷します。
+	// カラム効果では同じ効果を自分と敵の両方にバフとして付与します（フィールド効果）。
 	TargetBoth EffectTarget = "both"
+
+	// TargetAll は生存している敵全体を対象とする効果です（複数敵バトル用の全体攻撃・全体デバフ）。
+	TargetAll EffectTarget = "all"
 )
 
 // HPFormula はHP増減の計算式を表します。
@@ -64,6 +69,9 @@ type ModuleEffect struct {
 	// 0: LUKの影響なし
 	LUKFactor float64
 
+	// Element はHP効果の属性です。敵へのダメージに敵の弱点・耐性が適用されます。
+	Element Element
+
 	// Icon は表示用アイコンです。
 	Icon string
 }
@@ -136,8 +144,12 @@ func (e *ModuleEffect) IsDamageEffect() bool {
 	if e.HPFormula == nil {
 		return false
 	}
-	// 敵対象かつHP減少（係数が正の場合、敵のHPを減らす = ダメージ）
-	return e.Target == TargetEnemy && (e.HPFormula.Base > 0 || e.HPFormula.StatCoef > 0)
+	// 両対象は符号によらず敵にダメージを与える
+	if e.Target == TargetBoth {
+		return e.HPFormula.Base != 0 || e.HPFormula.StatCoef != 0
+	}
+	// 敵対象（または敵全体）かつHP減少（係数が正の場合、敵のHPを減らす = ダメージ）
+	return (e.Target == TargetEnemy || e.Target == TargetAll) && (e.HPFormula.Base > 0 || e.HPFormula.StatCoef > 0)
 }
 
 // IsHealEffect は自分への回復効果かを判定します。
@@ -145,8 +157,8 @@ func (e *ModuleEffect) IsHealEffect() bool {
 	if e.HPFormula == nil {
 		return false
 	}
-	// 自分対象かつHP増加
-	return e.Target == TargetSelf && (e.HPFormula.Base > 0 || e.HPFormula.StatCoef > 0)
+	// 自分対象（または両対象のドレイン）かつHP増加
+	return (e.Target == TargetSelf || e.Target == TargetBoth) && (e.HPFormula.Base > 0 || e.HPFormula.StatCoef > 0)
 }
 
 // IsBuffEffect は自分へのバフ効果かを判定します。
@@ -154,8 +166,8 @@ func (e *ModuleEffect) IsBuffEffect() bool {
 	if e.ColumnSpec == nil {
 		return false
 	}
-	// 自分対象かつカラム効果（バフ = 自分へのステータス強化）
-	return e.Target == TargetSelf
+	// 自分対象（または両対象のフィールド効果）かつカラム効果（バフ = 自分へのステータス強化）
+	return e.Target == TargetSelf || e.Target == TargetBoth
 }
 
 // IsDebuffEffect は敵へのデバフ効果かを判定します。
@@ -163,6 +175,7 @@ func (e *ModuleEffect) IsDebuffEffect() bool {
 	if e.ColumnSpec == nil {
 		return false
 	}
-	// 敵対象かつカラム効果（デバフ = 敵へのステータス弱化）
-	return e.Target == TargetEnemy
+	// 敵対象（または敵全体）かつカラム効果（デバフ = 敵へのステータス弱化）
+	// 両対象のフィールド効果は敵にバフとして付与されるためデバフには含めない
+	return e.Target == TargetEnemy || e.Target == TargetAll
 }
@@ -466,3 +466,40 @@ func TestModuleEffect_IsDebuffEffect(t *testing.T) {
 		t.Error("自身対象のColumnSpec効果はデバフ効果ではないべきです")
 	}
 }
+
+// TestModuleEffect_TargetBoth は両対象効果の判定をテストします。
+func TestModuleEffect_TargetBoth(t *testing.T) {
+	drain := ModuleEffect{
+		Target:    TargetBoth,
+		HPFormula: &HPFormula{Base: 0, StatCoef: 0.6, StatRef: "INT"},
+	}
+	if !drain.IsDamageEffect() || !drain.IsHealEffect() {
+		t.Error("正の値の両対象HPFormula効果はダメージ効果かつ回復効果であるべきです")
+	}
+
+	reckless := ModuleEffect{
+		Target:    TargetBoth,
+		HPFormula: &HPFormula{Base: 0, StatCoef: -1.8, StatRef: "STR"},
+	}
+	if !reckless.IsDamageEffect() {
+		t.Error("負の値の両対象HPFormula効果はダメージ効果であるべきです")
+	}
+	if reckless.IsHealEffect() {
+		t.Error("負の値の両対象HPFormula効果は回復効果ではないべきです")
+	}
+
+	field := ModuleEffect{
+		Target: TargetBoth,
+		ColumnSpec: &EffectColumnSpec{
+			Column:   ColDamageMultiplier,
+			Value:    1.3,
+			Duration: 12.0,
+		},
+	}
+	if !field.IsBuffEffect() {
+		t.Error("両対象のColumnSpec効果はバフ効果であるべきです")
+	}
+	if field.IsDebuffEffect() {
+		t.Error("両対象のColumnSpec効果はデバフ効果ではないべきです")
+	}
+}
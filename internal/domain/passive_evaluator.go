@@ -34,6 +34,9 @@ const (
 
 	// PassiveEventDebuffReceived はデバフ受けイベントを表します。
 	PassiveEventDebuffReceived PassiveEvent = "debuff_received"
+
+	// PassiveEventCritical はクリティカルヒットイベントを表します。
+	PassiveEventCritical PassiveEvent = "critical"
 )
 
 // PassiveEvaluationContext はパッシブスキル評価時のコンテキストを表す構造体です。
@@ -253,6 +256,8 @@ func checkCondition(cond *TriggerCondition, ctx *PassiveEvaluationContext) bool
 		return ctx.Event == PassiveEventDebuffReceived
 	case TriggerConditionOnBattleStart:
 		return ctx.Event == PassiveEventBattleStart
+	case TriggerConditionOnCritical:
+		return ctx.Event == PassiveEventCritical
 	default:
 		return false
 	}
@@ -279,6 +284,8 @@ func checkEventMatch(condType TriggerConditionType, event PassiveEvent) bool {
 		return event == PassiveEventTimeout
 	case TriggerConditionOnDebuffReceived:
 		return event == PassiveEventDebuffReceived
+	case TriggerConditionOnCritical:
+		return event == PassiveEventCritical
 	default:
 		return false
 	}
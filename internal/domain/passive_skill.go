@@ -96,6 +96,12 @@ const (
 
 	// TriggerConditionSameAttackCount は同種攻撃カウントの条件です。
 	TriggerConditionSameAttackCount TriggerConditionType = "same_attack_count"
+
+	// TriggerConditionChainLengthAtLeast はチェイン数が指定値以上の条件です。
+	TriggerConditionChainLengthAtLeast TriggerConditionType = "chain_length_at_least"
+
+	// TriggerConditionOnCritical はクリティカルヒット時の条件です。
+	TriggerConditionOnCritical TriggerConditionType = "on_critical"
 )
 
 // TriggerCondition はパッシブスキルの発動条件を表す構造体です。
@@ -256,6 +262,11 @@ func (p PassiveSkill) buildCondition() func(*EffectContext) bool {
 			return ctx.EventType == EventOnTimeout
 		}
 
+	case TriggerConditionOnCritical:
+		return func(ctx *EffectContext) bool {
+			return ctx.EventType == EventOnCritical
+		}
+
 	case TriggerConditionOnPhysicalAttack:
 		return func(ctx *EffectContext) bool {
 			return ctx.EventType == EventOnModuleUse && ctx.IsPhysical
@@ -272,6 +283,12 @@ func (p PassiveSkill) buildCondition() func(*EffectContext) bool {
 			return ctx.SameAttackCount >= threshold
 		}
 
+	case TriggerConditionChainLengthAtLeast:
+		threshold := int(cond.Value)
+		return func(ctx *EffectContext) bool {
+			return ctx.ChainLength >= threshold
+		}
+
 	default:
 		return nil // 常に有効
 	}
@@ -72,6 +72,7 @@ func TestTriggerConditionType_定数の確認(t *testing.T) {
 		{"戦闘開始時", TriggerConditionOnBattleStart, "on_battle_start"},
 		{"ミスなし連続", TriggerConditionNoMissStreak, "no_miss_streak"},
 		{"同種攻撃カウント", TriggerConditionSameAttackCount, "same_attack_count"},
+		{"チェイン数以上", TriggerConditionChainLengthAtLeast, "chain_length_at_least"},
 	}
 
 	for _, tt := range tests {
@@ -297,3 +298,25 @@ func TestPassiveSkill_IsStackable(t *testing.T) {
 		})
 	}
 }
+
+// TestPassiveSkill_ChainLengthCondition はチェイン数の条件を持つパッシブスキルがチェイン数に応じて有効になることをテストします。
+func TestPassiveSkill_ChainLengthCondition(t *testing.T) {
+	skill := PassiveSkill{
+		ID:               "ps_chain",
+		Name:             "チェインブースト",
+		TriggerType:      PassiveTriggerConditional,
+		TriggerCondition: &TriggerCondition{Type: TriggerConditionChainLengthAtLeast, Value: 3},
+		Effects:          map[EffectColumn]float64{ColDamageMultiplier: 1.3},
+	}
+	entry := skill.ToEntry()
+
+	ctx := NewEffectContext(100, 100, 100, 100)
+	ctx.ChainLength = 2
+	if entry.IsEnabled(ctx) {
+		t.Error("2チェインでは無効であるべき")
+	}
+	ctx.ChainLength = 3
+	if !entry.IsEnabled(ctx) {
+		t.Error("3チェインで有効になるべき")
+	}
+}
@@ -133,6 +133,98 @@ func TestEnemiesJSONExists(t *testing.T) {
 	}
 }
 
+// TestEncountersAreValid はenemies.jsonのエンカウントが有効で、登場する敵タイプが存在することを検証します。
+func TestEncountersAreValid(t *testing.T) {
+	loader := createTestLoader()
+
+	enemyTypes, err := loader.LoadEnemyTypes()
+	if err != nil {
+		t.Fatalf("enemies.jsonの読み込みに失敗: %v", err)
+	}
+	encounters, err := loader.LoadEncounters()
+	if err != nil {
+		t.Fatalf("エンカウントの読み込みに失敗: %v", err)
+	}
+	if len(encounters) == 0 {
+		t.Fatal("エンカウントが定義されていません")
+	}
+
+	typeIDs := make(map[string]bool, len(enemyTypes))
+	for _, et := range enemyTypes {
+		typeIDs[et.ID] = true
+	}
+
+	for _, encounter := range encounters {
+		if err := ValidateEncounterData(encounter); err != nil {
+			t.Errorf("エンカウントのバリデーションに失敗: %v", err)
+		}
+		for _, wave := range encounter.Waves {
+			for _, member := range wave.Members {
+				if !typeIDs[member.EnemyTypeID] {
+					t.Errorf("エンカウント %s に存在しない敵タイプ: %s", encounter.ID, member.EnemyTypeID)
+				}
+			}
+		}
+	}
+}
+
+// TestEnemyActionRulesAreValid はenemies.jsonの行動ルールが存在する敵行動を参照していることを検証します。
+func TestEnemyActionRulesAreValid(t *testing.T) {
+	loader := createTestLoader()
+
+	enemyTypes, err := loader.LoadEnemyTypes()
+	if err != nil {
+		t.Fatalf("enemies.jsonの読み込みに失敗: %v", err)
+	}
+	actions, err := loader.LoadEnemyActions()
+	if err != nil {
+		t.Fatalf("enemy_actions.jsonの読み込みに失敗: %v", err)
+	}
+
+	actionIDs := make(map[string]bool, len(actions))
+	actionTypes := make(map[string]string, len(actions))
+	for _, action := range actions {
+		if err := ValidateEnemyActionData(action); err != nil {
+			t.Errorf("敵行動のバリデーションに失敗: %v", err)
+		}
+		actionIDs[action.ID] = true
+		actionTypes[action.ID] = action.ActionType
+	}
+
+	for _, et := range enemyTypes {
+		for _, rule := range et.ActionRules {
+			if !actionIDs[rule.ActionID] {
+				t.Errorf("敵タイプ %s の行動ルールに存在しない行動: %s", et.ID, rule.ActionID)
+			}
+		}
+		for _, phase := range et.Phases {
+			for _, id := range phase.ActionPatternIDs {
+				if !actionIDs[id] {
+					t.Errorf("敵タイプ %s のフェーズ %s に存在しない行動: %s", et.ID, phase.ID, id)
+				}
+			}
+			if phase.TransitionActionID == "" {
+				continue
+			}
+			if !actionIDs[phase.TransitionActionID] {
+				t.Errorf("敵タイプ %s のフェーズ %s に存在しない移行時行動: %s", et.ID, phase.ID, phase.TransitionActionID)
+			} else if actionTypes[phase.TransitionActionID] == "defense" {
+				t.Errorf("敵タイプ %s のフェーズ %s の移行時行動にディフェンスは使えません", et.ID, phase.ID)
+			}
+		}
+		for _, threshold := range et.VoltageThresholds {
+			if threshold.EnrageActionID == "" {
+				continue
+			}
+			if !actionIDs[threshold.EnrageActionID] {
+				t.Errorf("敵タイプ %s のボルテージ段階 %s に存在しない激昂時行動: %s", et.ID, threshold.Name, threshold.EnrageActionID)
+			} else if actionTypes[threshold.EnrageActionID] == "defense" {
+				t.Errorf("敵タイプ %s のボルテージ段階 %s の激昂時行動にディフェンスは使えません", et.ID, threshold.Name)
+			}
+		}
+	}
+}
+
 // TestWordsJSONExists はwords.jsonの存在と内容を検証します。
 // テスト用のwords.jsonを使用して、本番データの変更に影響されないようにします。
 func TestWordsJSONExists(t *testing.T) {
@@ -10,9 +10,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"hirorocky/type-battle/internal/domain"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // DataLoader は外部データファイルのロードを担当する構造体です。
@@ -72,6 +75,7 @@ type ExternalData struct {
 	EnemyTypes         []EnemyTypeData
 	EnemyActions       []EnemyActionData
 	EnemyPassiveSkills []EnemyPassiveSkillData
+	Encounters         []EncounterData
 	PassiveSkills      []PassiveSkillData
 	ChainEffects       []ChainEffectData
 	TypingDictionary   *TypingDictionary
@@ -88,6 +92,8 @@ type CoreTypeData struct {
 	StatWeights    map[string]float64 `json:"stat_weights"`
 	PassiveSkillID string             `json:"passive_skill_id"`
 	MinDropLevel   int                `json:"min_drop_level"`
+	// ElementAffinity は属性ごとの被ダメージ倍率です（例: {"dark": 0.75}）。
+	ElementAffinity map[string]float64 `json:"element_affinity,omitempty"`
 }
 
 // coresFileData はcores.jsonのルート構造です。
@@ -124,13 +130,27 @@ func (c *CoreTypeData) ToDomain() domain.CoreType {
 	}
 
 	return domain.CoreType{
-		ID:             c.ID,
-		Name:           c.Name,
-		StatWeights:    statWeights,
-		PassiveSkillID: c.PassiveSkillID,
-		AllowedTags:    allowedTags,
-		MinDropLevel:   c.MinDropLevel,
+		ID:              c.ID,
+		Name:            c.Name,
+		StatWeights:     statWeights,
+		PassiveSkillID:  c.PassiveSkillID,
+		AllowedTags:     allowedTags,
+		MinDropLevel:    c.MinDropLevel,
+		ElementAffinity: convertElementAffinity(c.ElementAffinity),
+	}
+}
+
+// convertElementAffinity は属性倍率のJSONデータをドメインモデルに変換します。
+// 未設定の場合はnilを返します（全属性1.0倍）。
+func convertElementAffinity(data map[string]float64) domain.ElementAffinity {
+	if len(data) == 0 {
+		return nil
 	}
+	affinity := make(domain.ElementAffinity, len(data))
+	for element, rate := range data {
+		affinity[domain.Element(element)] = rate
+	}
+	return affinity
 }
 
 // ==================== モジュール定義 ====================
@@ -156,6 +176,7 @@ type ModuleEffectData struct {
 	EffectColumn *EffectColumnData `json:"effect_column,omitempty"`
 	Probability  float64           `json:"probability"`
 	LUKFactor    float64           `json:"luk_factor"`
+	Element      string            `json:"element,omitempty"`
 	Icon         string            `json:"icon"`
 }
 
@@ -224,6 +245,7 @@ func (e *ModuleEffectData) ToDomain() domain.ModuleEffect {
 		Target:      convertEffectTarget(e.Target),
 		Probability: e.Probability,
 		LUKFactor:   e.LUKFactor,
+		Element:     domain.Element(e.Element),
 		Icon:        e.Icon,
 	}
 
@@ -255,6 +277,8 @@ func convertEffectTarget(s string) domain.EffectTarget {
 		return domain.TargetEnemy
 	case "both":
 		return domain.TargetBoth
+	case "all":
+		return domain.TargetAll
 	default:
 		return domain.TargetEnemy
 	}
@@ -291,16 +315,136 @@ type EnemyTypeData struct {
 	// VoltageRisePer10s は10秒あたりのボルテージ上昇量です。
 	// 0の場合はボルテージが上昇しません。未設定時のデフォルト値は10です。
 	VoltageRisePer10s *float64 `json:"voltage_rise_per_10s,omitempty"`
+
+	// VoltageThresholds はボルテージの到達段階（激昂）の定義です。
+	// 未設定時はデフォルトの段階（150%: 激昂、300%: 暴走）を適用します。
+	VoltageThresholds []VoltageThresholdData `json:"voltage_thresholds,omitempty"`
+
+	// ElementAffinity は属性ごとの被ダメージ倍率です（例: {"fire": 1.5}）。
+	ElementAffinity map[string]float64 `json:"element_affinity,omitempty"`
+
+	// ActionRules は条件付き・重み付きの行動選択ルールです。
+	ActionRules []EnemyActionRuleData `json:"action_rules,omitempty"`
+
+	// Phases は多段階フェーズの定義です。設定時は通常/強化の行動パターン・パッシブより優先されます。
+	Phases []EnemyPhaseData `json:"phases,omitempty"`
+}
+
+// EnemyPhaseData は敵のフェーズ1段階分のJSONデータ構造体です。
+type EnemyPhaseData struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// HPThreshold はこのフェーズに移行するHP割合です（最初のフェーズでは無視）。
+	HPThreshold float64 `json:"hp_threshold,omitempty"`
+
+	ActionPatternIDs   []string `json:"action_pattern,omitempty"`
+	PassiveID          string   `json:"passive_id,omitempty"`
+	ASCIIArt           string   `json:"ascii_art,omitempty"`
+	TransitionActionID string   `json:"transition_action_id,omitempty"`
+}
+
+// ToDomain はEnemyPhaseDataをドメインモデルのEnemyPhaseDefに変換します。
+// 行動・パッシブはResolveEnemyTypeActions・ConvertEnemyTypesWithPassivesで解決されます。
+func (p *EnemyPhaseData) ToDomain() domain.EnemyPhaseDef {
+	return domain.EnemyPhaseDef{
+		ID:                 p.ID,
+		Name:               p.Name,
+		HPThreshold:        p.HPThreshold,
+		ActionPatternIDs:   p.ActionPatternIDs,
+		PassiveID:          p.PassiveID,
+		ASCIIArt:           p.ASCIIArt,
+		TransitionActionID: p.TransitionActionID,
+	}
+}
+
+// VoltageThresholdData はボルテージの到達段階1つ分のJSONデータ構造体です。
+type VoltageThresholdData struct {
+	Voltage        float64 `json:"voltage"`
+	Name           string  `json:"name"`
+	ChargeTimeMult float64 `json:"charge_time_mult,omitempty"`
+	EnrageActionID string  `json:"enrage_action_id,omitempty"`
+}
+
+// ToDomain はVoltageThresholdDataをドメインモデルのVoltageThresholdに変換します。
+// 激昂時行動はResolveEnemyTypeActionsで解決されます。
+func (v *VoltageThresholdData) ToDomain() domain.VoltageThreshold {
+	return domain.VoltageThreshold{
+		Voltage:              v.Voltage,
+		Name:                 v.Name,
+		ChargeTimeMultiplier: v.ChargeTimeMult,
+		EnrageActionID:       v.EnrageActionID,
+	}
+}
+
+// DefaultVoltageThresholds はボルテージの到達段階が未設定の敵に適用するデフォルトの段階です。
+// 既存の敵のバランスを変えないよう、チャージ時間倍率は設定しません（段階の到達のみ記録します）。
+var DefaultVoltageThresholds = []VoltageThresholdData{
+	{Voltage: 150, Name: "激昂"},
+	{Voltage: 300, Name: "暴走"},
+}
+
+// phaseIndex はフェーズ指定（"normal" / "enhanced" またはフェーズID）をフェーズのインデックスに変換します。
+func (e *EnemyTypeData) phaseIndex(name string) (domain.EnemyPhase, bool) {
+	for i, phase := range e.Phases {
+		if phase.ID == name {
+			return domain.EnemyPhase(i), true
+		}
+	}
+	switch name {
+	case "normal":
+		return domain.PhaseNormal, true
+	case "enhanced":
+		return domain.PhaseEnhanced, true
+	}
+	return 0, false
+}
+
+// EnemyActionRuleData は敵の行動選択ルールのJSONデータ構造体です。
+type EnemyActionRuleData struct {
+	ActionID string `json:"action_id"`
+
+	// Phases はルールが有効なフェーズ（"normal" / "enhanced" またはフェーズID）です。空の場合は全フェーズ。
+	Phases []string `json:"phases,omitempty"`
+
+	// Condition は発動条件です（例: {"type": "self_hp_below", "value": 0.3}）。
+	Condition *TriggerConditionData `json:"condition,omitempty"`
+
+	Weight        int `json:"weight,omitempty"`
+	CooldownTurns int `json:"cooldown_turns,omitempty"`
+}
+
+// ToDomain はEnemyActionRuleDataをドメインモデルのEnemyActionRuleに変換します。
+// フェーズ指定は所属する敵タイプ（enemy）のフェーズ定義で解決します。
+// 行動（Action）はResolveEnemyTypeActionsで解決されます。
+func (r *EnemyActionRuleData) ToDomain(enemy *EnemyTypeData) domain.EnemyActionRule {
+	rule := domain.EnemyActionRule{
+		ActionID:      r.ActionID,
+		Weight:        r.Weight,
+		CooldownTurns: r.CooldownTurns,
+	}
+	for _, name := range r.Phases {
+		if phase, ok := enemy.phaseIndex(name); ok {
+			rule.Phases = append(rule.Phases, phase)
+		}
+	}
+	if r.Condition != nil {
+		rule.Condition = &domain.EnemyActionCondition{
+			Type:  domain.EnemyConditionType(r.Condition.Type),
+			Value: r.Condition.Value,
+		}
+	}
+	return rule
 }
 
 // enemiesFileData はenemies.jsonのルート構造です。
 type enemiesFileData struct {
 	EnemyTypes []EnemyTypeData `json:"enemy_types"`
+	Encounters []EncounterData `json:"encounters,omitempty"`
 }
 
-// LoadEnemyTypes はenemies.jsonから敵タイプ定義を読み込みます。
-
-func (l *DataLoader) LoadEnemyTypes() ([]EnemyTypeData, error) {
+// loadEnemiesFile はenemies.jsonを読み込みます。
+func (l *DataLoader) loadEnemiesFile() (*enemiesFileData, error) {
 	data, err := l.readFile("enemies.json")
 	if err != nil {
 		return nil, fmt.Errorf("enemies.jsonの読み込みに失敗: %w", err)
@@ -311,9 +455,28 @@ func (l *DataLoader) LoadEnemyTypes() ([]EnemyTypeData, error) {
 		return nil, fmt.Errorf("enemies.jsonのパースに失敗: %w", err)
 	}
 
+	return &fileData, nil
+}
+
+// LoadEnemyTypes はenemies.jsonから敵タイプ定義を読み込みます。
+
+func (l *DataLoader) LoadEnemyTypes() ([]EnemyTypeData, error) {
+	fileData, err := l.loadEnemiesFile()
+	if err != nil {
+		return nil, err
+	}
 	return fileData.EnemyTypes, nil
 }
 
+// LoadEncounters はenemies.jsonからエンカウント（複数敵・ウェーブ戦）定義を読み込みます。
+func (l *DataLoader) LoadEncounters() ([]EncounterData, error) {
+	fileData, err := l.loadEnemiesFile()
+	if err != nil {
+		return nil, err
+	}
+	return fileData.Encounters, nil
+}
+
 // DefaultVoltageRisePer10s はボルテージ上昇量のデフォルト値（10ポイント/10秒）です。
 const DefaultVoltageRisePer10s = 10.0
 
@@ -334,9 +497,54 @@ func (e *EnemyTypeData) ToDomain() domain.EnemyType {
 		DropItemCategory:         e.DropItemCategory,
 		DropItemTypeID:           e.DropItemTypeID,
 		VoltageRisePer10s:        e.GetVoltageRisePer10s(),
+		ElementAffinity:          convertElementAffinity(e.ElementAffinity),
+		ActionRules:              e.convertActionRules(),
+		Phases:                   e.convertPhases(),
+		VoltageThresholds:        e.convertVoltageThresholds(),
 	}
 }
 
+// convertVoltageThresholds はボルテージの到達段階のJSONデータをボルテージの昇順でドメインモデルに変換します。
+// 未設定の場合はデフォルトの段階を適用します。
+func (e *EnemyTypeData) convertVoltageThresholds() []domain.VoltageThreshold {
+	data := e.VoltageThresholds
+	if len(data) == 0 {
+		data = DefaultVoltageThresholds
+	}
+	thresholds := make([]domain.VoltageThreshold, len(data))
+	for i, v := range data {
+		thresholds[i] = v.ToDomain()
+	}
+	sort.SliceStable(thresholds, func(i, j int) bool {
+		return thresholds[i].Voltage < thresholds[j].Voltage
+	})
+	return thresholds
+}
+
+// convertActionRules は行動選択ルールのJSONデータをドメインモデルに変換します。
+func (e *EnemyTypeData) convertActionRules() []domain.EnemyActionRule {
+	if len(e.ActionRules) == 0 {
+		return nil
+	}
+	rules := make([]domain.EnemyActionRule, len(e.ActionRules))
+	for i, r := range e.ActionRules {
+		rules[i] = r.ToDomain(e)
+	}
+	return rules
+}
+
+// convertPhases はフェーズ定義のJSONデータをドメインモデルに変換します。
+func (e *EnemyTypeData) convertPhases() []domain.EnemyPhaseDef {
+	if len(e.Phases) == 0 {
+		return nil
+	}
+	phases := make([]domain.EnemyPhaseDef, len(e.Phases))
+	for i, p := range e.Phases {
+		phases[i] = p.ToDomain()
+	}
+	return phases
+}
+
 // GetVoltageRisePer10s は10秒あたりのボルテージ上昇量を返します。
 // 未設定の場合はデフォルト値を返します。
 func (e *EnemyTypeData) GetVoltageRisePer10s() float64 {
@@ -346,6 +554,46 @@ func (e *EnemyTypeData) GetVoltageRisePer10s() float64 {
 	return *e.VoltageRisePer10s
 }
 
+// ==================== エンカウント定義 ====================
+
+// EncounterMemberData はエンカウントに登場する敵1体のデータです。
+type EncounterMemberData struct {
+	EnemyTypeID string `json:"enemy_type_id"`
+	Level       int    `json:"level"`
+}
+
+// EncounterWaveData は同時に出現する敵グループ（ウェーブ）のデータです。
+type EncounterWaveData struct {
+	Members []EncounterMemberData `json:"members"`
+}
+
+// EncounterData はenemies.jsonから読み込むエンカウントデータの構造体です。
+type EncounterData struct {
+	ID    string              `json:"id"`
+	Name  string              `json:"name"`
+	Waves []EncounterWaveData `json:"waves"`
+}
+
+// ToDomain はEncounterDataをドメインモデルのEncounterに変換します。
+func (e *EncounterData) ToDomain() domain.Encounter {
+	encounter := domain.Encounter{
+		ID:    e.ID,
+		Name:  e.Name,
+		Waves: make([]domain.EncounterWave, len(e.Waves)),
+	}
+	for i, wave := range e.Waves {
+		members := make([]domain.EncounterMember, len(wave.Members))
+		for j, member := range wave.Members {
+			members[j] = domain.EncounterMember{
+				EnemyTypeID: member.EnemyTypeID,
+				Level:       member.Level,
+			}
+		}
+		encounter.Waves[i] = domain.EncounterWave{Members: members}
+	}
+	return encounter
+}
+
 // ==================== 敵行動定義 ====================
 
 // EnemyActionData はenemy_actions.jsonから読み込む敵行動データの構造体です。
@@ -364,6 +612,7 @@ type EnemyActionData struct {
 	EvadeRate      float64 `json:"evade_rate,omitempty"`
 	DurationSec    float64 `json:"duration_seconds,omitempty"`
 	ChargeTimeMS   int64   `json:"charge_time_ms"`
+	ParryWindowMS  int64   `json:"parry_window_ms,omitempty"`
 }
 
 // enemyActionsFileData はenemy_actions.jsonのルート構造です。
@@ -392,10 +641,11 @@ func (a *EnemyActionData) ToDomain() domain.EnemyAction {
 		ID:             a.ID,
 		Name:           a.Name,
 		ChargeTime:     time.Duration(a.ChargeTimeMS) * time.Millisecond,
+		ParryWindow:    time.Duration(a.ParryWindowMS) * time.Millisecond,
 		AttackType:     a.AttackType,
 		DamageBase:     a.DamageBase,
 		DamagePerLevel: a.DamagePerLevel,
-		Element:        a.Element,
+		Element:        domain.Element(a.Element),
 		EffectType:     a.EffectType,
 		EffectValue:    a.EffectValue,
 		Duration:       a.DurationSec,
@@ -413,6 +663,10 @@ func (a *EnemyActionData) ToDomain() domain.EnemyAction {
 		action.ActionType = domain.EnemyActionDebuff
 	case "defense":
 		action.ActionType = domain.EnemyActionDefense
+	case "heal":
+		action.ActionType = domain.EnemyActionHeal
+	case "dispel":
+		action.ActionType = domain.EnemyActionDispel
 	}
 
 	// DefenseTypeの変換
@@ -436,7 +690,7 @@ func ValidateEnemyActionData(data EnemyActionData) error {
 	if data.ActionType == "" {
 		return fmt.Errorf("敵行動タイプが空です: ID=%s", data.ID)
 	}
-	validTypes := map[string]bool{"attack": true, "buff": true, "debuff": true, "defense": true}
+	validTypes := map[string]bool{"attack": true, "buff": true, "debuff": true, "defense": true, "heal": true, "dispel": true}
 	if !validTypes[data.ActionType] {
 		return fmt.Errorf("敵行動タイプが不正です: ID=%s, ActionType=%s", data.ID, data.ActionType)
 	}
@@ -459,6 +713,8 @@ type PassiveSkillData struct {
 	MaxStacks        int                   `json:"max_stacks"`
 	StackIncrement   float64               `json:"stack_increment"`
 	UsesPerBattle    int                   `json:"uses_per_battle"`
+	// Effects は効果列ごとの効果値です（例: {"fire_cut": 0.3}）。設定時はEffectTypeより優先されます。
+	Effects map[string]float64 `json:"effects,omitempty"`
 }
 
 // TriggerConditionData はトリガー条件のJSONデータ構造体です。
@@ -503,6 +759,13 @@ func (p *PassiveSkillData) ToDomain() domain.PassiveSkill {
 		UsesPerBattle:    p.UsesPerBattle,
 	}
 
+	if len(p.Effects) > 0 {
+		skill.Effects = make(map[domain.EffectColumn]float64, len(p.Effects))
+		for key, value := range p.Effects {
+			skill.Effects[domain.EffectColumn(key)] = value
+		}
+	}
+
 	if p.TriggerCondition != nil {
 		skill.TriggerCondition = &domain.TriggerCondition{
 			Type:  convertTriggerConditionType(p.TriggerCondition.Type),
@@ -580,6 +843,10 @@ func convertTriggerConditionType(s string) domain.TriggerConditionType {
 		return domain.TriggerConditionNoMissStreak
 	case "same_attack_count":
 		return domain.TriggerConditionSameAttackCount
+	case "chain_length_at_least":
+		return domain.TriggerConditionChainLengthAtLeast
+	case "on_critical":
+		return domain.TriggerConditionOnCritical
 	default:
 		return domain.TriggerConditionAccuracyEquals
 	}
@@ -643,6 +910,9 @@ type ChainEffectData struct {
 	MinValue         float64 `json:"min_value"`
 	MaxValue         float64 `json:"max_value"`
 	MinDropLevel     int     `json:"min_drop_level"`
+
+	// MinChainLength は発動に必要な最低チェイン数です（未設定時は制限なし）。
+	MinChainLength int `json:"min_chain_length,omitempty"`
 }
 
 // chainEffectsFileData はchain_effects.jsonのルート構造です。
@@ -688,6 +958,7 @@ func (s *ChainEffectData) ToChainEffectDefinition() ChainEffectDefinitionData {
 		MinValue:         s.MinValue,
 		MaxValue:         s.MaxValue,
 		MinDropLevel:     s.MinDropLevel,
+		MinChainLength:   s.MinChainLength,
 	}
 }
 
@@ -720,6 +991,9 @@ type ChainEffectDefinitionData struct {
 
 	// MinDropLevel はこのチェイン効果がドロップする最低敵レベルです。
 	MinDropLevel int
+
+	// MinChainLength は発動に必要な最低チェイン数です。
+	MinChainLength int
 }
 
 // convertChainEffectType は文字列をChainEffectTypeに変換します。
@@ -798,6 +1072,25 @@ type TypingDictionary struct {
 	Easy   []string `json:"easy"`
 	Medium []string `json:"medium"`
 	Hard   []string `json:"hard"`
+
+	// Parry はパリィ用の短い単語です（省略可能）。
+	Parry []string `json:"parry,omitempty"`
+
+	// Japanese はローマ字モード用の日本語辞書です（省略可能）。
+	Japanese *JapaneseDictionaryData `json:"japanese,omitempty"`
+}
+
+// JapaneseWordData は表示テキストと読みの組を表すJSONデータ構造体です。
+type JapaneseWordData struct {
+	Text    string `json:"text"`
+	Reading string `json:"reading"`
+}
+
+// JapaneseDictionaryData はローマ字モード用の難易度別日本語辞書です。
+type JapaneseDictionaryData struct {
+	Easy   []JapaneseWordData `json:"easy"`
+	Medium []JapaneseWordData `json:"medium"`
+	Hard   []JapaneseWordData `json:"hard"`
 }
 
 // wordsFileData はwords.jsonのルート構造です。
@@ -818,9 +1111,29 @@ func (l *DataLoader) LoadTypingDictionary() (*TypingDictionary, error) {
 		return nil, fmt.Errorf("words.jsonのパースに失敗: %w", err)
 	}
 
+	fileData.Words.normalize()
 	return &fileData.Words, nil
 }
 
+// normalize は辞書の単語をNFC（合成済みの文字）に正規化します。
+// 分解形式（"e"+U+0301など）で保存された辞書でも、キーボードが送る合成済みの文字で入力できるようにします。
+func (d *TypingDictionary) normalize() {
+	for _, words := range [][]string{d.Easy, d.Medium, d.Hard, d.Parry} {
+		for i, word := range words {
+			words[i] = norm.NFC.String(word)
+		}
+	}
+	if d.Japanese == nil {
+		return
+	}
+	for _, words := range [][]JapaneseWordData{d.Japanese.Easy, d.Japanese.Medium, d.Japanese.Hard} {
+		for i := range words {
+			words[i].Text = norm.NFC.String(words[i].Text)
+			words[i].Reading = norm.NFC.String(words[i].Reading)
+		}
+	}
+}
+
 // ==================== 初期エージェント定義 ====================
 
 // FirstAgentData はfirst_agent.jsonから読み込む初期エージェントデータの構造体です。
@@ -898,6 +1211,11 @@ func (l *DataLoader) LoadAllExternalData() (*ExternalData, error) {
 		enemyPassiveSkills = []EnemyPassiveSkillData{}
 	}
 
+	encounters, err := l.LoadEncounters()
+	if err != nil {
+		return nil, fmt.Errorf("エンカウントのロードに失敗: %w", err)
+	}
+
 	passiveSkills, err := l.LoadPassiveSkills()
 	if err != nil {
 		return nil, fmt.Errorf("パッシブスキルのロードに失敗: %w", err)
@@ -924,6 +1242,7 @@ func (l *DataLoader) LoadAllExternalData() (*ExternalData, error) {
 		EnemyTypes:         enemyTypes,
 		EnemyActions:       enemyActions,
 		EnemyPassiveSkills: enemyPassiveSkills,
+		Encounters:         encounters,
 		PassiveSkills:      passiveSkills,
 		ChainEffects:       chainEffects,
 		TypingDictionary:   dictionary,
@@ -978,5 +1297,76 @@ func ValidateEnemyTypeData(data EnemyTypeData) error {
 	if data.BaseAttackPower <= 0 {
 		return fmt.Errorf("敵の基礎攻撃力が不正です: ID=%s, BaseAttackPower=%d", data.ID, data.BaseAttackPower)
 	}
+	for _, rule := range data.ActionRules {
+		if rule.ActionID == "" {
+			return fmt.Errorf("行動ルールの行動IDが空です: ID=%s", data.ID)
+		}
+		if rule.Condition != nil && !domain.EnemyConditionType(rule.Condition.Type).IsValid() {
+			return fmt.Errorf("行動ルールの条件が不正です: ID=%s, Condition=%s", data.ID, rule.Condition.Type)
+		}
+		for _, phase := range rule.Phases {
+			if _, ok := data.phaseIndex(phase); !ok {
+				return fmt.Errorf("行動ルールのフェーズが不正です: ID=%s, Phase=%s", data.ID, phase)
+			}
+		}
+	}
+	return validateEnemyPhases(data)
+}
+
+// validateEnemyPhases は敵タイプのフェーズ定義のバリデーションを行います。
+// 2番目以降のフェーズのHP閾値は0より大きく1未満で、前のフェーズより小さい必要があります。
+func validateEnemyPhases(data EnemyTypeData) error {
+	if len(data.Phases) == 0 {
+		return nil
+	}
+	if len(data.Phases[0].ActionPatternIDs) == 0 {
+		return fmt.Errorf("最初のフェーズの行動パターンが空です: ID=%s", data.ID)
+	}
+	ids := make(map[string]bool, len(data.Phases))
+	prevThreshold := 1.0
+	for i, phase := range data.Phases {
+		if phase.ID == "" {
+			return fmt.Errorf("フェーズIDが空です: ID=%s, Phase=%d", data.ID, i+1)
+		}
+		if ids[phase.ID] {
+			return fmt.Errorf("フェーズIDが重複しています: ID=%s, Phase=%s", data.ID, phase.ID)
+		}
+		ids[phase.ID] = true
+		if i == 0 {
+			continue
+		}
+		if phase.HPThreshold <= 0 || phase.HPThreshold >= prevThreshold {
+			return fmt.Errorf("フェーズのHP閾値が不正です: ID=%s, Phase=%s, HPThreshold=%v", data.ID, phase.ID, phase.HPThreshold)
+		}
+		prevThreshold = phase.HPThreshold
+	}
+	return nil
+}
+
+// ValidateEncounterData はエンカウントデータのバリデーションを行います。
+// 1ウェーブの敵の数は1〜domain.MaxEncounterMembers体です。
+func ValidateEncounterData(data EncounterData) error {
+	if data.ID == "" {
+		return fmt.Errorf("エンカウントIDが空です")
+	}
+	if data.Name == "" {
+		return fmt.Errorf("エンカウント名が空です: ID=%s", data.ID)
+	}
+	if len(data.Waves) == 0 {
+		return fmt.Errorf("ウェーブが空です: ID=%s", data.ID)
+	}
+	for i, wave := range data.Waves {
+		if len(wave.Members) == 0 || len(wave.Members) > domain.MaxEncounterMembers {
+			return fmt.Errorf("ウェーブの敵の数が不正です: ID=%s, Wave=%d, 敵の数=%d", data.ID, i+1, len(wave.Members))
+		}
+		for _, member := range wave.Members {
+			if member.EnemyTypeID == "" {
+				return fmt.Errorf("敵タイプIDが空です: ID=%s, Wave=%d", data.ID, i+1)
+			}
+			if member.Level <= 0 {
+				return fmt.Errorf("敵のレベルが不正です: ID=%s, Wave=%d, Level=%d", data.ID, i+1, member.Level)
+			}
+		}
+	}
 	return nil
 }
@@ -239,6 +239,72 @@ func TestLoadTypingDictionary(t *testing.T) {
 	}
 }
 
+// TestLoadTypingDictionary_Japanese はローマ字モード用日本語辞書のロードをテストします。
+func TestLoadTypingDictionary_Japanese(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	wordsJSON := `{
+		"words": {
+			"easy": ["cat"],
+			"medium": ["function"],
+			"hard": ["implementation"],
+			"japanese": {
+				"easy": [{"text": "猫", "reading": "ねこ"}],
+				"medium": [{"text": "学校", "reading": "がっこう"}],
+				"hard": []
+			}
+		}
+	}`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "words.json"), []byte(wordsJSON), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗: %v", err)
+	}
+
+	loader := NewDataLoader(tmpDir)
+	dictionary, err := loader.LoadTypingDictionary()
+	if err != nil {
+		t.Fatalf("タイピング辞書のロードに失敗: %v", err)
+	}
+
+	if dictionary.Japanese == nil {
+		t.Fatal("Japanese辞書がnilです")
+	}
+	if len(dictionary.Japanese.Easy) != 1 {
+		t.Fatalf("Japanese.Easy単語数: got %d, want 1", len(dictionary.Japanese.Easy))
+	}
+	if dictionary.Japanese.Easy[0].Text != "猫" || dictionary.Japanese.Easy[0].Reading != "ねこ" {
+		t.Errorf("Japanese.Easy[0]: got %+v, want 猫/ねこ", dictionary.Japanese.Easy[0])
+	}
+	if dictionary.Japanese.Medium[0].Reading != "がっこう" {
+		t.Errorf("Japanese.Medium[0].Reading: got %s, want がっこう", dictionary.Japanese.Medium[0].Reading)
+	}
+}
+
+// TestLoadTypingDictionary_NormalizesNFD は分解形式（NFD）の単語がNFCに正規化されることをテストします。
+func TestLoadTypingDictionary_NormalizesNFD(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	wordsJSON := `{
+		"words": {
+			"easy": ["cafe\u0301"],
+			"medium": ["gro\u0308\u00dfe"],
+			"hard": [],
+			"parry": ["e\u0301te\u0301"]
+		}
+	}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "words.json"), []byte(wordsJSON), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗: %v", err)
+	}
+
+	dictionary, err := NewDataLoader(tmpDir).LoadTypingDictionary()
+	if err != nil {
+		t.Fatalf("タイピング辞書のロードに失敗: %v", err)
+	}
+	if dictionary.Easy[0] != "café" || dictionary.Medium[0] != "größe" || dictionary.Parry[0] != "été" {
+		t.Errorf("NFCに正規化されていません: %q %q %q", dictionary.Easy[0], dictionary.Medium[0], dictionary.Parry[0])
+	}
+}
+
 // TestLoadCoreTypesFileNotFound はファイルが存在しない場合のエラーをテストします。
 func TestLoadCoreTypesFileNotFound(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -995,3 +1061,234 @@ func TestEnemyTypeToDomainWithVoltage(t *testing.T) {
 		t.Errorf("VoltageRisePer10s: got %f, want 20.0", domainEnemy.VoltageRisePer10s)
 	}
 }
+
+// TestEnemyTypeToDomainWithElementAffinity は属性倍率がドメインモデルに変換されることをテストします。
+func TestEnemyTypeToDomainWithElementAffinity(t *testing.T) {
+	data := EnemyTypeData{
+		ID:              "slime",
+		Name:            "スライム",
+		BaseHP:          50,
+		BaseAttackPower: 5,
+		AttackType:      "physical",
+		ElementAffinity: map[string]float64{"fire": 1.5, "poison": 0.5},
+	}
+
+	enemy := data.ToDomain()
+
+	if got := enemy.ElementAffinity.Multiplier(domain.ElementFire); got != 1.5 {
+		t.Errorf("炎倍率: got %f, want 1.5", got)
+	}
+	if got := enemy.ElementAffinity.Multiplier(domain.ElementPoison); got != 0.5 {
+		t.Errorf("毒倍率: got %f, want 0.5", got)
+	}
+	if got := enemy.ElementAffinity.Multiplier(domain.ElementIce); got != 1.0 {
+		t.Errorf("未設定属性の倍率: got %f, want 1.0", got)
+	}
+
+	// 未設定の場合はnil
+	data.ElementAffinity = nil
+	if enemy := data.ToDomain(); enemy.ElementAffinity != nil {
+		t.Errorf("属性倍率未設定時はnilであるべき: got %v", enemy.ElementAffinity)
+	}
+}
+
+// TestLoadEnemyTypesWithActionRules は行動ルールの読み込み・変換・バリデーションをテストします。
+func TestLoadEnemyTypesWithActionRules(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	enemiesJSON := `{
+		"enemy_types": [
+			{
+				"id": "slime",
+				"name": "スライム",
+				"base_hp": 50,
+				"base_attack_power": 5,
+				"attack_type": "physical",
+				"ascii_art": "  ___",
+				"action_rules": [
+					{"action_id": "act_heal", "phases": ["enhanced"], "condition": {"type": "self_hp_below", "value": 0.3}, "cooldown_turns": 3},
+					{"action_id": "act_attack", "weight": 2}
+				]
+			}
+		]
+	}`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "enemies.json"), []byte(enemiesJSON), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗: %v", err)
+	}
+
+	enemyTypes, err := NewDataLoader(tmpDir).LoadEnemyTypes()
+	if err != nil {
+		t.Fatalf("敵タイプのロードに失敗: %v", err)
+	}
+	if err := ValidateEnemyTypeData(enemyTypes[0]); err != nil {
+		t.Errorf("バリデーションに失敗: %v", err)
+	}
+
+	rules := enemyTypes[0].ToDomain().ActionRules
+	if len(rules) != 2 {
+		t.Fatalf("行動ルール数: got %d, want 2", len(rules))
+	}
+	heal := rules[0]
+	if heal.ActionID != "act_heal" || heal.CooldownTurns != 3 || len(heal.Phases) != 1 || heal.Phases[0] != domain.PhaseEnhanced {
+		t.Errorf("条件付きルール: got %+v", heal)
+	}
+	if heal.Condition == nil || heal.Condition.Type != domain.ConditionSelfHPBelow || heal.Condition.Value != 0.3 {
+		t.Errorf("発動条件: got %+v", heal.Condition)
+	}
+	if rules[1].Condition != nil || rules[1].Weight != 2 || len(rules[1].Phases) != 0 {
+		t.Errorf("条件なしルール: got %+v", rules[1])
+	}
+
+	// 未知の条件はバリデーションエラー
+	enemyTypes[0].ActionRules[0].Condition.Type = "unknown"
+	if err := ValidateEnemyTypeData(enemyTypes[0]); err == nil {
+		t.Error("未知の条件でバリデーションエラーになるべき")
+	}
+}
+
+// TestLoadEnemyTypesWithPhases は多段階フェーズの読み込み・変換・バリデーションをテストします。
+func TestLoadEnemyTypesWithPhases(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	enemiesJSON := `{
+		"enemy_types": [
+			{
+				"id": "lich",
+				"name": "リッチ",
+				"base_hp": 120,
+				"base_attack_power": 14,
+				"attack_type": "magic",
+				"ascii_art": "  .-^-.",
+				"phases": [
+					{"id": "chant", "name": "詠唱", "action_pattern": ["act_attack"], "passive_id": "p_chant"},
+					{"id": "awakened", "name": "覚醒", "hp_threshold": 0.6, "action_pattern": ["act_magic"], "transition_action_id": "act_barrier", "ascii_art": " (@ @)"},
+					{"id": "collapse", "name": "崩壊", "hp_threshold": 0.25}
+				],
+				"action_rules": [
+					{"action_id": "act_dispel", "phases": ["awakened", "collapse"]}
+				]
+			}
+		]
+	}`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "enemies.json"), []byte(enemiesJSON), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗: %v", err)
+	}
+
+	enemyTypes, err := NewDataLoader(tmpDir).LoadEnemyTypes()
+	if err != nil {
+		t.Fatalf("敵タイプのロードに失敗: %v", err)
+	}
+	if err := ValidateEnemyTypeData(enemyTypes[0]); err != nil {
+		t.Errorf("バリデーションに失敗: %v", err)
+	}
+
+	enemyType := enemyTypes[0].ToDomain()
+	if len(enemyType.Phases) != 3 {
+		t.Fatalf("フェーズ数: got %d, want 3", len(enemyType.Phases))
+	}
+	awakened := enemyType.Phases[1]
+	if awakened.ID != "awakened" || awakened.HPThreshold != 0.6 || awakened.TransitionActionID != "act_barrier" || awakened.ASCIIArt != " (@ @)" {
+		t.Errorf("2番目のフェーズ: got %+v", awakened)
+	}
+	if !enemyType.HasValidNormalActionPattern() {
+		t.Error("最初のフェーズの行動パターンが有効な行動パターンとして扱われるべき")
+	}
+
+	// 行動ルールのフェーズはフェーズIDからインデックスに変換される
+	rule := enemyType.ActionRules[0]
+	if len(rule.Phases) != 2 || rule.Phases[0] != 1 || rule.Phases[1] != 2 {
+		t.Errorf("行動ルールのフェーズ: got %v, want [1 2]", rule.Phases)
+	}
+
+	// HP閾値が前のフェーズ以上の場合はバリデーションエラー
+	enemyTypes[0].Phases[2].HPThreshold = 0.7
+	if err := ValidateEnemyTypeData(enemyTypes[0]); err == nil {
+		t.Error("HP閾値が降順でない場合はバリデーションエラーになるべき")
+	}
+	enemyTypes[0].Phases[2].HPThreshold = 0.25
+
+	// 存在しないフェーズIDを指定した行動ルールはバリデーションエラー
+	enemyTypes[0].ActionRules[0].Phases = []string{"unknown"}
+	if err := ValidateEnemyTypeData(enemyTypes[0]); err == nil {
+		t.Error("存在しないフェーズIDでバリデーションエラーになるべき")
+	}
+}
+
+// TestPassiveSkillToDomainWithEffects は効果列指定のパッシブスキルが変換されることをテストします。
+func TestPassiveSkillToDomainWithEffects(t *testing.T) {
+	data := PassiveSkillData{
+		ID:      "ps_fire_guard",
+		Name:    "炎の守り",
+		Effects: map[string]float64{"fire_cut": 0.3},
+	}
+
+	skill := data.ToDomain()
+
+	if got := skill.Effects[domain.ColFireCut]; got != 0.3 {
+		t.Errorf("fire_cut: got %f, want 0.3", got)
+	}
+}
+
+// TestLoadEnemyTypesVoltageThresholds はボルテージ段階の読み込み（昇順への並び替え）と未設定時のデフォルトをテストします。
+func TestLoadEnemyTypesVoltageThresholds(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	enemiesJSON := `{
+		"enemy_types": [
+			{
+				"id": "boss",
+				"name": "ボス",
+				"base_hp": 500,
+				"base_attack_power": 20,
+				"attack_type": "physical",
+				"ascii_art": "  BOSS",
+				"voltage_thresholds": [
+					{ "voltage": 250, "name": "暴走", "charge_time_mult": 0.5, "enrage_action_id": "act_rage" },
+					{ "voltage": 180, "name": "激昂" }
+				]
+			},
+			{
+				"id": "slime",
+				"name": "スライム",
+				"base_hp": 50,
+				"base_attack_power": 5,
+				"attack_type": "physical",
+				"ascii_art": "  ___"
+			}
+		]
+	}`
+
+	enemiesPath := filepath.Join(tmpDir, "enemies.json")
+	if err := os.WriteFile(enemiesPath, []byte(enemiesJSON), 0644); err != nil {
+		t.Fatalf("テストファイルの作成に失敗: %v", err)
+	}
+
+	loader := NewDataLoader(tmpDir)
+	enemyTypes, err := loader.LoadEnemyTypes()
+	if err != nil {
+		t.Fatalf("敵タイプのロードに失敗: %v", err)
+	}
+
+	boss := enemyTypes[0].ToDomain()
+	if len(boss.VoltageThresholds) != 2 {
+		t.Fatalf("ボルテージ段階数: got %d, want 2", len(boss.VoltageThresholds))
+	}
+	if boss.VoltageThresholds[0].Voltage != 180 || boss.VoltageThresholds[1].Name != "暴走" {
+		t.Errorf("ボルテージ段階は昇順に並ぶべき: got %+v", boss.VoltageThresholds)
+	}
+	if boss.VoltageThresholds[1].ChargeTimeMultiplier != 0.5 || boss.VoltageThresholds[1].EnrageActionID != "act_rage" {
+		t.Errorf("暴走の段階: got %+v", boss.VoltageThresholds[1])
+	}
+
+	slime := enemyTypes[1].ToDomain()
+	if len(slime.VoltageThresholds) != len(DefaultVoltageThresholds) || slime.VoltageThresholds[0].Voltage != 150 {
+		t.Errorf("未設定時はデフォルトの段階を適用するべき: got %+v", slime.VoltageThresholds)
+	}
+	for _, threshold := range slime.VoltageThresholds {
+		if threshold.ChargeTimeMultiplier != 0 {
+			t.Errorf("デフォルトの段階はチャージ時間を変えないべき: got %+v", threshold)
+		}
+	}
+}
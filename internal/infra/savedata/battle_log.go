@@ -0,0 +1,147 @@
+// Package savedata はセーブデータの永続化を担当します。
+// battle_log.go はバトルログのJSON Lines形式での書き出しを担当します。
+
+package savedata
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BattleLogDirName はセーブディレクトリ内のバトルログ書き出し先ディレクトリ名です。
+const BattleLogDirName = "logs"
+
+// BattleLogFileExt はバトルログファイルの拡張子です。
+const BattleLogFileExt = ".jsonl"
+
+// BattleLogEventSave はバトルログのイベント1件（JSON Linesの1行）です。
+type BattleLogEventSave struct {
+	// AtNs はバトル開始からの経過時間（ナノ秒）です。
+	AtNs int64 `json:"at_ns"`
+
+	// Kind はイベントの種別です（damage_dealt, damage_taken, heal, evade, buff_added, buff_expired, passive, chain, phase_change, parry, voltage_down, enrage）。
+	Kind string `json:"kind"`
+
+	// Source は発生源です。
+	Source string `json:"source,omitempty"`
+
+	// Target は対象です。
+	Target string `json:"target,omitempty"`
+
+	// Amount はダメージ量・回復量です。
+	Amount int `json:"amount,omitempty"`
+
+	// Detail は補足情報です。
+	Detail string `json:"detail,omitempty"`
+
+	// Trace はダメージ・回復量の計算トレースです。
+	Trace *CalculationTraceSave `json:"trace,omitempty"`
+}
+
+// CalculationTraceSave はダメージ・回復量の計算トレースです。
+type CalculationTraceSave struct {
+	// Steps は計算の各段階（古い順）です。
+	Steps []TraceStepSave `json:"steps"`
+
+	// Effects は計算に寄与した効果テーブルのエントリです。
+	Effects []TraceEffectSave `json:"effects,omitempty"`
+}
+
+// TraceStepSave は計算の1段階です。
+type TraceStepSave struct {
+	// Label は段階の名前です。
+	Label string `json:"label"`
+
+	// Formula は段階で適用した式や係数の説明です。
+	Formula string `json:"formula,omitempty"`
+
+	// Value はこの段階を適用した後の値です。
+	Value float64 `json:"value"`
+}
+
+// TraceEffectSave は計算に寄与した効果テーブルのエントリです。
+type TraceEffectSave struct {
+	// Owner は効果テーブルの持ち主です。
+	Owner string `json:"owner"`
+
+	// Source は効果の表示名です。
+	Source string `json:"source"`
+
+	// Column は効果列です。
+	Column string `json:"column"`
+
+	// Value は効果値です。
+	Value float64 `json:"value"`
+
+	// Aggregation は列の集計方法です。
+	Aggregation string `json:"aggregation"`
+}
+
+// BattleLogIO はバトルログファイルの書き出しを担当する構造体です。
+type BattleLogIO struct {
+	// logDir はバトルログを書き出すディレクトリパスです。
+	logDir string
+}
+
+// NewBattleLogIO は新しいBattleLogIOを作成します。
+// バトルログはセーブディレクトリ内のlogsディレクトリに書き出されます。
+func NewBattleLogIO(saveDir string) *BattleLogIO {
+	return &BattleLogIO{
+		logDir: filepath.Join(saveDir, BattleLogDirName),
+	}
+}
+
+// Dir はバトルログの書き出し先ディレクトリを返します。
+func (io *BattleLogIO) Dir() string {
+	return io.logDir
+}
+
+// SaveBattleLog はイベントを1行1件のJSON Lines形式でファイルに書き出し、書き出し先のパスを返します。
+func (io *BattleLogIO) SaveBattleLog(exportedAt time.Time, events []BattleLogEventSave) (string, error) {
+	if err := os.MkdirAll(io.logDir, 0755); err != nil {
+		return "", fmt.Errorf("バトルログディレクトリの作成に失敗: %w", err)
+	}
+
+	// 一時ファイルに書き込んでからリネーム（原子的書き込み）
+	path := filepath.Join(io.logDir, exportedAt.Format("20060102-150405.000")+BattleLogFileExt)
+	tmpPath := path + ".tmp"
+	if err := writeJSONLines(tmpPath, events); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("バトルログファイルのリネームに失敗: %w", err)
+	}
+
+	return path, nil
+}
+
+// writeJSONLines はイベントを1行ずつJSONとしてファイルに書き込みます。
+func writeJSONLines(path string, events []BattleLogEventSave) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("バトルログの書き込みに失敗: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("バトルログのシリアライズに失敗: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("バトルログの書き込みに失敗: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("バトルログの書き込みに失敗: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package savedata はセーブデータの永続化を担当します。
+package savedata
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSaveBattleLog はバトルログが1行1イベントのJSON Lines形式で書き出されることをテストします。
+func TestSaveBattleLog(t *testing.T) {
+	io := NewBattleLogIO(t.TempDir())
+	events := []BattleLogEventSave{
+		{AtNs: int64(time.Second), Kind: "damage_dealt", Source: "ファイアボール", Target: "スライム", Amount: 12, Detail: "クリティカル", Trace: &CalculationTraceSave{
+			Steps:   []TraceStepSave{{Label: "基本値", Formula: "5 + 0.5 × 10", Value: 10}, {Label: "ダメージ倍率", Formula: "×1.20", Value: 12}},
+			Effects: []TraceEffectSave{{Owner: "プレイヤー", Source: "攻撃UP", Column: "damage_mult", Value: 1.2, Aggregation: "乗算"}},
+		}},
+		{AtNs: int64(2 * time.Second), Kind: "evade", Source: "スライム", Target: "プレイヤー"},
+	}
+
+	path, err := io.SaveBattleLog(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), events)
+	if err != nil {
+		t.Fatalf("バトルログの書き出しに失敗: %v", err)
+	}
+	if filepath.Dir(path) != io.Dir() || filepath.Ext(path) != BattleLogFileExt {
+		t.Errorf("書き出し先: 実際 %s", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("バトルログを開けません: %v", err)
+	}
+	defer file.Close()
+
+	var loaded []BattleLogEventSave
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event BattleLogEventSave
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("行のJSONパースに失敗: %v (%s)", err, scanner.Text())
+		}
+		loaded = append(loaded, event)
+	}
+	if len(loaded) != len(events) {
+		t.Fatalf("行数: 期待 %d, 実際 %d", len(events), len(loaded))
+	}
+	for i := range events {
+		if !reflect.DeepEqual(loaded[i], events[i]) {
+			t.Errorf("%d行目: 期待 %+v, 実際 %+v", i+1, events[i], loaded[i])
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Package savedata はセーブデータの永続化を担当します。
+// replay.go はバトルリプレイファイルの保存・一覧・読み込みを担当します。
+
+package savedata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CurrentReplayVersion は現在のリプレイファイルのバージョンです。
+const CurrentReplayVersion = "1.0.0"
+
+// ReplayDirName はセーブディレクトリ内のリプレイ保存先ディレクトリ名です。
+const ReplayDirName = "replays"
+
+// ReplayFileExt はリプレイファイルの拡張子です。
+const ReplayFileExt = ".replay.json"
+
+// MaxReplayCount は保存するリプレイの最大数です。超えた分は古いものから削除します。
+const MaxReplayCount = 200
+
+// ReplayData はバトルリプレイファイルの内容を表す構造体です。
+// 時間はすべてナノ秒で保存します（再生時に記録時と同じ時刻で入力を再投入するため）。
+type ReplayData struct {
+	// Version はリプレイファイルのバージョンです。
+	Version string `json:"version"`
+
+	// RecordedAt はバトル開始時刻です。
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// Victory は勝利したかどうかです。
+	Victory bool `json:"victory"`
+
+	// Retreated は撤退によってバトルが終了したかどうかです。
+	Retreated bool `json:"retreated,omitempty"`
+
+	// DurationNs はバトル時間（ナノ秒）です。
+	DurationNs int64 `json:"duration_ns"`
+
+	// Seeds は乱数シードです。
+	Seeds ReplaySeedsSave `json:"seeds"`
+
+	// Enemy は敵のスナップショットです（複数敵バトルでは最初のウェーブの先頭の敵）。
+	Enemy ReplayEnemySave `json:"enemy"`
+
+	// Waves は複数敵バトルのウェーブごとの敵のスナップショットです（1対1バトルでは省略）。
+	Waves [][]ReplayEnemySave `json:"waves,omitempty"`
+
+	// PlayerMaxHP はプレイヤーの最大HPです。
+	PlayerMaxHP int `json:"player_max_hp"`
+
+	// PlayerHP は開始時のプレイヤーHPです（0の場合は最大HP）。
+	PlayerHP int `json:"player_hp,omitempty"`
+
+	// Agents は装備していたエージェントです。
+	Agents []AgentInstanceSave `json:"agents"`
+
+	// Events は時刻順の入力列です。
+	Events []ReplayEventSave `json:"events"`
+}
+
+// ReplaySeedsSave は乱数シードのセーブデータです。
+type ReplaySeedsSave struct {
+	Engine        int64 `json:"engine"`
+	Challenge     int64 `json:"challenge"`
+	PlayerEffects int64 `json:"player_effects"`
+	EnemyEffects  int64 `json:"enemy_effects"`
+}
+
+// ReplayEnemySave は敵のスナップショットのセーブデータです。
+type ReplayEnemySave struct {
+	TypeID      string `json:"type_id"`
+	Name        string `json:"name"`
+	Level       int    `json:"level"`
+	MaxHP       int    `json:"max_hp"`
+	AttackPower int    `json:"attack_power"`
+}
+
+// ReplayEventSave は入力1件のセーブデータです。
+type ReplayEventSave struct {
+	// AtNs はバトル開始からの経過時間（ナノ秒）です。
+	AtNs int64 `json:"at_ns"`
+
+	// Kind は入力の種別です（tick, challenge, key, cancel, pause, resume, target, parry, retreat）。
+	Kind string `json:"kind"`
+
+	// DeltaNs はTickで進めた時間（ナノ秒）です。
+	DeltaNs int64 `json:"delta_ns,omitempty"`
+
+	// Slot は選択したモジュールスロットです。
+	Slot int `json:"slot,omitempty"`
+
+	// Challenge は出題されたチャレンジです。
+	Challenge *ReplayChallengeSave `json:"challenge,omitempty"`
+
+	// Key は入力された文字です。
+	Key string `json:"key,omitempty"`
+
+	// Target は選択した攻撃対象のインデックスです。
+	Target int `json:"target,omitempty"`
+}
+
+// ReplayChallengeSave はタイピングチャレンジのセーブデータです。
+type ReplayChallengeSave struct {
+	Text        string `json:"text"`
+	Reading     string `json:"reading,omitempty"`
+	TimeLimitNs int64  `json:"time_limit_ns"`
+	Difficulty  int    `json:"difficulty"`
+	Mode        string `json:"mode"`
+}
+
+// ReplayFileInfo はリプレイ一覧表示用の概要です。
+type ReplayFileInfo struct {
+	// Name はファイル名です（LoadReplayに渡します）。
+	Name string
+
+	// Path はファイルのフルパスです。
+	Path string
+
+	RecordedAt time.Time
+	EnemyName  string
+	EnemyLevel int
+	Victory    bool
+	Retreated  bool
+	Duration   time.Duration
+}
+
+// replayHeader は一覧表示のために読み込むリプレイのヘッダ部分です。
+type replayHeader struct {
+	Version    string          `json:"version"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Victory    bool            `json:"victory"`
+	Retreated  bool            `json:"retreated,omitempty"`
+	DurationNs int64           `json:"duration_ns"`
+	Enemy      ReplayEnemySave `json:"enemy"`
+}
+
+// ReplayIO はリプレイファイルのI/Oを担当する構造体です。
+type ReplayIO struct {
+	// replayDir はリプレイファイルを保存するディレクトリパスです。
+	replayDir string
+}
+
+// NewReplayIO は新しいReplayIOを作成します。
+// リプレイはセーブディレクトリ内のreplaysディレクトリに保存されます。
+func NewReplayIO(saveDir string) *ReplayIO {
+	return &ReplayIO{
+		replayDir: filepath.Join(saveDir, ReplayDirName),
+	}
+}
+
+// Dir はリプレイの保存先ディレクトリを返します。
+func (io *ReplayIO) Dir() string {
+	return io.replayDir
+}
+
+// SaveReplay はリプレイをファイルに保存し、保存先のパスを返します。
+// 保存数がMaxReplayCountを超えた場合は古いリプレイから削除します。
+func (io *ReplayIO) SaveReplay(data *ReplayData) (string, error) {
+	data.Version = CurrentReplayVersion
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("リプレイのシリアライズに失敗: %w", err)
+	}
+
+	if err := os.MkdirAll(io.replayDir, 0755); err != nil {
+		return "", fmt.Errorf("リプレイディレクトリの作成に失敗: %w", err)
+	}
+
+	// 一時ファイルに書き込んでからリネーム（原子的書き込み）
+	path := filepath.Join(io.replayDir, replayFileName(data))
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, jsonData, 0644); err != nil {
+		return "", fmt.Errorf("リプレイの書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("リプレイファイルのリネームに失敗: %w", err)
+	}
+
+	// 古いリプレイを削除（失敗しても保存は成功扱い）
+	_ = io.pruneReplays()
+
+	return path, nil
+}
+
+// replayFileName は記録時刻と敵タイプからファイル名を生成します。
+// 名前順に並べると記録時刻順になります。
+func replayFileName(data *ReplayData) string {
+	return fmt.Sprintf("%s_%s_lv%d%s",
+		data.RecordedAt.Format("20060102-150405.000"),
+		data.Enemy.TypeID,
+		data.Enemy.Level,
+		ReplayFileExt,
+	)
+}
+
+// replayFileNames はリプレイファイル名を記録時刻の古い順で返します。
+func (io *ReplayIO) replayFileNames() ([]string, error) {
+	entries, err := os.ReadDir(io.replayDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("リプレイディレクトリの読み込みに失敗: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ReplayFileExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// pruneReplays はMaxReplayCountを超えた古いリプレイを削除します。
+func (io *ReplayIO) pruneReplays() error {
+	names, err := io.replayFileNames()
+	if err != nil {
+		return err
+	}
+	for len(names) > MaxReplayCount {
+		if err := os.Remove(filepath.Join(io.replayDir, names[0])); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// ListReplays は保存済みリプレイの概要を新しい順で返します。
+// 読み込めないファイルは一覧から除外します。
+func (io *ReplayIO) ListReplays() ([]ReplayFileInfo, error) {
+	names, err := io.replayFileNames()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ReplayFileInfo, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		path := filepath.Join(io.replayDir, names[i])
+		jsonData, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var header replayHeader
+		if err := json.Unmarshal(jsonData, &header); err != nil || header.Version == "" {
+			continue
+		}
+		infos = append(infos, ReplayFileInfo{
+			Name:       names[i],
+			Path:       path,
+			RecordedAt: header.RecordedAt,
+			EnemyName:  header.Enemy.Name,
+			EnemyLevel: header.Enemy.Level,
+			Victory:    header.Victory,
+			Retreated:  header.Retreated,
+			Duration:   time.Duration(header.DurationNs),
+		})
+	}
+	return infos, nil
+}
+
+// LoadReplay は指定された名前のリプレイファイルを読み込みます。
+// ファイルパスを指定した場合はそのファイルを読み込みます（バグ報告に添付されたリプレイ用）。
+func (io *ReplayIO) LoadReplay(name string) (*ReplayData, error) {
+	path := name
+	if filepath.Base(name) == name {
+		path = filepath.Join(io.replayDir, name)
+	}
+
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("リプレイの読み込みに失敗: %w", err)
+	}
+
+	var data ReplayData
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return nil, fmt.Errorf("リプレイのJSONパースに失敗: %w", err)
+	}
+	if data.Version == "" {
+		return nil, fmt.Errorf("リプレイのバージョンが不正です")
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,133 @@
+// Package savedata はセーブデータの永続化を担当します。
+package savedata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestReplayData はテスト用のリプレイデータを作成します。
+func newTestReplayData(recordedAt time.Time, victory bool) *ReplayData {
+	return &ReplayData{
+		RecordedAt: recordedAt,
+		Victory:    victory,
+		DurationNs: int64(42 * time.Second),
+		Seeds:      ReplaySeedsSave{Engine: 1, Challenge: 2, PlayerEffects: 3, EnemyEffects: 4},
+		Enemy:      ReplayEnemySave{TypeID: "slime", Name: "スライム", Level: 3, MaxHP: 150, AttackPower: 12},
+		Agents: []AgentInstanceSave{
+			{ID: "agent_1", Core: CoreInstanceSave{CoreTypeID: "all_rounder", Level: 5}},
+		},
+		Events: []ReplayEventSave{
+			{AtNs: int64(100 * time.Millisecond), Kind: "tick", DeltaNs: int64(100 * time.Millisecond)},
+			{AtNs: int64(200 * time.Millisecond), Kind: "key", Key: "a"},
+		},
+	}
+}
+
+// TestSaveAndLoadReplay はリプレイの保存・一覧・読み込みをテストします。
+func TestSaveAndLoadReplay(t *testing.T) {
+	io := NewReplayIO(t.TempDir())
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	retreated := newTestReplayData(base, false)
+	retreated.Retreated = true
+	if _, err := io.SaveReplay(retreated); err != nil {
+		t.Fatalf("リプレイの保存に失敗: %v", err)
+	}
+	path, err := io.SaveReplay(newTestReplayData(base.Add(time.Minute), true))
+	if err != nil {
+		t.Fatalf("リプレイの保存に失敗: %v", err)
+	}
+	if filepath.Dir(path) != io.Dir() {
+		t.Errorf("保存先: 期待 %s, 実際 %s", io.Dir(), filepath.Dir(path))
+	}
+
+	infos, err := io.ListReplays()
+	if err != nil {
+		t.Fatalf("リプレイ一覧の取得に失敗: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("リプレイ数: 期待 2, 実際 %d", len(infos))
+	}
+	// 新しい順
+	if !infos[0].Victory || !infos[0].RecordedAt.Equal(base.Add(time.Minute)) {
+		t.Errorf("先頭のリプレイが最新ではありません: %+v", infos[0])
+	}
+	if infos[0].Retreated || !infos[1].Retreated || infos[1].Victory {
+		t.Errorf("撤退の結果: 実際 %+v / %+v", infos[0], infos[1])
+	}
+	if infos[0].EnemyName != "スライム" || infos[0].EnemyLevel != 3 || infos[0].Duration != 42*time.Second {
+		t.Errorf("リプレイ概要: 実際 %+v", infos[0])
+	}
+
+	loaded, err := io.LoadReplay(infos[0].Name)
+	if err != nil {
+		t.Fatalf("リプレイの読み込みに失敗: %v", err)
+	}
+	if loaded.Version != CurrentReplayVersion {
+		t.Errorf("バージョン: 期待 %s, 実際 %s", CurrentReplayVersion, loaded.Version)
+	}
+	if loaded.Seeds.Challenge != 2 || len(loaded.Agents) != 1 || len(loaded.Events) != 2 || loaded.Events[1].Key != "a" {
+		t.Errorf("読み込んだリプレイが一致しません: %+v", loaded)
+	}
+
+	// フルパスでも読み込める
+	if _, err := io.LoadReplay(path); err != nil {
+		t.Errorf("パス指定での読み込みに失敗: %v", err)
+	}
+}
+
+// TestListReplays_SkipsBrokenFiles は壊れたリプレイファイルが一覧から除外されることをテストします。
+func TestListReplays_SkipsBrokenFiles(t *testing.T) {
+	io := NewReplayIO(t.TempDir())
+
+	infos, err := io.ListReplays()
+	if err != nil || len(infos) != 0 {
+		t.Fatalf("ディレクトリが無い場合は空の一覧: 実際 %v, %v", infos, err)
+	}
+
+	if _, err := io.SaveReplay(newTestReplayData(time.Now(), true)); err != nil {
+		t.Fatalf("リプレイの保存に失敗: %v", err)
+	}
+	broken := filepath.Join(io.Dir(), "broken"+ReplayFileExt)
+	if err := os.WriteFile(broken, []byte("{invalid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err = io.ListReplays()
+	if err != nil {
+		t.Fatalf("リプレイ一覧の取得に失敗: %v", err)
+	}
+	if len(infos) != 1 {
+		t.Errorf("リプレイ数: 期待 1, 実際 %d", len(infos))
+	}
+	if _, err := io.LoadReplay("broken" + ReplayFileExt); err == nil {
+		t.Error("壊れたリプレイの読み込みでエラーになりませんでした")
+	}
+}
+
+// TestSaveReplay_PrunesOldReplays は保存数の上限を超えた古いリプレイが削除されることをテストします。
+func TestSaveReplay_PrunesOldReplays(t *testing.T) {
+	io := NewReplayIO(t.TempDir())
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i := 0; i < MaxReplayCount+3; i++ {
+		if _, err := io.SaveReplay(newTestReplayData(base.Add(time.Duration(i)*time.Second), true)); err != nil {
+			t.Fatalf("リプレイの保存に失敗: %v", err)
+		}
+	}
+
+	infos, err := io.ListReplays()
+	if err != nil {
+		t.Fatalf("リプレイ一覧の取得に失敗: %v", err)
+	}
+	if len(infos) != MaxReplayCount {
+		t.Fatalf("リプレイ数: 期待 %d, 実際 %d", MaxReplayCount, len(infos))
+	}
+	oldest := infos[len(infos)-1].RecordedAt
+	if !oldest.Equal(base.Add(3 * time.Second)) {
+		t.Errorf("最古のリプレイ: 期待 %v, 実際 %v", base.Add(3*time.Second), oldest)
+	}
+}
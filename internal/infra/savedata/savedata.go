@@ -158,6 +158,9 @@ type StatisticsSaveData struct {
 	// Defeats は敗北数です。
 	Defeats int `json:"defeats"`
 
+	// Retreats は撤退数です。
+	Retreats int `json:"retreats,omitempty"`
+
 	// MaxLevelReached は到達最高レベルです。
 
 	MaxLevelReached int `json:"max_level_reached"`
@@ -174,11 +177,47 @@ type StatisticsSaveData struct {
 	// TotalCharactersTyped は総タイプ文字数です。
 	TotalCharactersTyped int `json:"total_characters_typed"`
 
+	// TotalCriticalHits はクリティカルヒットの総数です。
+	TotalCriticalHits int `json:"total_critical_hits"`
+
+	// TowerBestFloor はエンドレスタワーの最高到達階です。
+	TowerBestFloor int `json:"tower_best_floor,omitempty"`
+
 	// EncounteredEnemies はエンカウントした敵のIDリストです（敵図鑑用）。
 	EncounteredEnemies []string `json:"encountered_enemies"`
 
 	// DefeatedEnemies は撃破済み敵の情報です（敵タイプID→撃破最高レベル）。
 	DefeatedEnemies map[string]int `json:"defeated_enemies,omitempty"`
+
+	// DiscoveredWeaknesses は発見済みの敵の弱点属性です（敵タイプID→属性リスト、敵図鑑用）。
+	DiscoveredWeaknesses map[string][]string `json:"discovered_weaknesses,omitempty"`
+
+	// KeyStats は文字・バイグラムごとの入力統計です（アダプティブ出題用）。
+	KeyStats *KeyStatsSaveData `json:"key_stats,omitempty"`
+}
+
+// KeyStatsSaveData は文字・バイグラムごとの入力統計のセーブデータです。
+type KeyStatsSaveData struct {
+	// Chars は文字ごとの統計です。
+	Chars map[string]KeyStatSaveData `json:"chars"`
+
+	// Bigrams はバイグラムごとの統計です。
+	Bigrams map[string]KeyStatSaveData `json:"bigrams"`
+}
+
+// KeyStatSaveData は1キーの入力統計のセーブデータです。
+type KeyStatSaveData struct {
+	// Count は入力回数です。
+	Count int `json:"count"`
+
+	// Errors はミス回数です。
+	Errors int `json:"errors"`
+
+	// TotalLatencyMs は正しく入力できた際の所要時間の合計（ミリ秒）です。
+	TotalLatencyMs int64 `json:"total_latency_ms"`
+
+	// LatencyCount は所要時間を記録した回数です。
+	LatencyCount int `json:"latency_count"`
 }
 
 // AchievementsSaveData は実績のセーブデータです。
@@ -194,6 +233,12 @@ type AchievementsSaveData struct {
 type SettingsSaveData struct {
 	// KeyBindings はキーバインド設定です。
 	KeyBindings map[string]string `json:"key_bindings"`
+
+	// TypingMode はタイピングの入力モード（"english" / "romaji"）です。
+	TypingMode string `json:"typing_mode,omitempty"`
+
+	// AdaptiveWords は苦手キーを含む単語を優先して出題するかどうかです。
+	AdaptiveWords bool `json:"adaptive_words,omitempty"`
 }
 
 // NewSaveData は新しいセーブデータを作成します。
@@ -101,7 +101,7 @@ func (i *NewGameInitializer) CreateInitialAgents() []*domain.AgentModel {
 						modData.ChainEffectValue,
 						chainEffectDef.Description,
 						chainEffectDef.ShortDescription,
-					)
+					).WithMinChainLength(chainEffectDef.MinChainLength)
 					chainEffect = &ce
 				} else {
 					slog.Warn("チェイン効果定義が見つかりません",
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
 	"hirorocky/type-battle/internal/usecase/combat"
 	"hirorocky/type-battle/internal/usecase/typing"
 )
@@ -149,7 +150,7 @@ func TestBattleFlow_EnemyAttack(t *testing.T) {
 	initialHP := state.Player.HP
 
 	// 敵攻撃を処理
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// ダメージが与えられた
 	if damage <= 0 {
@@ -349,7 +350,7 @@ func TestBattleFlow_BuffDebuffInteraction(t *testing.T) {
 	})
 
 	// 敵攻撃
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	expectedMaxDamage := state.Enemy.AttackPower // バフなしの場合
 
 	// ダメージが軽減されている
@@ -859,3 +860,45 @@ func TestBattleFlow_BuffDebuffPattern(t *testing.T) {
 		t.Error("デバフがプレイヤーのEffectTableに登録されるべきです")
 	}
 }
+
+// TestBattleFlow_HeadlessSession はTUIを介さずにBattleSessionで1戦を最後まで実行できることを検証します。
+func TestBattleFlow_HeadlessSession(t *testing.T) {
+	enemyTypes := createTestEnemyTypes()
+	enemyTypes[0].ResolvedNormalActions = []domain.EnemyAction{
+		{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: time.Second},
+	}
+	enemy := domain.NewEnemy("goblin", "ゴブリン Lv.1", 1, 200, 5, enemyTypes[0])
+	player := domain.NewPlayer()
+	player.MaxHP = 200
+	player.HP = 200
+
+	session := combat.NewBattleSession(enemy, player, createTestAgents(), nil)
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	session.SetClock(c)
+	session.Start()
+
+	// 物理打撃モジュールを繰り返し使用する
+	const tick = 100 * time.Millisecond
+	for i := 0; i < 3000 && !session.IsOver(); i++ {
+		if !session.IsTyping() && session.SelectModule(0) {
+			for _, r := range session.TypingText() {
+				session.ProcessTypingInput(r)
+			}
+		}
+		c.Advance(tick)
+		session.Tick(tick)
+	}
+
+	if !session.IsOver() {
+		t.Fatal("バトルが決着するべきです")
+	}
+	if !session.IsVictory() {
+		t.Errorf("勝利するべきです: 敵HP %d, プレイヤーHP %d", enemy.HP, player.HP)
+	}
+	if player.HP >= player.MaxHP {
+		t.Error("バトル中に敵の攻撃を受けているべきです")
+	}
+	if session.Result().Stats.TotalTypingCount == 0 {
+		t.Error("タイピング結果が統計に記録されるべきです")
+	}
+}
@@ -604,7 +604,7 @@ func TestE2E_DefeatAndRetry(t *testing.T) {
 
 	// 敵の攻撃を受け続けて敗北
 	for battleState.Player.IsAlive() {
-		engine.ProcessEnemyAttackDamage(battleState, "physical")
+		engine.ProcessEnemyAttackDamage(battleState, "physical", domain.ElementNone)
 	}
 
 	// 敗北確認
@@ -307,7 +307,7 @@ func TestRefactoring_BattleFlowUnchanged(t *testing.T) {
 
 	// 敵攻撃の検証
 	initialHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	if damage <= 0 {
 		t.Error("ダメージは正の値であるべき")
 	}
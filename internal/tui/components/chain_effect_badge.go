@@ -111,7 +111,15 @@ func (b *ChainEffectBadge) RenderWithValue() string {
 	// 白で統一
 	style := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
 
-	return style.Render(b.effect.ShortDescription)
+	return style.Render(b.shortLabel())
+}
+
+// shortLabel は短い説明文に必要チェイン数（あれば）を添えた表示文字列を返します。
+func (b *ChainEffectBadge) shortLabel() string {
+	if !b.effect.RequiresChain() {
+		return b.effect.ShortDescription
+	}
+	return fmt.Sprintf("%s [%dチェイン〜]", b.effect.ShortDescription, b.effect.MinChainLength)
 }
 
 // RenderFull はバッジをフルフォーマットでレンダリングします（アイコン、効果値、説明）。
@@ -178,7 +186,7 @@ func (b *ChainEffectBadge) RenderActive() string {
 		Bold(true).
 		Padding(0, 1)
 
-	return activeStyle.Render(b.effect.ShortDescription)
+	return activeStyle.Render(b.shortLabel())
 }
 
 // HasEffect はこのバッジが有効なチェイン効果を持っているかを返します。
@@ -154,6 +154,7 @@ func (n *PassiveSkillNotification) RenderEffectsList() []string {
 		domain.ColTimeExtend:       "入力時間",
 		domain.ColCooldownReduce:   "CD短縮",
 		domain.ColCritRate:         "クリ率",
+		domain.ColCritDamage:       "クリダメ",
 		domain.ColSTRBonus:         "STR",
 		domain.ColINTBonus:         "INT",
 		domain.ColWILBonus:         "WIL",
@@ -183,6 +184,7 @@ func (n *PassiveSkillNotification) RenderEffectsList() []string {
 		domain.ColEvasion:        true,
 		domain.ColCooldownReduce: true,
 		domain.ColCritRate:       true,
+		domain.ColCritDamage:     true,
 		domain.ColLifeSteal:      true,
 	}
 
@@ -82,12 +82,20 @@ func CreateEncyclopediaData(gs *session.GameState) *screens.EncyclopediaData {
 		acquiredModuleTypes = append(acquiredModuleTypes, module.TypeID)
 	}
 
+	// 敵タイプはマスタデータを優先（エンカウント済みIDと一致させるため）
+	enemyTypes := baseData.AllEnemyTypes
+	if gen := gs.EnemyGenerator(); gen != nil && len(gen.GetEnemyTypes()) > 0 {
+		enemyTypes = gen.GetEnemyTypes()
+	}
+
 	return &screens.EncyclopediaData{
 		AllCoreTypes:        baseData.AllCoreTypes,
 		AllModuleTypes:      baseData.AllModuleTypes,
-		AllEnemyTypes:       baseData.AllEnemyTypes,
+		AllEnemyTypes:       enemyTypes,
 		AcquiredCoreTypes:   acquiredCoreTypes,
 		AcquiredModuleTypes: acquiredModuleTypes,
 		EncounteredEnemies:  gs.GetEncounteredEnemies(),
+
+		DiscoveredWeaknesses: gs.GetDiscoveredWeaknesses(),
 	}
 }
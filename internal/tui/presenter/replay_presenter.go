@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"log/slog"
+
+	"hirorocky/type-battle/internal/infra/savedata"
+	"hirorocky/type-battle/internal/tui/screens"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/session"
+)
+
+// ReplayProviderAdapter はReplayIOをReplayProviderインターフェースに適合させます。
+// リプレイファイルからの復元にはマスタデータ（敵タイプ・コア・モジュール）を使用します。
+type ReplayProviderAdapter struct {
+	io      *savedata.ReplayIO
+	sources *session.DomainDataSources
+}
+
+// NewReplayProviderAdapter は新しいReplayProviderAdapterを作成します。
+func NewReplayProviderAdapter(io *savedata.ReplayIO, sources *session.DomainDataSources) *ReplayProviderAdapter {
+	return &ReplayProviderAdapter{
+		io:      io,
+		sources: sources,
+	}
+}
+
+// ListReplays は保存済みリプレイを新しい順で返します。
+func (a *ReplayProviderAdapter) ListReplays() []screens.ReplayListItem {
+	infos, err := a.io.ListReplays()
+	if err != nil {
+		slog.Error("リプレイ一覧の取得に失敗",
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	items := make([]screens.ReplayListItem, len(infos))
+	for i, info := range infos {
+		items[i] = screens.ReplayListItem{
+			Name:       info.Name,
+			RecordedAt: info.RecordedAt,
+			EnemyName:  info.EnemyName,
+			EnemyLevel: info.EnemyLevel,
+			Victory:    info.Victory,
+			Retreated:  info.Retreated,
+			Duration:   info.Duration,
+		}
+	}
+	return items
+}
+
+// LoadReplay は指定された名前のリプレイを読み込みます。
+func (a *ReplayProviderAdapter) LoadReplay(name string) (*combat.Replay, error) {
+	data, err := a.io.LoadReplay(name)
+	if err != nil {
+		return nil, err
+	}
+	return session.ReplayFromSaveData(data, a.sources)
+}
@@ -9,8 +9,10 @@ import (
 func CreateSettingsData(gs *session.GameState) *screens.SettingsData {
 	settings := gs.Settings()
 	return &screens.SettingsData{
-		Keybinds:    settings.Keybinds(),
-		SoundVolume: settings.SoundVolume(),
-		Difficulty:  string(settings.Difficulty()),
+		Keybinds:      settings.Keybinds(),
+		SoundVolume:   settings.SoundVolume(),
+		Difficulty:    string(settings.Difficulty()),
+		TypingMode:    string(settings.TypingMode()),
+		AdaptiveWords: settings.AdaptiveWords(),
 	}
 }
@@ -26,6 +26,9 @@ func TestCreateSettingsData(t *testing.T) {
 	if data.Keybinds == nil {
 		t.Error("Keybinds is nil")
 	}
+	if data.TypingMode != "english" {
+		t.Errorf("TypingMode expected 'english', got %s", data.TypingMode)
+	}
 }
 
 // TestCreateSettingsData_ModifiedSettings は変更後の設定データ作成をテストします。
@@ -36,6 +39,7 @@ func TestCreateSettingsData_ModifiedSettings(t *testing.T) {
 	gs.Settings().SetSoundVolume(50)
 	gs.Settings().SetDifficulty(session.DifficultyHard)
 	gs.Settings().SetKeybind("custom_action", "x")
+	gs.Settings().SetTypingMode(session.TypingModeRomaji)
 
 	data := CreateSettingsData(gs)
 
@@ -48,4 +52,7 @@ func TestCreateSettingsData_ModifiedSettings(t *testing.T) {
 	if data.Keybinds["custom_action"] != "x" {
 		t.Errorf("Keybind expected 'x', got %s", data.Keybinds["custom_action"])
 	}
+	if data.TypingMode != "romaji" {
+		t.Errorf("TypingMode expected 'romaji', got %s", data.TypingMode)
+	}
 }
@@ -35,7 +35,11 @@ func CreateStatsData(gs *session.GameState) *screens.StatsData {
 			TotalBattles:    stats.Battle().TotalBattles,
 			Wins:            stats.Battle().Wins,
 			Losses:          stats.Battle().Losses,
+			Retreats:        stats.Battle().Retreats,
 			MaxLevelReached: gs.MaxLevelReached,
+
+			TotalCriticalHits: stats.Battle().TotalCriticalHits,
+			TowerBestFloor:    stats.Battle().TowerBestFloor,
 		},
 		Achievements: achievementData,
 	}
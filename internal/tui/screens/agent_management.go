@@ -1622,7 +1622,7 @@ func (s *AgentManagementScreen) handleDebugChainEffectSelection(msg tea.KeyMsg)
 				ce.MaxValue,
 				ce.Description,
 				ce.ShortDescription,
-			)
+			).WithMinChainLength(ce.MinChainLength)
 			s.debugSynthesisState.selectedModules[moduleIdx].ChainEffect = &chainEffect
 		}
 
@@ -1899,9 +1899,25 @@ func getModuleEffectSummary(module *domain.ModuleModel) string {
 	var parts []string
 
 	for _, effect := range module.Type.Effects {
-		if effect.IsDamageEffect() {
+		if effect.Target == domain.TargetBoth {
+			// 両対象はドレイン・捨て身・フィールド効果として表示
+			if effect.HPFormula != nil {
+				label := "ドレイン"
+				if !effect.IsHealEffect() {
+					label = "捨て身"
+				}
+				parts = append(parts, fmt.Sprintf("%s(%.1f×%s)", label, effect.HPFormula.StatCoef, effect.HPFormula.StatRef))
+			}
+			if effect.ColumnSpec != nil {
+				parts = append(parts, "フィールド")
+			}
+		} else if effect.IsDamageEffect() {
 			if effect.HPFormula != nil {
-				parts = append(parts, fmt.Sprintf("ダメージ(%.1f×%s)", effect.HPFormula.StatCoef, effect.HPFormula.StatRef))
+				label := "ダメージ"
+				if effect.Target == domain.TargetAll {
+					label = "全体ダメージ"
+				}
+				parts = append(parts, fmt.Sprintf("%s(%.1f×%s)", label, effect.HPFormula.StatCoef, effect.HPFormula.StatRef))
 			}
 		} else if effect.IsHealEffect() {
 			if effect.HPFormula != nil {
@@ -1910,7 +1926,11 @@ func getModuleEffectSummary(module *domain.ModuleModel) string {
 		} else if effect.IsBuffEffect() {
 			parts = append(parts, "バフ")
 		} else if effect.IsDebuffEffect() {
-			parts = append(parts, "デバフ")
+			if effect.Target == domain.TargetAll {
+				parts = append(parts, "全体デバフ")
+			} else {
+				parts = append(parts, "デバフ")
+			}
 		}
 	}
 
@@ -1,19 +1,18 @@
 // Package screens はTUIゲームの画面を提供します。
 // battle.go はバトル画面のModel構造体とInit/Updateメソッドを担当します。
-// UIレンダリングはbattle_view.go、ゲームロジックはbattle_logic.goに分離されています。
+// UIレンダリングはbattle_view.goに分離され、ゲームロジックはcombat.BattleSessionが担当します。
 package screens
 
 import (
-	"fmt"
 	"time"
 
 	"hirorocky/type-battle/internal/config"
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/tui/ascii"
+	"hirorocky/type-battle/internal/tui/components"
 	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/clock"
 	"hirorocky/type-battle/internal/usecase/combat"
-	"hirorocky/type-battle/internal/usecase/combat/chain"
-	"hirorocky/type-battle/internal/usecase/combat/recast"
 	"hirorocky/type-battle/internal/usecase/typing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,6 +24,17 @@ import (
 // config.BattleTickIntervalを参照しています。
 var tickInterval = config.BattleTickInterval
 
+// pauseKey はバトルの一時停止・再開を切り替えるキーです。
+// タイピング中も文字入力と衝突しないようTabを使用します。
+const pauseKey = "tab"
+
+// parryKey は敵のチャージ終盤にパリィを開始するキーです。
+// タイピング中は、チャレンジが空白を受け付ける位置でなければSpaceでパリィに切り替えます。
+const parryKey = " "
+
+// replaySpeeds はリプレイ再生で選択できる再生速度（倍率）です。
+var replaySpeeds = map[string]int{"1": 1, "2": 2, "4": 4}
+
 // ==================== メッセージ型 ====================
 
 // BattleTickMsg はバトル画面の定期更新メッセージです。
@@ -32,88 +42,71 @@ type BattleTickMsg struct{}
 
 // BattleResultMsg はバトル結果メッセージです。
 type BattleResultMsg struct {
-	Victory   bool
-	Level     int
-	Stats     *combat.BattleStatistics // バトル統計
-	EnemyID   string                   // 敵図鑑更新用
-	EnemyType *domain.EnemyType        // 確定ドロップ設定参照用
+	Victory    bool
+	Retreated  bool // 撤退による終了（Victoryはfalse、敗北とは別に記録する）
+	Level      int
+	Stats      *combat.BattleStatistics // バトル統計
+	EnemyID    string                   // 敵図鑑更新用（複数敵バトルでは最後のウェーブのリーダー）
+	EnemyLevel int                      // EnemyIDの敵のレベル（撃破記録用）
+	EnemyType  *domain.EnemyType        // 確定ドロップ設定参照用
+	Replay     *combat.Replay           // リプレイ保存用
+
+	// EncounteredEnemies はバトルに出現した敵の敵タイプIDごとの最高レベルです（敵図鑑・撃破記録用）
+	EncounteredEnemies map[string]int
+
+	// DiscoveredWeaknesses はバトル中に突いた敵の弱点属性です（敵タイプID→属性リスト、敵図鑑更新用）
+	DiscoveredWeaknesses map[string][]domain.Element
+}
+
+// SetPassiveSkills はパッシブスキル定義を設定します。
+// これにより、RegisterPassiveSkills で条件付きパッシブスキルが EffectTable に登録されます。
+func (s *BattleScreen) SetPassiveSkills(skills map[string]domain.PassiveSkill) {
+	s.session.SetPassiveSkills(skills)
 }
 
-// ==================== モジュールスロット ====================
+// SetClock はバトルの時刻取得に使う時計を設定します。
+// バトル開始前（NewBattleScreen直後）に呼び出してください。
+func (s *BattleScreen) SetClock(c clock.Clock) {
+	s.session.SetClock(c)
+}
 
-// ModuleSlot はモジュールスロットを表します。
-type ModuleSlot struct {
-	Module            *domain.ModuleModel
-	Agent             *domain.AgentModel
-	AgentIndex        int
-	ModuleIndex       int
-	CooldownRemaining float64
-	CooldownTotal     float64
+// SetTypingMode はタイピングチャレンジの入力方式を設定します。
+func (s *BattleScreen) SetTypingMode(mode typing.InputMode) {
+	s.session.SetTypingMode(mode)
 }
 
-// IsReady はモジュールが使用可能かを返します。
-func (s *ModuleSlot) IsReady() bool {
-	return s.CooldownRemaining <= 0
+// SetKeyStats は苦手キー統計を設定します。
+func (s *BattleScreen) SetKeyStats(stats *typing.KeyStats) {
+	s.session.SetKeyStats(stats)
 }
 
-// SetPassiveSkills はパッシブスキル定義を設定します。
-// これにより、RegisterPassiveSkills で条件付きパッシブスキルが EffectTable に登録されます。
-func (s *BattleScreen) SetPassiveSkills(skills map[string]domain.PassiveSkill) {
-	if s.battleEngine != nil {
-		s.battleEngine.SetPassiveSkills(skills)
-	}
+// SetAdaptiveWords は苦手キーを含む単語を優先して出題するかを設定します。
+func (s *BattleScreen) SetAdaptiveWords(enabled bool) {
+	s.session.SetAdaptiveWords(enabled)
 }
 
 // ==================== BattleScreen構造体 ====================
 
 // BattleScreen はバトル画面を表します。
+// バトルの進行はcombat.BattleSessionが担当し、画面は描画とキー入力の転送のみを行います。
 
 // UI-Improvement Requirements: 3.1, 3.2, 3.9
 type BattleScreen struct {
-	// 戦闘参加者
+	// バトル進行
+	session *combat.BattleSession
+
+	// 戦闘参加者（表示用、複数敵バトルではenemyは選択中の攻撃対象）
 	enemy          *domain.EnemyModel
 	player         *domain.PlayerModel
 	equippedAgents []*domain.AgentModel
 
-	// モジュールスロット
-	moduleSlots  []ModuleSlot
+	// モジュール選択状態
 	selectedSlot int
 
 	// エージェント選択状態（UI改善: 3エリアレイアウト用）
 	selectedAgentIdx int
 
-	// タイピング状態
-	isTyping             bool
-	typingText           string
-	typingIndex          int
-	typingMistakes       []int
-	typingStartTime      time.Time
-	typingTimeLimit      time.Duration
-	selectedModuleIdx    int
-	autoCorrectRemaining int // AutoCorrectによるミス無視残り回数
-
-	// タイピングシステム
-	challengeGenerator *typing.ChallengeGenerator
-	evaluator          *typing.Evaluator
-	typingState        *typing.ChallengeState
-
-	// バトルエンジン
-	battleEngine *combat.BattleEngine
-	battleState  *combat.BattleState
-
-	// リキャスト・チェイン効果管理
-	recastManager      *recast.RecastManager
-	chainEffectManager *chain.ChainEffectManager
-
-	// パッシブスキル関連
-	comboCount            int  // ミスなし連続タイピング回数
-	typoRecoveryUsed      bool // ps_typo_recovery使用済みフラグ（チャレンジ毎にリセット）
-	secondChanceUsed      bool // ps_second_chance使用済みフラグ（チャレンジ毎にリセット）
-	firstStrikeAgentIndex int  // ps_first_strike発動エージェント（-1は無効）
-
-	// ゲーム終了状態
-	gameOver      bool
-	victory       bool
+	// 結果表示状態
 	showingResult bool
 
 	// UI
@@ -121,12 +114,28 @@ type BattleScreen struct {
 	winLoseRenderer ascii.WinLoseRenderer
 	width           int
 	height          int
-	message         string
 
 	// アニメーション（UI改善: フローティングダメージ、HPバーアニメーション）
 	floatingDamageManager *styles.FloatingDamageManager
 	playerHPBar           *styles.AnimatedHPBar
 	enemyHPBar            *styles.AnimatedHPBar
+	enemyHPBars           map[*domain.EnemyModel]*styles.AnimatedHPBar // 複数敵バトルの敵ごとのHPバー
+
+	// リプレイ再生（nilの場合は通常のバトル）
+	replay       *combat.ReplayPlayer
+	replaySpeed  int
+	replayPaused bool
+
+	// バトルログパネル（logScrollは最新から遡ったイベント数）
+	showLog   bool
+	logScroll int
+	logNotice string
+
+	// 計算内訳（検査オーバーレイ）の表示
+	showInspect bool
+
+	// 撤退の確認ダイアログ
+	retreatDialog *components.ConfirmDialog
 }
 
 // ==================== コンストラクタ ====================
@@ -134,101 +143,58 @@ type BattleScreen struct {
 // NewBattleScreen は新しいBattleScreenを作成します。
 // dictionaryがnilの場合はデフォルト辞書を使用します。
 func NewBattleScreen(enemy *domain.EnemyModel, player *domain.PlayerModel, agents []*domain.AgentModel, dictionary *typing.Dictionary) *BattleScreen {
-	// 辞書がnilの場合はデフォルト辞書を使用
-	if dictionary == nil {
-		dictionary = createDefaultDictionary()
-	}
+	return newBattleScreen(combat.NewBattleSession(enemy, player, agents, dictionary))
+}
 
-	// 敵タイプリストを作成（BattleEngine用）
-	enemyTypes := []domain.EnemyType{enemy.Type}
+// NewEncounterBattleScreen は複数の敵・ウェーブで構成されるバトルのBattleScreenを作成します。
+// dictionaryがnilの場合はデフォルト辞書を使用します。
+func NewEncounterBattleScreen(waves [][]*domain.EnemyModel, player *domain.PlayerModel, agents []*domain.AgentModel, dictionary *typing.Dictionary) *BattleScreen {
+	return newBattleScreen(combat.NewEncounterSession(waves, player, agents, dictionary))
+}
+
+// NewReplayBattleScreen はリプレイを再生するBattleScreenを作成します。
+// 記録された入力をバトルセッションに再投入し、通常のバトルと同じ画面で描画します。
+// パッシブスキル定義はSetPassiveSkillsでInit前に設定してください。
+func NewReplayBattleScreen(replay *combat.Replay, dictionary *typing.Dictionary) *BattleScreen {
+	session := replay.NewSession(dictionary)
+	s := newBattleScreen(session)
+	s.replay = combat.NewReplayPlayer(replay, session)
+	s.replaySpeed = 1
+	return s
+}
 
+// newBattleScreen はバトルセッションを描画するBattleScreenを作成します。
+func newBattleScreen(session *combat.BattleSession) *BattleScreen {
 	gs := styles.NewGameStyles()
-	screen := &BattleScreen{
-		enemy:              enemy,
-		player:             player,
-		equippedAgents:     agents,
-		moduleSlots:        make([]ModuleSlot, 0),
-		selectedSlot:       0,
-		selectedAgentIdx:   0,
-		isTyping:           false,
-		challengeGenerator: typing.NewChallengeGenerator(dictionary),
-		evaluator:          typing.NewEvaluator(),
-		battleEngine:       combat.NewBattleEngine(enemyTypes),
-		// リキャスト・チェイン効果管理を初期化
-		recastManager:      recast.NewRecastManager(),
-		chainEffectManager: chain.NewChainEffectManager(),
-		// パッシブスキル関連
-		firstStrikeAgentIndex: -1, // 無効値で初期化
-		styles:                gs,
-		winLoseRenderer:       ascii.NewWinLoseRenderer(gs),
-		width:                 140,
-		height:                40,
+	enemy := session.Enemy()
+	player := session.Player()
+	enemyHPBar := styles.NewAnimatedHPBar(enemy.MaxHP)
+
+	// 前のバトルからHPを持ち越している場合（エンドレスタワー）は開始時のHPから表示する
+	playerHPBar := styles.NewAnimatedHPBar(player.MaxHP)
+	playerHPBar.SetTarget(player.HP)
+	playerHPBar.ForceComplete()
+
+	// 計算内訳と戦闘ログで表示するため、計算トレースを常に記録する
+	session.SetCalculationTrace(true)
+
+	return &BattleScreen{
+		session:          session,
+		enemy:            enemy,
+		player:           player,
+		equippedAgents:   session.Agents(),
+		selectedSlot:     0,
+		selectedAgentIdx: 0,
+		styles:           gs,
+		winLoseRenderer:  ascii.NewWinLoseRenderer(gs),
+		width:            140,
+		height:           40,
 		// UI改善: アニメーション初期化
 		floatingDamageManager: styles.NewFloatingDamageManager(),
-		playerHPBar:           styles.NewAnimatedHPBar(player.MaxHP),
-		enemyHPBar:            styles.NewAnimatedHPBar(enemy.MaxHP),
-	}
-
-	// バトル状態を初期化
-	screen.battleState = &combat.BattleState{
-		Enemy:          enemy,
-		Player:         player,
-		EquippedAgents: agents,
-		Level:          enemy.Level,
-		Stats: &combat.BattleStatistics{
-			StartTime: time.Now(),
-		},
-	}
-
-	// 最初の行動を準備してチャージ開始
-	screen.battleState.Enemy.PrepareNextAction()
-	screen.battleEngine.StartEnemyCharging(screen.battleState, time.Now())
-
-	// 敵のパッシブスキルを登録
-	screen.battleEngine.RegisterEnemyPassive(screen.battleState)
-
-	// モジュールスロットを初期化
-
-	for agentIdx, agent := range agents {
-		for modIdx, module := range agent.Modules {
-			screen.moduleSlots = append(screen.moduleSlots, ModuleSlot{
-				Module:            module,
-				Agent:             agent,
-				AgentIndex:        agentIdx,
-				ModuleIndex:       modIdx,
-				CooldownRemaining: 0,
-				CooldownTotal:     config.DefaultModuleCooldown,
-			})
-		}
-	}
-
-	return screen
-}
-
-// createDefaultDictionary はデフォルトのタイピング辞書を作成します。
-func createDefaultDictionary() *typing.Dictionary {
-	return &typing.Dictionary{
-		// Easy: 3-6文字の単語
-		Easy: []string{
-			"cat", "dog", "run", "jump", "fire", "ice",
-			"hit", "cut", "heal", "buff", "fast", "slow",
-			"axe", "bow", "sun", "moon", "star", "wind",
-			"red", "blue", "gold", "dark", "life", "mana",
-		},
-		// Medium: 7-11文字の単語
-		Medium: []string{
-			"warrior", "monster", "defense", "attack",
-			"healing", "protect", "thunder", "blizzard",
-			"fireball", "critical", "accuracy", "strength",
-			"powerful", "ultimate", "blessing", "cursed",
-		},
-		// Hard: 12-20文字の単語
-		Hard: []string{
-			"thunderstorm", "annihilation", "resurrection",
-			"extraordinary", "invulnerable", "battleground",
-			"concentration", "determination", "acceleration",
-			"purification", "hallucination", "obliteration",
-		},
+		playerHPBar:           playerHPBar,
+		enemyHPBar:            enemyHPBar,
+		enemyHPBars:           map[*domain.EnemyModel]*styles.AnimatedHPBar{enemy: enemyHPBar},
+		retreatDialog:         newRetreatDialog(),
 	}
 }
 
@@ -239,24 +205,13 @@ func (s *BattleScreen) Init() tea.Cmd {
 	// チャージシステムはInitBattleで初期化済み（PrepareNextAction + StartCharging）
 
 	// ps_first_strike: バトル開始時に最初のスキル即発動を評価
-	s.evaluateFirstStrike()
-
-	return s.tick()
-}
-
-// evaluateFirstStrike はps_first_strikeの発動を評価します。
-func (s *BattleScreen) evaluateFirstStrike() {
-	if s.battleEngine == nil || s.battleState == nil {
-		return
+	if s.replay != nil {
+		s.replay.Start()
+	} else {
+		s.session.Start()
 	}
 
-	for agentIdx, agent := range s.battleState.EquippedAgents {
-		if s.battleEngine.EvaluateFirstStrike(s.battleState, agent) {
-			s.firstStrikeAgentIndex = agentIdx
-			s.message = fmt.Sprintf("[ファーストストライク！ %sが即発動可能！]", agent.Core.Name)
-			return
-		}
-	}
+	return s.tick()
 }
 
 // tick は次のtickコマンドを返します。
@@ -277,6 +232,10 @@ func (s *BattleScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case BattleTickMsg:
 		return s.handleTick()
 
+	case BattleLogExportedMsg:
+		s.handleLogExported(msg)
+		return s, nil
+
 	case tea.KeyMsg:
 		return s.handleKeyMsg(msg)
 	}
@@ -287,124 +246,171 @@ func (s *BattleScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // ==================== メッセージハンドラ ====================
 
 // handleTick は定期更新を処理します。
+// ゲーム進行はBattleSession.Tickに委譲し、アニメーションと結果表示のみを担当します。
 func (s *BattleScreen) handleTick() (tea.Model, tea.Cmd) {
 	// ゲーム終了済みなら何もしない（最優先）
-	if s.gameOver {
+	if s.IsGameOver() {
 		return s, nil
 	}
 
-	// 結果表示中はtickを継続するが、ゲーム進行はしない
-	if s.showingResult {
+	// リプレイ再生中は記録された入力で進める（記録中の一時停止も入力として再生する）
+	if s.replay != nil {
+		return s.handleReplayTick()
+	}
+
+	// 一時停止中はtickを継続するが、全てのタイマーを進めない
+	if s.IsPaused() {
 		return s, s.tick()
 	}
 
-	deltaSeconds := tickInterval.Seconds()
+	// UI改善: アニメーション更新
+	s.updateAnimations()
 
-	// 勝敗判定（結果表示状態に入る）
-	if s.checkGameOver() {
-		s.showingResult = true
-		// HP表示を実際のHPに即座に合わせる
-		if s.enemy.HP <= 0 {
-			s.enemyHPBar.SetTarget(0)
-			s.enemyHPBar.ForceComplete()
-		}
-		if s.player.HP <= 0 {
-			s.playerHPBar.SetTarget(0)
-			s.playerHPBar.ForceComplete()
-		}
+	// ゲーム進行
+	s.session.Tick(tickInterval)
+	s.syncHPDisplay()
+	s.checkResult()
+
+	// 次のtickを返す
+	return s, s.tick()
+}
+
+// handleReplayTick はリプレイ再生中の定期更新を処理します。
+// 再生速度に応じた時間だけ記録を進めます。
+func (s *BattleScreen) handleReplayTick() (tea.Model, tea.Cmd) {
+	if s.replayPaused {
 		return s, s.tick()
 	}
 
-	// クールダウンを更新
-	s.UpdateCooldowns(deltaSeconds)
+	s.updateAnimations()
 
-	// リキャストを更新（チェイン効果の期限切れも処理）
-	s.UpdateRecasts(deltaSeconds)
+	s.replay.Advance(tickInterval * time.Duration(s.replaySpeed))
+	s.syncHPDisplay()
+	s.checkResult()
 
-	// UI改善: アニメーション更新
+	return s, s.tick()
+}
+
+// updateAnimations はフローティングダメージとHPバーのアニメーションを1tick分進めます。
+func (s *BattleScreen) updateAnimations() {
 	deltaMS := int(tickInterval.Milliseconds())
 	s.floatingDamageManager.Update(deltaMS)
 	s.playerHPBar.Update(deltaMS)
-	s.enemyHPBar.Update(deltaMS)
-
-	// タイピング中の時間切れチェック
-	if s.isTyping {
-		elapsed := time.Since(s.typingStartTime)
-		if elapsed >= s.typingTimeLimit {
-			// ps_second_chance: タイムアウト時に再挑戦（1回/チャレンジ）
-			if !s.secondChanceUsed && s.battleEngine != nil && s.battleState != nil {
-				slot := s.moduleSlots[s.selectedModuleIdx]
-				agent := slot.Agent
-				if s.battleEngine.EvaluateSecondChance(s.battleState, agent) {
-					s.secondChanceUsed = true
-					// タイピング状態をリセットして再挑戦
-					s.typingIndex = 0
-					s.typingMistakes = nil
-					s.typingStartTime = time.Now()
-					s.message = "[セカンドチャンス発動！ 再挑戦！]"
-					return s, s.tick()
-				}
-			}
-			s.CancelTyping()
-			s.message = "タイムアップ！"
-		}
+	for _, bar := range s.enemyHPBars {
+		bar.Update(deltaMS)
 	}
+}
 
-	// ディフェンス終了チェック
-	now := time.Now()
-	if s.enemy.WaitMode == domain.WaitModeDefending && !s.enemy.IsDefenseActive(now) {
-		s.enemy.EndDefense()
-		// 次の行動を準備してチャージ開始
-		s.enemy.PrepareNextAction()
-		if action := s.enemy.GetNextAction(); action != nil {
-			s.battleEngine.StartEnemyCharging(s.battleState, now)
+// checkResult は勝敗が決まっていれば結果表示状態に入ります。
+func (s *BattleScreen) checkResult() {
+	if s.session.IsOver() {
+		s.showingResult = true
+		// HP表示を実際のHPに即座に合わせる
+		for _, enemy := range s.session.Enemies() {
+			if enemy.HP <= 0 {
+				bar := s.enemyHPBarFor(enemy)
+				bar.SetTarget(0)
+				bar.ForceComplete()
+			}
+		}
+		if s.player.HP <= 0 {
+			s.playerHPBar.SetTarget(0)
+			s.playerHPBar.ForceComplete()
 		}
-		s.message = fmt.Sprintf("%sのディフェンスが終了した", s.enemy.Name)
 	}
+}
 
-	// 敵攻撃チェック（チャージ完了判定）
-	if s.enemy.IsChargeComplete(now) {
-		s.processEnemyAttack()
-
-		// 攻撃後の敗北判定（結果表示状態に入る）
-		if s.checkGameOver() {
-			s.showingResult = true
-			// HP表示を実際のHPに即座に合わせる
-			if s.enemy.HP <= 0 {
-				s.enemyHPBar.SetTarget(0)
-				s.enemyHPBar.ForceComplete()
-			}
-			if s.player.HP <= 0 {
-				s.playerHPBar.SetTarget(0)
-				s.playerHPBar.ForceComplete()
-			}
-			return s, s.tick()
+// syncHPDisplay はバトル中に発生したHP変化をフローティング表示とHPバーに反映します。
+func (s *BattleScreen) syncHPDisplay() {
+	for _, change := range s.session.TakeHPChanges() {
+		if change.IsHeal {
+			s.floatingDamageManager.AddHeal(change.Amount, string(change.Target))
+		} else if change.IsCritical {
+			s.floatingDamageManager.AddCriticalDamage(change.Amount, string(change.Target))
+		} else {
+			s.floatingDamageManager.AddDamage(change.Amount, string(change.Target))
 		}
 	}
-
-	// バフ・デバフの持続時間とボルテージを更新
-	if s.battleEngine != nil && s.battleState != nil {
-		s.battleEngine.UpdateEffects(s.battleState, deltaSeconds)
+	s.playerHPBar.SetTarget(s.player.HP)
+	for _, enemy := range s.session.Enemies() {
+		s.enemyHPBarFor(enemy).SetTarget(enemy.HP)
 	}
+	s.syncTarget()
+}
 
-	// 次のtickを返す
-	return s, s.tick()
+// syncTarget は表示中の敵をセッションの攻撃対象（撃破・ウェーブ進行で切り替わる）に合わせます。
+func (s *BattleScreen) syncTarget() {
+	s.enemy = s.session.Enemy()
+	s.enemyHPBar = s.enemyHPBarFor(s.enemy)
+}
+
+// enemyHPBarFor は敵のHPバーを返します。ウェーブ進行で出現した敵のHPバーはここで作成します。
+func (s *BattleScreen) enemyHPBarFor(enemy *domain.EnemyModel) *styles.AnimatedHPBar {
+	bar, ok := s.enemyHPBars[enemy]
+	if !ok {
+		bar = styles.NewAnimatedHPBar(enemy.MaxHP)
+		bar.SetTarget(enemy.HP)
+		bar.ForceComplete()
+		s.enemyHPBars[enemy] = bar
+	}
+	return bar
 }
 
 // handleKeyMsg はキーボード入力を処理します。
 func (s *BattleScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// 撤退の確認ダイアログ表示中はダイアログの操作のみ受け付ける
+	if s.IsConfirmingRetreat() {
+		return s.handleRetreatInput(msg)
+	}
+
+	// バトルログの操作はリプレイ再生中・結果表示中・一時停止中も受け付ける
+	if cmd, ok := s.handleLogInput(msg); ok {
+		return s, cmd
+	}
+	if s.handleInspectInput(msg) {
+		return s, nil
+	}
+
+	// リプレイ再生中は再生操作のみ受け付ける
+	if s.replay != nil {
+		return s.handleReplayInput(msg)
+	}
+
 	// 結果表示中はEnterでのみ遷移
 	if s.showingResult {
 		return s.handleResultInput(msg)
 	}
 
-	if s.isTyping {
+	// 一時停止の切り替え（一時停止中は再開以外の入力を受け付けない）
+	if msg.String() == pauseKey {
+		s.TogglePause()
+		return s, nil
+	}
+	if s.IsPaused() {
+		return s, nil
+	}
+
+	if s.session.IsTyping() {
 		return s.handleTypingInput(msg)
 	}
 
 	return s.handleModuleSelection(msg)
 }
 
+// handleReplayInput はリプレイ再生中のキー入力を処理します。
+// 1/2/4で再生速度を切り替え、Tabで再生を一時停止します。
+func (s *BattleScreen) handleReplayInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if speed, ok := replaySpeeds[key]; ok {
+		s.replaySpeed = speed
+		return s, nil
+	}
+	if key == pauseKey {
+		s.replayPaused = !s.replayPaused
+	}
+	return s, nil
+}
+
 // handleResultInput は結果表示中のキー入力を処理します。
 func (s *BattleScreen) handleResultInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
@@ -442,27 +448,24 @@ func (s *BattleScreen) handleModuleSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	case "down", "j":
 		// 現在のエージェント内で次のモジュールに移動
 		s.moveToNextModuleInAgent()
+	case "[":
+		// 前の敵を攻撃対象にする（複数敵バトル）
+		s.session.CycleTarget(-1)
+		s.syncTarget()
+	case "]":
+		// 次の敵を攻撃対象にする（複数敵バトル）
+		s.session.CycleTarget(1)
+		s.syncTarget()
+	case parryKey:
+		// パリィ受付時間内の敵の行動をパリィする
+		s.session.StartParry()
 	case "enter":
-		// モジュール使用可能チェック（クールダウンとリキャスト両方）
-		if len(s.moduleSlots) > 0 && s.isModuleUsable(s.selectedSlot) {
-			// モジュール選択 → タイピングチャレンジ開始
-			s.selectedModuleIdx = s.selectedSlot
-			module := s.moduleSlots[s.selectedSlot].Module
-
-			// モジュールの難易度に応じたタイピングチャレンジを生成
-			difficulty := typing.GetDifficultyForModuleLevel(module.Difficulty())
-			timeLimit := typing.GetDefaultTimeLimit(difficulty)
-			challenge := s.challengeGenerator.Generate(difficulty, timeLimit)
-
-			if challenge != nil {
-				s.StartTypingChallenge(challenge.Text, challenge.TimeLimit)
-			}
-		}
-	case "esc":
-		// バトルを中断してホームに戻る（デバッグ用）
-		return s, func() tea.Msg {
-			return ChangeSceneMsg{Scene: "home"}
-		}
+		// モジュール選択 → タイピングチャレンジ開始
+		// （クールダウン・リキャスト中のモジュールは使用できない）
+		s.session.SelectModule(s.selectedSlot)
+	case retreatKey:
+		// 確認ダイアログを表示してから撤退する（誤操作防止）
+		s.openRetreatDialog()
 	}
 
 	return s, nil
@@ -470,14 +473,21 @@ func (s *BattleScreen) handleModuleSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd
 
 // handleTypingInput はタイピング中のキー処理を行います。
 func (s *BattleScreen) handleTypingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// パリィ受付時間内ならモジュールのタイピングを中断してパリィに切り替える
+	// （空白を含む単語で空白を入力する位置では、パリィではなく空白として入力する）
+	if msg.String() == parryKey && !s.session.AcceptsTypingInput(' ') && s.session.StartParry() {
+		return s, nil
+	}
+
 	switch msg.String() {
 	case "esc":
 		// タイピングをキャンセル
-		s.CancelTyping()
+		s.session.CancelTyping()
 		return s, nil
 	default:
 		if len(msg.Runes) == 1 {
-			s.ProcessTypingInput(msg.Runes[0])
+			s.session.ProcessTypingInput(msg.Runes[0])
+			s.syncHPDisplay()
 		}
 	}
 
@@ -0,0 +1,92 @@
+// Package screens はTUIゲームの画面を提供します。
+// battle_inspect_view.go はバトル画面の計算内訳（検査オーバーレイ）の操作と描画を担当します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/combat"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inspectKey は計算内訳の表示を切り替えるキーです。
+// タイピング中も文字入力と衝突しないようCtrl+Tを使用します。
+const inspectKey = "ctrl+t"
+
+// handleInspectInput は計算内訳の表示切り替えキーを処理します。
+// 計算内訳のキーでなければfalseを返します。
+func (s *BattleScreen) handleInspectInput(msg tea.KeyMsg) bool {
+	if msg.String() != inspectKey {
+		return false
+	}
+	s.showInspect = !s.showInspect
+	return true
+}
+
+// latestTracedEvents は計算トレース付きの直近のモジュール使用と敵の攻撃のイベントを返します。
+func latestTracedEvents(events []combat.BattleEvent) (module, enemy *combat.BattleEvent) {
+	for i := len(events) - 1; i >= 0 && (module == nil || enemy == nil); i-- {
+		event := &events[i]
+		if event.Trace == nil {
+			continue
+		}
+		switch event.Kind {
+		case combat.BattleEventDamageDealt, combat.BattleEventHeal:
+			if module == nil {
+				module = event
+			}
+		case combat.BattleEventDamageTaken:
+			if enemy == nil {
+				enemy = event
+			}
+		}
+	}
+	return module, enemy
+}
+
+// renderInspectOverlay は直近のモジュール使用と敵の攻撃の計算内訳を描画します。
+func (s *BattleScreen) renderInspectOverlay() string {
+	module, enemy := latestTracedEvents(s.session.BattleLog().Events())
+
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("計算の内訳")}
+	lines = append(lines, renderTracedEvent("モジュール", module)...)
+	lines = append(lines, renderTracedEvent("敵の攻撃", enemy)...)
+
+	width := s.width - 4
+	if width < 40 {
+		width = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorInfo).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderTracedEvent は1つのイベントの計算トレースを段階ごとの行に変換します。
+func renderTracedEvent(heading string, event *combat.BattleEvent) []string {
+	headingStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary).Bold(true)
+	subtle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	if event == nil {
+		return []string{headingStyle.Render("▶ " + heading), subtle.Render("  まだ記録がありません")}
+	}
+
+	lines := []string{headingStyle.Render("▶ " + heading + ": " + formatBattleEvent(*event))}
+	for _, step := range event.Trace.Steps {
+		lines = append(lines, fmt.Sprintf("  %s: %s = %s", step.Label, step.Formula, step.FormatValue()))
+	}
+	lines = append(lines, fmt.Sprintf("  結果: %d", event.Amount))
+	if len(event.Trace.Effects) > 0 {
+		lines = append(lines, subtle.Render("  寄与した効果:"))
+		for _, effect := range event.Trace.Effects {
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorBuff).Render(
+				fmt.Sprintf("    [%s] %s", effect.Owner, effect.Contribution.Describe())))
+		}
+	}
+	return lines
+}
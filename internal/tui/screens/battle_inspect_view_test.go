@@ -0,0 +1,40 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestBattleScreenInspectOverlay はCtrl+Tで計算内訳を開閉し、直近の敵の攻撃の計算過程が表示されることをテストします。
+func TestBattleScreenInspectOverlay(t *testing.T) {
+	screen, c := newPausableTestBattle()
+	screen.width = 120
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	rendered := screen.View()
+	if !strings.Contains(rendered, "計算の内訳") || !strings.Contains(rendered, "まだ記録がありません") {
+		t.Errorf("記録がない場合もその旨が表示されるべき:\n%s", rendered)
+	}
+
+	c.Advance(2 * time.Second)
+	_, _ = screen.Update(BattleTickMsg{})
+	rendered = screen.View()
+	if !strings.Contains(rendered, "▶ 敵の攻撃:") || !strings.Contains(rendered, "ボルテージ: ×") || !strings.Contains(rendered, "結果:") {
+		t.Errorf("敵の攻撃の計算過程が表示されていません:\n%s", rendered)
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if strings.Contains(screen.View(), "計算の内訳") {
+		t.Error("Ctrl+Tで計算内訳が閉じるべき")
+	}
+
+	// 戦闘ログにも計算過程の要約が表示される
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if !strings.Contains(screen.View(), "└ 攻撃力") {
+		t.Errorf("戦闘ログに計算過程の要約が表示されていません:\n%s", screen.View())
+	}
+}
@@ -0,0 +1,210 @@
+// Package screens はTUIゲームの画面を提供します。
+// battle_log_view.go はバトル画面のバトルログ（戦闘ログ）パネルの操作と描画を担当します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/combat"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logPanelKey はバトルログパネルの表示を切り替えるキーです。
+// タイピング中も文字入力と衝突しないようCtrl+Lを使用します。
+const logPanelKey = "ctrl+l"
+
+// logExportKey はバトルログをJSON Lines形式で書き出すキーです。
+const logExportKey = "ctrl+e"
+
+// battleLogPanelLines はバトルログパネルに一度に表示するイベント数です。
+const battleLogPanelLines = 8
+
+// BattleLogExportMsg はバトルログの書き出しを要求するメッセージです。
+type BattleLogExportMsg struct {
+	// Events は書き出すイベント（バトル開始からの全イベント、古い順）です。
+	Events []combat.BattleEvent
+}
+
+// BattleLogExportedMsg はバトルログの書き出し結果を通知するメッセージです。
+type BattleLogExportedMsg struct {
+	// Path は書き出し先のファイルパスです。
+	Path string
+
+	// Err は書き出しに失敗した場合のエラーです。
+	Err error
+}
+
+// handleLogInput はバトルログパネルの操作キーを処理します。
+// バトルログのキーでなければfalseを返します。
+func (s *BattleScreen) handleLogInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case logPanelKey:
+		s.showLog = !s.showLog
+		s.logScroll = 0
+		return nil, true
+
+	case logExportKey:
+		// パネル用のリングバッファではなく、破棄されたものを含む全イベントを書き出す
+		events := s.session.BattleLog().AllEvents()
+		return func() tea.Msg {
+			return BattleLogExportMsg{Events: events}
+		}, true
+
+	case "pgup":
+		if !s.showLog {
+			return nil, false
+		}
+		s.logScroll += battleLogPanelLines
+		if limit := s.maxLogScroll(); s.logScroll > limit {
+			s.logScroll = limit
+		}
+		return nil, true
+
+	case "pgdown":
+		if !s.showLog {
+			return nil, false
+		}
+		s.logScroll -= battleLogPanelLines
+		if s.logScroll < 0 {
+			s.logScroll = 0
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// maxLogScroll はバトルログパネルを最も古いイベントまで遡ったときのスクロール量を返します。
+func (s *BattleScreen) maxLogScroll() int {
+	limit := s.session.BattleLog().Len() - battleLogPanelLines
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// handleLogExported はバトルログの書き出し結果をパネルに表示します。
+func (s *BattleScreen) handleLogExported(msg BattleLogExportedMsg) {
+	if msg.Err != nil {
+		s.logNotice = fmt.Sprintf("ログの書き出しに失敗しました: %v", msg.Err)
+		return
+	}
+	s.logNotice = "ログを書き出しました: " + msg.Path
+}
+
+// renderBattleLogPanel はバトルログパネルを描画します。
+// 最新のイベントを下端に表示し、PgUp/PgDnで過去のイベントへスクロールします。
+func (s *BattleScreen) renderBattleLogPanel() string {
+	events := s.session.BattleLog().Events()
+	end := len(events) - s.logScroll
+	if end < 0 {
+		end = 0
+	}
+	start := end - battleLogPanelLines
+	if start < 0 {
+		start = 0
+	}
+
+	lines := make([]string, 0, battleLogPanelLines+1)
+	title := fmt.Sprintf("戦闘ログ (%d/%d)", end, len(events))
+	if s.logScroll > 0 {
+		title += fmt.Sprintf("  ↓ 新しいログ %d件", s.logScroll)
+	}
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render(title))
+	if len(events) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("まだ何も起きていません"))
+	}
+	for _, event := range events[start:end] {
+		lines = append(lines, lipgloss.NewStyle().Foreground(battleEventColor(event.Kind)).Render(formatBattleEvent(event)))
+		if summary := event.Trace.Summary(); summary != "" {
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("          └ "+summary))
+		}
+	}
+	if s.logNotice != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorInfo).Render(s.logNotice))
+	}
+
+	width := s.width - 4
+	if width < 40 {
+		width = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorSubtle).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// formatBattleEvent はバトルイベントを1行の表示文字列に変換します。
+func formatBattleEvent(event combat.BattleEvent) string {
+	var text string
+	switch event.Kind {
+	case combat.BattleEventDamageDealt, combat.BattleEventDamageTaken:
+		text = withEventDetail(fmt.Sprintf("%s → %s %dダメージ", event.Source, event.Target, event.Amount), event.Detail)
+	case combat.BattleEventHeal:
+		text = withEventDetail(fmt.Sprintf("%s → %s %d回復", event.Source, event.Target, event.Amount), event.Detail)
+	case combat.BattleEventEvade:
+		text = fmt.Sprintf("%sが%sの攻撃を回避", event.Target, event.Source)
+	case combat.BattleEventBuffAdded:
+		text = fmt.Sprintf("%sに%sを付与", event.Target, event.Detail)
+		if event.Source != "" {
+			text = event.Source + ": " + text
+		}
+	case combat.BattleEventBuffExpired:
+		if event.Source != "" {
+			text = fmt.Sprintf("%sにより%sの%sが解除された", event.Source, event.Target, event.Detail)
+		} else {
+			text = fmt.Sprintf("%sの%sが切れた", event.Target, event.Detail)
+		}
+	case combat.BattleEventPassive:
+		text = withEventDetail("パッシブ発動: "+event.Source, event.Detail)
+	case combat.BattleEventChain:
+		text = "チェイン発動: " + event.Source
+		if event.Amount > 0 {
+			text += fmt.Sprintf(" → %s %d", event.Target, event.Amount)
+		}
+		text = withEventDetail(text, event.Detail)
+	case combat.BattleEventPhaseChange:
+		text = fmt.Sprintf("%sが%sフェーズに突入", event.Source, event.Detail)
+	case combat.BattleEventParry:
+		text = fmt.Sprintf("%sの攻撃に%s", event.Target, event.Detail)
+	case combat.BattleEventVoltageDown:
+		text = fmt.Sprintf("%s: %sのボルテージ-%d%% → %s", event.Source, event.Target, event.Amount, event.Detail)
+	case combat.BattleEventEnrage:
+		text = fmt.Sprintf("%sが%s", event.Source, event.Detail)
+	default:
+		text = withEventDetail(string(event.Kind), event.Detail)
+	}
+	return fmt.Sprintf("[%6.1fs] %s", event.At.Seconds(), text)
+}
+
+// withEventDetail は補足情報があれば括弧書きで添えます。
+func withEventDetail(text, detail string) string {
+	if detail == "" {
+		return text
+	}
+	return fmt.Sprintf("%s (%s)", text, detail)
+}
+
+// battleEventColor はイベント種別ごとの表示色を返します。
+func battleEventColor(kind combat.BattleEventKind) lipgloss.Color {
+	switch kind {
+	case combat.BattleEventDamageTaken:
+		return styles.ColorDamage
+	case combat.BattleEventHeal:
+		return styles.ColorHeal
+	case combat.BattleEventBuffAdded, combat.BattleEventChain, combat.BattleEventPassive:
+		return styles.ColorBuff
+	case combat.BattleEventPhaseChange, combat.BattleEventParry, combat.BattleEventEnrage:
+		return styles.ColorWarning
+	case combat.BattleEventVoltageDown:
+		return styles.ColorBuff
+	case combat.BattleEventBuffExpired, combat.BattleEventEvade:
+		return styles.ColorSubtle
+	}
+	return styles.ColorSecondary
+}
@@ -0,0 +1,140 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/usecase/combat"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestFormatBattleEvent はバトルイベントの1行表示をテストします。
+func TestFormatBattleEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event combat.BattleEvent
+		want  string
+	}{
+		{
+			"クリティカルの与ダメージ",
+			combat.BattleEvent{At: 1500 * time.Millisecond, Kind: combat.BattleEventDamageDealt, Source: "ファイアボール", Target: "スライム", Amount: 30, Detail: "クリティカル"},
+			"[   1.5s] ファイアボール → スライム 30ダメージ (クリティカル)",
+		},
+		{
+			"回避",
+			combat.BattleEvent{At: 2 * time.Second, Kind: combat.BattleEventEvade, Source: "スライム", Target: combat.BattleEventPlayer},
+			"[   2.0s] プレイヤーがスライムの攻撃を回避",
+		},
+		{
+			"効果切れ",
+			combat.BattleEvent{At: 3 * time.Second, Kind: combat.BattleEventBuffExpired, Target: combat.BattleEventPlayer, Detail: "攻撃UP"},
+			"[   3.0s] プレイヤーの攻撃UPが切れた",
+		},
+		{
+			"バフ解除",
+			combat.BattleEvent{At: 3 * time.Second, Kind: combat.BattleEventBuffExpired, Source: "スケルトン", Target: combat.BattleEventPlayer, Detail: "バフ2個"},
+			"[   3.0s] スケルトンによりプレイヤーのバフ2個が解除された",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatBattleEvent(tt.event); got != tt.want {
+				t.Errorf("表示: 期待 %q, 実際 %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestBattleScreenBattleLogPanel はCtrl+Lで戦闘ログパネルを開閉し、発生したイベントが表示されることをテストします。
+func TestBattleScreenBattleLogPanel(t *testing.T) {
+	screen, c := newPausableTestBattle()
+	screen.width = 120
+	c.Advance(2 * time.Second)
+	_, _ = screen.Update(BattleTickMsg{})
+
+	if strings.Contains(screen.View(), "戦闘ログ (") {
+		t.Fatal("戦闘ログパネルは初期状態では閉じているべき")
+	}
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	rendered := screen.View()
+	if !strings.Contains(rendered, "戦闘ログ (") || !strings.Contains(rendered, "→ プレイヤー") {
+		t.Errorf("戦闘ログパネルに敵の攻撃が表示されていません:\n%s", rendered)
+	}
+
+	// イベントが少ない場合はスクロールしない
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if screen.logScroll != 0 {
+		t.Errorf("スクロール量: 期待 0, 実際 %d", screen.logScroll)
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if strings.Contains(screen.View(), "戦闘ログ (") {
+		t.Error("Ctrl+Lで戦闘ログパネルが閉じるべき")
+	}
+}
+
+// TestBattleScreenBattleLogScroll はPgUp/PgDnで過去のイベントへスクロールできることをテストします。
+func TestBattleScreenBattleLogScroll(t *testing.T) {
+	screen, _ := newPausableTestBattle()
+	log := screen.session.BattleLog()
+	for i := 0; i < battleLogPanelLines*2+3; i++ {
+		log.Add(combat.BattleEvent{Kind: combat.BattleEventHeal, Source: "リジェネ", Target: combat.BattleEventPlayer, Amount: i})
+	}
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+
+	for i := 0; i < 5; i++ {
+		_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	}
+	if want := log.Len() - battleLogPanelLines; screen.logScroll != want {
+		t.Errorf("最も古いイベントまでのスクロール量: 期待 %d, 実際 %d", want, screen.logScroll)
+	}
+	if !strings.Contains(screen.renderBattleLogPanel(), "0回復") {
+		t.Error("スクロール後は最も古いイベントが表示されるべき")
+	}
+
+	for i := 0; i < 5; i++ {
+		_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	}
+	if screen.logScroll != 0 {
+		t.Errorf("最新までのスクロール量: 期待 0, 実際 %d", screen.logScroll)
+	}
+}
+
+// TestBattleScreenBattleLogExport はCtrl+Eでバトルログの書き出しを要求し、結果が表示されることをテストします。
+// タイピング中でもログの操作キーは入力として扱われません。
+func TestBattleScreenBattleLogExport(t *testing.T) {
+	screen, _ := newPausableTestBattle()
+	screen.session.BattleLog().Add(combat.BattleEvent{Kind: combat.BattleEventEvade, Source: "スライム"})
+	screen.session.StartTypingChallenge(0, "test", 5*time.Second)
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if cmd == nil {
+		t.Fatal("Ctrl+Eで書き出しコマンドが返されるべき")
+	}
+	exportMsg, ok := cmd().(BattleLogExportMsg)
+	if !ok || len(exportMsg.Events) != 1 {
+		t.Fatalf("書き出し要求メッセージ: 実際 %+v", exportMsg)
+	}
+
+	// パネルの保持件数を超えたイベントも全て書き出す
+	for i := 0; i < config.BattleLogCapacity; i++ {
+		screen.session.BattleLog().Add(combat.BattleEvent{Kind: combat.BattleEventHeal, Amount: i})
+	}
+	_, cmd = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if exportMsg := cmd().(BattleLogExportMsg); len(exportMsg.Events) != config.BattleLogCapacity+1 || exportMsg.Events[0].Kind != combat.BattleEventEvade {
+		t.Errorf("書き出すイベント数: 期待 %d, 実際 %d", config.BattleLogCapacity+1, len(exportMsg.Events))
+	}
+	if screen.session.TypingIndex() != 0 || !screen.session.IsTyping() {
+		t.Error("ログの操作キーがタイピング入力として処理されました")
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	_, _ = screen.Update(BattleLogExportedMsg{Path: "/tmp/battle.jsonl"})
+	if !strings.Contains(screen.View(), "/tmp/battle.jsonl") {
+		t.Error("書き出し先が戦闘ログパネルに表示されるべき")
+	}
+}
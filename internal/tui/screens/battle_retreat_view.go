@@ -0,0 +1,46 @@
+// Package screens はTUIゲームの画面を提供します。
+// battle_retreat_view.go はバトル画面の撤退（確認ダイアログ）の操作と描画を担当します。
+package screens
+
+import (
+	"hirorocky/type-battle/internal/tui/components"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// retreatKey はモジュール選択中に撤退の確認ダイアログを開くキーです。
+// タイピング中のEscはタイピングのキャンセルに使うため、撤退はモジュール選択中のみ受け付けます。
+const retreatKey = "esc"
+
+// newRetreatDialog は撤退の確認ダイアログを作成します。
+func newRetreatDialog() *components.ConfirmDialog {
+	return components.NewConfirmDialog(
+		"撤退確認",
+		"バトルから撤退しますか？（報酬は得られません）",
+	)
+}
+
+// openRetreatDialog はバトルを一時停止して撤退の確認ダイアログを表示します。
+func (s *BattleScreen) openRetreatDialog() {
+	s.session.Pause()
+	s.retreatDialog.Show()
+}
+
+// IsConfirmingRetreat は撤退の確認ダイアログを表示中かを返します。
+func (s *BattleScreen) IsConfirmingRetreat() bool {
+	return s.retreatDialog.Visible
+}
+
+// handleRetreatInput は撤退の確認ダイアログ表示中のキー入力を処理します。
+// 「はい」で撤退してバトル結果を返し、「いいえ」・キャンセルではバトルを再開します。
+func (s *BattleScreen) handleRetreatInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch s.retreatDialog.HandleKey(msg.String()) {
+	case components.ConfirmResultYes:
+		if s.session.Retreat() {
+			return s, s.createGameOverCmd()
+		}
+	case components.ConfirmResultNo, components.ConfirmResultCancelled:
+		s.session.Resume()
+	}
+	return s, nil
+}
@@ -0,0 +1,70 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestBattleScreenRetreatCancel は撤退の確認ダイアログを「いいえ」で閉じるとバトルが再開されることをテストします。
+func TestBattleScreenRetreatCancel(t *testing.T) {
+	screen, _ := newPausableTestBattle()
+	screen.width = 120
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !screen.IsConfirmingRetreat() || !screen.IsPaused() {
+		t.Fatal("Escで撤退の確認ダイアログが開き、バトルが一時停止するべき")
+	}
+	if !strings.Contains(screen.View(), "撤退確認") {
+		t.Error("撤退の確認ダイアログが表示されていません")
+	}
+
+	// 確認ダイアログ表示中はモジュールを使用できない
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || screen.session.IsTyping() || screen.IsGameOver() {
+		t.Error("初期選択の「いいえ」で撤退やモジュール使用が行われるべきではない")
+	}
+	if screen.IsConfirmingRetreat() || screen.IsPaused() {
+		t.Error("「いいえ」で確認ダイアログが閉じ、バトルが再開されるべき")
+	}
+}
+
+// TestBattleScreenRetreatConfirm は撤退を確定すると撤退のバトル結果メッセージが返されることをテストします。
+func TestBattleScreenRetreatConfirm(t *testing.T) {
+	screen, c := newPausableTestBattle()
+
+	// 撤退前のタイピング統計が結果に含まれる
+	screen.session.StartTypingChallenge(0, "ab", 5*time.Second)
+	for _, r := range "ab" {
+		_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("撤退を確定したらバトル結果のコマンドを返すべき")
+	}
+	result, ok := cmd().(BattleResultMsg)
+	if !ok {
+		t.Fatalf("バトル結果メッセージではありません: %T", cmd())
+	}
+	if !result.Retreated || result.Victory {
+		t.Errorf("撤退の結果: 撤退 %v, 勝利 %v", result.Retreated, result.Victory)
+	}
+	if result.Stats == nil || result.Stats.TotalTypingCount != 1 {
+		t.Errorf("撤退前のタイピング統計が含まれていません: %+v", result.Stats)
+	}
+
+	// 撤退後は時間が経過しても敵は攻撃しない
+	hp := screen.player.HP
+	c.Advance(5 * time.Second)
+	_, _ = screen.Update(BattleTickMsg{})
+	if screen.player.HP != hp {
+		t.Error("撤退後に敵が攻撃しました")
+	}
+}
@@ -30,6 +30,7 @@ const (
 type StartBattleMsg struct {
 	Level       int
 	EnemyTypeID string // カルーセル方式で選択した敵タイプID（空の場合はランダム）
+	EncounterID string // カルーセル方式で選択したエンカウントID（空の場合は1対1バトル）
 }
 
 // BattleSelectScreen はバトル選択画面を表します。
@@ -337,11 +338,19 @@ type EnemyTypeProvider interface {
 	GetEnemyTypes() []domain.EnemyType
 }
 
+// EncounterProvider はエンカウント（複数敵バトル）のリストを提供するインターフェースです。
+// EnemyTypeProviderが実装している場合、解放済みのエンカウントがカルーセルに追加されます。
+type EncounterProvider interface {
+	GetEncounters() []domain.Encounter
+}
+
 // BattleSelectScreenCarousel はカルーセル方式のバトル選択画面を表します。
+// 敵タイプの後に、登場する敵を全て撃破済みのエンカウントが並びます。
 type BattleSelectScreenCarousel struct {
 	agentProvider    AgentProvider
 	defeatedProvider DefeatedEnemyProvider
 	enemyTypes       []domain.EnemyType
+	encounters       []domain.Encounter
 
 	// 敵種類選択用
 	selectedTypeIdx int
@@ -351,6 +360,9 @@ type BattleSelectScreenCarousel struct {
 	minSelectableLevel int // 敵タイプのデフォルトレベル
 	maxSelectableLevel int // 撃破済み最高レベル+1（未撃破ならデフォルトレベル）
 
+	// matchup は相性分析の結果のキャッシュです（敵タイプ・レベル・装備エージェントが変わった時のみ再計算）
+	matchup *matchupCache
+
 	error  string
 	styles *styles.GameStyles
 	width  int
@@ -401,10 +413,21 @@ func NewBattleSelectScreenCarousel(
 		filteredEnemyTypes = append(filteredEnemyTypes, *nextUndefeated)
 	}
 
+	// 3. エンカウント: 登場する敵を全て撃破済みのものを追加
+	var unlockedEncounters []domain.Encounter
+	if encounterProvider, ok := enemyTypeProvider.(EncounterProvider); ok {
+		for _, encounter := range encounterProvider.GetEncounters() {
+			if isEncounterUnlocked(encounter, defeatedProvider) {
+				unlockedEncounters = append(unlockedEncounters, encounter)
+			}
+		}
+	}
+
 	s := &BattleSelectScreenCarousel{
 		agentProvider:    agentProvider,
 		defeatedProvider: defeatedProvider,
 		enemyTypes:       filteredEnemyTypes,
+		encounters:       unlockedEncounters,
 		selectedTypeIdx:  0,
 		styles:           styles.NewGameStyles(),
 		width:            140,
@@ -412,15 +435,47 @@ func NewBattleSelectScreenCarousel(
 	}
 
 	// 初期選択敵タイプのレベル範囲を設定
-	if len(filteredEnemyTypes) > 0 {
+	if s.entryCount() > 0 {
 		s.updateLevelRange()
 	}
 
 	return s
 }
 
+// isEncounterUnlocked はエンカウントに登場する敵を全て撃破済みかを返します。
+func isEncounterUnlocked(encounter domain.Encounter, defeatedProvider DefeatedEnemyProvider) bool {
+	for _, id := range encounter.MemberTypeIDs() {
+		if !defeatedProvider.IsEnemyDefeated(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// entryCount はカルーセルの項目数（敵タイプ＋エンカウント）を返します。
+func (s *BattleSelectScreenCarousel) entryCount() int {
+	return len(s.enemyTypes) + len(s.encounters)
+}
+
+// selectedEncounter は選択中のエンカウントを返します（敵タイプ選択中はnil）。
+func (s *BattleSelectScreenCarousel) selectedEncounter() *domain.Encounter {
+	idx := s.selectedTypeIdx - len(s.enemyTypes)
+	if idx < 0 || idx >= len(s.encounters) {
+		return nil
+	}
+	return &s.encounters[idx]
+}
+
 // updateLevelRange は現在選択中の敵タイプに応じてレベル範囲を更新します。
 func (s *BattleSelectScreenCarousel) updateLevelRange() {
+	// エンカウントはレベル固定
+	if encounter := s.selectedEncounter(); encounter != nil {
+		s.minSelectableLevel = encounter.Level()
+		s.maxSelectableLevel = encounter.Level()
+		s.selectedLevel = encounter.Level()
+		return
+	}
+
 	if len(s.enemyTypes) == 0 {
 		return
 	}
@@ -483,10 +538,10 @@ func (s *BattleSelectScreenCarousel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, te
 
 	case tea.KeyLeft:
 		// 左キーで前の敵タイプへ（ループ）
-		if len(s.enemyTypes) > 0 {
+		if s.entryCount() > 0 {
 			s.selectedTypeIdx--
 			if s.selectedTypeIdx < 0 {
-				s.selectedTypeIdx = len(s.enemyTypes) - 1
+				s.selectedTypeIdx = s.entryCount() - 1
 			}
 			s.updateLevelRange()
 		}
@@ -494,9 +549,9 @@ func (s *BattleSelectScreenCarousel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, te
 
 	case tea.KeyRight:
 		// 右キーで次の敵タイプへ（ループ）
-		if len(s.enemyTypes) > 0 {
+		if s.entryCount() > 0 {
 			s.selectedTypeIdx++
-			if s.selectedTypeIdx >= len(s.enemyTypes) {
+			if s.selectedTypeIdx >= s.entryCount() {
 				s.selectedTypeIdx = 0
 			}
 			s.updateLevelRange()
@@ -525,11 +580,20 @@ func (s *BattleSelectScreenCarousel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, te
 			return s, nil
 		}
 
-		if len(s.enemyTypes) == 0 {
+		if s.entryCount() == 0 {
 			s.error = "敵タイプが読み込まれていません。"
 			return s, nil
 		}
 
+		if encounter := s.selectedEncounter(); encounter != nil {
+			return s, func() tea.Msg {
+				return StartBattleMsg{
+					Level:       encounter.Level(),
+					EncounterID: encounter.ID,
+				}
+			}
+		}
+
 		selectedEnemy := s.enemyTypes[s.selectedTypeIdx]
 		return s, func() tea.Msg {
 			return StartBattleMsg{
@@ -537,6 +601,14 @@ func (s *BattleSelectScreenCarousel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, te
 				EnemyTypeID: selectedEnemy.ID,
 			}
 		}
+
+	case tea.KeyRunes:
+		// Tキーでエンドレスタワーへ
+		if msg.String() == "t" {
+			return s, func() tea.Msg {
+				return ChangeSceneMsg{Scene: "tower"}
+			}
+		}
 	}
 
 	return s, nil
@@ -556,7 +628,7 @@ func (s *BattleSelectScreenCarousel) View() string {
 	builder.WriteString(titleStyle.Render("バトル選択"))
 	builder.WriteString("\n\n")
 
-	if len(s.enemyTypes) == 0 {
+	if s.entryCount() == 0 {
 		builder.WriteString("敵タイプが読み込まれていません")
 		return builder.String()
 	}
@@ -565,11 +637,20 @@ func (s *BattleSelectScreenCarousel) View() string {
 	s.renderEnemyCarousel(&builder)
 
 	// 敵情報パネル
-	s.renderEnemyInfoPanel(&builder)
+	if encounter := s.selectedEncounter(); encounter != nil {
+		s.renderEncounterInfoPanel(&builder, encounter)
+	} else {
+		s.renderEnemyInfoPanel(&builder)
+	}
 
 	// レベル選択
 	s.renderLevelSelector(&builder)
 
+	// 相性分析（選択レベルの敵で見積もるため、レベル選択の後に表示）
+	if s.selectedEncounter() == nil {
+		s.renderMatchupPanel(&builder)
+	}
+
 	// エラーメッセージ
 	if s.error != "" {
 		errorStyle := lipgloss.NewStyle().
@@ -586,15 +667,20 @@ func (s *BattleSelectScreenCarousel) View() string {
 		Align(lipgloss.Center).
 		Width(s.width)
 
-	builder.WriteString(hintStyle.Render("←→: 敵選択  ↑↓: レベル選択  Enter: バトル開始  Esc: 戻る"))
+	builder.WriteString(hintStyle.Render("←→: 敵選択  ↑↓: レベル選択  Enter: バトル開始  T: エンドレスタワー  Esc: 戻る"))
 
 	return builder.String()
 }
 
 // renderEnemyCarousel は敵選択カルーセルをレンダリングします。
 func (s *BattleSelectScreenCarousel) renderEnemyCarousel(builder *strings.Builder) {
-	// カルーセル表示：< [敵名] >
-	selectedEnemy := s.enemyTypes[s.selectedTypeIdx]
+	// カルーセル表示：< [敵名] >（エンカウントは名前の前に★）
+	var name string
+	if encounter := s.selectedEncounter(); encounter != nil {
+		name = "★" + encounter.Name
+	} else {
+		name = s.enemyTypes[s.selectedTypeIdx].Name
+	}
 
 	carouselStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -602,7 +688,7 @@ func (s *BattleSelectScreenCarousel) renderEnemyCarousel(builder *strings.Builde
 		Align(lipgloss.Center).
 		Width(s.width)
 
-	carousel := fmt.Sprintf("◀  %s  ▶", selectedEnemy.Name)
+	carousel := fmt.Sprintf("◀  %s  ▶", name)
 	builder.WriteString(carouselStyle.Render(carousel))
 	builder.WriteString("\n")
 
@@ -612,7 +698,7 @@ func (s *BattleSelectScreenCarousel) renderEnemyCarousel(builder *strings.Builde
 		Align(lipgloss.Center).
 		Width(s.width)
 
-	indexInfo := fmt.Sprintf("(%d / %d)", s.selectedTypeIdx+1, len(s.enemyTypes))
+	indexInfo := fmt.Sprintf("(%d / %d)", s.selectedTypeIdx+1, s.entryCount())
 	builder.WriteString(indexStyle.Render(indexInfo))
 	builder.WriteString("\n\n")
 }
@@ -627,12 +713,15 @@ func (s *BattleSelectScreenCarousel) renderEnemyInfoPanel(builder *strings.Build
 	infoPanel.AddItem("基礎HP", fmt.Sprintf("%d", selectedEnemy.BaseHP))
 	infoPanel.AddItem("デフォルトLv", fmt.Sprintf("%d", selectedEnemy.DefaultLevel))
 
-	// パッシブスキル情報（descriptionを表示）
-	if selectedEnemy.NormalPassive != nil {
-		infoPanel.AddItem("通常パッシブ", "★"+selectedEnemy.NormalPassive.Description)
+	// フェーズごとのパッシブスキル情報（descriptionを表示）
+	phases := selectedEnemy.GetPhases()
+	if len(phases) > 2 {
+		infoPanel.AddItem("フェーズ", fmt.Sprintf("%d段階", len(phases)))
 	}
-	if selectedEnemy.EnhancedPassive != nil {
-		infoPanel.AddItem("強化パッシブ", "★"+selectedEnemy.EnhancedPassive.Description)
+	for _, phase := range phases {
+		if phase.Passive != nil {
+			infoPanel.AddItem(phase.Name+"パッシブ", "★"+phase.Passive.Description)
+		}
 	}
 
 	// 撃破状態
@@ -652,6 +741,37 @@ func (s *BattleSelectScreenCarousel) renderEnemyInfoPanel(builder *strings.Build
 	builder.WriteString("\n\n")
 }
 
+// renderEncounterInfoPanel はエンカウント情報パネル（ウェーブごとの敵）をレンダリングします。
+func (s *BattleSelectScreenCarousel) renderEncounterInfoPanel(builder *strings.Builder, encounter *domain.Encounter) {
+	names := make(map[string]string, len(s.enemyTypes))
+	for _, et := range s.enemyTypes {
+		names[et.ID] = et.Name
+	}
+
+	infoPanel := components.NewInfoPanel("エンカウント情報")
+	infoPanel.AddItem("名前", encounter.Name)
+	infoPanel.AddItem("敵の数", fmt.Sprintf("%d体 / %dウェーブ", encounter.EnemyCount(), len(encounter.Waves)))
+	for i, wave := range encounter.Waves {
+		members := make([]string, 0, len(wave.Members))
+		for _, member := range wave.Members {
+			name, ok := names[member.EnemyTypeID]
+			if !ok {
+				name = member.EnemyTypeID
+			}
+			members = append(members, fmt.Sprintf("%s Lv.%d", name, member.Level))
+		}
+		infoPanel.AddItem(fmt.Sprintf("ウェーブ%d", i+1), strings.Join(members, ", "))
+	}
+
+	infoPanelRendered := infoPanel.Render(60)
+	centeredInfo := lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(infoPanelRendered)
+	builder.WriteString(centeredInfo)
+	builder.WriteString("\n\n")
+}
+
 // formatAttackType は攻撃タイプを日本語に変換します。
 func (s *BattleSelectScreenCarousel) formatAttackType(attackType string) string {
 	switch attackType {
@@ -0,0 +1,109 @@
+// Package screens はTUIゲームの画面を提供します。
+// battle_select_matchup_view.go はバトル選択画面の相性分析パネルの描画を担当します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/tui/components"
+	"hirorocky/type-battle/internal/usecase/matchup"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderMatchupPanel は装備エージェントと選択中の敵（選択レベル）の相性分析パネルをレンダリングします。
+// エージェントごとの推定DPS、敵のパッシブ・耐性で弱体化するモジュール、推定撃破時間と推定耐久時間を表示します。
+func (s *BattleSelectScreenCarousel) renderMatchupPanel(builder *strings.Builder) {
+	infoPanel := components.NewInfoPanel("相性分析")
+
+	agents := s.agentProvider.GetEquippedAgents()
+	if len(agents) == 0 {
+		infoPanel.AddItem("装備", "エージェント未装備")
+	} else {
+		analysis := s.matchupAnalysis(agents)
+
+		infoPanel.AddItem(fmt.Sprintf("敵 Lv.%d", analysis.EnemyLevel),
+			fmt.Sprintf("HP %d / DPS %.1f", analysis.EnemyHP, analysis.EnemyDPS))
+
+		for _, agent := range analysis.Agents {
+			label := fmt.Sprintf("%s Lv.%d", agent.Agent.GetCoreTypeName(), agent.Agent.Level)
+			if agent.Best < 0 {
+				infoPanel.AddItem(label, "攻撃手段なし")
+				continue
+			}
+			infoPanel.AddItem(label, fmt.Sprintf("DPS %.1f（%s）", agent.DPS, agent.Modules[agent.Best].Module.Name()))
+
+			// 敵のパッシブ・耐性で弱体化するモジュール
+			for _, module := range agent.Modules {
+				if !module.Weakened {
+					continue
+				}
+				infoPanel.AddItem("⚠ "+module.Module.Name(), fmt.Sprintf("%.0f→%.0f（%s）",
+					module.RawDamage, module.Damage, strings.Join(module.Notes, ", ")))
+			}
+		}
+
+		infoPanel.AddItem("推定撃破時間", formatMatchupSeconds(analysis.TimeToKill, "撃破不可"))
+		infoPanel.AddItem("推定耐久時間", formatMatchupSeconds(analysis.TimeToDie, "被ダメージなし"))
+		if analysis.IsFavorable() {
+			infoPanel.AddItem("見込み", "有利")
+		} else {
+			infoPanel.AddItem("見込み", "不利")
+		}
+	}
+
+	infoPanelRendered := infoPanel.Render(70)
+	centeredInfo := lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(infoPanelRendered)
+	builder.WriteString(centeredInfo)
+	builder.WriteString("\n\n")
+}
+
+// matchupCache は相性分析の結果と、分析した敵タイプ・レベル・装備エージェントです。
+type matchupCache struct {
+	typeIdx  int
+	level    int
+	agents   []*domain.AgentModel
+	analysis *matchup.Analysis
+}
+
+// matchupAnalysis は選択中の敵タイプ・レベルと装備エージェントの相性分析を返します。
+// 分析は敵の生成を伴うため、描画ごとではなく条件が変わった時のみ再計算します。
+func (s *BattleSelectScreenCarousel) matchupAnalysis(agents []*domain.AgentModel) *matchup.Analysis {
+	if c := s.matchup; c != nil && c.typeIdx == s.selectedTypeIdx && c.level == s.selectedLevel && sameAgents(c.agents, agents) {
+		return c.analysis
+	}
+	analysis := matchup.Analyze(agents, s.enemyTypes[s.selectedTypeIdx], s.selectedLevel)
+	s.matchup = &matchupCache{
+		typeIdx:  s.selectedTypeIdx,
+		level:    s.selectedLevel,
+		agents:   append([]*domain.AgentModel(nil), agents...),
+		analysis: analysis,
+	}
+	return analysis
+}
+
+// sameAgents は2つのエージェントのリストが同じエージェントを同じ順に含むかを判定します。
+func sameAgents(a, b []*domain.AgentModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatMatchupSeconds は推定秒数を表示用に整形します（0以下の場合はnoneを返します）。
+func formatMatchupSeconds(seconds float64, none string) string {
+	if seconds <= 0 {
+		return none
+	}
+	return fmt.Sprintf("%.1f秒", seconds)
+}
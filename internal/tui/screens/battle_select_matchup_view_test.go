@@ -0,0 +1,86 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// TestBattleSelectCarouselMatchupPanel は相性分析パネルに推定DPS・弱体化するモジュール・推定時間が表示されることをテストします。
+func TestBattleSelectCarouselMatchupPanel(t *testing.T) {
+	enemyTypes := []domain.EnemyType{
+		{
+			ID:           "slime",
+			Name:         "スライム",
+			DefaultLevel: 1,
+			BaseHP:       50,
+			AttackType:   "physical",
+			NormalPassive: &domain.EnemyPassiveSkill{
+				ID:      "slime_normal",
+				Name:    "ぷるぷるボディ",
+				Effects: map[domain.EffectColumn]float64{domain.ColDamageCut: 0.5},
+			},
+			ResolvedNormalActions: []domain.EnemyAction{
+				{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", DamageBase: 5, DamagePerLevel: 1, ChargeTime: 2 * time.Second},
+			},
+		},
+	}
+	coreType := domain.CoreType{
+		ID:          "attack_balance",
+		Name:        "攻撃バランス",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+	}
+	core := domain.NewCore("core_001", "コア", 5, coreType, domain.PassiveSkill{})
+	slash := domain.NewModuleFromType(domain.ModuleType{
+		ID:              "slash",
+		Name:            "斬撃",
+		CooldownSeconds: 5.0,
+		Difficulty:      1,
+		Effects: []domain.ModuleEffect{
+			{Target: domain.TargetEnemy, HPFormula: &domain.HPFormula{StatCoef: 1.0, StatRef: "STR"}, Probability: 1.0},
+		},
+	}, nil)
+	agent := domain.NewAgent("agent_001", core, []*domain.ModuleModel{slash})
+
+	screen := NewBattleSelectScreenCarousel(
+		&mockAgentProvider{agents: []*domain.AgentModel{agent}},
+		&mockDefeatedEnemyProvider{defeated: map[string]int{}, maxLevelReached: 0},
+		&mockEnemyTypeProvider{enemyTypes: enemyTypes},
+	)
+	screen.width = 120
+
+	view := screen.View()
+	for _, want := range []string{"相性分析", "攻撃バランス Lv.5", "DPS", "⚠ 斬撃", "ぷるぷるボディ 被ダメ軽減50%", "推定撃破時間", "推定耐久時間", "見込み"} {
+		if !containsString(view, want) {
+			t.Errorf("相性分析パネルに %q が表示されていません", want)
+		}
+	}
+
+	// 条件が変わらない間は再描画しても分析を再計算しない
+	cached := screen.matchup.analysis
+	_ = screen.View()
+	if screen.matchup.analysis != cached {
+		t.Error("敵タイプ・レベル・装備が変わらない再描画で相性分析が再計算されました")
+	}
+	screen.selectedLevel++
+	_ = screen.View()
+	if screen.matchup.analysis == cached || screen.matchup.analysis.EnemyLevel != screen.selectedLevel {
+		t.Error("レベル変更後に相性分析が再計算されていません")
+	}
+	cached = screen.matchup.analysis
+	other := domain.NewAgent("agent_002", core, []*domain.ModuleModel{slash})
+	screen.agentProvider = &mockAgentProvider{agents: []*domain.AgentModel{agent, other}}
+	_ = screen.View()
+	if screen.matchup.analysis == cached || len(screen.matchup.analysis.Agents) != 2 {
+		t.Error("装備変更後に相性分析が再計算されていません")
+	}
+
+	// エージェント未装備の場合は見積もりを表示しない
+	screen.agentProvider = &mockAgentProvider{}
+	view = screen.View()
+	if !containsString(view, "エージェント未装備") || containsString(view, "推定撃破時間") {
+		t.Error("エージェント未装備の場合は未装備の表示のみにするべき")
+	}
+}
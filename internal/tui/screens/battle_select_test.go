@@ -654,3 +654,85 @@ func TestBattleSelectCarouselStartBattleWithEnemyTypeID(t *testing.T) {
 		t.Errorf("レベル: got %d, want 3", startBattleMsg.Level)
 	}
 }
+
+// mockEncounterProvider はエンカウントも提供するテスト用のEnemyTypeProvider実装です。
+type mockEncounterProvider struct {
+	mockEnemyTypeProvider
+	encounters []domain.Encounter
+}
+
+func (m *mockEncounterProvider) GetEncounters() []domain.Encounter {
+	return m.encounters
+}
+
+// TestBattleSelectCarouselEncounters は登場する敵を全て撃破済みのエンカウントがカルーセルに追加されることをテストします。
+func TestBattleSelectCarouselEncounters(t *testing.T) {
+	enemyTypes, defeated, maxLevelReached := createTestEnemyTypesAllVisible()
+	provider := &mockEncounterProvider{
+		mockEnemyTypeProvider: mockEnemyTypeProvider{enemyTypes: enemyTypes},
+		encounters: []domain.Encounter{
+			{
+				ID:   "pack",
+				Name: "群れ",
+				Waves: []domain.EncounterWave{
+					{Members: []domain.EncounterMember{{EnemyTypeID: "slime", Level: 3}, {EnemyTypeID: "goblin", Level: 4}}},
+				},
+			},
+			{
+				ID:   "locked",
+				Name: "未解放",
+				Waves: []domain.EncounterWave{
+					{Members: []domain.EncounterMember{{EnemyTypeID: "lich", Level: 20}}},
+				},
+			},
+		},
+	}
+	core := domain.NewCore("core1", "テストコア", 5, domain.CoreType{ID: "test", Name: "テスト"}, domain.PassiveSkill{})
+	agents := []*domain.AgentModel{domain.NewAgent("agent1", core, nil)}
+	screen := NewBattleSelectScreenCarousel(
+		&mockAgentProvider{agents: agents},
+		&mockDefeatedEnemyProvider{defeated: defeated, maxLevelReached: maxLevelReached},
+		provider,
+	)
+
+	if screen.entryCount() != 4 {
+		t.Fatalf("カルーセルの項目数: got %d, want 4（未解放のエンカウントは含まない）", screen.entryCount())
+	}
+
+	// 先頭から左キーで最後の項目（エンカウント）へ
+	screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyLeft})
+	if screen.selectedEncounter() == nil || screen.selectedEncounter().ID != "pack" {
+		t.Fatal("エンカウントが選択されていません")
+	}
+	if screen.selectedLevel != 4 || screen.minSelectableLevel != screen.maxSelectableLevel {
+		t.Errorf("エンカウントのレベルは最高レベルで固定: got Lv.%d (%d〜%d)", screen.selectedLevel, screen.minSelectableLevel, screen.maxSelectableLevel)
+	}
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("バトル開始コマンドが返されません")
+	}
+	msg, ok := cmd().(StartBattleMsg)
+	if !ok || msg.EncounterID != "pack" || msg.Level != 4 {
+		t.Errorf("バトル開始メッセージ: got %+v", msg)
+	}
+}
+
+// TestBattleSelectCarouselTower はTキーでエンドレスタワーへ遷移することをテストします。
+func TestBattleSelectCarouselTower(t *testing.T) {
+	screen := NewBattleSelectScreenCarousel(
+		&mockAgentProvider{},
+		&mockDefeatedEnemyProvider{defeated: map[string]int{}, maxLevelReached: 0},
+		&mockEnemyTypeProvider{enemyTypes: createTestEnemyTypes()},
+	)
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("Tキーでコマンドが返されません")
+	}
+
+	msg, ok := cmd().(ChangeSceneMsg)
+	if !ok || msg.Scene != "tower" {
+		t.Errorf("エンドレスタワーへの遷移メッセージではありません: %+v", msg)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/typing"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -91,14 +93,14 @@ func TestBattleScreenModuleList(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// モジュールスロットが作成されていることを確認
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Error("モジュールスロットが空です")
 	}
 
 	// エージェントごとにグループ化されているか
 	expectedSlots := len(agents) * 4 // 各エージェント4モジュール
-	if len(screen.moduleSlots) != expectedSlots {
-		t.Errorf("モジュールスロット数: got %d, want %d", len(screen.moduleSlots), expectedSlots)
+	if len(screen.session.Slots()) != expectedSlots {
+		t.Errorf("モジュールスロット数: got %d, want %d", len(screen.session.Slots()), expectedSlots)
 	}
 }
 
@@ -112,9 +114,9 @@ func TestBattleScreenCooldownDisplay(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// クールダウンを設定
-	if len(screen.moduleSlots) > 0 {
-		screen.moduleSlots[0].CooldownRemaining = 3.0
-		screen.moduleSlots[0].CooldownTotal = 5.0
+	if len(screen.session.Slots()) > 0 {
+		screen.session.Slots()[0].CooldownRemaining = 3.0
+		screen.session.Slots()[0].CooldownTotal = 5.0
 	}
 
 	screen.width = 120
@@ -137,14 +139,83 @@ func TestBattleScreenTypingChallenge(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// タイピングチャレンジを開始
-	screen.StartTypingChallenge("hello", 5*time.Second)
+	screen.session.StartTypingChallenge(0, "hello", 5*time.Second)
 
-	if !screen.isTyping {
+	if !screen.session.IsTyping() {
 		t.Error("タイピング状態になっていません")
 	}
 
-	if screen.typingText != "hello" {
-		t.Errorf("タイピングテキスト: got %s, want hello", screen.typingText)
+	if screen.session.TypingText() != "hello" {
+		t.Errorf("タイピングテキスト: got %s, want hello", screen.session.TypingText())
+	}
+}
+
+// TestBattleScreenRomajiTyping はローマ字モードのタイピング入力をテストします。
+func TestBattleScreenRomajiTyping(t *testing.T) {
+	enemy := createTestEnemy()
+	player := createTestPlayer()
+	agents := createTestAgents()
+
+	screen := NewBattleScreen(enemy, player, agents, nil)
+	screen.session.StartChallenge(0, &typing.Challenge{
+		Text:      "寿司",
+		Reading:   "すし",
+		Mode:      typing.InputModeRomaji,
+		TimeLimit: 10 * time.Second,
+	})
+
+	if screen.session.TypingLength() != 2 {
+		t.Errorf("入力単位数: got %d, want 2", screen.session.TypingLength())
+	}
+
+	for _, r := range "sux" {
+		screen.session.ProcessTypingInput(r)
+	}
+	if screen.session.TypingIndex() != 1 {
+		t.Errorf("かな単位の進捗: got %d, want 1", screen.session.TypingIndex())
+	}
+	if len(screen.session.TypingMistakes()) != 1 {
+		t.Errorf("ミス数: got %d, want 1", len(screen.session.TypingMistakes()))
+	}
+
+	screen.width = 120
+	screen.height = 40
+	if !strings.Contains(screen.View(), "寿司") {
+		t.Error("表示テキストがレンダリングされていません")
+	}
+
+	for _, r := range "shi" {
+		screen.session.ProcessTypingInput(r)
+	}
+	if screen.session.IsTyping() {
+		t.Error("ローマ字入力完了後もタイピング中のままです")
+	}
+}
+
+// TestBattleScreenNonASCIITyping は非ASCIIテキストの入力位置が文字単位で進むことをテストします。
+func TestBattleScreenNonASCIITyping(t *testing.T) {
+	enemy := createTestEnemy()
+	player := createTestPlayer()
+	agents := createTestAgents()
+
+	screen := NewBattleScreen(enemy, player, agents, nil)
+	screen.session.StartTypingChallenge(0, "naïve", 10*time.Second)
+
+	for _, r := range "naï" {
+		screen.session.ProcessTypingInput(r)
+	}
+	if screen.session.TypingIndex() != 3 {
+		t.Errorf("入力位置: got %d, want 3", screen.session.TypingIndex())
+	}
+	if screen.session.TypingLength() != 5 {
+		t.Errorf("入力単位数: got %d, want 5", screen.session.TypingLength())
+	}
+
+	for _, r := range "ve" {
+		screen.session.ProcessTypingInput(r)
+	}
+	if screen.session.IsTyping() {
+		t.Error("入力完了後もタイピング中のままです")
 	}
 }
 
@@ -158,11 +229,11 @@ func TestBattleScreenTimeLimit(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// タイピングチャレンジを開始
-	screen.StartTypingChallenge("test", 10*time.Second)
+	screen.session.StartTypingChallenge(0, "test", 10*time.Second)
 
 	// 時間制限が設定されているか
-	if screen.typingTimeLimit != 10*time.Second {
-		t.Errorf("タイピング制限時間: got %v, want 10s", screen.typingTimeLimit)
+	if screen.session.TypingTimeLimit() != 10*time.Second {
+		t.Errorf("タイピング制限時間: got %v, want 10s", screen.session.TypingTimeLimit())
 	}
 }
 
@@ -208,18 +279,18 @@ func TestBattleScreenTickUpdatesCooldowns(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// クールダウンを設定
-	if len(screen.moduleSlots) > 0 {
-		screen.moduleSlots[0].CooldownRemaining = 3.0
+	if len(screen.session.Slots()) > 0 {
+		screen.session.Slots()[0].CooldownRemaining = 3.0
 	}
 
 	// TickMsgを送信（100ms経過をシミュレート）
 	_, _ = screen.Update(BattleTickMsg{})
 
 	// クールダウンが減少していること
-	if len(screen.moduleSlots) > 0 {
+	if len(screen.session.Slots()) > 0 {
 		// tickInterval (100ms = 0.1秒) 分減少しているはず
 		expected := 3.0 - 0.1
-		actual := screen.moduleSlots[0].CooldownRemaining
+		actual := screen.session.Slots()[0].CooldownRemaining
 		if actual > expected+0.01 || actual < expected-0.01 {
 			t.Errorf("クールダウンが更新されていません: got %.2f, want %.2f", actual, expected)
 		}
@@ -278,22 +349,154 @@ func TestBattleScreenTypingTimeout(t *testing.T) {
 	agents := createTestAgents()
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
+	c := clock.NewManualClock(time.Now())
+	screen.SetClock(c)
 
 	// タイピングを開始（非常に短い制限時間）
-	screen.StartTypingChallenge("test", 10*time.Millisecond)
+	screen.session.StartTypingChallenge(0, "test", 10*time.Millisecond)
 
 	// 時間を経過させる
-	time.Sleep(20 * time.Millisecond)
+	c.Advance(20 * time.Millisecond)
 
 	// TickMsgを送信
 	_, _ = screen.Update(BattleTickMsg{})
 
 	// タイピングがキャンセルされているはず
-	if screen.isTyping {
+	if screen.session.IsTyping() {
 		t.Error("タイピング時間切れでもisTypingがtrueのままです")
 	}
 }
 
+// TestBattleScreenManualClockEnemyCharge は注入した時計で敵のチャージが進むことをテストします。
+func TestBattleScreenManualClockEnemyCharge(t *testing.T) {
+	enemy := createTestEnemy()
+	enemy.Type.ResolvedNormalActions = []domain.EnemyAction{
+		{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: 2 * time.Second},
+	}
+	player := createTestPlayer()
+	agents := createTestAgents()
+
+	screen := NewBattleScreen(enemy, player, agents, nil)
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	screen.SetClock(c)
+
+	chargeTime := enemy.CurrentChargeTime
+	if chargeTime <= 0 {
+		t.Fatalf("チャージ時間が設定されていません: %v", chargeTime)
+	}
+	if remaining := enemy.GetChargeRemainingTime(c.Now()); remaining != chargeTime {
+		t.Errorf("残りチャージ時間: 期待 %v, 実際 %v", chargeTime, remaining)
+	}
+
+	// 時計を進めない限りチャージは完了しない
+	initialHP := player.HP
+	_, _ = screen.Update(BattleTickMsg{})
+	if player.HP != initialHP {
+		t.Error("時計を進めていないのに敵が攻撃しました")
+	}
+
+	c.Advance(chargeTime)
+	_, _ = screen.Update(BattleTickMsg{})
+	if player.HP >= initialHP {
+		t.Error("チャージ完了後に敵が攻撃していません")
+	}
+}
+
+// ==================== 一時停止テスト ====================
+
+// newPausableTestBattle は手動時計を設定したテスト用バトル画面を作成します。
+func newPausableTestBattle() (*BattleScreen, *clock.ManualClock) {
+	enemy := createTestEnemy()
+	enemy.Type.ResolvedNormalActions = []domain.EnemyAction{
+		{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: 2 * time.Second},
+	}
+	screen := NewBattleScreen(enemy, createTestPlayer(), createTestAgents(), nil)
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	screen.SetClock(c)
+	return screen, c
+}
+
+// TestBattleScreenPauseFreezesTimers は一時停止中にチャージ・タイピング・クールダウンが進まないことをテストします。
+func TestBattleScreenPauseFreezesTimers(t *testing.T) {
+	screen, c := newPausableTestBattle()
+	screen.session.StartTypingChallenge(0, "test", 5*time.Second)
+	screen.session.Slots()[1].CooldownRemaining = 3.0
+
+	c.Advance(time.Second)
+	progressBefore := screen.enemy.GetChargeProgress(screen.session.Now())
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !screen.IsPaused() {
+		t.Fatal("Tabキーで一時停止されていません")
+	}
+
+	// 一時停止中に長時間経過してもタイマーは進まない
+	initialHP := screen.player.HP
+	c.Advance(time.Minute)
+	for i := 0; i < 10; i++ {
+		_, _ = screen.Update(BattleTickMsg{})
+	}
+
+	if !screen.session.IsTyping() {
+		t.Error("一時停止中にタイピングが時間切れになりました")
+	}
+	if screen.player.HP != initialHP {
+		t.Error("一時停止中に敵が攻撃しました")
+	}
+	if screen.session.Slots()[1].CooldownRemaining != 3.0 {
+		t.Errorf("一時停止中にクールダウンが進みました: %f", screen.session.Slots()[1].CooldownRemaining)
+	}
+
+	// 再開後は停止した時点から続く（チャージ進捗が飛ばない）
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if screen.IsPaused() {
+		t.Fatal("Tabキーで再開されていません")
+	}
+	if progress := screen.enemy.GetChargeProgress(screen.session.Now()); progress != progressBefore {
+		t.Errorf("再開後のチャージ進捗: 期待 %f, 実際 %f", progressBefore, progress)
+	}
+	if remaining := screen.session.TypingRemaining(); remaining != 4*time.Second {
+		t.Errorf("再開後のタイピング残り時間: 期待 4s, 実際 %v", remaining)
+	}
+}
+
+// TestBattleScreenPauseIgnoresInput は一時停止中に再開以外の入力を受け付けないことをテストします。
+func TestBattleScreenPauseIgnoresInput(t *testing.T) {
+	screen, _ := newPausableTestBattle()
+	screen.session.StartTypingChallenge(0, "test", 5*time.Second)
+	screen.Pause()
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if screen.session.TypingIndex() != 0 {
+		t.Error("一時停止中にタイピング入力が処理されました")
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !screen.session.IsTyping() {
+		t.Error("一時停止中にタイピングがキャンセルされました")
+	}
+}
+
+// TestBattleScreenPauseOverlay は一時停止中にオーバーレイが表示されチャレンジが隠れることをテストします。
+func TestBattleScreenPauseOverlay(t *testing.T) {
+	screen, _ := newPausableTestBattle()
+	screen.width = 120
+	screen.height = 40
+	screen.session.StartTypingChallenge(0, "zebra", 5*time.Second)
+	if !strings.Contains(screen.View(), "zebra") {
+		t.Fatal("一時停止前にタイピングテキストが表示されていません")
+	}
+	screen.Pause()
+
+	rendered := screen.View()
+	if !strings.Contains(rendered, "PAUSED") {
+		t.Error("一時停止オーバーレイが表示されていません")
+	}
+	if strings.Contains(rendered, "zebra") {
+		t.Error("一時停止中にタイピングテキストが表示されています")
+	}
+}
+
 // ==================== Task: 敗北判定テスト ====================
 
 // TestBattleScreenDefeatDetection はプレイヤーHP0で敗北判定されることをテストします。
@@ -445,6 +648,33 @@ func TestBattleScreenResultDisplaysMessage(t *testing.T) {
 	}
 }
 
+// TestBattleScreenParryKeyTypesSpace は空白を入力する位置ではSpaceがパリィではなく空白として入力されることをテストします。
+func TestBattleScreenParryKeyTypesSpace(t *testing.T) {
+	screen, c := newPausableTestBattle()
+	screen.session.Enemy().PendingAction.ParryWindow = time.Second
+	c.Advance(1500 * time.Millisecond)
+	_, _ = screen.Update(BattleTickMsg{})
+	if screen.session.ParryableEnemy() == nil {
+		t.Fatal("パリィ受付時間内になっていません")
+	}
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	// 空白を含む単語の空白の位置では空白として入力する
+	screen.session.StartTypingChallenge(0, "a b", 5*time.Second)
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	_, _ = screen.Update(space)
+	if screen.session.IsParrying() || screen.session.TypingIndex() != 2 {
+		t.Errorf("空白の位置: パリィ中 %v, 入力済み %d（期待 2）", screen.session.IsParrying(), screen.session.TypingIndex())
+	}
+
+	// 空白以外の位置ではパリィに切り替える
+	_, _ = screen.Update(space)
+	if !screen.session.IsParrying() {
+		t.Error("空白以外の位置でSpaceを押してもパリィに切り替わりませんでした")
+	}
+}
+
 // ==================== ヘルパー関数 ====================
 
 func createTestEnemy() *domain.EnemyModel {
@@ -605,11 +835,11 @@ func TestBattleScreenTypingColorDisplay(t *testing.T) {
 	screen.height = 40
 
 	// タイピングチャレンジを開始
-	screen.StartTypingChallenge("hello", 10*time.Second)
+	screen.session.StartTypingChallenge(0, "hello", 10*time.Second)
 
 	// 数文字入力
-	screen.ProcessTypingInput('h')
-	screen.ProcessTypingInput('e')
+	screen.session.ProcessTypingInput('h')
+	screen.session.ProcessTypingInput('e')
 
 	rendered := screen.View()
 
@@ -689,13 +919,13 @@ func TestBattleScreenLogicSeparation(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// checkGameOver - 正常状態では終了しない
-	if screen.checkGameOver() {
+	if screen.session.CheckGameOver() {
 		t.Error("HP残っているのにゲームオーバー判定されました")
 	}
 
 	// 敵HP0で勝利判定
 	enemy.HP = 0
-	if !screen.checkGameOver() {
+	if !screen.session.CheckGameOver() {
 		t.Error("敵HP0でゲームオーバー判定されませんでした")
 	}
 	if !screen.IsVictory() {
@@ -748,30 +978,30 @@ func TestBattleScreenCooldownLogic(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// クールダウン開始
-	screen.StartCooldown(0, 5.0)
-	if screen.moduleSlots[0].CooldownRemaining != 5.0 {
-		t.Errorf("クールダウン設定失敗: got %.2f, want 5.0", screen.moduleSlots[0].CooldownRemaining)
+	screen.session.StartCooldown(0, 5.0)
+	if screen.session.Slots()[0].CooldownRemaining != 5.0 {
+		t.Errorf("クールダウン設定失敗: got %.2f, want 5.0", screen.session.Slots()[0].CooldownRemaining)
 	}
 
 	// クールダウン更新
-	screen.UpdateCooldowns(1.0)
-	if screen.moduleSlots[0].CooldownRemaining != 4.0 {
-		t.Errorf("クールダウン更新失敗: got %.2f, want 4.0", screen.moduleSlots[0].CooldownRemaining)
+	screen.session.UpdateCooldowns(1.0)
+	if screen.session.Slots()[0].CooldownRemaining != 4.0 {
+		t.Errorf("クールダウン更新失敗: got %.2f, want 4.0", screen.session.Slots()[0].CooldownRemaining)
 	}
 
 	// IsReady確認
-	if screen.moduleSlots[0].IsReady() {
+	if screen.session.Slots()[0].IsReady() {
 		t.Error("クールダウン中なのにIsReady=trueになっています")
 	}
 
 	// クールダウン完了
-	screen.UpdateCooldowns(5.0)
-	if !screen.moduleSlots[0].IsReady() {
+	screen.session.UpdateCooldowns(5.0)
+	if !screen.session.Slots()[0].IsReady() {
 		t.Error("クールダウン完了後もIsReady=falseのままです")
 	}
 }
@@ -786,29 +1016,29 @@ func TestBattleScreenTypingLogic(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// タイピング開始
-	screen.StartTypingChallenge("test", 10*time.Second)
-	if !screen.isTyping {
+	screen.session.StartTypingChallenge(0, "test", 10*time.Second)
+	if !screen.session.IsTyping() {
 		t.Error("タイピングが開始されていません")
 	}
-	if screen.typingText != "test" {
-		t.Errorf("タイピングテキストが正しくありません: got %s, want test", screen.typingText)
+	if screen.session.TypingText() != "test" {
+		t.Errorf("タイピングテキストが正しくありません: got %s, want test", screen.session.TypingText())
 	}
 
 	// タイピング入力処理
-	screen.ProcessTypingInput('t')
-	if screen.typingIndex != 1 {
-		t.Errorf("タイピングインデックスが更新されていません: got %d, want 1", screen.typingIndex)
+	screen.session.ProcessTypingInput('t')
+	if screen.session.TypingIndex() != 1 {
+		t.Errorf("タイピングインデックスが更新されていません: got %d, want 1", screen.session.TypingIndex())
 	}
 
 	// 誤入力
-	screen.ProcessTypingInput('x')
-	if len(screen.typingMistakes) == 0 {
+	screen.session.ProcessTypingInput('x')
+	if len(screen.session.TypingMistakes()) == 0 {
 		t.Error("誤入力が記録されていません")
 	}
 
 	// タイピングキャンセル
-	screen.CancelTyping()
-	if screen.isTyping {
+	screen.session.CancelTyping()
+	if screen.session.IsTyping() {
 		t.Error("タイピングがキャンセルされていません")
 	}
 }
@@ -837,7 +1067,7 @@ func TestBattleScreenEffectDuration(t *testing.T) {
 	}
 
 	// エフェクト持続時間更新
-	screen.updateEffectDurations(1.0)
+	screen.session.Engine().UpdateEffects(screen.session.State(), 1.0)
 
 	buffs = player.EffectTable.FindBySourceType(domain.SourceBuff)
 	if len(buffs) == 0 {
@@ -858,7 +1088,7 @@ func TestBattleScreenHasRecastManager(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if screen.recastManager == nil {
+	if screen.session.RecastManager() == nil {
 		t.Error("RecastManagerが初期化されていません")
 	}
 }
@@ -871,22 +1101,21 @@ func TestBattleScreenModuleUsageStartsRecast(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// モジュール使用前はエージェントがReady
-	if !screen.recastManager.IsAgentReady(0) {
+	if !screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("初期状態でエージェントがリキャスト中になっています")
 	}
 
 	// タイピングチャレンジを開始してモジュールを使用
-	screen.selectedModuleIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a') // タイピング完了
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a') // タイピング完了
 
 	// エージェント0がリキャスト中になっているはず
-	if screen.recastManager.IsAgentReady(0) {
+	if screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("モジュール使用後もエージェントがReady状態です")
 	}
 }
@@ -899,15 +1128,15 @@ func TestBattleScreenRecastBlocksModuleUsage(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// エージェント0のリキャストを開始
-	screen.recastManager.StartRecast(0, 5*time.Second)
+	screen.session.RecastManager().StartRecast(0, 5*time.Second)
 
 	// エージェント0のモジュールは使用不可
-	if screen.isModuleUsable(0) {
+	if screen.session.IsModuleUsable(0) {
 		t.Error("リキャスト中のエージェントのモジュールが使用可能になっています")
 	}
 }
@@ -921,9 +1150,9 @@ func TestBattleScreenTickUpdatesRecast(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// リキャストを開始（3秒）
-	screen.recastManager.StartRecast(0, 3*time.Second)
+	screen.session.RecastManager().StartRecast(0, 3*time.Second)
 
-	initialState := screen.recastManager.GetRecastState(0)
+	initialState := screen.session.RecastManager().GetRecastState(0)
 	if initialState == nil {
 		t.Fatal("リキャスト状態が取得できません")
 	}
@@ -932,7 +1161,7 @@ func TestBattleScreenTickUpdatesRecast(t *testing.T) {
 	// TickMsgを送信
 	_, _ = screen.Update(BattleTickMsg{})
 
-	state := screen.recastManager.GetRecastState(0)
+	state := screen.session.RecastManager().GetRecastState(0)
 	if state == nil {
 		t.Fatal("TickMsg後にリキャスト状態が取得できません")
 	}
@@ -953,10 +1182,10 @@ func TestBattleScreenRecastCompletionEnablesAgent(t *testing.T) {
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
 	// 短いリキャストを開始（0.05秒 = 50ms = tick1回未満）
-	screen.recastManager.StartRecast(0, 50*time.Millisecond)
+	screen.session.RecastManager().StartRecast(0, 50*time.Millisecond)
 
 	// リキャスト中
-	if screen.recastManager.IsAgentReady(0) {
+	if screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("リキャスト開始直後にエージェントがReady状態です")
 	}
 
@@ -964,7 +1193,7 @@ func TestBattleScreenRecastCompletionEnablesAgent(t *testing.T) {
 	_, _ = screen.Update(BattleTickMsg{})
 
 	// リキャスト完了
-	if !screen.recastManager.IsAgentReady(0) {
+	if !screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("リキャスト時間経過後もエージェントがリキャスト中です")
 	}
 }
@@ -979,7 +1208,7 @@ func TestBattleScreenHasChainEffectManager(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if screen.chainEffectManager == nil {
+	if screen.session.ChainEffectManager() == nil {
 		t.Error("ChainEffectManagerが初期化されていません")
 	}
 }
@@ -992,22 +1221,21 @@ func TestBattleScreenModuleUsageRegistersChainEffect(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// 登録前は待機中チェイン効果なし
-	if len(screen.chainEffectManager.GetPendingEffects()) != 0 {
+	if len(screen.session.ChainEffectManager().GetPendingEffects()) != 0 {
 		t.Error("初期状態で待機中チェイン効果が存在します")
 	}
 
 	// タイピングチャレンジを開始してモジュールを使用
-	screen.selectedModuleIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a') // タイピング完了
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a') // タイピング完了
 
 	// チェイン効果が登録されているはず
-	pendingEffects := screen.chainEffectManager.GetPendingEffects()
+	pendingEffects := screen.session.ChainEffectManager().GetPendingEffects()
 	if len(pendingEffects) == 0 {
 		t.Error("モジュール使用後にチェイン効果が登録されていません")
 	}
@@ -1021,28 +1249,26 @@ func TestBattleScreenChainEffectTrigger(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) < 5 {
+	if len(screen.session.Slots()) < 5 {
 		t.Skip("モジュールスロットが足りません")
 	}
 
 	// エージェント0のモジュールを使用（チェイン効果を登録）
-	screen.selectedModuleIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a')
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a')
 
 	// 待機中チェイン効果があること
-	if len(screen.chainEffectManager.GetPendingEffects()) == 0 {
+	if len(screen.session.ChainEffectManager().GetPendingEffects()) == 0 {
 		t.Error("エージェント0のチェイン効果が登録されていません")
 	}
 
 	// エージェント1のモジュールを使用（チェイン効果が発動）
-	screen.selectedModuleIdx = 4 // エージェント1の最初のモジュール
 	screen.selectedAgentIdx = 1
-	screen.StartTypingChallenge("b", 10*time.Second)
-	screen.ProcessTypingInput('b')
+	screen.session.StartTypingChallenge(4, "b", 10*time.Second)
+	screen.session.ProcessTypingInput('b')
 
 	// チェイン効果が発動して削除されているはず
-	pendingEffects := screen.chainEffectManager.GetPendingEffects()
+	pendingEffects := screen.session.ChainEffectManager().GetPendingEffects()
 	// エージェント0の効果は発動済み、エージェント1の効果は待機中
 	foundAgent0 := false
 	for _, pe := range pendingEffects {
@@ -1063,34 +1289,33 @@ func TestBattleScreenRecastCompletionExpiresChainEffect(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// エージェント0のモジュールを使用（チェイン効果を登録）
-	screen.selectedModuleIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a')
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a')
 
 	// チェイン効果が登録されている
-	if len(screen.chainEffectManager.GetPendingEffects()) == 0 {
+	if len(screen.session.ChainEffectManager().GetPendingEffects()) == 0 {
 		t.Error("チェイン効果が登録されていません")
 	}
 
 	// リキャストを短い時間に設定して終了させる
-	screen.recastManager.CancelRecast(0)
-	screen.recastManager.StartRecast(0, 50*time.Millisecond)
+	screen.session.RecastManager().CancelRecast(0)
+	screen.session.RecastManager().StartRecast(0, 50*time.Millisecond)
 
 	// TickMsgを送信してリキャストを終了
 	_, _ = screen.Update(BattleTickMsg{})
 
 	// リキャスト完了
-	if !screen.recastManager.IsAgentReady(0) {
+	if !screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("リキャストが終了していません")
 	}
 
 	// チェイン効果が破棄されているはず
-	for _, pe := range screen.chainEffectManager.GetPendingEffects() {
+	for _, pe := range screen.session.ChainEffectManager().GetPendingEffects() {
 		if pe.AgentIndex == 0 {
 			t.Error("リキャスト終了時にエージェント0のチェイン効果が破棄されていません")
 		}
@@ -1107,36 +1332,35 @@ func TestBattleScreenModuleRecastChainFlowIntegration(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// Step 1: 初期状態確認
-	if !screen.recastManager.IsAgentReady(0) {
+	if !screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("初期状態: エージェント0がリキャスト中")
 	}
-	if len(screen.chainEffectManager.GetPendingEffects()) != 0 {
+	if len(screen.session.ChainEffectManager().GetPendingEffects()) != 0 {
 		t.Error("初期状態: 待機中チェイン効果が存在")
 	}
 
 	// Step 2: モジュール使用
-	screen.selectedModuleIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a')
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a')
 
 	// Step 3: リキャスト開始確認
-	if screen.recastManager.IsAgentReady(0) {
+	if screen.session.RecastManager().IsAgentReady(0) {
 		t.Error("モジュール使用後: エージェント0がリキャスト中になっていない")
 	}
 
 	// Step 4: チェイン効果登録確認
-	pendingEffects := screen.chainEffectManager.GetPendingEffects()
+	pendingEffects := screen.session.ChainEffectManager().GetPendingEffects()
 	if len(pendingEffects) == 0 {
 		t.Error("モジュール使用後: チェイン効果が登録されていない")
 	}
 
 	// Step 5: エージェント0のモジュール使用がブロックされる
-	if screen.isModuleUsable(0) {
+	if screen.session.IsModuleUsable(0) {
 		t.Error("リキャスト中: エージェント0のモジュールが使用可能になっている")
 	}
 }
@@ -1149,12 +1373,12 @@ func TestBattleScreenRecastBlockedModuleSelection(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
 	// エージェント0をリキャスト状態に
-	screen.recastManager.StartRecast(0, 5*time.Second)
+	screen.session.RecastManager().StartRecast(0, 5*time.Second)
 
 	// エージェント0のモジュールを選択してEnterを押す
 	screen.selectedSlot = 0
@@ -1162,7 +1386,7 @@ func TestBattleScreenRecastBlockedModuleSelection(t *testing.T) {
 	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
 	// タイピングチャレンジが開始されていないはず
-	if screen.isTyping {
+	if screen.session.IsTyping() {
 		t.Error("リキャスト中のエージェントのモジュールでタイピングチャレンジが開始されました")
 	}
 }
@@ -1175,18 +1399,17 @@ func TestBattleScreenChainEffectTimingVerification(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) < 5 {
+	if len(screen.session.Slots()) < 5 {
 		t.Skip("モジュールスロットが足りません（2エージェント必要）")
 	}
 
 	// エージェント0の攻撃モジュールを使用（ダメージボーナスのチェイン効果を登録）
-	screen.selectedModuleIdx = 0
 	screen.selectedAgentIdx = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a')
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a')
 
 	// 待機中チェイン効果が存在
-	pendingBefore := len(screen.chainEffectManager.GetPendingEffects())
+	pendingBefore := len(screen.session.ChainEffectManager().GetPendingEffects())
 	if pendingBefore == 0 {
 		t.Error("チェイン効果が登録されていません")
 	}
@@ -1194,10 +1417,9 @@ func TestBattleScreenChainEffectTimingVerification(t *testing.T) {
 	initialEnemyHP := enemy.HP
 
 	// エージェント1の攻撃モジュールを使用（チェイン効果が発動するはず）
-	screen.selectedModuleIdx = 4 // エージェント1の最初のモジュール
 	screen.selectedAgentIdx = 1
-	screen.StartTypingChallenge("b", 10*time.Second)
-	screen.ProcessTypingInput('b')
+	screen.session.StartTypingChallenge(4, "b", 10*time.Second)
+	screen.session.ProcessTypingInput('b')
 
 	// チェイン効果が発動している（敵にダメージが与えられている）
 	if enemy.HP >= initialEnemyHP {
@@ -1205,7 +1427,7 @@ func TestBattleScreenChainEffectTimingVerification(t *testing.T) {
 	}
 
 	// エージェント0の待機中チェイン効果は発動済みで削除されている
-	for _, pe := range screen.chainEffectManager.GetPendingEffects() {
+	for _, pe := range screen.session.ChainEffectManager().GetPendingEffects() {
 		if pe.AgentIndex == 0 {
 			t.Error("エージェント0のチェイン効果が発動後も残っています")
 		}
@@ -1255,12 +1477,12 @@ func TestTimeExtend_ExtendsTypingTimeLimit(t *testing.T) {
 
 	// タイピングチャレンジを開始
 	originalTimeLimit := 5 * time.Second
-	screen.StartTypingChallenge("test", originalTimeLimit)
+	screen.session.StartTypingChallenge(0, "test", originalTimeLimit)
 
 	// 制限時間が延長されていることを確認
 	expectedTimeLimit := originalTimeLimit + 3*time.Second
-	if screen.typingTimeLimit != expectedTimeLimit {
-		t.Errorf("TimeExtend効果が適用されていない: got %v, want %v", screen.typingTimeLimit, expectedTimeLimit)
+	if screen.session.TypingTimeLimit() != expectedTimeLimit {
+		t.Errorf("TimeExtend効果が適用されていない: got %v, want %v", screen.session.TypingTimeLimit(), expectedTimeLimit)
 	}
 }
 
@@ -1279,12 +1501,12 @@ func TestTimeExtend_NegativeValue(t *testing.T) {
 
 	// タイピングチャレンジを開始
 	originalTimeLimit := 5 * time.Second
-	screen.StartTypingChallenge("test", originalTimeLimit)
+	screen.session.StartTypingChallenge(0, "test", originalTimeLimit)
 
 	// 制限時間が短縮されていることを確認
 	expectedTimeLimit := originalTimeLimit - 2*time.Second
-	if screen.typingTimeLimit != expectedTimeLimit {
-		t.Errorf("TimeExtendデバフが適用されていない: got %v, want %v", screen.typingTimeLimit, expectedTimeLimit)
+	if screen.session.TypingTimeLimit() != expectedTimeLimit {
+		t.Errorf("TimeExtendデバフが適用されていない: got %v, want %v", screen.session.TypingTimeLimit(), expectedTimeLimit)
 	}
 }
 
@@ -1302,12 +1524,12 @@ func TestTimeExtend_MinimumTimeLimit(t *testing.T) {
 	})
 
 	// タイピングチャレンジを開始（5秒制限）
-	screen.StartTypingChallenge("test", 5*time.Second)
+	screen.session.StartTypingChallenge(0, "test", 5*time.Second)
 
 	// 制限時間は最低1秒を下回らない
 	minTimeLimit := 1 * time.Second
-	if screen.typingTimeLimit < minTimeLimit {
-		t.Errorf("制限時間が最低値を下回っている: got %v, want >= %v", screen.typingTimeLimit, minTimeLimit)
+	if screen.session.TypingTimeLimit() < minTimeLimit {
+		t.Errorf("制限時間が最低値を下回っている: got %v, want >= %v", screen.session.TypingTimeLimit(), minTimeLimit)
 	}
 }
 
@@ -1319,7 +1541,7 @@ func TestCooldownReduce_ShortensInitialCooldown(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
@@ -1329,20 +1551,20 @@ func TestCooldownReduce_ShortensInitialCooldown(t *testing.T) {
 	})
 
 	// クールダウンを設定（10秒）→ 30%短縮で7秒になるはず
-	screen.StartCooldown(0, 10.0)
+	screen.session.StartCooldown(0, 10.0)
 
 	expected := 7.0
 	tolerance := 0.01
-	if screen.moduleSlots[0].CooldownRemaining < expected-tolerance ||
-		screen.moduleSlots[0].CooldownRemaining > expected+tolerance {
+	if screen.session.Slots()[0].CooldownRemaining < expected-tolerance ||
+		screen.session.Slots()[0].CooldownRemaining > expected+tolerance {
 		t.Errorf("CooldownReduce効果が初期値に適用されていない: got %.2f, want %.2f",
-			screen.moduleSlots[0].CooldownRemaining, expected)
+			screen.session.Slots()[0].CooldownRemaining, expected)
 	}
 
 	// CooldownTotal は元の値（表示用）
-	if screen.moduleSlots[0].CooldownTotal != 10.0 {
+	if screen.session.Slots()[0].CooldownTotal != 10.0 {
 		t.Errorf("CooldownTotal が変更されている: got %.2f, want 10.0",
-			screen.moduleSlots[0].CooldownTotal)
+			screen.session.Slots()[0].CooldownTotal)
 	}
 }
 
@@ -1354,7 +1576,7 @@ func TestCooldownReduce_NegativeValue(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
@@ -1364,14 +1586,14 @@ func TestCooldownReduce_NegativeValue(t *testing.T) {
 	})
 
 	// クールダウンを設定（10秒）→ -30%延長で13秒になるはず
-	screen.StartCooldown(0, 10.0)
+	screen.session.StartCooldown(0, 10.0)
 
 	expected := 13.0
 	tolerance := 0.01
-	if screen.moduleSlots[0].CooldownRemaining < expected-tolerance ||
-		screen.moduleSlots[0].CooldownRemaining > expected+tolerance {
+	if screen.session.Slots()[0].CooldownRemaining < expected-tolerance ||
+		screen.session.Slots()[0].CooldownRemaining > expected+tolerance {
 		t.Errorf("CooldownReduce延長効果が初期値に適用されていない: got %.2f, want %.2f",
-			screen.moduleSlots[0].CooldownRemaining, expected)
+			screen.session.Slots()[0].CooldownRemaining, expected)
 	}
 }
 
@@ -1383,7 +1605,7 @@ func TestCooldownReduce_MinimumLimit(t *testing.T) {
 
 	screen := NewBattleScreen(enemy, player, agents, nil)
 
-	if len(screen.moduleSlots) == 0 {
+	if len(screen.session.Slots()) == 0 {
 		t.Skip("モジュールスロットがありません")
 	}
 
@@ -1393,22 +1615,17 @@ func TestCooldownReduce_MinimumLimit(t *testing.T) {
 	})
 
 	// クールダウンを設定（10秒）→ 最低10%で1秒になるはず
-	screen.StartCooldown(0, 10.0)
+	screen.session.StartCooldown(0, 10.0)
 
 	minExpected := 1.0
-	if screen.moduleSlots[0].CooldownRemaining < minExpected {
+	if screen.session.Slots()[0].CooldownRemaining < minExpected {
 		t.Errorf("CooldownReduce の下限が適用されていない: got %.2f, want >= %.2f",
-			screen.moduleSlots[0].CooldownRemaining, minExpected)
+			screen.session.Slots()[0].CooldownRemaining, minExpected)
 	}
 }
 
 // TestDoubleCast_DoublesDamageEffect はダブルキャストによる2回発動をテストします。
 func TestDoubleCast_DoublesDamageEffect(t *testing.T) {
-	// 乱数を固定して確率判定を決定的にする
-	originalRandFloat := randFloat
-	randFloat = func() float64 { return 0.0 } // 常に0を返す（100%確率は必ず成功）
-	defer func() { randFloat = originalRandFloat }()
-
 	// まず、DoubleCastなしでの基準ダメージを計測
 	enemy1 := createTestEnemy()
 	enemy1.HP = 1000
@@ -1416,11 +1633,15 @@ func TestDoubleCast_DoublesDamageEffect(t *testing.T) {
 	agents1 := createTestAgents()
 	screen1 := NewBattleScreen(enemy1, player1, agents1, nil)
 
-	screen1.selectedModuleIdx = 0
+	// クリティカルによるダメージの揺れを除外
+	player1.EffectTable.AddBuff("クリ無効", 10.0, map[domain.EffectColumn]float64{
+		domain.ColCritRate: -10.0,
+	})
+
 	screen1.selectedSlot = 0
-	screen1.StartTypingChallenge("a", 10*time.Second)
+	screen1.session.StartTypingChallenge(0, "a", 10*time.Second)
 	initialHP1 := enemy1.HP
-	screen1.ProcessTypingInput('a')
+	screen1.session.ProcessTypingInput('a')
 	baseDamage := initialHP1 - enemy1.HP
 
 	// 次に、DoubleCast100%でのダメージを計測
@@ -1434,12 +1655,14 @@ func TestDoubleCast_DoublesDamageEffect(t *testing.T) {
 	player2.EffectTable.AddBuff("ダブルキャスト", 10.0, map[domain.EffectColumn]float64{
 		domain.ColDoubleCast: 1.0, // 100%
 	})
+	player2.EffectTable.AddBuff("クリ無効", 10.0, map[domain.EffectColumn]float64{
+		domain.ColCritRate: -10.0,
+	})
 
-	screen2.selectedModuleIdx = 0
 	screen2.selectedSlot = 0
-	screen2.StartTypingChallenge("a", 10*time.Second)
+	screen2.session.StartTypingChallenge(0, "a", 10*time.Second)
 	initialHP2 := enemy2.HP
-	screen2.ProcessTypingInput('a')
+	screen2.session.ProcessTypingInput('a')
 	doubleDamage := initialHP2 - enemy2.HP
 
 	// DoubleCastにより2倍のダメージが与えられているはず
@@ -1466,11 +1689,10 @@ func TestDoubleCast_ZeroProbability(t *testing.T) {
 	// バフを追加しない
 
 	// タイピングチャレンジを完了
-	screen.selectedModuleIdx = 0
 	screen.selectedSlot = 0
-	screen.StartTypingChallenge("a", 10*time.Second)
+	screen.session.StartTypingChallenge(0, "a", 10*time.Second)
 	initialEnemyHP := enemy.HP
-	screen.ProcessTypingInput('a')
+	screen.session.ProcessTypingInput('a')
 
 	// 通常の1回分ダメージのみ
 	damageDone := initialEnemyHP - enemy.HP
@@ -1504,10 +1726,9 @@ func TestOverheal_ConvertExcessToTempHP(t *testing.T) {
 	player.EffectTable.Entries = append(player.EffectTable.Entries, entry)
 
 	// 回復モジュールを使用
-	screen.selectedModuleIdx = 2 // 回復モジュール
 	screen.selectedSlot = 2
-	screen.StartTypingChallenge("a", 10*time.Second)
-	screen.ProcessTypingInput('a')
+	screen.session.StartTypingChallenge(2, "a", 10*time.Second)
+	screen.session.ProcessTypingInput('a')
 
 	// Overhealにより超過分がTempHPに変換されているはず
 	if player.TempHP == 0 {
@@ -1579,27 +1800,27 @@ func TestAutoCorrect_IgnoresMistakes(t *testing.T) {
 	})
 
 	// タイピングチャレンジを開始
-	screen.StartTypingChallenge("abc", 10*time.Second)
+	screen.session.StartTypingChallenge(0, "abc", 10*time.Second)
 
 	// 1回目のミス（無視される）
-	screen.ProcessTypingInput('x')
-	if len(screen.typingMistakes) != 0 {
-		t.Errorf("AutoCorrectでミスが無視されていない（1回目）: got %d mistakes", len(screen.typingMistakes))
+	screen.session.ProcessTypingInput('x')
+	if len(screen.session.TypingMistakes()) != 0 {
+		t.Errorf("AutoCorrectでミスが無視されていない（1回目）: got %d mistakes", len(screen.session.TypingMistakes()))
 	}
-	if screen.typingIndex != 0 {
-		t.Errorf("ミス無視後にインデックスが進んでいる: got %d", screen.typingIndex)
+	if screen.session.TypingIndex() != 0 {
+		t.Errorf("ミス無視後にインデックスが進んでいる: got %d", screen.session.TypingIndex())
 	}
 
 	// 2回目のミス（無視される）
-	screen.ProcessTypingInput('y')
-	if len(screen.typingMistakes) != 0 {
-		t.Errorf("AutoCorrectでミスが無視されていない（2回目）: got %d mistakes", len(screen.typingMistakes))
+	screen.session.ProcessTypingInput('y')
+	if len(screen.session.TypingMistakes()) != 0 {
+		t.Errorf("AutoCorrectでミスが無視されていない（2回目）: got %d mistakes", len(screen.session.TypingMistakes()))
 	}
 
 	// 3回目のミス（AutoCorrect消費済みなので記録される）
-	screen.ProcessTypingInput('z')
-	if len(screen.typingMistakes) != 1 {
-		t.Errorf("AutoCorrect消費後にミスが記録されていない: got %d mistakes, want 1", len(screen.typingMistakes))
+	screen.session.ProcessTypingInput('z')
+	if len(screen.session.TypingMistakes()) != 1 {
+		t.Errorf("AutoCorrect消費後にミスが記録されていない: got %d mistakes, want 1", len(screen.session.TypingMistakes()))
 	}
 }
 
@@ -1636,3 +1857,92 @@ func createTestAgentsWithChainEffectMultiple() []*domain.AgentModel {
 
 	return []*domain.AgentModel{agent1, agent2}
 }
+
+// ==================== 複数敵バトルのテスト ====================
+
+// newEncounterTestBattle は1ウェーブ目に2体、2ウェーブ目に1体の敵が出現するバトル画面を作成します。
+func newEncounterTestBattle() *BattleScreen {
+	waves := [][]*domain.EnemyModel{
+		{createTestEnemy(), createTestEnemy()},
+		{createTestEnemy()},
+	}
+	waves[0][1].ID = "enemy2"
+	waves[1][0].ID = "enemy3"
+	screen := NewEncounterBattleScreen(waves, createTestPlayer(), createTestAgents(), nil)
+	screen.width = 140
+	screen.height = 40
+	return screen
+}
+
+// TestBattleScreenEncounterTargetSwitch は[ ]キーで攻撃対象を切り替えられることをテストします。
+func TestBattleScreenEncounterTargetSwitch(t *testing.T) {
+	screen := newEncounterTestBattle()
+	enemies := screen.session.Enemies()
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if screen.enemy != enemies[1] || screen.enemyHPBar != screen.enemyHPBarFor(enemies[1]) {
+		t.Error("]キーで次の敵が攻撃対象になっていません")
+	}
+
+	_, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	if screen.enemy != enemies[0] {
+		t.Error("[キーで前の敵が攻撃対象になっていません")
+	}
+
+	rendered := screen.View()
+	if !strings.Contains(rendered, "WAVE 1/2") || !strings.Contains(rendered, "▶") {
+		t.Error("複数敵バトルのウェーブ表示・攻撃対象マーカーがありません")
+	}
+}
+
+// TestBattleScreenEncounterResult は複数敵バトルの結果に出現した敵と最高レベルが含まれることをテストします。
+func TestBattleScreenEncounterResult(t *testing.T) {
+	screen := newEncounterTestBattle()
+
+	for wave := 0; wave < 2; wave++ {
+		for _, enemy := range screen.session.Enemies() {
+			enemy.HP = 0
+		}
+		_, _ = screen.Update(BattleTickMsg{})
+	}
+	if !screen.IsVictory() {
+		t.Fatal("全ウェーブの敵を倒しても勝利になっていません")
+	}
+	if screen.enemy != screen.session.Enemies()[0] {
+		t.Error("表示中の敵が最終ウェーブの敵に切り替わっていません")
+	}
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	result, ok := cmd().(BattleResultMsg)
+	if !ok {
+		t.Fatal("BattleResultMsgが返されていません")
+	}
+	if result.Level != 5 || result.EncounteredEnemies["test_enemy"] != 5 {
+		t.Errorf("バトル結果: Level=%d, EncounteredEnemies=%v", result.Level, result.EncounteredEnemies)
+	}
+}
+
+// TestBattleScreenEncounterResultLeader は複数敵バトルの結果のリーダーが最後のウェーブの先頭の敵で、そのレベルが記録されることをテストします。
+func TestBattleScreenEncounterResultLeader(t *testing.T) {
+	boss := createTestEnemy()
+	boss.ID = "boss"
+	boss.Level = 3
+	boss.Type.ID = "boss_type"
+	waves := [][]*domain.EnemyModel{
+		{createTestEnemy()},
+		{boss, createTestEnemy()},
+	}
+	screen := NewEncounterBattleScreen(waves, createTestPlayer(), createTestAgents(), nil)
+
+	// 1ウェーブ目で終了した場合もリーダーは最後のウェーブの先頭の敵
+	result, ok := screen.createGameOverCmd()().(BattleResultMsg)
+	if !ok {
+		t.Fatal("BattleResultMsgが返されていません")
+	}
+	if result.EnemyID != "boss_type" || result.EnemyLevel != 3 {
+		t.Errorf("リーダー: 期待 boss_type Lv.3, 実際 %s Lv.%d", result.EnemyID, result.EnemyLevel)
+	}
+	if result.Level != 5 {
+		t.Errorf("バトルのレベルは出現する敵の最高レベル: 期待 5, 実際 %d", result.Level)
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"strings"
 	"time"
 
+	"hirorocky/type-battle/internal/config"
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/tui/components"
 	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/combat/chain"
 	"hirorocky/type-battle/internal/usecase/combat/recast"
+	"hirorocky/type-battle/internal/usecase/typing"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -32,7 +36,13 @@ func (s *BattleScreen) View() string {
 		// 結果表示（WIN/LOSE ASCIIアート）
 		resultArea := s.renderResultArea()
 		builder.WriteString(resultArea)
-	} else if s.isTyping {
+	} else if s.IsConfirmingRetreat() {
+		// 撤退の確認ダイアログ（表示中はバトルを一時停止している）
+		builder.WriteString(s.retreatDialog.Render(s.width, s.height))
+	} else if s.IsPaused() {
+		// 一時停止中はタイピングテキストを隠してオーバーレイを表示
+		builder.WriteString(s.renderPauseOverlay())
+	} else if s.session.IsTyping() {
 		// タイピングチャレンジ
 		typingArea := s.renderTypingArea()
 		builder.WriteString(typingArea)
@@ -43,18 +53,36 @@ func (s *BattleScreen) View() string {
 	}
 	builder.WriteString("\n")
 
+	// チェインの経過（チェイン中のみ）
+	if track := s.renderChainTrack(); track != "" {
+		builder.WriteString(track)
+		builder.WriteString("\n")
+	}
+
 	// 下部: プレイヤー情報エリア
 	playerArea := s.renderPlayerArea()
 	builder.WriteString(playerArea)
 	builder.WriteString("\n")
 
 	// メッセージ
-	if s.message != "" {
+	if message := s.session.Message(); message != "" {
 		msgStyle := lipgloss.NewStyle().
 			Foreground(styles.ColorInfo).
 			Align(lipgloss.Center).
 			Width(s.width)
-		builder.WriteString(msgStyle.Render(s.message))
+		builder.WriteString(msgStyle.Render(message))
+		builder.WriteString("\n")
+	}
+
+	// 計算内訳
+	if s.showInspect {
+		builder.WriteString(s.renderInspectOverlay())
+		builder.WriteString("\n")
+	}
+
+	// バトルログパネル
+	if s.showLog {
+		builder.WriteString(s.renderBattleLogPanel())
 		builder.WriteString("\n")
 	}
 
@@ -65,23 +93,67 @@ func (s *BattleScreen) View() string {
 		Width(s.width)
 
 	var hint string
-	if s.showingResult {
+	if s.replay != nil {
+		hint = s.replayHint()
+	} else if s.showingResult {
 		hint = "Enter: 続ける"
-	} else if s.isTyping {
-		hint = "タイピング中...  Esc: キャンセル"
+	} else if s.IsConfirmingRetreat() {
+		hint = "←/→: 選択  Enter: 決定  Esc: バトルに戻る"
+	} else if s.IsPaused() {
+		hint = "Tab: 再開"
+	} else if s.session.IsParrying() {
+		hint = "パリィ中...  Tab: 一時停止  Esc: 諦める"
+	} else {
+		if s.session.IsTyping() {
+			hint = "タイピング中...  Tab: 一時停止  Esc: キャンセル"
+		} else {
+			hint = "←/→: エージェント切替  ↑/↓: モジュール選択  Enter: 使用  Tab: 一時停止  Esc: 撤退"
+			if s.session.IsMultiEnemy() {
+				hint = "[/]: 攻撃対象切替  " + hint
+			}
+		}
+		if s.session.ParryableEnemy() != nil {
+			hint = "Space: パリィ！  " + hint
+		}
+	}
+	if s.showLog {
+		hint += "  PgUp/PgDn: ログスクロール  Ctrl+E: ログ書き出し  Ctrl+L: ログを閉じる"
+	} else {
+		hint += "  Ctrl+L: 戦闘ログ"
+	}
+	if s.showInspect {
+		hint += "  Ctrl+T: 内訳を閉じる"
 	} else {
-		hint = "←/→: エージェント切替  ↑/↓: モジュール選択  Enter: 使用  Esc: 中断"
+		hint += "  Ctrl+T: 計算内訳"
 	}
 	builder.WriteString(hintStyle.Render(hint))
 
 	return builder.String()
 }
 
+// replayHint はリプレイ再生中のヒント（再生状況と操作）を返します。
+func (s *BattleScreen) replayHint() string {
+	status := fmt.Sprintf("▶ REPLAY %dx", s.replaySpeed)
+	if s.replayPaused {
+		status = "⏸ REPLAY 一時停止中"
+	}
+	progress := fmt.Sprintf("%.1f / %.1f秒", s.replay.Elapsed().Seconds(), s.replay.Replay().Duration.Seconds())
+	if s.showingResult {
+		return fmt.Sprintf("%s  %s  Enter: 一覧に戻る", status, progress)
+	}
+	return fmt.Sprintf("%s  %s  1/2/4: 再生速度  Tab: 一時停止  Backspace: 一覧に戻る", status, progress)
+}
+
 // ==================== エリアレンダリング ====================
 
 // renderEnemyArea は敵情報エリアをレンダリングします。
 // UI-Improvement Requirement 3.1: 敵情報エリア
 func (s *BattleScreen) renderEnemyArea() string {
+	// 複数敵バトルは敵ごとのカードを横並びで表示
+	if s.session.IsMultiEnemy() {
+		return s.renderMultiEnemyArea()
+	}
+
 	var builder strings.Builder
 
 	// ボックス内の利用可能幅（ボックス幅 - パディング左右）
@@ -91,6 +163,9 @@ func (s *BattleScreen) renderEnemyArea() string {
 	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorDamage)
 	leftContent := nameStyle.Render(s.enemy.Name) + fmt.Sprintf(" Lv.%d", s.enemy.Level)
 	rightContent := s.styles.RenderVoltage(s.enemy.GetVoltage())
+	if threshold := s.enemy.CurrentVoltageThreshold(); threshold != nil {
+		rightContent = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorWarning).Render("["+threshold.Name+"] ") + rightContent
+	}
 
 	// 左側を左揃え、右側を右揃えで配置
 	leftStyle := lipgloss.NewStyle().Width(contentWidth - lipgloss.Width(rightContent)).Align(lipgloss.Left)
@@ -98,21 +173,19 @@ func (s *BattleScreen) renderEnemyArea() string {
 	builder.WriteString(firstLine)
 	builder.WriteString("\n")
 
-	// パッシブスキル表示（フェーズに応じて通常または強化パッシブを表示）
+	// フェーズ固有の外観（多段階フェーズの敵のみ）
+	if art := s.enemy.GetPhaseDef().ASCIIArt; art != "" {
+		artStyle := lipgloss.NewStyle().Foreground(styles.ColorDamage).Width(contentWidth).Align(lipgloss.Center)
+		builder.WriteString(artStyle.Render(art))
+		builder.WriteString("\n")
+	}
+
+	// フェーズ表示と現在のフェーズのパッシブスキル
 	passiveStyle := lipgloss.NewStyle().Foreground(styles.ColorBuff)
-	if s.enemy.IsEnhanced() {
-		phaseStyle := lipgloss.NewStyle().Foreground(styles.ColorDamage).Bold(true)
-		builder.WriteString(phaseStyle.Render("[強化フェーズ]"))
-		// 強化パッシブを表示
-		if s.enemy.Type.EnhancedPassive != nil {
-			builder.WriteString("  ")
-			builder.WriteString(passiveStyle.Render("★" + s.enemy.Type.EnhancedPassive.Description))
-		}
-	} else {
-		// 通常パッシブを表示
-		if s.enemy.Type.NormalPassive != nil {
-			builder.WriteString(passiveStyle.Render("★" + s.enemy.Type.NormalPassive.Description))
-		}
+	builder.WriteString(s.renderPhaseIndicator(s.enemy))
+	if passive := s.enemy.GetCurrentPassive(); passive != nil {
+		builder.WriteString("  ")
+		builder.WriteString(passiveStyle.Render("★" + passive.Description))
 	}
 	builder.WriteString("\n")
 
@@ -125,19 +198,7 @@ func (s *BattleScreen) renderEnemyArea() string {
 	builder.WriteString(hpValue)
 
 	// フローティングダメージ表示
-	floatingTexts := s.floatingDamageManager.GetTextsForArea("enemy")
-	if len(floatingTexts) > 0 {
-		// 最新のフローティングテキストを表示
-		text := floatingTexts[0]
-		var floatStyle lipgloss.Style
-		if text.IsHealing {
-			floatStyle = lipgloss.NewStyle().Foreground(styles.ColorHPHigh).Bold(true)
-		} else {
-			floatStyle = lipgloss.NewStyle().Foreground(styles.ColorDamage).Bold(true)
-		}
-		builder.WriteString("  ")
-		builder.WriteString(floatStyle.Render(text.Text))
-	}
+	builder.WriteString(s.renderEnemyFloatingText())
 	builder.WriteString("\n")
 
 	// 敵のバフ表示
@@ -152,37 +213,195 @@ func (s *BattleScreen) renderEnemyArea() string {
 		builder.WriteString("\n")
 	}
 
+	// 敵の状態異常表示
+	if ailments := s.renderAilments(s.enemy.EffectTable.GetAilments()); ailments != "" {
+		builder.WriteString(ailments)
+		builder.WriteString("\n")
+	}
+
 	// チャージ後行動
-	icon, actionText, actionColor := s.getActionDisplay()
+	icon, actionText, actionColor := s.getActionDisplay(s.enemy)
 	actionStyle := lipgloss.NewStyle().Foreground(actionColor).Bold(true)
 	builder.WriteString(actionStyle.Render(fmt.Sprintf("%s %s", icon, actionText)))
+	builder.WriteString(s.renderParryIndicator(s.enemy))
 	builder.WriteString("\n")
 
-	// 待機状態のプログレスバー（チャージ中/ディフェンス中で計算を分岐）
-	now := time.Now()
-	var remaining time.Duration
-	var ratio float64
-	var barColor lipgloss.Color
+	// 待機状態のプログレスバー
+	remaining, ratio, barColor := s.enemyWaitProgress(s.enemy)
+	builder.WriteString(s.renderEnemyActionBar(remaining.Seconds(), ratio, barColor))
+
+	// エリアボックス
+	areaStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorDamage).
+		Padding(1, 2).
+		Width(s.width - 4)
+
+	title := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtle).
+		Render("─────────────────────────────────  ENEMY  ─────────────────────────────────")
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		areaStyle.Render(builder.String()),
+	)
+}
+
+// renderMultiEnemyArea は複数敵バトルの敵情報エリア（敵ごとのカード横並び）をレンダリングします。
+// 選択中の攻撃対象には▶を付け、撃破済みの敵は灰色で表示します。
+func (s *BattleScreen) renderMultiEnemyArea() string {
+	enemies := s.session.Enemies()
+	cardWidth := (s.width - 4) / len(enemies)
+	if cardWidth < 30 {
+		cardWidth = 30
+	}
+
+	cards := make([]string, 0, len(enemies))
+	for i, enemy := range enemies {
+		cards = append(cards, s.renderEnemyCard(enemy, i == s.session.TargetIndex(), cardWidth))
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtle).
+		Render(fmt.Sprintf("─────────────────────────────  ENEMY  WAVE %d/%d  ─────────────────────────────", s.session.Wave()+1, s.session.WaveCount()))
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		lipgloss.JoinHorizontal(lipgloss.Top, cards...),
+	)
+}
+
+// renderEnemyCard は複数敵バトルの敵1体分のカードをレンダリングします。
+func (s *BattleScreen) renderEnemyCard(enemy *domain.EnemyModel, targeted bool, width int) string {
+	var builder strings.Builder
+	barWidth := width - 16
+
+	// 1行目: 攻撃対象マーカーと敵名
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorDamage)
+	marker := "  "
+	if targeted {
+		marker = "▶ "
+	}
+	builder.WriteString(marker + nameStyle.Render(enemy.Name) + fmt.Sprintf(" Lv.%d", enemy.Level))
+	builder.WriteString(" ")
+	builder.WriteString(s.renderPhasePips(enemy))
+	builder.WriteString("\n")
+
+	// 撃破済みの敵はHPのみ表示
+	if !enemy.IsAlive() {
+		builder.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("撃破"))
+		return s.enemyCardStyle(styles.ColorSubtle, width).Render(builder.String())
+	}
+
+	// HP表示
+	bar := s.enemyHPBarFor(enemy)
+	builder.WriteString("HP: ")
+	builder.WriteString(bar.Render(s.styles, barWidth))
+	builder.WriteString(fmt.Sprintf(" %d/%d", bar.GetCurrentHP(), enemy.MaxHP))
+	if targeted {
+		// ダメージは攻撃対象のカードにまとめて表示（全体攻撃は合計値）
+		builder.WriteString(s.renderEnemyFloatingText())
+	}
+	builder.WriteString("\n")
+
+	// 敵のバフ・状態異常表示
+	for _, buff := range enemy.EffectTable.FindBySourceType(domain.SourceBuff) {
+		if buff.Duration != nil {
+			builder.WriteString(s.styles.RenderBuff(buff.Name, *buff.Duration))
+			builder.WriteString(" ")
+		}
+	}
+	builder.WriteString(s.renderAilments(enemy.EffectTable.GetAilments()))
+	builder.WriteString("\n")
+
+	// チャージ後行動とプログレスバー
+	icon, actionText, actionColor := s.getActionDisplay(enemy)
+	actionStyle := lipgloss.NewStyle().Foreground(actionColor).Bold(true)
+	builder.WriteString(actionStyle.Render(fmt.Sprintf("%s %s", icon, actionText)))
+	builder.WriteString(s.renderParryIndicator(enemy))
+	builder.WriteString("\n")
+	remaining, ratio, barColor := s.enemyWaitProgress(enemy)
+	builder.WriteString(s.renderProgressBar(remaining.Seconds(), ratio, barColor, barWidth))
+
+	borderColor := styles.ColorSubtle
+	if targeted {
+		borderColor = styles.ColorDamage
+	}
+	return s.enemyCardStyle(borderColor, width).Render(builder.String())
+}
+
+// renderPhaseIndicator は敵のフェーズインジケーター（到達済みフェーズの印と現在のフェーズ名）をレンダリングします。
+func (s *BattleScreen) renderPhaseIndicator(enemy *domain.EnemyModel) string {
+	nameStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	if enemy.IsEnhanced() {
+		nameStyle = lipgloss.NewStyle().Foreground(styles.ColorDamage).Bold(true)
+	}
+	return s.renderPhasePips(enemy) + " " + nameStyle.Render(fmt.Sprintf("[%sフェーズ]", enemy.GetPhaseString()))
+}
+
+// renderPhasePips はフェーズ数分の印（到達済み◆、未到達◇）をレンダリングします。
+func (s *BattleScreen) renderPhasePips(enemy *domain.EnemyModel) string {
+	count := enemy.Type.GetPhaseCount()
+	reached := int(enemy.Phase) + 1
+	if reached > count {
+		reached = count
+	}
+	reachedStyle := lipgloss.NewStyle().Foreground(styles.ColorDamage)
+	pendingStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	return reachedStyle.Render(strings.Repeat("◆", reached)) + pendingStyle.Render(strings.Repeat("◇", count-reached))
+}
 
-	switch s.enemy.WaitMode {
+// renderEnemyFloatingText は敵エリアの最新のフローティングテキスト（ダメージ・回復）を返します。
+func (s *BattleScreen) renderEnemyFloatingText() string {
+	floatingTexts := s.floatingDamageManager.GetTextsForArea("enemy")
+	if len(floatingTexts) == 0 {
+		return ""
+	}
+
+	// 最新のフローティングテキストを表示
+	text := floatingTexts[0]
+	var floatStyle lipgloss.Style
+	if text.IsHealing {
+		floatStyle = lipgloss.NewStyle().Foreground(styles.ColorHPHigh).Bold(true)
+	} else if text.IsCritical {
+		floatStyle = lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true).Underline(true)
+	} else {
+		floatStyle = lipgloss.NewStyle().Foreground(styles.ColorDamage).Bold(true)
+	}
+	return "  " + floatStyle.Render(text.Text)
+}
+
+// enemyCardStyle は複数敵バトルの敵カードの枠スタイルを返します。
+func (s *BattleScreen) enemyCardStyle(borderColor lipgloss.Color, width int) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(width - 2)
+}
+
+// enemyWaitProgress は敵の待機状態（チャージ中/ディフェンス中）の残り時間・残り割合・バーの色を返します。
+func (s *BattleScreen) enemyWaitProgress(enemy *domain.EnemyModel) (remaining time.Duration, ratio float64, barColor lipgloss.Color) {
+	now := s.session.Now()
+
+	switch enemy.WaitMode {
 	case domain.WaitModeCharging:
-		remaining = s.enemy.GetChargeRemainingTime(now)
-		ratio = 1.0 - s.enemy.GetChargeProgress(now)
+		remaining = enemy.GetChargeRemainingTime(now)
+		ratio = 1.0 - enemy.GetChargeProgress(now)
 		barColor = styles.ColorSubtle
+		if enemy.IsInParryWindow(now) {
+			barColor = styles.ColorWarning // パリィ受付中は黄色
+		}
 	case domain.WaitModeDefending:
-		remaining = s.enemy.GetDefenseRemainingTime(now)
+		remaining = enemy.GetDefenseRemainingTime(now)
 		// ディフェンス進捗を計算
-		if s.enemy.DefenseDuration > 0 {
-			elapsed := now.Sub(s.enemy.DefenseStartTime)
-			progress := float64(elapsed) / float64(s.enemy.DefenseDuration)
+		if enemy.DefenseDuration > 0 {
+			elapsed := now.Sub(enemy.DefenseStartTime)
+			progress := float64(elapsed) / float64(enemy.DefenseDuration)
 			ratio = 1.0 - progress
-		} else {
-			ratio = 0
 		}
 		barColor = styles.ColorInfo // ディフェンス中は青
 	default:
-		remaining = 0
-		ratio = 0
 		barColor = styles.ColorSubtle
 	}
 
@@ -195,23 +414,28 @@ func (s *BattleScreen) renderEnemyArea() string {
 	if ratio > 1 {
 		ratio = 1
 	}
-	builder.WriteString(s.renderEnemyActionBar(remaining.Seconds(), ratio, barColor))
-
-	// エリアボックス
-	areaStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(styles.ColorDamage).
-		Padding(1, 2).
-		Width(s.width - 4)
-
-	title := lipgloss.NewStyle().
-		Foreground(styles.ColorSubtle).
-		Render("─────────────────────────────────  ENEMY  ─────────────────────────────────")
+	return remaining, ratio, barColor
+}
 
-	return lipgloss.JoinVertical(lipgloss.Center,
-		title,
-		areaStyle.Render(builder.String()),
-	)
+// renderParryIndicator は敵のチャージ中の行動に対するパリィの受付状況・結果を返します。
+func (s *BattleScreen) renderParryIndicator(enemy *domain.EnemyModel) string {
+	var text string
+	var color lipgloss.Color
+	switch {
+	case enemy == s.session.ParryTarget():
+		text, color = "🛡パリィ中", styles.ColorWarning
+	case enemy.IsInParryWindow(s.session.Now()):
+		text, color = "🛡パリィ可能[Space]", styles.ColorWarning
+	case enemy.Parry == domain.ParryPerfect:
+		text, color = "🛡完全パリィ", styles.ColorHeal
+	case enemy.Parry == domain.ParryPartial:
+		text, color = "🛡パリィ", styles.ColorInfo
+	case enemy.Parry == domain.ParryFailed:
+		text, color = "💢パリィ失敗", styles.ColorDamage
+	default:
+		return ""
+	}
+	return "  " + lipgloss.NewStyle().Foreground(color).Bold(true).Render(text)
 }
 
 // renderAgentArea はエージェントエリア（3体横並びカード）をレンダリングします。
@@ -235,7 +459,7 @@ func (s *BattleScreen) renderAgentArea() string {
 		// リキャスト状態を取得（枠色判定にも使用）
 		var recastState *recast.RecastState
 		if i < len(s.equippedAgents) {
-			recastState = s.recastManager.GetRecastState(i)
+			recastState = s.session.RecastManager().GetRecastState(i)
 		}
 
 		if i < len(s.equippedAgents) {
@@ -262,6 +486,13 @@ func (s *BattleScreen) renderAgentArea() string {
 				cardContent.WriteString("\n")
 			}
 
+			// スタン・沈黙表示
+			locked := s.session.IsAgentLocked(i)
+			if ailments := s.renderAilments(s.agentAilments(i)); ailments != "" {
+				cardContent.WriteString(ailments)
+				cardContent.WriteString("\n")
+			}
+
 			// リキャスト状態表示
 			if recastState != nil {
 				recastBar := components.NewRecastProgressBar()
@@ -273,7 +504,7 @@ func (s *BattleScreen) renderAgentArea() string {
 
 			// エージェントのモジュール一覧（2行表示）
 			// 待機中チェイン効果を取得（発動中の強調表示判定用）
-			pendingChain := s.chainEffectManager.GetPendingEffectForAgent(i)
+			pendingChain := s.session.ChainEffectManager().GetPendingEffectForAgent(i)
 
 			agentModules := s.getModulesForAgent(i)
 			for j, slot := range agentModules {
@@ -289,8 +520,8 @@ func (s *BattleScreen) renderAgentArea() string {
 						Bold(true).
 						Foreground(styles.ColorSelectedFg).
 						Background(styles.ColorSelectedBg)
-				} else if !slot.IsReady() || recastState != nil {
-					// クールダウン中またはリキャスト中は淡い色
+				} else if !slot.IsReady() || recastState != nil || locked {
+					// クールダウン中・リキャスト中・スタン/沈黙中は淡い色
 					moduleStyle = lipgloss.NewStyle().Foreground(styles.ColorSubtle)
 				} else {
 					moduleStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
@@ -369,6 +600,76 @@ func (s *BattleScreen) renderAgentArea() string {
 	)
 }
 
+// renderAilments は状態異常をアイコン付きで残り時間とともにレンダリングします。
+func (s *BattleScreen) renderAilments(ailments []domain.EffectEntry) string {
+	var builder strings.Builder
+	for _, ailment := range ailments {
+		if ailment.Duration == nil {
+			continue
+		}
+		col := ailment.AilmentColumn()
+		builder.WriteString(s.styles.RenderDebuff(domain.AilmentIcon(col)+ailment.Name, *ailment.Duration))
+		builder.WriteString(" ")
+	}
+	return builder.String()
+}
+
+// agentAilments は指定エージェントに付与されたスタン・沈黙を返します。
+func (s *BattleScreen) agentAilments(agentIndex int) []domain.EffectEntry {
+	var result []domain.EffectEntry
+	for _, ailment := range s.player.EffectTable.GetAilments() {
+		if ailment.SourceIndex == agentIndex {
+			result = append(result, ailment)
+		}
+	}
+	return result
+}
+
+// chainTrackMaxLinks はチェインの経過に表示する直近のエージェント数です。
+const chainTrackMaxLinks = 5
+
+// renderChainTrack は現在のチェインでモジュールを使用したエージェントの順番と、
+// チェインが途切れるまでの残り時間をレンダリングします。チェインがない場合は空文字列を返します。
+func (s *BattleScreen) renderChainTrack() string {
+	manager := s.session.ChainEffectManager()
+	track := manager.ChainTrack()
+	if len(track) == 0 || s.showingResult {
+		return ""
+	}
+
+	linkStyle := lipgloss.NewStyle().Foreground(styles.ColorBuff).Bold(true)
+	links := make([]string, 0, chainTrackMaxLinks+1)
+	start := 0
+	if len(track) > chainTrackMaxLinks {
+		start = len(track) - chainTrackMaxLinks
+		links = append(links, "…")
+	}
+	for i := start; i < len(track); i++ {
+		name := fmt.Sprintf("エージェント%d", track[i]+1)
+		if track[i] < len(s.equippedAgents) {
+			name = s.equippedAgents[track[i]].GetCoreTypeName()
+		}
+		links = append(links, linkStyle.Render(fmt.Sprintf("%d.%s", i+1, name)))
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorWarning).Render(fmt.Sprintf("⛓ %dチェイン", len(track)))
+	if multiplier := chain.ChainBonusMultiplier(len(track)); multiplier > 1.0 {
+		header += lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(fmt.Sprintf(" ×%.2f", multiplier))
+	}
+
+	// 残り時間のゲージ
+	remaining := manager.ChainTimeRemaining()
+	filled := int(remaining / config.ChainWindowSeconds * 10)
+	if filled < 0 {
+		filled = 0
+	}
+	gauge := lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render(
+		fmt.Sprintf("%s%s %.1fs", strings.Repeat("▮", filled), strings.Repeat("▯", 10-filled), remaining))
+
+	return lipgloss.NewStyle().Width(s.width).Align(lipgloss.Center).Render(
+		header + "  " + strings.Join(links, " → ") + "  " + gauge)
+}
+
 // renderPlayerArea はプレイヤー情報エリアをレンダリングします。
 // UI-Improvement Requirement 3.1: プレイヤー情報エリア
 func (s *BattleScreen) renderPlayerArea() string {
@@ -395,6 +696,8 @@ func (s *BattleScreen) renderPlayerArea() string {
 		var floatStyle lipgloss.Style
 		if text.IsHealing {
 			floatStyle = lipgloss.NewStyle().Foreground(styles.ColorHPHigh).Bold(true)
+		} else if text.IsCritical {
+			floatStyle = lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true).Underline(true)
 		} else {
 			floatStyle = lipgloss.NewStyle().Foreground(styles.ColorDamage).Bold(true)
 		}
@@ -429,6 +732,13 @@ func (s *BattleScreen) renderPlayerArea() string {
 		builder.WriteString("\n")
 	}
 
+	// 状態異常表示
+	if ailments := s.renderAilments(s.player.EffectTable.GetAilments()); ailments != "" {
+		builder.WriteString("状態異常: ")
+		builder.WriteString(ailments)
+		builder.WriteString("\n")
+	}
+
 	// パッシブスキル表示（スタック型パッシブのダメージ倍率）
 	passives := s.player.EffectTable.FindBySourceType(domain.SourcePassive)
 	hasStackPassive := false
@@ -439,7 +749,7 @@ func (s *BattleScreen) renderPlayerArea() string {
 				hasStackPassive = true
 			}
 			// スタック数を計算（コンボ数を使用、最大スタックでキャップ）
-			stacks := s.comboCount
+			stacks := s.session.ComboCount()
 			if stacks > passive.MaxStacks {
 				stacks = passive.MaxStacks
 			}
@@ -467,8 +777,11 @@ func (s *BattleScreen) renderPlayerArea() string {
 // UI-Improvement Requirement 3.9: WIN/LOSE ASCIIアート表示
 func (s *BattleScreen) renderResultArea() string {
 	var resultArt string
-	if s.victory {
+	if s.session.IsVictory() {
 		resultArt = s.winLoseRenderer.RenderWin()
+	} else if s.session.IsRetreated() {
+		// 撤退（リプレイ再生で表示される）
+		resultArt = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorWarning).Render("― 撤退 ―")
 	} else {
 		resultArt = s.winLoseRenderer.RenderLose()
 	}
@@ -489,6 +802,39 @@ func (s *BattleScreen) renderResultArea() string {
 	return areaStyle.Render(centeredArt)
 }
 
+// renderPauseOverlay は一時停止中のオーバーレイを描画します。
+func (s *BattleScreen) renderPauseOverlay() string {
+	resumeHint := "Tabキーで再開します"
+	if s.replay != nil {
+		resumeHint = "記録中に一時停止されていた区間です"
+	}
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorWarning).
+		Bold(true)
+	subtleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtle)
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		titleStyle.Render("⏸ PAUSED"),
+		"",
+		"一時停止中",
+		subtleStyle.Render(resumeHint),
+	)
+
+	centered := lipgloss.NewStyle().
+		Width(s.width - 8).
+		Align(lipgloss.Center).
+		Render(content)
+
+	areaStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorWarning).
+		Padding(2, 2).
+		Width(s.width - 4)
+
+	return areaStyle.Render(centered)
+}
+
 // renderTypingArea はタイピングエリアを描画します。
 
 // UI改善: 残り時間をプログレスバー形式で表示
@@ -496,19 +842,29 @@ func (s *BattleScreen) renderTypingArea() string {
 	var builder strings.Builder
 
 	// 制限時間計算
-	elapsed := time.Since(s.typingStartTime)
-	remaining := s.typingTimeLimit - elapsed
-	if remaining < 0 {
-		remaining = 0
-	}
+	remaining := s.session.TypingRemaining()
 
 	// UI改善: 残り時間プログレスバー（バー内に秒数表示）
-	timeRatio := remaining.Seconds() / s.typingTimeLimit.Seconds()
+	timeRatio := remaining.Seconds() / s.session.TypingTimeLimit().Seconds()
 	builder.WriteString(s.renderTimeProgressBar(remaining.Seconds(), timeRatio))
 	builder.WriteString("\n\n")
 
+	// パリィ対象の表示
+	if enemy := s.session.ParryTarget(); enemy != nil && enemy.PendingAction != nil {
+		parryStyle := lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true)
+		builder.WriteString(parryStyle.Render(fmt.Sprintf("🛡 パリィ: %sの%s", enemy.Name, enemy.PendingAction.Name)))
+		builder.WriteString("\n")
+	}
+
+	// 妨害の表示
+	if labels := disruptionLabels(s.session.TypingDisruption()); len(labels) > 0 {
+		disruptionStyle := lipgloss.NewStyle().Foreground(styles.ColorDebuff).Bold(true)
+		builder.WriteString(disruptionStyle.Render("妨害: " + strings.Join(labels, " ")))
+		builder.WriteString("\n")
+	}
+
 	// タイピングテキスト
-	typingDisplay := s.styles.RenderTypingChallenge(s.typingText, s.typingIndex, s.typingMistakes)
+	typingDisplay := s.renderTypingText()
 
 	typingBox := lipgloss.NewStyle().
 		Border(lipgloss.DoubleBorder()).
@@ -520,8 +876,13 @@ func (s *BattleScreen) renderTypingArea() string {
 	builder.WriteString("\n\n")
 
 	// 進捗表示
-	progress := float64(s.typingIndex) / float64(len(s.typingText)) * 100
-	progressStr := fmt.Sprintf("進捗: %d/%d (%.0f%%)", s.typingIndex, len(s.typingText), progress)
+	length := s.session.TypingLength()
+	index := s.session.TypingIndex()
+	progress := 0.0
+	if length > 0 {
+		progress = float64(index) / float64(length) * 100
+	}
+	progressStr := fmt.Sprintf("進捗: %d/%d (%.0f%%)", index, length, progress)
 	progressStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorSubtle)
 
@@ -533,6 +894,68 @@ func (s *BattleScreen) renderTypingArea() string {
 		Render(builder.String())
 }
 
+// renderTypingText はチャレンジテキストを入力方式に応じて描画します。
+// ローマ字モードでは表示テキスト・かな・ローマ字ガイドの3行を表示します。
+// 先読み封じ・鏡文字の妨害を受けている場合は表示に反映します。
+func (s *BattleScreen) renderTypingText() string {
+	text := s.session.TypingText()
+	index := s.session.TypingIndex()
+	mistakes := s.session.TypingMistakes()
+	disruption := s.session.TypingDisruption()
+
+	romaji := s.session.RomajiMatcher()
+	if romaji == nil {
+		return s.renderTypingUnits(disruption, typing.SplitGraphemes(text), index, mistakes)
+	}
+
+	guide := romaji.Guide()
+	if disruption.VisibleAhead > 0 {
+		// 先読み封じ中は表示テキストとローマ字ガイドを隠す
+		text = strings.Repeat(typing.HiddenMark, typing.GraphemeCount(text))
+		if runes := []rune(guide); len(runes) > 1 {
+			guide = string(runes[:1])
+		}
+	}
+
+	textStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	lines := []string{
+		textStyle.Render(text),
+		s.renderTypingUnits(disruption, romaji.Units(), index, mistakes),
+		s.styles.RenderRomajiGuide(romaji.Typed(), guide),
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
+
+// renderTypingUnits は妨害を反映して入力単位を描画します。
+func (s *BattleScreen) renderTypingUnits(disruption typing.Disruption, units []string, index int, mistakes []int) string {
+	units = disruption.MaskUnits(units, index)
+	if disruption.Mirrored {
+		return s.styles.RenderMirroredTypingUnits(units, index, mistakes)
+	}
+	return s.styles.RenderTypingUnits(units, index, mistakes)
+}
+
+// disruptionLabels は有効なタイピング妨害の表示名を返します。
+func disruptionLabels(d typing.Disruption) []string {
+	var labels []string
+	if d.VisibleAhead > 0 {
+		labels = append(labels, fmt.Sprintf("👁先読み封じ(%d文字)", d.VisibleAhead))
+	}
+	if d.Mirrored {
+		labels = append(labels, "🪞鏡文字")
+	}
+	if d.Shuffled {
+		labels = append(labels, "🔀シャッフル")
+	}
+	if d.TimeShrink > 0 {
+		labels = append(labels, "⏬時間縮小")
+	}
+	if d.CaseSensitive {
+		labels = append(labels, "🔠大文字小文字")
+	}
+	return labels
+}
+
 // ==================== プログレスバーレンダリング ====================
 
 // renderTimeProgressBar は残り時間をプログレスバー形式で描画します。
@@ -594,7 +1017,11 @@ func (s *BattleScreen) renderTimeProgressBar(remainingSeconds float64, ratio flo
 
 // renderEnemyActionBar は敵の次回行動までのプログレスバーを描画します。
 func (s *BattleScreen) renderEnemyActionBar(remainingSeconds float64, ratio float64, barColor lipgloss.Color) string {
-	barWidth := 40
+	return s.renderProgressBar(remainingSeconds, ratio, barColor, 40)
+}
+
+// renderProgressBar は残り時間をバー中央に表示するプログレスバーを指定幅で描画します。
+func (s *BattleScreen) renderProgressBar(remainingSeconds float64, ratio float64, barColor lipgloss.Color, barWidth int) string {
 	timeText := fmt.Sprintf("%.1fs", remainingSeconds)
 
 	// 塗りつぶし部分の計算
@@ -641,9 +1068,9 @@ func (s *BattleScreen) renderEnemyActionBar(remainingSeconds float64, ratio floa
 // ==================== UIヘルパー ====================
 
 // getModulesForAgent は指定エージェントのモジュールスロットを取得します。
-func (s *BattleScreen) getModulesForAgent(agentIdx int) []ModuleSlot {
-	var modules []ModuleSlot
-	for _, slot := range s.moduleSlots {
+func (s *BattleScreen) getModulesForAgent(agentIdx int) []combat.ModuleSlot {
+	var modules []combat.ModuleSlot
+	for _, slot := range s.session.Slots() {
 		if slot.AgentIndex == agentIdx {
 			modules = append(modules, slot)
 		}
@@ -658,13 +1085,13 @@ func (s *BattleScreen) getSelectedModuleInAgent(agentIdx int) int {
 	}
 
 	// 現在選択されているスロットがこのエージェントのものか確認
-	if s.selectedSlot >= 0 && s.selectedSlot < len(s.moduleSlots) {
-		slot := s.moduleSlots[s.selectedSlot]
+	if s.selectedSlot >= 0 && s.selectedSlot < len(s.session.Slots()) {
+		slot := s.session.Slots()[s.selectedSlot]
 		if slot.AgentIndex == agentIdx {
 			// このエージェント内での相対位置を計算
 			moduleIdx := 0
 			for i := 0; i < s.selectedSlot; i++ {
-				if s.moduleSlots[i].AgentIndex == agentIdx {
+				if s.session.Slots()[i].AgentIndex == agentIdx {
 					moduleIdx++
 				}
 			}
@@ -60,7 +60,7 @@ func TestBattleScreen_RenderAgentAreaWithRecast(t *testing.T) {
 	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), []*domain.AgentModel{agent}, nil)
 
 	// エージェント0のリキャストを開始
-	screen.recastManager.StartRecast(0, 5*time.Second)
+	screen.session.RecastManager().StartRecast(0, 5*time.Second)
 
 	// View()を呼び出し
 	result := screen.View()
@@ -87,7 +87,7 @@ func TestBattleScreen_RenderAgentAreaWithChainEffect(t *testing.T) {
 	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), []*domain.AgentModel{agent}, nil)
 
 	// チェイン効果を登録
-	screen.chainEffectManager.RegisterChainEffect(0, &chainEffect, "test_module")
+	screen.session.ChainEffectManager().RegisterChainEffect(0, &chainEffect, "test_module")
 
 	// View()を呼び出し
 	result := screen.View()
@@ -143,15 +143,15 @@ func TestBattleScreen_RecastStateAffectsModuleUsability(t *testing.T) {
 	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), []*domain.AgentModel{agent}, nil)
 
 	// リキャスト前は使用可能
-	if !screen.isModuleUsable(0) {
+	if !screen.session.IsModuleUsable(0) {
 		t.Error("Module should be usable before recast")
 	}
 
 	// リキャスト開始
-	screen.recastManager.StartRecast(0, 5*time.Second)
+	screen.session.RecastManager().StartRecast(0, 5*time.Second)
 
 	// リキャスト中は使用不可
-	if screen.isModuleUsable(0) {
+	if screen.session.IsModuleUsable(0) {
 		t.Error("Module should not be usable during recast")
 	}
 }
@@ -228,10 +228,10 @@ func TestBattleScreen_RenderRecastProgress(t *testing.T) {
 	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), []*domain.AgentModel{agent}, nil)
 
 	// リキャスト開始
-	screen.recastManager.StartRecast(0, 5*time.Second)
+	screen.session.RecastManager().StartRecast(0, 5*time.Second)
 
 	// リキャスト進捗取得
-	progress := screen.recastManager.GetProgress(0)
+	progress := screen.session.RecastManager().GetProgress(0)
 
 	// 開始直後は0.0
 	if progress != 0.0 {
@@ -239,7 +239,7 @@ func TestBattleScreen_RenderRecastProgress(t *testing.T) {
 	}
 
 	// 未リキャストのエージェントは1.0
-	progress1 := screen.recastManager.GetProgress(1)
+	progress1 := screen.session.RecastManager().GetProgress(1)
 	if progress1 != 1.0 {
 		t.Errorf("GetProgress(1) = %v, want 1.0 for non-recast agent", progress1)
 	}
@@ -265,10 +265,10 @@ func TestBattleScreen_ChainEffectFeedback(t *testing.T) {
 	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), []*domain.AgentModel{agent0, agent1}, nil)
 
 	// エージェント0のチェイン効果を登録
-	screen.chainEffectManager.RegisterChainEffect(0, &chainEffect, "test_module")
+	screen.session.ChainEffectManager().RegisterChainEffect(0, &chainEffect, "test_module")
 
 	// エージェント1のモジュール使用でチェイン効果発動をチェック
-	triggered := screen.chainEffectManager.CheckAndTrigger(1, chain.ModuleEffectFlags{HasDamage: true})
+	triggered := screen.session.ChainEffectManager().CheckAndTrigger(1, chain.ModuleEffectFlags{HasDamage: true})
 
 	// チェイン効果が発動する
 	if len(triggered) != 1 {
@@ -282,6 +282,33 @@ func TestBattleScreen_ChainEffectFeedback(t *testing.T) {
 	}
 }
 
+// TestBattleScreen_RenderChainTrack はチェイン中にチェイン数・使用順・倍率が表示されることをテストします。
+func TestBattleScreen_RenderChainTrack(t *testing.T) {
+	modules := []*domain.ModuleModel{createTestModuleWithChain("攻撃A", nil)}
+	agents := []*domain.AgentModel{
+		createTestAgentWithPassive(domain.PassiveSkill{}, modules),
+		createTestAgentWithPassive(domain.PassiveSkill{}, modules),
+		createTestAgentWithPassive(domain.PassiveSkill{}, modules),
+	}
+	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), agents, nil)
+	screen.width = 160
+
+	if track := screen.renderChainTrack(); track != "" {
+		t.Errorf("チェインがない場合は表示しないべき: %q", track)
+	}
+
+	manager := screen.session.ChainEffectManager()
+	for i := range agents {
+		manager.RecordModuleUse(i)
+	}
+	track := screen.renderChainTrack()
+	for _, want := range []string{"3チェイン", "×1.25", "1.", "→ ", "3."} {
+		if !strings.Contains(track, want) {
+			t.Errorf("チェインの表示に「%s」が含まれるべき: %q", want, track)
+		}
+	}
+}
+
 // ==================== ボルテージ表示テスト ====================
 
 // TestBattleScreen_VoltageDisplay はボルテージ表示のテストです。
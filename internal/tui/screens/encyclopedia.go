@@ -533,10 +533,26 @@ func (s *EncyclopediaScreen) renderEnemyPreview() string {
 	panel.AddItem("ID", et.ID)
 	panel.AddItem("基礎HP", fmt.Sprintf("%d", et.BaseHP))
 	panel.AddItem("基礎攻撃力", fmt.Sprintf("%d", et.BaseAttackPower))
+	panel.AddItem("弱点", s.describeDiscoveredWeaknesses(et))
 
 	return panel.Render(45)
 }
 
+// describeDiscoveredWeaknesses は発見済みの弱点属性を倍率付きで返します。
+// バトルで弱点を突くまでは「未発見」と表示します。
+func (s *EncyclopediaScreen) describeDiscoveredWeaknesses(et domain.EnemyType) string {
+	discovered := s.data.DiscoveredWeaknesses[et.ID]
+	if len(discovered) == 0 {
+		return "未発見"
+	}
+
+	parts := make([]string, 0, len(discovered))
+	for _, element := range discovered {
+		parts = append(parts, fmt.Sprintf("%s×%g", element, et.ElementAffinity.Multiplier(element)))
+	}
+	return strings.Join(parts, " / ")
+}
+
 // renderCompletionRate はコンプリート率をレンダリングします。
 func (s *EncyclopediaScreen) renderCompletionRate() string {
 	coreRate := s.getCoreCompletionRate()
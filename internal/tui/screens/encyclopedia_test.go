@@ -173,6 +173,22 @@ func TestEncyclopediaRender(t *testing.T) {
 	}
 }
 
+// TestEncyclopediaEnemyWeaknesses は発見済み弱点の表示をテストします。
+func TestEncyclopediaEnemyWeaknesses(t *testing.T) {
+	data := createTestEncyclopediaData()
+	data.AllEnemyTypes[0].ElementAffinity = domain.ElementAffinity{domain.ElementFire: 1.5, domain.ElementIce: 1.25}
+	screen := NewEncyclopediaScreen(data)
+
+	if got := screen.describeDiscoveredWeaknesses(data.AllEnemyTypes[0]); got != "未発見" {
+		t.Errorf("未発見の弱点表示が不正です: got %q", got)
+	}
+
+	data.DiscoveredWeaknesses = map[string][]domain.Element{"goblin": {domain.ElementFire}}
+	if got := screen.describeDiscoveredWeaknesses(data.AllEnemyTypes[0]); got != "炎×1.5" {
+		t.Errorf("発見済みの弱点表示が不正です: got %q, want %q", got, "炎×1.5")
+	}
+}
+
 // ==================== ヘルパー関数 ====================
 
 func createTestEncyclopediaData() *EncyclopediaData {
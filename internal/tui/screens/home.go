@@ -81,6 +81,7 @@ func NewHomeScreen(maxLevelReached int, agentProvider AgentProvider) *HomeScreen
 		{Label: "バトル選択", Value: "battle_select", Disabled: !hasEquippedAgents},
 		{Label: "図鑑", Value: "encyclopedia"},
 		{Label: "統計/実績", Value: "stats_achievements"},
+		{Label: "リプレイ", Value: "replay"},
 		{Label: "セーブ", Value: "save"},
 		{Label: "設定", Value: "settings"},
 	}
@@ -0,0 +1,258 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/typing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ==================== リプレイ画面 ====================
+
+// ReplayListItem はリプレイ一覧の1件です。
+type ReplayListItem struct {
+	Name       string
+	RecordedAt time.Time
+	EnemyName  string
+	EnemyLevel int
+	Victory    bool
+	Retreated  bool
+	Duration   time.Duration
+}
+
+// ReplayProvider はリプレイの一覧と読み込みを提供するインターフェースです。
+type ReplayProvider interface {
+	// ListReplays は保存済みリプレイを新しい順で返します。
+	ListReplays() []ReplayListItem
+	// LoadReplay は指定された名前のリプレイを読み込みます。
+	LoadReplay(name string) (*combat.Replay, error)
+}
+
+// ReplayScreen はリプレイ一覧と再生を行う画面です。
+// 再生はBattleScreenのリプレイモードで行い、通常のバトルと同じ描画で表示します。
+type ReplayScreen struct {
+	provider      ReplayProvider
+	items         []ReplayListItem
+	selectedIndex int
+	errorMessage  string
+
+	// 再生用の設定
+	passiveSkills map[string]domain.PassiveSkill
+	dictionary    *typing.Dictionary
+
+	// battleScreen は再生中のバトル画面です（nilの場合は一覧表示）
+	battleScreen *BattleScreen
+
+	styles *styles.GameStyles
+	width  int
+	height int
+}
+
+// NewReplayScreen は新しいReplayScreenを作成します。
+func NewReplayScreen(provider ReplayProvider, passiveSkills map[string]domain.PassiveSkill, dictionary *typing.Dictionary) *ReplayScreen {
+	s := &ReplayScreen{
+		provider:      provider,
+		passiveSkills: passiveSkills,
+		dictionary:    dictionary,
+		styles:        styles.NewGameStyles(),
+		width:         140,
+		height:        40,
+	}
+	s.refresh()
+	return s
+}
+
+// refresh はリプレイ一覧を読み込み直します。
+func (s *ReplayScreen) refresh() {
+	s.items = nil
+	if s.provider != nil {
+		s.items = s.provider.ListReplays()
+	}
+	if s.selectedIndex >= len(s.items) {
+		s.selectedIndex = 0
+	}
+}
+
+// Init は画面の初期化を行います。
+func (s *ReplayScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update はメッセージを処理します。
+func (s *ReplayScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		if s.battleScreen != nil {
+			s.battleScreen.Update(msg)
+		}
+		return s, nil
+
+	case BattleTickMsg:
+		// 一覧に戻った後に届いたtickは破棄する（tickの連鎖を止める）
+		if s.battleScreen == nil {
+			return s, nil
+		}
+		_, cmd := s.battleScreen.Update(msg)
+		return s, cmd
+
+	case BattleLogExportedMsg:
+		if s.battleScreen != nil {
+			s.battleScreen.Update(msg)
+		}
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.battleScreen != nil {
+			return s.handlePlaybackInput(msg)
+		}
+		return s.handleListInput(msg)
+	}
+
+	return s, nil
+}
+
+// handleListInput は一覧表示中のキー入力を処理します。
+func (s *ReplayScreen) handleListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.selectedIndex > 0 {
+			s.selectedIndex--
+		}
+	case "down", "j":
+		if s.selectedIndex < len(s.items)-1 {
+			s.selectedIndex++
+		}
+	case "enter":
+		return s, s.startPlayback()
+	}
+	return s, nil
+}
+
+// handlePlaybackInput は再生中のキー入力を処理します。
+// Backspaceまたは結果表示中のEnterで一覧に戻り、それ以外はバトル画面に転送します。
+func (s *ReplayScreen) handlePlaybackInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyBackspace || (msg.Type == tea.KeyEnter && s.battleScreen.IsShowingResult()) {
+		s.battleScreen = nil
+		return s, nil
+	}
+	_, cmd := s.battleScreen.Update(msg)
+	return s, cmd
+}
+
+// startPlayback は選択中のリプレイの再生を開始します。
+func (s *ReplayScreen) startPlayback() tea.Cmd {
+	if len(s.items) == 0 {
+		return nil
+	}
+
+	replay, err := s.provider.LoadReplay(s.items[s.selectedIndex].Name)
+	if err != nil {
+		s.errorMessage = fmt.Sprintf("リプレイを読み込めませんでした: %v", err)
+		return nil
+	}
+	s.errorMessage = ""
+
+	s.battleScreen = NewReplayBattleScreen(replay, s.dictionary)
+	if s.passiveSkills != nil {
+		s.battleScreen.SetPassiveSkills(s.passiveSkills)
+	}
+	s.battleScreen.Update(tea.WindowSizeMsg{Width: s.width, Height: s.height})
+	return s.battleScreen.Init()
+}
+
+// IsPlaying はリプレイを再生中かを返します。
+func (s *ReplayScreen) IsPlaying() bool {
+	return s.battleScreen != nil
+}
+
+// View は画面をレンダリングします。
+func (s *ReplayScreen) View() string {
+	if s.battleScreen != nil {
+		return s.battleScreen.View()
+	}
+
+	var builder strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorPrimary).
+		Align(lipgloss.Center).
+		Width(s.width)
+
+	builder.WriteString(titleStyle.Render("リプレイ"))
+	builder.WriteString("\n\n")
+
+	builder.WriteString(lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(s.renderList()))
+	builder.WriteString("\n\n")
+
+	if s.errorMessage != "" {
+		builder.WriteString(lipgloss.NewStyle().
+			Foreground(styles.ColorDamage).
+			Align(lipgloss.Center).
+			Width(s.width).
+			Render(s.errorMessage))
+		builder.WriteString("\n\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtle).
+		Align(lipgloss.Center).
+		Width(s.width)
+
+	builder.WriteString(hintStyle.Render("↑/↓: 選択  Enter: 再生  Esc: 戻る"))
+
+	return builder.String()
+}
+
+// renderList はリプレイ一覧をレンダリングします。
+func (s *ReplayScreen) renderList() string {
+	if len(s.items) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(styles.ColorSubtle).
+			Render("リプレイがありません（バトルを行うと自動で記録されます）")
+	}
+
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	normalStyle := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+
+	lines := make([]string, 0, len(s.items))
+	for i, item := range s.items {
+		result := "敗北"
+		if item.Victory {
+			result = "勝利"
+		} else if item.Retreated {
+			result = "撤退"
+		}
+		line := fmt.Sprintf("%s  %s Lv.%d  %s  %.1f秒",
+			item.RecordedAt.Local().Format("2006-01-02 15:04:05"),
+			item.EnemyName,
+			item.EnemyLevel,
+			result,
+			item.Duration.Seconds(),
+		)
+		if i == s.selectedIndex {
+			lines = append(lines, selectedStyle.Render("> "+line))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorSubtle).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
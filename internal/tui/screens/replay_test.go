@@ -0,0 +1,136 @@
+// Package screens はTUI画面のテストを提供します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/combat"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeReplayProvider はテスト用のReplayProviderです。
+type fakeReplayProvider struct {
+	items   []ReplayListItem
+	replays map[string]*combat.Replay
+}
+
+func (p *fakeReplayProvider) ListReplays() []ReplayListItem {
+	return p.items
+}
+
+func (p *fakeReplayProvider) LoadReplay(name string) (*combat.Replay, error) {
+	replay, ok := p.replays[name]
+	if !ok {
+		return nil, fmt.Errorf("リプレイが見つかりません: %s", name)
+	}
+	return replay, nil
+}
+
+// recordTestReplay は数tick分のバトルを記録したリプレイを作成します。
+func recordTestReplay() *combat.Replay {
+	screen := NewBattleScreen(createTestEnemy(), createTestPlayer(), createTestAgents(), nil)
+	c := clock.NewManualClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	screen.SetClock(c)
+	screen.Init()
+	for i := 0; i < 5; i++ {
+		c.Advance(tickInterval)
+		screen.Update(BattleTickMsg{})
+	}
+	return screen.session.Replay()
+}
+
+// newTestReplayScreen はリプレイ2件（うち1件は読み込み不可）を持つReplayScreenを作成します。
+func newTestReplayScreen() *ReplayScreen {
+	provider := &fakeReplayProvider{
+		items: []ReplayListItem{
+			{Name: "latest", EnemyName: "テストスライム", EnemyLevel: 5, Victory: true, Duration: 30 * time.Second},
+			{Name: "missing", EnemyName: "テストゴブリン", EnemyLevel: 3, Duration: 10 * time.Second},
+		},
+		replays: map[string]*combat.Replay{"latest": recordTestReplay()},
+	}
+	return NewReplayScreen(provider, nil, nil)
+}
+
+// TestReplayScreen_List はリプレイ一覧の表示と選択をテストします。
+func TestReplayScreen_List(t *testing.T) {
+	screen := newTestReplayScreen()
+
+	view := screen.View()
+	if !strings.Contains(view, "テストスライム Lv.5") || !strings.Contains(view, "勝利") {
+		t.Error("リプレイ一覧に敵名・勝敗が表示されていません")
+	}
+
+	// 撤退したバトルは敗北ではなく撤退として表示する
+	retreated := NewReplayScreen(&fakeReplayProvider{items: []ReplayListItem{
+		{Name: "retreated", EnemyName: "テストドラゴン", EnemyLevel: 7, Retreated: true, Duration: 5 * time.Second},
+	}}, nil, nil)
+	if view := retreated.View(); !strings.Contains(view, "撤退") || strings.Contains(view, "敗北") {
+		t.Error("撤退したリプレイが撤退として表示されていません")
+	}
+
+	screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if screen.selectedIndex != 1 {
+		t.Errorf("下キー後の選択インデックス: got %d, want 1", screen.selectedIndex)
+	}
+	screen.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if screen.selectedIndex != 1 {
+		t.Errorf("末尾で下キーを押しても移動しないこと: got %d", screen.selectedIndex)
+	}
+
+	// 読み込めないリプレイはエラーを表示して一覧に留まる
+	screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen.IsPlaying() {
+		t.Error("読み込めないリプレイで再生が開始されました")
+	}
+	if !strings.Contains(screen.View(), "リプレイを読み込めませんでした") {
+		t.Error("読み込みエラーが表示されていません")
+	}
+}
+
+// TestReplayScreen_Playback はリプレイの再生・速度変更・一覧への復帰をテストします。
+func TestReplayScreen_Playback(t *testing.T) {
+	screen := newTestReplayScreen()
+
+	_, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !screen.IsPlaying() {
+		t.Fatal("Enterで再生が開始されていません")
+	}
+	if cmd == nil {
+		t.Error("再生開始時にtickコマンドが返されていません")
+	}
+	if !screen.battleScreen.IsReplay() {
+		t.Error("再生画面がリプレイモードではありません")
+	}
+	if !strings.Contains(screen.View(), "REPLAY") {
+		t.Error("再生中の表示にREPLAYが含まれていません")
+	}
+
+	// 再生速度の切り替え
+	screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	if screen.battleScreen.ReplaySpeed() != 4 {
+		t.Errorf("再生速度: got %d, want 4", screen.battleScreen.ReplaySpeed())
+	}
+
+	// tickで記録が進む
+	_, cmd = screen.Update(BattleTickMsg{})
+	if cmd == nil {
+		t.Error("再生中のtickで次のtickが返されていません")
+	}
+	if elapsed := screen.battleScreen.replay.Elapsed(); elapsed != 4*tickInterval {
+		t.Errorf("4倍速の経過時間: got %v, want %v", elapsed, 4*tickInterval)
+	}
+
+	// Backspaceで一覧に戻り、以降のtickは破棄される
+	screen.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if screen.IsPlaying() {
+		t.Error("Backspaceで一覧に戻っていません")
+	}
+	if _, cmd := screen.Update(BattleTickMsg{}); cmd != nil {
+		t.Error("一覧表示中のtickでコマンドが返されました")
+	}
+}
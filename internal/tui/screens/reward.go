@@ -142,6 +142,16 @@ func (s *RewardScreen) renderBattleStats() string {
 		if s.result.Stats.TotalHealAmount > 0 {
 			items = append(items, itemStyle.Render(fmt.Sprintf("回復量: %d", s.result.Stats.TotalHealAmount)))
 		}
+		if s.result.Stats.CriticalHits > 0 {
+			items = append(items, itemStyle.Render(fmt.Sprintf("クリティカル: %d回", s.result.Stats.CriticalHits)))
+		}
+		if s.result.Stats.PeakVoltage > 0 {
+			items = append(items, itemStyle.Render(fmt.Sprintf("ピークボルテージ: %.0f%%", s.result.Stats.PeakVoltage)))
+		}
+		if s.result.VoltageBonus > 0 {
+			items = append(items, lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(
+				fmt.Sprintf("ボルテージ報酬: ドロップLv+%d", s.result.VoltageBonus)))
+		}
 	} else {
 		items = append(items, itemStyle.Render("統計データなし"))
 	}
@@ -25,6 +25,37 @@ type SettingsScreen struct {
 	height        int
 }
 
+// TypingModeChangedMsg はタイピング入力モードの変更を通知するメッセージです。
+type TypingModeChangedMsg struct {
+	// Mode は変更後の入力モード（"english" / "romaji"）です。
+	Mode string
+}
+
+// AdaptiveWordsChangedMsg は苦手キー優先出題の切り替えを通知するメッセージです。
+type AdaptiveWordsChangedMsg struct {
+	// Enabled は苦手キー優先出題が有効かどうかです。
+	Enabled bool
+}
+
+// タイピング入力モードの設定値
+const (
+	typingModeEnglish = "english"
+	typingModeRomaji  = "romaji"
+)
+
+// タイピング設定項目のID
+const (
+	typingItemMode     = "typing_mode"
+	typingItemAdaptive = "adaptive_words"
+)
+
+// TypingSettingItem はEnterで切り替えるタイピング設定項目を表します。
+type TypingSettingItem struct {
+	ID    string
+	Label string
+	Value string
+}
+
 // KeybindItem はキーバインド項目を表します。
 type KeybindItem struct {
 	ID    string
@@ -82,6 +113,9 @@ func (s *SettingsScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "down", "j":
 		s.moveDown()
 	case "enter":
+		if item, ok := s.selectedTypingItem(); ok {
+			return s, s.toggleTypingItem(item.ID)
+		}
 		s.startEditing()
 	}
 
@@ -117,7 +151,8 @@ func (s *SettingsScreen) moveUp() {
 
 // moveDown は選択を下に移動します。
 func (s *SettingsScreen) moveDown() {
-	maxIndex := len(s.getKeybindItems())
+	// キーバインド項目の後にタイピング設定項目が続く
+	maxIndex := len(s.getKeybindItems()) + len(s.getTypingItems())
 	if s.selectedIndex < maxIndex-1 {
 		s.selectedIndex++
 	}
@@ -132,6 +167,56 @@ func (s *SettingsScreen) startEditing() {
 	}
 }
 
+// getTypingItems はタイピング設定項目のリストを返します。
+func (s *SettingsScreen) getTypingItems() []TypingSettingItem {
+	mode := "英単語"
+	if s.settings.TypingMode == typingModeRomaji {
+		mode = "ローマ字（日本語）"
+	}
+	adaptive := "ランダム"
+	if s.settings.AdaptiveWords {
+		adaptive = "苦手キー優先"
+	}
+	return []TypingSettingItem{
+		{ID: typingItemMode, Label: "入力モード", Value: mode},
+		{ID: typingItemAdaptive, Label: "出題方式", Value: adaptive},
+	}
+}
+
+// selectedTypingItem は選択中のタイピング設定項目を返します。
+// キーバインド項目を選択中の場合はfalseを返します。
+func (s *SettingsScreen) selectedTypingItem() (TypingSettingItem, bool) {
+	idx := s.selectedIndex - len(s.getKeybindItems())
+	items := s.getTypingItems()
+	if idx < 0 || idx >= len(items) {
+		return TypingSettingItem{}, false
+	}
+	return items[idx], true
+}
+
+// toggleTypingItem はタイピング設定項目を切り替え、変更通知コマンドを返します。
+func (s *SettingsScreen) toggleTypingItem(id string) tea.Cmd {
+	switch id {
+	case typingItemMode:
+		if s.settings.TypingMode == typingModeRomaji {
+			s.settings.TypingMode = typingModeEnglish
+		} else {
+			s.settings.TypingMode = typingModeRomaji
+		}
+		mode := s.settings.TypingMode
+		return func() tea.Msg {
+			return TypingModeChangedMsg{Mode: mode}
+		}
+	case typingItemAdaptive:
+		s.settings.AdaptiveWords = !s.settings.AdaptiveWords
+		enabled := s.settings.AdaptiveWords
+		return func() tea.Msg {
+			return AdaptiveWordsChangedMsg{Enabled: enabled}
+		}
+	}
+	return nil
+}
+
 // applyKeybindChange はキーバインドの変更を適用します。
 
 func (s *SettingsScreen) applyKeybindChange(newKey string) {
@@ -170,6 +255,10 @@ func (s *SettingsScreen) View() string {
 	builder.WriteString(s.renderKeybindSettings())
 	builder.WriteString("\n\n")
 
+	// タイピング設定
+	builder.WriteString(s.renderTypingSettings())
+	builder.WriteString("\n\n")
+
 	// ヒント
 	hintStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorSubtle).
@@ -179,6 +268,8 @@ func (s *SettingsScreen) View() string {
 	var hints string
 	if s.editing {
 		hints = "新しいキーを押してください  Esc: キャンセル"
+	} else if _, ok := s.selectedTypingItem(); ok {
+		hints = "↑/↓: 選択  Enter: 切替  Esc: 戻る"
 	} else {
 		hints = "↑/↓: 選択  Enter: 変更  Esc: 戻る"
 	}
@@ -246,6 +337,51 @@ func (s *SettingsScreen) renderKeybindSettings() string {
 	return builder.String()
 }
 
+// renderTypingSettings はタイピング設定をレンダリングします。
+func (s *SettingsScreen) renderTypingSettings() string {
+	var builder strings.Builder
+
+	sectionTitle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorSecondary).
+		Render("タイピング設定")
+
+	builder.WriteString(lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(sectionTitle))
+	builder.WriteString("\n\n")
+
+	selected, hasSelection := s.selectedTypingItem()
+	var itemLines []string
+	for _, item := range s.getTypingItems() {
+		style := lipgloss.NewStyle()
+		prefix := "  "
+		if hasSelection && item.ID == selected.ID {
+			prefix = "> "
+			style = style.Bold(true).
+				Foreground(styles.ColorSelectedFg).
+				Background(styles.ColorSelectedBg)
+		}
+		line := fmt.Sprintf("%s%-12s : %s", prefix, item.Label, item.Value)
+		itemLines = append(itemLines, style.Render(line))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Width(40).
+		Render(strings.Join(itemLines, "\n"))
+
+	builder.WriteString(lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(box))
+
+	return builder.String()
+}
+
 // ==================== Screenインターフェース実装 ====================
 
 // SetSize は画面サイズを設定します。
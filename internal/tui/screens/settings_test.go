@@ -111,6 +111,61 @@ func TestSettingsNavigation(t *testing.T) {
 	}
 }
 
+// TestSettingsTypingModeToggle はタイピング入力モードの切り替えをテストします。
+func TestSettingsTypingModeToggle(t *testing.T) {
+	settings := createTestSettings()
+	screen := NewSettingsScreen(settings)
+
+	// キーバインド項目の直後がタイピングモード項目
+	screen.selectedIndex = len(screen.getKeybindItems())
+	if item, ok := screen.selectedTypingItem(); !ok || item.ID != typingItemMode {
+		t.Fatalf("タイピングモード項目が選択されていません: index=%d", screen.selectedIndex)
+	}
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("切り替え時にコマンドが返されません")
+	}
+	msg, ok := cmd().(TypingModeChangedMsg)
+	if !ok {
+		t.Fatal("TypingModeChangedMsgが返されません")
+	}
+	if msg.Mode != "romaji" || settings.TypingMode != "romaji" {
+		t.Errorf("切り替え後のモード: got %s/%s, want romaji", msg.Mode, settings.TypingMode)
+	}
+	if screen.editing {
+		t.Error("タイピングモード切り替えで編集モードに入ってはいけません")
+	}
+
+	screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if settings.TypingMode != "english" {
+		t.Errorf("再切り替え後のモード: got %s, want english", settings.TypingMode)
+	}
+}
+
+// TestSettingsAdaptiveWordsToggle は苦手キー優先出題の切り替えをテストします。
+func TestSettingsAdaptiveWordsToggle(t *testing.T) {
+	settings := createTestSettings()
+	screen := NewSettingsScreen(settings)
+
+	// 最下部まで移動すると出題方式項目
+	for i := 0; i < 20; i++ {
+		screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if item, ok := screen.selectedTypingItem(); !ok || item.ID != typingItemAdaptive {
+		t.Fatalf("出題方式項目が選択されていません: index=%d", screen.selectedIndex)
+	}
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("切り替え時にコマンドが返されません")
+	}
+	msg, ok := cmd().(AdaptiveWordsChangedMsg)
+	if !ok || !msg.Enabled || !settings.AdaptiveWords {
+		t.Errorf("切り替え後: got %+v / %v, want Enabled", msg, settings.AdaptiveWords)
+	}
+}
+
 // ==================== ヘルパー関数 ====================
 
 func createTestSettings() *SettingsData {
@@ -125,5 +180,6 @@ func createTestSettings() *SettingsData {
 		},
 		SoundVolume: 100,
 		Difficulty:  "normal",
+		TypingMode:  "english",
 	}
 }
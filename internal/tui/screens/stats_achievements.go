@@ -244,8 +244,11 @@ func (s *StatsAchievementsScreen) renderBattleStats() string {
 	panel.AddItem("総バトル数", fmt.Sprintf("%d戦", s.data.BattleStats.TotalBattles))
 	panel.AddItem("勝利数", fmt.Sprintf("%d勝", s.data.BattleStats.Wins))
 	panel.AddItem("敗北数", fmt.Sprintf("%d敗", s.data.BattleStats.Losses))
+	panel.AddItem("撤退数", fmt.Sprintf("%d回", s.data.BattleStats.Retreats))
 	panel.AddItem("勝率", fmt.Sprintf("%.1f%%", winRate))
 	panel.AddItem("到達最高レベル", fmt.Sprintf("Lv.%d", s.data.BattleStats.MaxLevelReached))
+	panel.AddItem("クリティカル回数", fmt.Sprintf("%d回", s.data.BattleStats.TotalCriticalHits))
+	panel.AddItem("タワー最高到達", fmt.Sprintf("%d階", s.data.BattleStats.TowerBestFloor))
 
 	content := panel.Render(50)
 
@@ -0,0 +1,248 @@
+// Package screens はTUIゲームの画面を提供します。
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/tui/components"
+	"hirorocky/type-battle/internal/tui/styles"
+	"hirorocky/type-battle/internal/usecase/climbing"
+	"hirorocky/type-battle/internal/usecase/rewarding"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ==================== エンドレスタワー画面 ====================
+
+// TowerAdvanceMsg はエンドレスタワーの現在の階へ進むことを要求するメッセージです。
+// 戦闘階ではバトルを開始し、休憩階ではHPを部分回復して次の階へ進みます。
+type TowerAdvanceMsg struct{}
+
+// TowerScreen はエンドレスタワーの階の間に表示する画面です。
+// 現在の階の情報と持ち越しHPを表示し、次の階への挑戦・休憩を受け付けます。
+type TowerScreen struct {
+	run           *climbing.TowerRun
+	agentProvider AgentProvider
+	bestFloor     int
+
+	// message は直前の階の結果メッセージです
+	message string
+
+	// reward は直前に獲得したマイルストーン報酬です（nilの場合は表示しない）
+	reward *rewarding.RewardResult
+
+	error  string
+	styles *styles.GameStyles
+	width  int
+	height int
+}
+
+// NewTowerScreen は新しいTowerScreenを作成します。
+func NewTowerScreen(run *climbing.TowerRun, agentProvider AgentProvider, bestFloor int) *TowerScreen {
+	return &TowerScreen{
+		run:           run,
+		agentProvider: agentProvider,
+		bestFloor:     bestFloor,
+		styles:        styles.NewGameStyles(),
+		width:         140,
+		height:        40,
+	}
+}
+
+// SetMessage は直前の階の結果メッセージを設定します。
+func (s *TowerScreen) SetMessage(message string) {
+	s.message = message
+}
+
+// SetMilestoneReward は獲得したマイルストーン報酬を設定します（nilで非表示）。
+func (s *TowerScreen) SetMilestoneReward(reward *rewarding.RewardResult) {
+	s.reward = reward
+}
+
+// SetBestFloor は最高到達階を設定します。
+func (s *TowerScreen) SetBestFloor(floor int) {
+	s.bestFloor = floor
+}
+
+// Init は画面の初期化を行います。
+func (s *TowerScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update はメッセージを処理します。
+func (s *TowerScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		return s, nil
+
+	case tea.KeyMsg:
+		return s.handleKeyMsg(msg)
+	}
+
+	return s, nil
+}
+
+// handleKeyMsg はキーボード入力を処理します。
+func (s *TowerScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// 撤退（挑戦中の階は記録済みのためそのままホームへ）
+		return s, func() tea.Msg {
+			return ChangeSceneMsg{Scene: "home"}
+		}
+
+	case tea.KeyEnter:
+		if s.run.IsOver() {
+			return s, func() tea.Msg {
+				return ChangeSceneMsg{Scene: "home"}
+			}
+		}
+
+		if len(s.agentProvider.GetEquippedAgents()) == 0 {
+			s.error = "エージェントが装備されていません。\nエージェント管理でエージェントを装備してください。"
+			return s, nil
+		}
+
+		s.error = ""
+		return s, func() tea.Msg {
+			return TowerAdvanceMsg{}
+		}
+	}
+
+	return s, nil
+}
+
+// View は画面をレンダリングします。
+func (s *TowerScreen) View() string {
+	var builder strings.Builder
+	floor := s.run.Floor()
+
+	// タイトル
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorPrimary).
+		Align(lipgloss.Center).
+		Width(s.width)
+
+	builder.WriteString(titleStyle.Render("エンドレスタワー"))
+	builder.WriteString("\n\n")
+
+	floorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.ColorSecondary).
+		Align(lipgloss.Center).
+		Width(s.width)
+
+	if s.run.IsOver() {
+		builder.WriteString(floorStyle.Foreground(styles.ColorDamage).Render(fmt.Sprintf("第%d階で力尽きた…", floor.Number)))
+	} else {
+		builder.WriteString(floorStyle.Render(fmt.Sprintf("第 %d 階", floor.Number)))
+	}
+	builder.WriteString("\n\n")
+
+	// 持ち越しHP
+	hp := components.RenderHPWithLabel("HP", s.run.PlayerHP(), s.run.PlayerMaxHP(), 30, s.styles)
+	builder.WriteString(lipgloss.NewStyle().Width(s.width).Align(lipgloss.Center).Render(hp))
+	builder.WriteString("\n\n")
+
+	// 直前の階の結果
+	if s.message != "" {
+		messageStyle := lipgloss.NewStyle().
+			Foreground(styles.ColorInfo).
+			Align(lipgloss.Center).
+			Width(s.width)
+		builder.WriteString(messageStyle.Render(s.message))
+		builder.WriteString("\n\n")
+	}
+
+	// 階情報
+	s.renderFloorInfoPanel(&builder, floor)
+
+	// マイルストーン報酬
+	if s.reward != nil {
+		s.renderMilestoneReward(&builder)
+	}
+
+	// エラーメッセージ
+	if s.error != "" {
+		errorStyle := lipgloss.NewStyle().
+			Foreground(styles.ColorDamage).
+			Align(lipgloss.Center).
+			Width(s.width)
+		builder.WriteString(errorStyle.Render(s.error))
+		builder.WriteString("\n\n")
+	}
+
+	// ヒント
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtle).
+		Align(lipgloss.Center).
+		Width(s.width)
+
+	var hint string
+	switch {
+	case s.run.IsOver():
+		hint = "Enter: ホームに戻る"
+	case floor.Rest:
+		hint = "Enter: 休憩する  Esc: 撤退"
+	default:
+		hint = "Enter: 挑戦する  Esc: 撤退"
+	}
+	builder.WriteString(hintStyle.Render(hint))
+
+	return builder.String()
+}
+
+// renderFloorInfoPanel は現在の階の情報パネルをレンダリングします。
+func (s *TowerScreen) renderFloorInfoPanel(builder *strings.Builder, floor climbing.Floor) {
+	infoPanel := components.NewInfoPanel("階情報")
+	if floor.Rest {
+		infoPanel.AddItem("種類", "休憩階")
+		infoPanel.AddItem("効果", fmt.Sprintf("最大HPの%.0f%%回復", config.TowerRestHealRatio*100))
+	} else {
+		infoPanel.AddItem("種類", "戦闘階")
+		infoPanel.AddItem("敵レベル", fmt.Sprintf("Lv.%d", floor.Level))
+		modifiers := floor.ModifierNames()
+		if modifiers == "" {
+			modifiers = "なし"
+		}
+		infoPanel.AddItem("敵補正", modifiers)
+	}
+	infoPanel.AddItem("次の報酬", fmt.Sprintf("%d階突破", climbing.NextMilestoneFloor(floor.Number)))
+	infoPanel.AddItem("最高到達", fmt.Sprintf("%d階", s.bestFloor))
+
+	infoPanelRendered := infoPanel.Render(50)
+	centeredInfo := lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(infoPanelRendered)
+	builder.WriteString(centeredInfo)
+	builder.WriteString("\n\n")
+}
+
+// renderMilestoneReward は獲得したマイルストーン報酬をレンダリングします。
+func (s *TowerScreen) renderMilestoneReward(builder *strings.Builder) {
+	rewardPanel := components.NewInfoPanel("🎁 マイルストーン報酬")
+	for _, core := range s.reward.DroppedCores {
+		rewardPanel.AddItem("コア", fmt.Sprintf("%s (Lv.%d)", core.Name, core.Level))
+	}
+	for _, module := range s.reward.DroppedModules {
+		rewardPanel.AddItem("モジュール", fmt.Sprintf("%s %s", module.Icon(), module.Name()))
+	}
+	if len(s.reward.DroppedCores) == 0 && len(s.reward.DroppedModules) == 0 {
+		rewardPanel.AddItem("報酬", "ドロップアイテムなし")
+	}
+
+	rewardPanelRendered := rewardPanel.Render(50)
+	centeredReward := lipgloss.NewStyle().
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(rewardPanelRendered)
+	builder.WriteString(centeredReward)
+	builder.WriteString("\n\n")
+}
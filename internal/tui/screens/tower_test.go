@@ -0,0 +1,70 @@
+// Package screens はTUI画面のテストを提供します。
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/climbing"
+	"hirorocky/type-battle/internal/usecase/spawning"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestTowerScreen はテスト用のエンドレスタワー画面を作成します。
+func newTestTowerScreen(agents []*domain.AgentModel) (*TowerScreen, *climbing.TowerRun) {
+	generator := spawning.NewEnemyGenerator([]domain.EnemyType{
+		{ID: "slime", Name: "スライム", BaseHP: 100, BaseAttackPower: 10, AttackType: "physical"},
+	})
+	run := climbing.NewTowerRun(generator, 100)
+	return NewTowerScreen(run, &mockAgentProvider{agents: agents}, 3), run
+}
+
+// TestTowerScreen_Advance はEnterで現在の階へ進むメッセージが送られることをテストします。
+func TestTowerScreen_Advance(t *testing.T) {
+	screen, _ := newTestTowerScreen([]*domain.AgentModel{createTestAgent()})
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enterでコマンドが返されません")
+	}
+	if _, ok := cmd().(TowerAdvanceMsg); !ok {
+		t.Error("TowerAdvanceMsgが送られていません")
+	}
+
+	view := screen.View()
+	if !strings.Contains(view, "第 1 階") || !strings.Contains(view, "3階") {
+		t.Error("現在の階と最高到達階が表示されていません")
+	}
+}
+
+// TestTowerScreen_NoAgent はエージェント未装備では挑戦できないことをテストします。
+func TestTowerScreen_NoAgent(t *testing.T) {
+	screen, _ := newTestTowerScreen(nil)
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("エージェント未装備で挑戦できてしまった")
+	}
+	if screen.error == "" {
+		t.Error("エラーメッセージが設定されていません")
+	}
+}
+
+// TestTowerScreen_Over は挑戦終了後のEnterでホームに戻ることをテストします。
+func TestTowerScreen_Over(t *testing.T) {
+	screen, run := newTestTowerScreen([]*domain.AgentModel{createTestAgent()})
+	run.RecordDefeat()
+
+	_, cmd := screen.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enterでコマンドが返されません")
+	}
+	if msg, ok := cmd().(ChangeSceneMsg); !ok || msg.Scene != "home" {
+		t.Error("挑戦終了後はホームに戻るべき")
+	}
+	if !strings.Contains(screen.View(), "力尽きた") {
+		t.Error("挑戦終了が表示されていません")
+	}
+}
@@ -72,6 +72,9 @@ type EncyclopediaData struct {
 	AcquiredCoreTypes   []string
 	AcquiredModuleTypes []string
 	EncounteredEnemies  []string
+
+	// DiscoveredWeaknesses は発見済みの敵の弱点属性です（敵タイプID→属性リスト）
+	DiscoveredWeaknesses map[string][]domain.Element
 }
 
 // ModuleTypeInfo はモジュールタイプ情報です。
@@ -85,9 +88,11 @@ type ModuleTypeInfo struct {
 
 // SettingsData は設定データです。
 type SettingsData struct {
-	Keybinds    map[string]string
-	SoundVolume int
-	Difficulty  string
+	Keybinds      map[string]string
+	SoundVolume   int
+	Difficulty    string
+	TypingMode    string
+	AdaptiveWords bool
 }
 
 // TypingStatsData はタイピング統計データです。
@@ -103,7 +108,14 @@ type BattleStatsData struct {
 	TotalBattles    int
 	Wins            int
 	Losses          int
+	Retreats        int
 	MaxLevelReached int
+
+	// TotalCriticalHits はクリティカルヒットの総数です
+	TotalCriticalHits int
+
+	// TowerBestFloor はエンドレスタワーの最高到達階です
+	TowerBestFloor int
 }
 
 // AchievementData は実績データです。
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
 )
 
 // MessageType は強調メッセージの種類を表す型です。
@@ -89,40 +90,76 @@ func (gs *GameStyles) RenderTypingIncorrect(text string) string {
 }
 
 // RenderTypingChallenge はタイピングチャレンジ全体を描画します。
+// currentIndexとmistakesは書記素クラスタのインデックスです。
 
 func (gs *GameStyles) RenderTypingChallenge(text string, currentIndex int, mistakes []int) string {
 	if len(text) == 0 {
 		return ""
 	}
 
+	// インデックスは書記素クラスタ単位（アクセント付き文字や絵文字も1文字）
+	units := make([]string, 0, len(text))
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		units = append(units, graphemes.Str())
+	}
+	return gs.RenderTypingUnits(units, currentIndex, mistakes)
+}
+
+// RenderTypingUnits は入力単位（かな単位など）のリストでタイピングチャレンジを描画します。
+// currentIndexとmistakesは単位のインデックスです。
+func (gs *GameStyles) RenderTypingUnits(units []string, currentIndex int, mistakes []int) string {
+	return strings.Join(renderTypingPieces(units, currentIndex, mistakes), "")
+}
+
+// RenderMirroredTypingUnits は入力単位を左右反転した順序でタイピングチャレンジを描画します（鏡文字の妨害）。
+// 入力済み・現在位置の色分けは元の順序のインデックスで行います。
+func (gs *GameStyles) RenderMirroredTypingUnits(units []string, currentIndex int, mistakes []int) string {
+	pieces := renderTypingPieces(units, currentIndex, mistakes)
+	for i, j := 0, len(pieces)-1; i < j; i, j = i+1, j-1 {
+		pieces[i], pieces[j] = pieces[j], pieces[i]
+	}
+	return strings.Join(pieces, "")
+}
+
+// renderTypingPieces は入力単位ごとに入力状態に応じたスタイルで描画した文字列を返します。
+func renderTypingPieces(units []string, currentIndex int, mistakes []int) []string {
 	ts := newTypingStyles()
-	var result strings.Builder
 
-	// 誤入力位置をマップ化
 	mistakeMap := make(map[int]bool)
 	for _, pos := range mistakes {
 		mistakeMap[pos] = true
 	}
 
-	for i, char := range text {
-		charStr := string(char)
-
-		if i < currentIndex {
-			// 完了済み（誤入力があった位置はマーク）
-			if mistakeMap[i] {
-				result.WriteString(ts.Incorrect.Render(charStr))
-			} else {
-				result.WriteString(ts.Completed.Render(charStr))
-			}
-		} else if i == currentIndex {
-			// 入力中
-			result.WriteString(ts.Current.Render(charStr))
-		} else {
-			// 未入力
-			result.WriteString(ts.Remaining.Render(charStr))
+	pieces := make([]string, 0, len(units))
+	for i, unit := range units {
+		switch {
+		case i < currentIndex && mistakeMap[i]:
+			pieces = append(pieces, ts.Incorrect.Render(unit))
+		case i < currentIndex:
+			pieces = append(pieces, ts.Completed.Render(unit))
+		case i == currentIndex:
+			pieces = append(pieces, ts.Current.Render(unit))
+		default:
+			pieces = append(pieces, ts.Remaining.Render(unit))
 		}
 	}
 
+	return pieces
+}
+
+// RenderRomajiGuide は入力済みローマ字と残りの入力ガイドを描画します。
+func (gs *GameStyles) RenderRomajiGuide(typed, guide string) string {
+	ts := newTypingStyles()
+	var result strings.Builder
+
+	result.WriteString(ts.Completed.Render(typed))
+	if guide != "" {
+		runes := []rune(guide)
+		result.WriteString(ts.Current.Render(string(runes[0])))
+		result.WriteString(ts.Remaining.Render(string(runes[1:])))
+	}
+
 	return result.String()
 }
 
@@ -511,6 +548,7 @@ func (a *AnimatedHPBar) ForceComplete() {
 type FloatingText struct {
 	Text        string
 	IsHealing   bool   // true=回復（緑）、false=ダメージ（赤）
+	IsCritical  bool   // true=クリティカルダメージ（強調表示）
 	RemainingMS int    // 残り表示時間（ミリ秒）
 	YOffset     int    // Y方向オフセット（上方向に増加）
 	TargetArea  string // "enemy", "player", "agent_{index}"
@@ -552,6 +590,18 @@ func (m *FloatingDamageManager) AddDamage(amount int, targetArea string) {
 	})
 }
 
+// AddCriticalDamage はクリティカルダメージ表示を追加します。
+func (m *FloatingDamageManager) AddCriticalDamage(amount int, targetArea string) {
+	m.Texts = append(m.Texts, FloatingText{
+		Text:        fmt.Sprintf("-%d CRITICAL!", amount),
+		IsHealing:   false,
+		IsCritical:  true,
+		RemainingMS: FloatingTextDurationMS,
+		YOffset:     0,
+		TargetArea:  targetArea,
+	})
+}
+
 // AddHeal は回復表示を追加します。
 
 func (m *FloatingDamageManager) AddHeal(amount int, targetArea string) {
@@ -605,5 +655,8 @@ func (m *FloatingDamageManager) RenderFloatingText(text FloatingText, styles *Ga
 	if text.IsHealing {
 		return styles.Battle.Heal.Render(text.Text)
 	}
+	if text.IsCritical {
+		return styles.Battle.Critical.Render(text.Text)
+	}
 	return styles.Battle.Damage.Render(text.Text)
 }
@@ -2,7 +2,9 @@
 package styles
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 // ==================== Task 11.2: アニメーションとフィードバックのテスト ====================
@@ -54,6 +56,34 @@ func TestRenderTypingChallenge(t *testing.T) {
 	}
 }
 
+// TestRenderTypingChallenge_NonASCII は非ASCIIテキストの描画が書記素単位で行われることをテストします。
+func TestRenderTypingChallenge_NonASCII(t *testing.T) {
+	styles := NewGameStyles()
+
+	for _, text := range []string{"café", "größe", "日本語", "e\u0301t\u00e9"} {
+		result := styles.RenderTypingChallenge(text, 1, []int{0})
+		if !utf8.ValidString(result) {
+			t.Errorf("%q の描画で文字が壊れています: %q", text, result)
+		}
+		for _, r := range text {
+			if !strings.ContainsRune(result, r) {
+				t.Errorf("%q の描画に %q が含まれていません", text, r)
+			}
+		}
+	}
+}
+
+// TestRenderMirroredTypingUnits は鏡文字の描画で入力単位の表示順が反転することをテストします。
+func TestRenderMirroredTypingUnits(t *testing.T) {
+	styles := NewGameStyles()
+
+	result := styles.RenderMirroredTypingUnits([]string{"a", "b", "c"}, 1, nil)
+	a, c := strings.Index(result, "a"), strings.Index(result, "c")
+	if a < 0 || c < 0 || c > a {
+		t.Errorf("鏡文字では表示順が反転するべきです: %q", result)
+	}
+}
+
 // TestDamageAnimation はダメージアニメーションのテストです。
 
 func TestDamageAnimation(t *testing.T) {
@@ -353,6 +383,24 @@ func TestFloatingDamageManagerAddHeal(t *testing.T) {
 	}
 }
 
+// TestFloatingDamageManagerAddCriticalDamage はクリティカルダメージ表示の追加をテストします。
+func TestFloatingDamageManagerAddCriticalDamage(t *testing.T) {
+	manager := NewFloatingDamageManager()
+
+	manager.AddCriticalDamage(120, "enemy")
+
+	texts := manager.GetTextsForArea("enemy")
+	if len(texts) != 1 {
+		t.Fatalf("enemy エリアのテキスト数が正しくありません: got %d, want 1", len(texts))
+	}
+	if !texts[0].IsCritical || texts[0].IsHealing {
+		t.Error("クリティカルテキストのフラグが正しくありません")
+	}
+	if texts[0].Text != "-120 CRITICAL!" {
+		t.Errorf("クリティカルテキストが正しくありません: got %q", texts[0].Text)
+	}
+}
+
 // TestFloatingDamageManagerUpdate は時間経過による更新をテストします。
 
 func TestFloatingDamageManagerUpdate(t *testing.T) {
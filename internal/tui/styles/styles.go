@@ -100,6 +100,7 @@ type HPStyles struct {
 // BattleStyles はバトル画面用スタイルを保持します。
 type BattleStyles struct {
 	Damage    lipgloss.Style
+	Critical  lipgloss.Style
 	Heal      lipgloss.Style
 	Buff      lipgloss.Style
 	Debuff    lipgloss.Style
@@ -192,6 +193,10 @@ func createStyles(noColor bool) *GameStyles {
 		Damage: lipgloss.NewStyle().
 			Foreground(ColorDamage).
 			Bold(true),
+		Critical: lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			Bold(true).
+			Underline(true),
 		Heal: lipgloss.NewStyle().
 			Foreground(ColorHeal).
 			Bold(true),
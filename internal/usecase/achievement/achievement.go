@@ -25,6 +25,10 @@ const (
 	AchievementLevel50   = "level_50"
 	AchievementLevel100  = "level_100"
 	AchievementNoDamage  = "no_damage"
+
+	// クリティカル実績
+	AchievementCritical1   = "critical_1"
+	AchievementCritical100 = "critical_100"
 )
 
 // ==================================================
@@ -65,6 +69,8 @@ var allAchievements = []AchievementDefinition{
 	{AchievementLevel50, "英雄", "レベル50に到達する", "battle"},
 	{AchievementLevel100, "覇者", "レベル100に到達する", "battle"},
 	{AchievementNoDamage, "無傷の勝利", "ノーダメージでバトルに勝利する", "battle"},
+	{AchievementCritical1, "会心の一撃", "初めてクリティカルヒットを出す", "battle"},
+	{AchievementCritical100, "急所狙い", "クリティカルヒットを累計100回出す", "battle"},
 }
 
 // ==================================================
@@ -198,6 +204,24 @@ func (m *AchievementManager) CheckBattleAchievements(totalDefeated int, maxLevel
 	return notifications
 }
 
+// CheckCriticalAchievements はクリティカルヒット累計数を基に実績の解除をチェックします。
+func (m *AchievementManager) CheckCriticalAchievements(totalCriticalHits int) []AchievementNotification {
+	var notifications []AchievementNotification
+
+	if totalCriticalHits >= 1 {
+		if n := m.tryUnlock(AchievementCritical1); n != nil {
+			notifications = append(notifications, *n)
+		}
+	}
+	if totalCriticalHits >= 100 {
+		if n := m.tryUnlock(AchievementCritical100); n != nil {
+			notifications = append(notifications, *n)
+		}
+	}
+
+	return notifications
+}
+
 // tryUnlock は実績の解除を試み、成功時に通知を返します。
 // 既に解除済みの場合はnilを返します。
 func (m *AchievementManager) tryUnlock(achievementID string) *AchievementNotification {
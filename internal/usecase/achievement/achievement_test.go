@@ -203,6 +203,28 @@ func TestAchievement_NoDuplicateUnlock(t *testing.T) {
 	}
 }
 
+// TestAchievement_Critical はクリティカル実績の解除をテストします。
+func TestAchievement_Critical(t *testing.T) {
+	manager := NewAchievementManager()
+
+	if n := manager.CheckCriticalAchievements(0); len(n) != 0 {
+		t.Errorf("クリティカル0回で実績が解除されました: %v", n)
+	}
+
+	manager.CheckCriticalAchievements(1)
+	if !manager.IsUnlocked(AchievementCritical1) {
+		t.Error("初クリティカル実績が解除されていません")
+	}
+	if manager.IsUnlocked(AchievementCritical100) {
+		t.Error("累計100回未満でクリティカル100実績が解除されました")
+	}
+
+	manager.CheckCriticalAchievements(100)
+	if !manager.IsUnlocked(AchievementCritical100) {
+		t.Error("クリティカル100実績が解除されていません")
+	}
+}
+
 // ==================================================
 // 実績一覧取得テスト
 // ==================================================
@@ -0,0 +1,253 @@
+// Package climbing はエンドレスタワー（階層を登り続けるモード）を提供します。
+// 階ごとの敵レベル・敵補正の決定、階をまたいだプレイヤーHPの持ち越し、
+// 休憩階での部分回復、マイルストーン判定を担当します。
+package climbing
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/spawning"
+)
+
+// ==================== 敵補正 ====================
+
+// FloorModifier は階の敵に付くランダムな補正です。
+type FloorModifier struct {
+	// ID は補正の一意識別子です。
+	ID string
+
+	// Name は表示名です（敵名の前に付きます）。
+	Name string
+
+	// LevelBonus は敵レベルへの加算値です。
+	LevelBonus int
+
+	// HPMultiplier は敵の最大HPに掛ける倍率です（0の場合は補正なし）。
+	HPMultiplier float64
+
+	// AttackMultiplier は敵の攻撃力に掛ける倍率です（0の場合は補正なし）。
+	AttackMultiplier float64
+}
+
+// floorModifiers は抽選対象の敵補正です。
+var floorModifiers = []FloorModifier{
+	{ID: "tough", Name: "強靭", HPMultiplier: 1.5},
+	{ID: "fierce", Name: "凶暴", AttackMultiplier: 1.3},
+	{ID: "elite", Name: "精鋭", LevelBonus: 3},
+	{ID: "wounded", Name: "手負い", HPMultiplier: 0.7},
+}
+
+// ==================== 階 ====================
+
+// Floor はタワーの1つの階です。
+type Floor struct {
+	// Number は階数です（1始まり）。
+	Number int
+
+	// Level は敵のレベルです（休憩階では0）。
+	Level int
+
+	// Modifiers は敵に付く補正です（休憩階では空）。
+	Modifiers []FloorModifier
+
+	// Rest は休憩階かどうかです。休憩階では戦闘せずにHPが部分回復します。
+	Rest bool
+}
+
+// ModifierNames は敵補正の表示名を「・」区切りで返します（補正なしの場合は空文字列）。
+func (f Floor) ModifierNames() string {
+	names := make([]string, len(f.Modifiers))
+	for i, modifier := range f.Modifiers {
+		names[i] = modifier.Name
+	}
+	return strings.Join(names, "・")
+}
+
+// IsRestFloor は指定した階が休憩階かどうかを返します。
+func IsRestFloor(number int) bool {
+	return number%config.TowerRestFloorInterval == 0
+}
+
+// IsMilestoneFloor は指定した階の突破がマイルストーン報酬の対象かどうかを返します。
+func IsMilestoneFloor(number int) bool {
+	return number%config.TowerMilestoneInterval == 0
+}
+
+// NextMilestoneFloor は指定した階以降で最初にマイルストーン報酬を獲得できる階を返します。
+func NextMilestoneFloor(number int) int {
+	interval := config.TowerMilestoneInterval
+	return (number + interval - 1) / interval * interval
+}
+
+// ==================== タワー挑戦 ====================
+
+// TowerRun はエンドレスタワーの1回の挑戦です。
+// プレイヤーHPは階をまたいで持ち越され、敗北すると挑戦は終了します。
+type TowerRun struct {
+	// generator は敵の生成に使う敵生成器です。
+	generator *spawning.EnemyGenerator
+
+	// floor は現在の階です。
+	floor Floor
+
+	// playerHP は持ち越しているプレイヤーHPです。
+	playerHP int
+
+	// playerMaxHP はプレイヤーの最大HPです。
+	playerMaxHP int
+
+	// over は挑戦が終了したかどうかです。
+	over bool
+
+	// rng は敵レベルの上振れと敵補正の抽選に使う乱数生成器です。
+	rng *rand.Rand
+}
+
+// NewTowerRun は1階から始まる新しい挑戦を作成します。
+// プレイヤーHPは最大HPの状態で開始します。
+func NewTowerRun(generator *spawning.EnemyGenerator, playerMaxHP int) *TowerRun {
+	r := &TowerRun{
+		generator:   generator,
+		playerHP:    playerMaxHP,
+		playerMaxHP: playerMaxHP,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	r.floor = r.newFloor(1)
+	return r
+}
+
+// SetRng は乱数生成器を設定します（テスト用）。
+// 現在の階の敵レベルと敵補正も新しい乱数生成器で決め直します。
+func (r *TowerRun) SetRng(rng *rand.Rand) {
+	r.rng = rng
+	r.floor = r.newFloor(r.floor.Number)
+}
+
+// newFloor は指定した階の敵レベルと敵補正を決定します。
+func (r *TowerRun) newFloor(number int) Floor {
+	if IsRestFloor(number) {
+		return Floor{Number: number, Rest: true}
+	}
+
+	floor := Floor{
+		Number: number,
+		Level:  number + r.rng.Intn(config.TowerLevelVariance+1),
+	}
+
+	// 高い階ほど多くの補正が付く可能性がある
+	maxModifiers := 1 + number/config.TowerModifierFloorStep
+	if maxModifiers > config.TowerMaxFloorModifiers {
+		maxModifiers = config.TowerMaxFloorModifiers
+	}
+	if maxModifiers > len(floorModifiers) {
+		maxModifiers = len(floorModifiers)
+	}
+	count := r.rng.Intn(maxModifiers + 1)
+	for _, idx := range r.rng.Perm(len(floorModifiers))[:count] {
+		modifier := floorModifiers[idx]
+		floor.Modifiers = append(floor.Modifiers, modifier)
+		floor.Level += modifier.LevelBonus
+	}
+
+	if floor.Level > spawning.MaxEnemyLevel {
+		floor.Level = spawning.MaxEnemyLevel
+	}
+	return floor
+}
+
+// Floor は現在の階を返します。
+func (r *TowerRun) Floor() Floor {
+	return r.floor
+}
+
+// PlayerHP は持ち越しているプレイヤーHPを返します。
+func (r *TowerRun) PlayerHP() int {
+	return r.playerHP
+}
+
+// PlayerMaxHP はプレイヤーの最大HPを返します。
+func (r *TowerRun) PlayerMaxHP() int {
+	return r.playerMaxHP
+}
+
+// IsOver は挑戦が終了したかどうかを返します。
+func (r *TowerRun) IsOver() bool {
+	return r.over
+}
+
+// GenerateEnemy は現在の階の敵を生成し、敵補正を適用します。
+// 休憩階や挑戦終了後はnilを返します。
+func (r *TowerRun) GenerateEnemy() *domain.EnemyModel {
+	if r.over || r.floor.Rest {
+		return nil
+	}
+
+	enemy := r.generator.Generate(r.floor.Level)
+	for _, modifier := range r.floor.Modifiers {
+		if modifier.HPMultiplier > 0 {
+			enemy.MaxHP = int(float64(enemy.MaxHP) * modifier.HPMultiplier)
+			if enemy.MaxHP < 1 {
+				enemy.MaxHP = 1
+			}
+			enemy.HP = enemy.MaxHP
+		}
+		if modifier.AttackMultiplier > 0 {
+			enemy.AttackPower = int(float64(enemy.AttackPower) * modifier.AttackMultiplier)
+		}
+	}
+	if names := r.floor.ModifierNames(); names != "" {
+		enemy.Name = fmt.Sprintf("[%s]%s", names, enemy.Name)
+	}
+	return enemy
+}
+
+// RecordVictory は現在の階の勝利を記録し、バトル終了時のHPを持ち越して次の階へ進みます。
+// 突破した階がマイルストーン報酬の対象の場合はtrueを返します。
+func (r *TowerRun) RecordVictory(playerHP int) bool {
+	if r.over || r.floor.Rest {
+		return false
+	}
+	r.playerHP = playerHP
+	if r.playerHP > r.playerMaxHP {
+		r.playerHP = r.playerMaxHP
+	}
+	return r.advance()
+}
+
+// Rest は休憩階で最大HPのTowerRestHealRatio分だけHPを回復し、次の階へ進みます。
+// 実際に回復した量と、突破した階がマイルストーン報酬の対象かどうかを返します。
+// 休憩階以外や挑戦終了後は何もしません。
+func (r *TowerRun) Rest() (healed int, milestone bool) {
+	if r.over || !r.floor.Rest {
+		return 0, false
+	}
+	before := r.playerHP
+	r.playerHP += int(float64(r.playerMaxHP) * config.TowerRestHealRatio)
+	if r.playerHP > r.playerMaxHP {
+		r.playerHP = r.playerMaxHP
+	}
+	return r.playerHP - before, r.advance()
+}
+
+// RecordDefeat は現在の階での敗北を記録し、挑戦を終了します。
+func (r *TowerRun) RecordDefeat() {
+	r.over = true
+	r.playerHP = 0
+}
+
+// RecordRetreat は現在の階からの撤退を記録し、挑戦を終了します。
+func (r *TowerRun) RecordRetreat() {
+	r.over = true
+}
+
+// advance は次の階へ進み、突破した階がマイルストーン報酬の対象かどうかを返します。
+func (r *TowerRun) advance() bool {
+	cleared := r.floor.Number
+	r.floor = r.newFloor(cleared + 1)
+	return IsMilestoneFloor(cleared)
+}
@@ -0,0 +1,171 @@
+// Package climbing はエンドレスタワー（階層を登り続けるモード）を提供します。
+package climbing
+
+import (
+	"math/rand"
+	"testing"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/spawning"
+)
+
+// newTestTowerRun はテスト用のタワー挑戦を作成するヘルパー関数です。
+func newTestTowerRun(playerMaxHP int) *TowerRun {
+	generator := spawning.NewEnemyGenerator([]domain.EnemyType{
+		{ID: "slime", Name: "スライム", BaseHP: 100, BaseAttackPower: 10, AttackType: "physical"},
+	})
+	generator.SetSeed(1)
+	run := NewTowerRun(generator, playerMaxHP)
+	run.SetRng(rand.New(rand.NewSource(1)))
+	return run
+}
+
+// TestTowerRun_FloorLevel は敵レベルが階数以上・上振れ幅以内になることをテストします。
+func TestTowerRun_FloorLevel(t *testing.T) {
+	run := newTestTowerRun(100)
+
+	for i := 0; i < 30; i++ {
+		floor := run.Floor()
+		if floor.Rest {
+			if floor.Level != 0 || len(floor.Modifiers) != 0 {
+				t.Errorf("%d階: 休憩階に敵レベル・補正が設定されている", floor.Number)
+			}
+			run.Rest()
+			continue
+		}
+
+		bonus := 0
+		for _, modifier := range floor.Modifiers {
+			bonus += modifier.LevelBonus
+		}
+		if floor.Level < floor.Number+bonus || floor.Level > floor.Number+bonus+config.TowerLevelVariance {
+			t.Errorf("%d階: 敵レベル %d が範囲外", floor.Number, floor.Level)
+		}
+
+		enemy := run.GenerateEnemy()
+		if enemy == nil || enemy.Level != floor.Level {
+			t.Fatalf("%d階: 階の敵レベルで敵が生成されていない", floor.Number)
+		}
+		run.RecordVictory(run.PlayerHP())
+	}
+}
+
+// TestTowerRun_ModifiersApplied は敵補正が生成した敵のステータスと名前に反映されることをテストします。
+func TestTowerRun_ModifiersApplied(t *testing.T) {
+	run := newTestTowerRun(100)
+	run.floor = Floor{
+		Number:    1,
+		Level:     1,
+		Modifiers: []FloorModifier{floorModifiers[0], floorModifiers[1]},
+	}
+
+	enemy := run.GenerateEnemy()
+
+	if enemy.MaxHP != 150 || enemy.HP != 150 {
+		t.Errorf("強靭の補正でHPが1.5倍になっていない: MaxHP=%d, HP=%d", enemy.MaxHP, enemy.HP)
+	}
+	if enemy.AttackPower != 15 {
+		t.Errorf("凶暴の補正で攻撃力が1.3倍になっていない: %d", enemy.AttackPower)
+	}
+	if enemy.Name != "[強靭・凶暴]スライム Lv.1" {
+		t.Errorf("敵名に補正が表示されていない: %q", enemy.Name)
+	}
+}
+
+// TestTowerRun_HPCarryOver はHPの持ち越しと休憩階での部分回復をテストします。
+func TestTowerRun_HPCarryOver(t *testing.T) {
+	run := newTestTowerRun(100)
+
+	for run.Floor().Number < config.TowerRestFloorInterval {
+		run.RecordVictory(40)
+	}
+	if run.PlayerHP() != 40 {
+		t.Errorf("HPが持ち越されていない: 期待 40, 実際 %d", run.PlayerHP())
+	}
+
+	if !run.Floor().Rest {
+		t.Fatalf("%d階が休憩階になっていない", run.Floor().Number)
+	}
+	if run.GenerateEnemy() != nil {
+		t.Error("休憩階で敵が生成された")
+	}
+	if run.RecordVictory(100) {
+		t.Error("休憩階で勝利を記録できてしまった")
+	}
+
+	healed, _ := run.Rest()
+	expected := int(100 * config.TowerRestHealRatio)
+	if healed != expected || run.PlayerHP() != 40+expected {
+		t.Errorf("休憩階の回復量: 期待 %d, 実際 %d (HP %d)", expected, healed, run.PlayerHP())
+	}
+	if run.Floor().Number != config.TowerRestFloorInterval+1 {
+		t.Errorf("休憩後に次の階へ進んでいない: %d階", run.Floor().Number)
+	}
+
+	// 最大HPを超えて回復しない
+	run.playerHP = 95
+	run.floor = Floor{Number: config.TowerRestFloorInterval * 2, Rest: true}
+	if healed, _ := run.Rest(); healed != 5 || run.PlayerHP() != 100 {
+		t.Errorf("最大HPを超えて回復した: 回復量 %d, HP %d", healed, run.PlayerHP())
+	}
+}
+
+// TestTowerRun_Milestone はマイルストーンの階を突破したときだけtrueが返ることをテストします。
+func TestTowerRun_Milestone(t *testing.T) {
+	run := newTestTowerRun(100)
+
+	milestones := []int{}
+	for run.Floor().Number <= config.TowerMilestoneInterval*2 {
+		number := run.Floor().Number
+		var milestone bool
+		if run.Floor().Rest {
+			_, milestone = run.Rest()
+		} else {
+			milestone = run.RecordVictory(100)
+		}
+		if milestone {
+			milestones = append(milestones, number)
+		}
+	}
+
+	if len(milestones) != 2 || milestones[0] != config.TowerMilestoneInterval || milestones[1] != config.TowerMilestoneInterval*2 {
+		t.Errorf("マイルストーンの階: 実際 %v", milestones)
+	}
+	if NextMilestoneFloor(1) != config.TowerMilestoneInterval || NextMilestoneFloor(config.TowerMilestoneInterval+1) != config.TowerMilestoneInterval*2 {
+		t.Error("次のマイルストーンの階が正しくない")
+	}
+}
+
+// TestTowerRun_Defeat は敗北で挑戦が終了し、以降は進行できないことをテストします。
+func TestTowerRun_Defeat(t *testing.T) {
+	run := newTestTowerRun(100)
+	run.RecordVictory(80)
+
+	run.RecordDefeat()
+
+	if !run.IsOver() || run.PlayerHP() != 0 {
+		t.Error("敗北で挑戦が終了していない")
+	}
+	if run.GenerateEnemy() != nil || run.RecordVictory(100) {
+		t.Error("挑戦終了後に進行できてしまった")
+	}
+	if run.Floor().Number != 2 {
+		t.Errorf("敗北した階が保持されていない: %d階", run.Floor().Number)
+	}
+}
+
+// TestTowerRun_Retreat は撤退で挑戦が終了し、撤退した階が保持されることをテストします。
+func TestTowerRun_Retreat(t *testing.T) {
+	run := newTestTowerRun(100)
+	run.RecordVictory(80)
+
+	run.RecordRetreat()
+
+	if !run.IsOver() || run.GenerateEnemy() != nil {
+		t.Error("撤退で挑戦が終了していない")
+	}
+	if run.Floor().Number != 2 {
+		t.Errorf("撤退した階が保持されていない: %d階", run.Floor().Number)
+	}
+}
@@ -0,0 +1,77 @@
+// Package clock はゲーム内の時刻取得を抽象化します。
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+// TestManualClock はManualClockの時刻操作をテストします。
+func TestManualClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+
+	if !c.Now().Equal(start) {
+		t.Errorf("初期時刻: 期待 %v, 実際 %v", start, c.Now())
+	}
+
+	c.Advance(1500 * time.Millisecond)
+	if got := Since(c, start); got != 1500*time.Millisecond {
+		t.Errorf("経過時間: 期待 1.5s, 実際 %v", got)
+	}
+
+	later := start.Add(time.Hour)
+	c.Set(later)
+	if !c.Now().Equal(later) {
+		t.Errorf("Set後の時刻: 期待 %v, 実際 %v", later, c.Now())
+	}
+}
+
+// TestSystemClock はSystemClockが壁時計を返すことをテストします。
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := NewSystemClock().Now()
+	if now.Before(before) {
+		t.Errorf("SystemClockの時刻が壁時計より前です: %v < %v", now, before)
+	}
+}
+
+// TestPausableClock は一時停止中に時刻が止まり、再開後に連続することをテストします。
+func TestPausableClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := NewManualClock(start)
+	c := NewPausableClock(base)
+
+	base.Advance(time.Second)
+	c.Pause()
+	if !c.IsPaused() {
+		t.Fatal("Pause後にIsPausedがfalseです")
+	}
+
+	// 一時停止中は時刻が進まない
+	base.Advance(10 * time.Second)
+	if got := Since(c, start); got != time.Second {
+		t.Errorf("一時停止中の経過時間: 期待 1s, 実際 %v", got)
+	}
+
+	// 二重のPauseは停止開始時刻を変えない
+	c.Pause()
+	c.Resume()
+	if c.IsPaused() {
+		t.Fatal("Resume後にIsPausedがtrueです")
+	}
+	if got := Since(c, start); got != time.Second {
+		t.Errorf("再開直後の経過時間: 期待 1s, 実際 %v", got)
+	}
+
+	base.Advance(500 * time.Millisecond)
+	if got := Since(c, start); got != 1500*time.Millisecond {
+		t.Errorf("再開後の経過時間: 期待 1.5s, 実際 %v", got)
+	}
+
+	// 一時停止中でなければResumeは何もしない
+	c.Resume()
+	if got := Since(c, start); got != 1500*time.Millisecond {
+		t.Errorf("不要なResume後の経過時間: 期待 1.5s, 実際 %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+// Package combat はバトルエンジンを提供します。
+// ailment.go は毒・火傷・スタン・沈黙などの状態異常の付与と解除を担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+)
+
+// applyAilmentToPlayer はプレイヤーに状態異常を付与します。
+// スタン・沈黙は装備エージェントからランダムに1体を対象にします。
+func (e *BattleEngine) applyAilmentToPlayer(state *BattleState, source string, col domain.EffectColumn, value, duration float64) {
+	agentIndex := -1
+	if !domain.IsDamageOverTimeColumn(col) {
+		if len(state.EquippedAgents) == 0 {
+			return
+		}
+		agentIndex = e.rng.Intn(len(state.EquippedAgents))
+	}
+	state.Player.EffectTable.AddAilment(col, value, duration, agentIndex)
+	e.emitEffectAdded(state, source, BattleEventPlayer, domain.AilmentName(col))
+}
+
+// applyModuleAilment はモジュール効果のうち状態異常の付与・解除を適用します。
+// 状態異常に関する効果でない場合はfalseを返し、通常のバフ/デバフとして扱わせます。
+func (e *BattleEngine) applyModuleAilment(state *BattleState, source string, effect *domain.ModuleEffect) bool {
+	col := effect.ColumnSpec.Column
+	if col == domain.ColCleanse {
+		// 状態異常解除は常にプレイヤー自身が対象
+		if removed := state.Player.EffectTable.RemoveAilments(); removed > 0 {
+			e.emit(state, BattleEvent{Kind: BattleEventBuffExpired, Source: source, Target: BattleEventPlayer, Detail: fmt.Sprintf("状態異常%d個", removed)})
+		}
+		return true
+	}
+	if !domain.IsAilmentColumn(col) {
+		return false
+	}
+
+	duration := effect.ColumnSpec.Duration
+	if duration == 0 {
+		duration = config.BuffDuration
+	}
+	switch effect.Target {
+	case domain.TargetEnemy, domain.TargetBoth:
+		state.Enemy.EffectTable.AddAilment(col, effect.ColumnSpec.Value, duration, -1)
+		e.emitEffectAdded(state, source, state.Enemy.Name, domain.AilmentName(col))
+	case domain.TargetAll:
+		for _, enemy := range state.AliveEnemies() {
+			enemy.EffectTable.AddAilment(col, effect.ColumnSpec.Value, duration, -1)
+			e.emitEffectAdded(state, source, enemy.Name, domain.AilmentName(col))
+		}
+	}
+	return true
+}
+
+// IsEnemySilenced は敵が沈黙により指定の行動を使えないかを判定します。
+// 沈黙中でも攻撃行動は使用できます。
+func (e *BattleEngine) IsEnemySilenced(state *BattleState, action domain.EnemyAction) bool {
+	return action.ActionType != domain.EnemyActionAttack && state.Enemy.EffectTable.HasAilment(domain.ColSilence)
+}
@@ -0,0 +1,101 @@
+// Package combat はバトルエンジンを提供します。
+// ailment_test.go は状態異常の付与・継続ダメージ・解除のテストです。
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// newAilmentModule は状態異常の効果列を持つモジュールを作成するヘルパー関数です。
+func newAilmentModule(target domain.EffectTarget, col domain.EffectColumn, value float64) *domain.ModuleModel {
+	return domain.NewModuleFromType(domain.ModuleType{
+		ID:   "ailment_test",
+		Name: "状態異常テスト",
+		Tags: []string{"magic_low"},
+		Effects: []domain.ModuleEffect{
+			{
+				Target:      target,
+				ColumnSpec:  &domain.EffectColumnSpec{Column: col, Value: value, Duration: 5.0},
+				Probability: 1.0,
+			},
+		},
+	}, nil)
+}
+
+// TestApplyPatternDebuff_Ailments は敵の毒でプレイヤーが継続ダメージを受け、スタンがエージェント1体に付与されることをテストします。
+func TestApplyPatternDebuff_Ailments(t *testing.T) {
+	engine, state, _ := newElementTestBattle(t, nil, nil)
+	state.Player.HP = 100
+	state.Player.MaxHP = 100
+
+	engine.ApplyPatternDebuff(state, domain.EnemyAction{EffectType: "poison", EffectValue: 4.0, Duration: 3.0})
+	engine.ApplyPatternDebuff(state, domain.EnemyAction{EffectType: "stun", Duration: 3.0})
+
+	if len(state.Player.EffectTable.FindBySourceType(domain.SourceDebuff)) != 0 {
+		t.Error("状態異常はデバフとして登録されるべきではありません")
+	}
+	if !state.Player.EffectTable.HasAgentAilment(domain.ColStun, 0) {
+		t.Error("装備エージェントにスタンが付与されるべき")
+	}
+
+	engine.UpdateEffects(state, 1.0)
+	if state.Player.HP != 96 {
+		t.Errorf("毒の継続ダメージ: 期待 HP96, 実際 %d", state.Player.HP)
+	}
+	if state.Stats.TotalDamageTaken != 4 {
+		t.Errorf("継続ダメージが被ダメージ統計に記録されるべき: 実際 %d", state.Stats.TotalDamageTaken)
+	}
+}
+
+// TestApplyModuleEffect_AilmentAndCleanse はモジュールで敵に状態異常を付与し、自分の状態異常を解除できることをテストします。
+func TestApplyModuleEffect_AilmentAndCleanse(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+
+	dealModuleDamage(engine, state, agent, newAilmentModule(domain.TargetEnemy, domain.ColBurn, 10.0))
+	if !state.Enemy.EffectTable.HasAilment(domain.ColBurn) {
+		t.Fatal("敵に火傷が付与されるべき")
+	}
+	if len(state.Enemy.EffectTable.FindBySourceType(domain.SourceDebuff)) != 0 {
+		t.Error("状態異常はデバフとして登録されるべきではありません")
+	}
+	enemyHPBefore := state.Enemy.HP
+	engine.UpdateEffects(state, 1.0)
+	if got := enemyHPBefore - state.Enemy.HP; got != 10 {
+		t.Errorf("火傷の継続ダメージ: 期待 10, 実際 %d", got)
+	}
+
+	state.Player.EffectTable.AddAilment(domain.ColPoison, 2.0, 5.0, -1)
+	state.Player.EffectTable.AddAilment(domain.ColSilence, 0, 5.0, 0)
+	dealModuleDamage(engine, state, agent, newAilmentModule(domain.TargetSelf, domain.ColCleanse, 1.0))
+	if len(state.Player.EffectTable.GetAilments()) != 0 {
+		t.Error("状態異常解除でプレイヤーの状態異常が全て解除されるべき")
+	}
+	if len(state.Player.EffectTable.FindBySourceType(domain.SourceBuff)) != 0 {
+		t.Error("状態異常解除はバフとして登録されるべきではありません")
+	}
+}
+
+// TestProcessEnemyTurn_Silenced は沈黙中の敵の攻撃以外の行動が不発になることをテストします。
+func TestProcessEnemyTurn_Silenced(t *testing.T) {
+	engine, state := newEnemyAITestBattle(nil, []domain.EnemyAction{aiTestRepair})
+	state.Enemy.HP = 50
+	state.Enemy.EffectTable.AddAilment(domain.ColSilence, 0, 5.0, -1)
+	state.Enemy.PrepareAction(aiTestRepair)
+
+	result := engine.ProcessEnemyTurn(state)
+	if state.Enemy.HP != 50 || result.Healed != 0 {
+		t.Errorf("沈黙中は回復行動が不発になるべき: HP %d", state.Enemy.HP)
+	}
+	if !strings.Contains(result.Message, "沈黙") {
+		t.Errorf("不発のメッセージ: %q", result.Message)
+	}
+
+	// 攻撃行動は沈黙中でも使用できる
+	state.Enemy.PrepareAction(aiTestBite)
+	if result := engine.ProcessEnemyTurn(state); result.Damage == 0 && !result.Evaded {
+		t.Errorf("沈黙中でも攻撃は実行されるべき: %+v", result)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"hirorocky/type-battle/internal/config"
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
 	"hirorocky/type-battle/internal/usecase/combat/voltage"
 	"hirorocky/type-battle/internal/usecase/typing"
 
@@ -46,6 +47,12 @@ const (
 
 	// EnemyActionDefense はディフェンス行動
 	EnemyActionDefense
+
+	// EnemyActionSelfHeal は自己回復行動
+	EnemyActionSelfHeal
+
+	// EnemyActionDispel はプレイヤーのバフ解除行動
+	EnemyActionDispel
 )
 
 // NextEnemyAction は敵の次回行動を表します。
@@ -90,8 +97,17 @@ type EnemyTurnResult struct {
 	// PhaseChanged はフェーズ遷移が発生したかどうか
 	PhaseChanged bool
 
+	// PhaseMessage はフェーズ遷移時のメッセージ（移行時行動の結果を含む）
+	PhaseMessage string
+
 	// Evaded は回避されたかどうか
 	Evaded bool
+
+	// Healed は敵が回復したHP（回復行動時のみ）
+	Healed int
+
+	// Parry は行動に対するプレイヤーのパリィの結果です。
+	Parry domain.ParryResult
 }
 
 // BattleStatistics はバトル統計を表す構造体です。
@@ -116,6 +132,12 @@ type BattleStatistics struct {
 
 	// TotalHealAmount は総回復量です。
 	TotalHealAmount int
+
+	// CriticalHits はクリティカルヒット回数です。
+	CriticalHits int
+
+	// PeakVoltage はバトル中に敵が到達したボルテージの最大値です（100.0 = 100%）。
+	PeakVoltage float64
 }
 
 // GetAverageWPM は平均WPMを返します。
@@ -134,16 +156,25 @@ func (s *BattleStatistics) GetAverageAccuracy() float64 {
 	return s.TotalAccuracy / float64(s.TotalTypingCount)
 }
 
-// GetClearTime はクリア時間を返します。
-func (s *BattleStatistics) GetClearTime() time.Duration {
-	return time.Since(s.StartTime)
+// GetClearTime はバトル開始からの経過時間をバトルの時計で返します。
+// 一時停止可能な時計を渡すと、一時停止していた時間は含まれません。
+func (s *BattleStatistics) GetClearTime(c clock.Clock) time.Duration {
+	return clock.Since(c, s.StartTime)
 }
 
 // BattleState はバトルの状態を表す構造体です。
 type BattleState struct {
 	// Enemy は敵の状態です。
+	// 複数敵バトルではプレイヤーの攻撃対象として選択中の敵を指します。
 	Enemy *domain.EnemyModel
 
+	// Waves はウェーブごとの敵です（複数敵・ウェーブ戦用）。
+	// 空の場合はEnemyのみの1対1バトルとして扱います。
+	Waves [][]*domain.EnemyModel
+
+	// Wave は現在のウェーブのインデックスです。
+	Wave int
+
 	// Player はプレイヤーの状態です。
 	Player *domain.PlayerModel
 
@@ -161,6 +192,25 @@ type BattleState struct {
 
 	// SameAttackCount は同じ属性の攻撃の連続回数です。
 	SameAttackCount int
+
+	// ChainLength はエージェントを切り替えながら連続でモジュールを使用した回数です（パッシブスキル評価用）。
+	ChainLength int
+
+	// DiscoveredWeaknesses はこのバトルで突いた敵の弱点属性です（敵タイプIDごと、敵図鑑用）。
+	DiscoveredWeaknesses map[string][]domain.Element
+}
+
+// recordWeakness は敵の弱点属性を突いたことを記録します。
+func (s *BattleState) recordWeakness(enemyTypeID string, element domain.Element) {
+	for _, e := range s.DiscoveredWeaknesses[enemyTypeID] {
+		if e == element {
+			return
+		}
+	}
+	if s.DiscoveredWeaknesses == nil {
+		s.DiscoveredWeaknesses = make(map[string][]domain.Element)
+	}
+	s.DiscoveredWeaknesses[enemyTypeID] = append(s.DiscoveredWeaknesses[enemyTypeID], element)
 }
 
 // BattleResult はバトル結果を表す構造体です。
@@ -168,6 +218,9 @@ type BattleResult struct {
 	// IsVictory は勝利かどうかです。
 	IsVictory bool
 
+	// IsRetreated は撤退による終了かどうかです。
+	IsRetreated bool
+
 	// Stats はバトル統計です。
 	Stats *BattleStatistics
 }
@@ -185,6 +238,15 @@ type BattleEngine struct {
 
 	// voltageManager はボルテージ管理を担当します。
 	voltageManager *voltage.VoltageManager
+
+	// clock はチャージ・ディフェンス判定に使う時計です。
+	clock clock.Clock
+
+	// log はバトルイベントの記録先です（nilの場合は記録しない）。
+	log *BattleLog
+
+	// tracing はダメージ・回復量の計算トレースを記録するかどうかです。
+	tracing bool
 }
 
 // NewBattleEngine は新しいBattleEngineを作成します。
@@ -193,6 +255,7 @@ func NewBattleEngine(enemyTypes []domain.EnemyType) *BattleEngine {
 		enemyTypes:     enemyTypes,
 		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
 		voltageManager: voltage.NewVoltageManager(),
+		clock:          clock.NewSystemClock(),
 	}
 }
 
@@ -207,6 +270,16 @@ func (e *BattleEngine) SetRng(rng *rand.Rand) {
 	e.rng = rng
 }
 
+// SetClock はチャージ・ディフェンス判定に使う時計を設定します。
+func (e *BattleEngine) SetClock(c clock.Clock) {
+	e.clock = c
+}
+
+// Now は時計の現在時刻を返します。
+func (e *BattleEngine) Now() time.Time {
+	return e.clock.Now()
+}
+
 // ==================== バトル初期化（Task 7.1） ====================
 
 // InitializeBattle はバトルを初期化します。
@@ -234,13 +307,13 @@ func (e *BattleEngine) InitializeBattle(level int, agents []*domain.AgentModel)
 		EquippedAgents: agents,
 		Level:          level,
 		Stats: &BattleStatistics{
-			StartTime: time.Now(),
+			StartTime: e.clock.Now(),
 		},
 	}
 
-	// 最初の行動を準備してチャージ開始
-	state.Enemy.PrepareNextAction()
-	e.StartEnemyCharging(state, time.Now())
+	// 最初の行動を決定してチャージ開始
+	e.PrepareEnemyAction(state)
+	e.StartEnemyCharging(state, e.clock.Now())
 
 	return state, nil
 }
@@ -287,7 +360,12 @@ func (e *BattleEngine) evaluateDamageRecvPassives(state *BattleState, damage int
 				if hpPercent <= threshold {
 					// 確率判定
 					if e.rng.Float64() < def.Probability {
-						return int(def.EffectValue) // ダメージを固定値に
+						fixed := int(def.EffectValue) // ダメージを固定値に
+						e.emit(state, BattleEvent{
+							Kind: BattleEventPassive, Source: def.Name, Target: BattleEventPlayer,
+							Detail: fmt.Sprintf("被ダメージを%dに固定", fixed),
+						})
+						return fixed
 					}
 				}
 			}
@@ -313,6 +391,7 @@ func (e *BattleEngine) applyPostDamagePassives(state *BattleState) {
 					}
 					description := domain.DescribeEffectValues(values)
 					state.Player.EffectTable.AddBuff(description, duration, values)
+					e.emit(state, BattleEvent{Kind: BattleEventPassive, Source: def.Name, Target: BattleEventPlayer, Detail: description})
 				}
 			}
 		}
@@ -330,13 +409,14 @@ func (e *BattleEngine) RecordAttackType(state *BattleState, attackType string) {
 }
 
 // ProcessEnemyAttackDamage は攻撃パターンを考慮してダメージを処理します。
-// ps_adaptive_shield（同種攻撃連続時の軽減）などを評価します。
-func (e *BattleEngine) ProcessEnemyAttackDamage(state *BattleState, attackType string) int {
+// ps_adaptive_shield（同種攻撃連続時の軽減）や、攻撃属性に対する装備コアの耐性・属性別軽減を評価します。
+func (e *BattleEngine) ProcessEnemyAttackDamage(state *BattleState, attackType string, element domain.Element) int {
 	// 攻撃タイプを記録
 	e.RecordAttackType(state, attackType)
 
 	// 敵バフ適用済みの攻撃力を取得
-	attackPower := e.getBuffedAttackPower(state)
+	trace := e.newTrace()
+	attackPower := e.buffedAttackPower(state, trace)
 
 	// プレイヤーの防御効果を計算
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
@@ -345,6 +425,7 @@ func (e *BattleEngine) ProcessEnemyAttackDamage(state *BattleState, attackType s
 
 	// 回避判定
 	if effects.Evasion > 0 && e.rng.Float64() < effects.Evasion {
+		e.emit(state, BattleEvent{Kind: BattleEventEvade, Source: state.Enemy.Name, Target: BattleEventPlayer})
 		return 0
 	}
 
@@ -357,16 +438,33 @@ func (e *BattleEngine) ProcessEnemyAttackDamage(state *BattleState, attackType s
 		totalDamageCut = 1.0 // 最大100%軽減
 	}
 	damage := calculateDamage(attackPower, totalDamageCut)
+	trace.step("防御軽減", float64(damage), "被ダメ軽減%.0f%% + 適応シールド%.0f%%（最大100%%・最低1）", effects.DamageCut*100, adaptiveShieldCut*100)
+	trace.addEffects(BattleEventPlayer, effects, domain.ColDamageCut)
+
+	// 属性倍率を適用（装備コアの弱点・耐性と属性別軽減）
+	damage = e.applyElementToPlayer(state, element, effects, damage, trace)
 
 	// ボルテージ乗算を適用（敵の怒りによるダメージ増加）
 	damage = e.applyVoltageMultiplier(state, damage)
+	trace.step("ボルテージ", float64(damage), "×%.2f", state.Enemy.GetVoltageMultiplier())
+
+	// パリィの結果を適用（軽減または失敗による増加）
+	damage = applyParryToDamage(state.Enemy.Parry, damage)
+	traceParry(trace, state.Enemy.Parry, damage)
 
 	// 被ダメージ時パッシブの評価
-	damage = e.evaluateDamageRecvPassives(state, damage)
+	if fixed := e.evaluateDamageRecvPassives(state, damage); fixed != damage {
+		damage = fixed
+		trace.step("被ダメージ時パッシブ", float64(damage), "ダメージを%dに固定", fixed)
+	}
 
 	// プレイヤーにダメージを与える
 	state.Player.TakeDamage(damage)
 	state.Stats.TotalDamageTaken += damage
+	e.emit(state, BattleEvent{
+		Kind: BattleEventDamageTaken, Source: state.Enemy.Name, Target: BattleEventPlayer,
+		Amount: damage, Detail: parryEventDetail(state.Enemy.Parry), Trace: trace,
+	})
 
 	// 反射処理
 	if effects.Reflect > 0 && damage > 0 {
@@ -374,6 +472,7 @@ func (e *BattleEngine) ProcessEnemyAttackDamage(state *BattleState, attackType s
 		if reflectDamage > 0 {
 			state.Enemy.TakeDamage(reflectDamage)
 			state.Stats.TotalDamageDealt += reflectDamage
+			e.emit(state, BattleEvent{Kind: BattleEventDamageDealt, Source: "反射", Target: state.Enemy.Name, Amount: reflectDamage})
 		}
 	}
 
@@ -390,46 +489,65 @@ func (e *BattleEngine) ProcessEnemyTurn(state *BattleState) EnemyTurnResult {
 	if action == nil {
 		return EnemyTurnResult{Message: "行動なし"}
 	}
-	result := EnemyTurnResult{ActionType: action.ActionType}
+	result := EnemyTurnResult{ActionType: action.ActionType, Parry: state.Enemy.Parry}
 
-	switch action.ActionType {
-	case domain.EnemyActionAttack:
-		result.Damage = e.ProcessEnemyAttackDamage(state, action.AttackType)
-		if result.Damage == 0 {
-			result.Evaded = true
-			result.Message = "回避！"
-		} else {
-			result.Message = fmt.Sprintf("%s！%dダメージを受けた！", action.Name, result.Damage)
-		}
+	if isParried(state.Enemy) {
+		// 完全パリィされた行動は無効化される
+		result.Message = parryMessage(state.Enemy, *action)
+	} else if e.IsEnemySilenced(state, *action) {
+		// 沈黙中は攻撃以外の行動が不発に終わる
+		result.Message = fmt.Sprintf("%sは沈黙していて%sを使えない！", state.Enemy.Name, action.Name)
+	} else {
+		switch action.ActionType {
+		case domain.EnemyActionAttack:
+			result.Damage = e.ProcessEnemyAttackDamage(state, action.AttackType, action.Element)
+			if result.Damage == 0 {
+				result.Evaded = true
+				result.Message = "回避！"
+			} else {
+				result.Message = fmt.Sprintf("%s！%dダメージを受けた！%s", action.Name, result.Damage, parryDamageLabel(result.Parry))
+			}
 
-	case domain.EnemyActionBuff:
-		e.ApplyPatternBuff(state, *action)
-		result.Message = fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
+		case domain.EnemyActionBuff:
+			e.ApplyPatternBuff(state, *action)
+			result.Message = fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
 
-	case domain.EnemyActionDebuff:
-		if e.CheckDebuffEvasion(state) {
-			result.Message = fmt.Sprintf("%sを回避した！", action.Name)
-		} else {
-			e.ApplyPatternDebuff(state, *action)
+		case domain.EnemyActionDebuff:
+			if e.CheckDebuffEvasion(state) {
+				result.Message = fmt.Sprintf("%sを回避した！", action.Name)
+			} else {
+				e.ApplyPatternDebuff(state, *action)
+				result.Message = fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
+			}
+
+		case domain.EnemyActionDefense:
 			result.Message = fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
-		}
 
-	case domain.EnemyActionDefense:
-		result.Message = fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
+		case domain.EnemyActionHeal:
+			result.Healed = e.ApplyPatternHeal(state, *action)
+			result.Message = fmt.Sprintf("%sでHPを%d回復した！", action.Name, result.Healed)
+
+		case domain.EnemyActionDispel:
+			removed := e.ApplyPatternDispel(state)
+			result.Message = fmt.Sprintf("%sでバフを%d個解除された！", action.Name, removed)
+		}
 	}
 
+	// パリィの結果はこの行動にのみ適用する（フェーズ移行時行動には適用しない）
+	state.Enemy.Parry = domain.ParryNone
+
 	// 行動インデックスを進める
 	state.Enemy.AdvanceActionIndex()
 
 	// フェーズ遷移判定
 	if e.CheckPhaseTransition(state) {
-		e.SwitchEnemyPassive(state)
+		result.PhaseMessage = e.EnterEnemyPhase(state)
 		result.PhaseChanged = true
 	}
 
-	// 次回行動を準備してチャージ開始
-	state.Enemy.PrepareNextAction()
-	e.StartEnemyCharging(state, time.Now())
+	// 次回行動を決定してチャージ開始
+	e.PrepareEnemyAction(state)
+	e.StartEnemyCharging(state, e.clock.Now())
 
 	return result
 }
@@ -452,24 +570,32 @@ func (e *BattleEngine) evaluateAdaptiveShield(state *BattleState) float64 {
 
 // IsAttackReady は敵のチャージが完了しているかを返します。
 func (e *BattleEngine) IsAttackReady(state *BattleState) bool {
-	return state.Enemy.IsChargeComplete(time.Now())
+	return state.Enemy.IsChargeComplete(e.clock.Now())
 }
 
 // GetTimeUntilNextAttack は次の攻撃までの残り時間を返します。
 func (e *BattleEngine) GetTimeUntilNextAttack(state *BattleState) time.Duration {
-	return state.Enemy.GetChargeRemainingTime(time.Now())
+	return state.Enemy.GetChargeRemainingTime(e.clock.Now())
 }
 
 // getBuffedAttackPower は敵のバフ効果を適用した攻撃力を返します。
 // 予測と実計算で共有される基本計算です。
 func (e *BattleEngine) getBuffedAttackPower(state *BattleState) int {
+	return e.buffedAttackPower(state, nil)
+}
+
+// buffedAttackPower は敵のバフ効果を適用した攻撃力を計算し、計算過程をトレースに記録します。
+func (e *BattleEngine) buffedAttackPower(state *BattleState, trace *CalculationTrace) int {
 	attackPower := state.Enemy.AttackPower
+	trace.step("攻撃力", float64(attackPower), "%sの攻撃力", state.Enemy.Name)
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
 
 	// 敵のバフ効果を適用（攻撃力倍率など）
 	enemyEffects := state.Enemy.EffectTable.Aggregate(ctx)
 	if enemyEffects.DamageMultiplier != 1.0 {
 		attackPower = int(float64(attackPower) * enemyEffects.DamageMultiplier)
+		trace.step("ダメージ倍率", float64(attackPower), "×%.2f", enemyEffects.DamageMultiplier)
+		trace.addEffects(state.Enemy.Name, enemyEffects, domain.ColDamageMultiplier)
 	}
 	return attackPower
 }
@@ -513,20 +639,35 @@ func (e *BattleEngine) DetermineNextAction(state *BattleState) NextEnemyAction {
 
 // ==================== 敵フェーズ変化と特殊行動 ====================
 
-// CheckPhaseTransition はフェーズ変化をチェックし、必要に応じて移行します。
+// CheckPhaseTransition はフェーズ変化をチェックし、必要に応じて次のフェーズへ移行します。
 func (e *BattleEngine) CheckPhaseTransition(state *BattleState) bool {
 	return state.Enemy.CheckAndTransitionPhase()
 }
 
 // UpdateEffects はバフ・デバフの時間を更新し、継続効果（Regen等）を適用します。
-// ボルテージの時間経過更新も行います。
+// ボルテージの時間経過更新も行います。複数敵バトルでは現在のウェーブの全ての敵を更新します。
 func (e *BattleEngine) UpdateEffects(state *BattleState, deltaSeconds float64) {
-	// 持続時間の更新
-	state.Player.EffectTable.UpdateDurations(deltaSeconds)
-	state.Enemy.EffectTable.UpdateDurations(deltaSeconds)
+	// 持続時間の更新（毒・火傷の継続ダメージもここで発生する）
+	if dot := state.Player.EffectTable.UpdateDurations(deltaSeconds); dot > 0 {
+		state.Player.TakeDamage(dot)
+		state.Stats.TotalDamageTaken += dot
+		e.emit(state, BattleEvent{Kind: BattleEventDamageTaken, Source: "継続ダメージ", Target: BattleEventPlayer, Amount: dot})
+	}
+	e.emitExpired(state, BattleEventPlayer, state.Player.EffectTable)
+	for _, enemy := range state.CurrentEnemies() {
+		if dot := enemy.EffectTable.UpdateDurations(deltaSeconds); dot > 0 && enemy.IsAlive() {
+			enemy.TakeDamage(dot)
+			state.Stats.TotalDamageDealt += dot
+			e.emit(state, BattleEvent{Kind: BattleEventDamageDealt, Source: "継続ダメージ", Target: enemy.Name, Amount: dot})
+		}
+		if enemy.IsAlive() {
+			e.emitExpired(state, enemy.Name, enemy.EffectTable)
+		}
 
-	// ボルテージの時間経過更新
-	e.voltageManager.Update(state.Enemy, deltaSeconds)
+		// ボルテージの時間経過更新
+		e.voltageManager.Update(enemy, deltaSeconds)
+	}
+	e.recordPeakVoltage(state)
 
 	// Regen 処理（プレイヤー）
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
@@ -537,6 +678,7 @@ func (e *BattleEngine) UpdateEffects(state *BattleState, deltaSeconds float64) {
 		if regenAmount > 0 {
 			state.Player.Heal(regenAmount)
 			state.Stats.TotalHealAmount += regenAmount
+			e.emit(state, BattleEvent{Kind: BattleEventHeal, Source: "リジェネ", Target: BattleEventPlayer, Amount: regenAmount})
 		}
 	}
 }
@@ -582,20 +724,28 @@ func (e *BattleEngine) calculateHPChange(
 	stats domain.Stats,
 	typingResult *typing.TypingResult,
 	effects domain.EffectResult,
+	trace *CalculationTrace,
 ) int {
 	if effect.HPFormula == nil {
 		return 0
 	}
 
 	// base + stat_coef * STAT（エフェクトによるステータス修飾を適用）
-	statValue := e.getModifiedStatValue(stats, effect.HPFormula.StatRef, effects)
+	statRef := effect.HPFormula.StatRef
+	statValue := e.getModifiedStatValue(stats, statRef, effects)
+	trace.step("ステータス", float64(statValue), "%s 基本%d", statRef, e.getStatValue(stats, statRef))
+	trace.addEffects(BattleEventPlayer, effects, statTraceColumns[statRef]...)
 	baseHP := effect.HPFormula.Base + effect.HPFormula.StatCoef*float64(statValue)
+	trace.step("基本値", baseHP, "%g + %g × %d", effect.HPFormula.Base, effect.HPFormula.StatCoef, statValue)
 
 	// タイピング結果による補正
 	if typingResult != nil {
+		trace.step("速度係数", baseHP*typingResult.SpeedFactor, "×%.2f", typingResult.SpeedFactor)
 		baseHP *= typingResult.SpeedFactor * typingResult.AccuracyFactor
+		trace.step("正確性係数", baseHP, "×%.2f", typingResult.AccuracyFactor)
 		if typingResult.AccuracyFactor < AccuracyPenaltyThreshold {
 			baseHP *= 0.5
+			trace.step("正確性ペナルティ", baseHP, "×0.5（正確性係数%.0f%%未満）", AccuracyPenaltyThreshold*100)
 		}
 	}
 
@@ -619,6 +769,7 @@ func (e *BattleEngine) ApplyModuleEffect(
 		ctx.SetTypingResult(typingResult.Accuracy, typingResult.WPM, 0)
 		ctx.SetEvent(domain.EventOnTypingDone)
 	}
+	ctx.ChainLength = state.ChainLength
 
 	playerEffects := state.Player.EffectTable.Aggregate(ctx)
 	enemyEffects := state.Enemy.EffectTable.Aggregate(ctx)
@@ -634,64 +785,53 @@ func (e *BattleEngine) ApplyModuleEffect(
 
 		// HP変化効果の適用
 		if effect.HPFormula != nil {
-			hpChange := e.calculateHPChange(&effect, agent.BaseStats, typingResult, playerEffects)
+			trace := e.newTrace()
+			hpChange := e.calculateHPChange(&effect, agent.BaseStats, typingResult, playerEffects, trace)
 
 			switch effect.Target {
 			case domain.TargetEnemy:
-				// ダメージ効果
-				damage := -hpChange // ダメージは負のHP変化
-				if damage < 0 {
-					damage = -damage
-				}
-
-				// ダメージ乗算を適用
-				if playerEffects.DamageMultiplier != 1.0 {
-					damage = int(float64(damage) * playerEffects.DamageMultiplier)
-				}
-
-				// ArmorPierce が有効でなければ敵の DamageCut を適用
-				if !playerEffects.ArmorPierce {
-					damage = calculateDamage(damage, enemyEffects.DamageCut)
-				}
-
-				state.Enemy.TakeDamage(damage)
-				state.Stats.TotalDamageDealt += damage
-				totalEffect += damage
-
-				// ライフスティール処理
-				if playerEffects.LifeSteal > 0 && damage > 0 {
-					healAmount := int(float64(damage) * playerEffects.LifeSteal)
-					if healAmount > 0 {
-						state.Player.Heal(healAmount)
-						state.Stats.TotalHealAmount += healAmount
-					}
-				}
+				// ダメージ効果（ダメージは負のHP変化だが、符号によらず絶対値を与える）
+				totalEffect += e.dealDamageToEnemy(state, agent, module.Name(), &effect, absInt(hpChange), playerEffects, enemyEffects, ctx, trace)
 
 			case domain.TargetSelf:
 				// 回復または自傷効果
 				if hpChange > 0 {
-					// 回復
-					healAmount := hpChange
-					if playerEffects.HealMultiplier != 1.0 {
-						healAmount = int(float64(healAmount) * playerEffects.HealMultiplier)
-					}
-					if playerEffects.Overheal {
-						state.Player.HealWithOverheal(healAmount)
-					} else {
-						state.Player.Heal(healAmount)
-					}
-					state.Stats.TotalHealAmount += healAmount
-					totalEffect += healAmount
+					totalEffect += e.healPlayer(state, module.Name(), hpChange, playerEffects, trace)
 				} else if hpChange < 0 {
 					// 自傷ダメージ
-					state.Player.TakeDamage(-hpChange)
+					e.damagePlayerBySelf(state, module.Name(), -hpChange)
 				}
 
 			case domain.TargetBoth:
-				// 両方に効果（将来の拡張用）
+				// 敵には常に絶対値のダメージを与え、自分側は符号で回復（ドレイン）か自傷（捨て身）かが決まる
+				totalEffect += e.dealDamageToEnemy(state, agent, module.Name(), &effect, absInt(hpChange), playerEffects, enemyEffects, ctx, trace.clone())
+				if hpChange > 0 {
+					e.healPlayer(state, module.Name(), hpChange, playerEffects, trace)
+				} else if hpChange < 0 {
+					e.damagePlayerBySelf(state, module.Name(), -hpChange)
+				}
+
+			case domain.TargetAll:
+				// 全体攻撃: 生存している敵それぞれの被ダメ軽減・属性倍率でダメージを与える
+				for _, enemy := range state.AliveEnemies() {
+					state.withEnemy(enemy, func() {
+						targetEffects := enemy.EffectTable.Aggregate(ctx)
+						totalEffect += e.dealDamageToEnemy(state, agent, module.Name(), &effect, absInt(hpChange), playerEffects, targetEffects, ctx, trace.clone())
+					})
+				}
 			}
 		}
 
+		// 状態異常の付与・解除
+		if effect.ColumnSpec != nil && e.applyModuleAilment(state, module.Name(), &effect) {
+			continue
+		}
+
+		// 敵のボルテージの低下
+		if effect.ColumnSpec != nil && e.applyModuleVoltage(state, module.Name(), &effect) {
+			continue
+		}
+
 		// EffectColumn効果の適用（バフ/デバフ）
 		if effect.ColumnSpec != nil {
 			values := map[domain.EffectColumn]float64{
@@ -706,8 +846,22 @@ func (e *BattleEngine) ApplyModuleEffect(
 			switch effect.Target {
 			case domain.TargetSelf:
 				state.Player.EffectTable.AddBuff(description, duration, values)
+				e.emitEffectAdded(state, module.Name(), BattleEventPlayer, description)
 			case domain.TargetEnemy:
 				state.Enemy.EffectTable.AddDebuff(description, duration, values)
+				e.emitEffectAdded(state, module.Name(), state.Enemy.Name, description)
+			case domain.TargetAll:
+				for _, enemy := range state.AliveEnemies() {
+					enemy.EffectTable.AddDebuff(description, duration, values)
+					e.emitEffectAdded(state, module.Name(), enemy.Name, description)
+				}
+			case domain.TargetBoth:
+				// フィールド効果: 同じ効果を自分と敵の両方にバフとして付与
+				fieldName := "フィールド:" + description
+				state.Player.EffectTable.AddBuff(fieldName, duration, values)
+				state.Enemy.EffectTable.AddBuff(fieldName, duration, values)
+				e.emitEffectAdded(state, module.Name(), BattleEventPlayer, fieldName)
+				e.emitEffectAdded(state, module.Name(), state.Enemy.Name, fieldName)
 			}
 		}
 	}
@@ -715,6 +869,189 @@ func (e *BattleEngine) ApplyModuleEffect(
 	return totalEffect
 }
 
+// dealDamageToEnemy はモジュール効果のダメージを敵に与え、与えたダメージを返します。
+// クリティカル、ダメージ倍率、敵の被ダメ軽減、属性倍率、ライフスティールを適用します。
+func (e *BattleEngine) dealDamageToEnemy(
+	state *BattleState,
+	agent *domain.AgentModel,
+	source string,
+	effect *domain.ModuleEffect,
+	damage int,
+	playerEffects domain.EffectResult,
+	enemyEffects domain.EffectResult,
+	ctx *domain.EffectContext,
+	trace *CalculationTrace,
+) int {
+	// クリティカル判定（LUKとcrit_rateに基づく）
+	hitEffects := playerEffects
+	critical := e.rollCritical(agent.BaseStats, playerEffects)
+	if critical {
+		// on_critical条件のパッシブのみを集計して加える
+		hitEffects = e.criticalEffects(state, playerEffects, ctx)
+		damage = int(float64(damage) * (config.CriticalDamageMultiplier + hitEffects.CritDamage))
+		state.Stats.CriticalHits++
+		trace.step("クリティカル", float64(damage), "×(%.2f + %.2f)", config.CriticalDamageMultiplier, hitEffects.CritDamage)
+		trace.addEffects(BattleEventPlayer, hitEffects, domain.ColCritRate, domain.ColCritDamage)
+	}
+
+	// ダメージ乗算を適用
+	if hitEffects.DamageMultiplier != 1.0 {
+		damage = int(float64(damage) * hitEffects.DamageMultiplier)
+		trace.step("ダメージ倍率", float64(damage), "×%.2f", hitEffects.DamageMultiplier)
+		trace.addEffects(BattleEventPlayer, hitEffects, domain.ColDamageMultiplier)
+	}
+
+	// ArmorPierce が有効でなければ敵の DamageCut を適用
+	if !hitEffects.ArmorPierce {
+		damage = calculateDamage(damage, enemyEffects.DamageCut)
+		trace.step("防御軽減", float64(damage), "被ダメ軽減%.0f%%（最低1）", enemyEffects.DamageCut*100)
+		trace.addEffects(state.Enemy.Name, enemyEffects, domain.ColDamageCut)
+	} else {
+		trace.step("防御軽減", float64(damage), "防御貫通により無視")
+		trace.addEffects(BattleEventPlayer, hitEffects, domain.ColArmorPierce)
+	}
+
+	// 属性倍率を適用（敵の弱点・耐性と属性別軽減）
+	damage = e.applyElementToEnemy(state, effect.Element, enemyEffects, damage, trace)
+
+	state.Enemy.TakeDamage(damage)
+	state.Stats.TotalDamageDealt += damage
+	event := BattleEvent{Kind: BattleEventDamageDealt, Source: source, Target: state.Enemy.Name, Amount: damage, Trace: trace}
+	if critical {
+		event.Detail = "クリティカル"
+	}
+	e.emit(state, event)
+
+	// ライフスティール処理
+	if hitEffects.LifeSteal > 0 && damage > 0 {
+		healAmount := int(float64(damage) * hitEffects.LifeSteal)
+		if healAmount > 0 {
+			state.Player.Heal(healAmount)
+			state.Stats.TotalHealAmount += healAmount
+			e.emit(state, BattleEvent{Kind: BattleEventHeal, Source: "ライフスティール", Target: BattleEventPlayer, Amount: healAmount})
+		}
+	}
+
+	return damage
+}
+
+// healPlayer はモジュール効果の回復をプレイヤーに適用し、回復量を返します。
+// 回復倍率とオーバーヒールを適用します。
+func (e *BattleEngine) healPlayer(state *BattleState, source string, amount int, playerEffects domain.EffectResult, trace *CalculationTrace) int {
+	if playerEffects.HealMultiplier != 1.0 {
+		amount = int(float64(amount) * playerEffects.HealMultiplier)
+		trace.step("回復倍率", float64(amount), "×%.2f", playerEffects.HealMultiplier)
+		trace.addEffects(BattleEventPlayer, playerEffects, domain.ColHealMultiplier)
+	}
+	if playerEffects.Overheal {
+		state.Player.HealWithOverheal(amount)
+		trace.step("オーバーヒール", float64(amount), "最大HPを超えて回復")
+		trace.addEffects(BattleEventPlayer, playerEffects, domain.ColOverheal)
+	} else {
+		state.Player.Heal(amount)
+	}
+	state.Stats.TotalHealAmount += amount
+	e.emit(state, BattleEvent{Kind: BattleEventHeal, Source: source, Target: BattleEventPlayer, Amount: amount, Trace: trace})
+	return amount
+}
+
+// damagePlayerBySelf はモジュール効果の自傷ダメージをプレイヤーに適用します。
+func (e *BattleEngine) damagePlayerBySelf(state *BattleState, source string, amount int) {
+	state.Player.TakeDamage(amount)
+	e.emit(state, BattleEvent{Kind: BattleEventDamageTaken, Source: source, Target: BattleEventPlayer, Amount: amount, Detail: "自傷"})
+}
+
+// absInt は整数の絶対値を返します。
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// ==================== クリティカル ====================
+
+// criticalRate はエージェントのクリティカル率を計算します。
+// 計算式: LUK（効果による修飾後）× CritRatePerLUK + crit_rate
+func (e *BattleEngine) criticalRate(stats domain.Stats, effects domain.EffectResult) float64 {
+	luk := e.getModifiedStatValue(stats, "LUK", effects)
+	return float64(luk)*config.CritRatePerLUK + effects.CritRate
+}
+
+// rollCritical はクリティカルヒットの判定を行います。
+func (e *BattleEngine) rollCritical(stats domain.Stats, effects domain.EffectResult) bool {
+	rate := e.criticalRate(stats, effects)
+	if rate <= 0 {
+		return false
+	}
+	return e.rng.Float64() < rate
+}
+
+// criticalEffects はクリティカルヒット時のプレイヤー効果を返します。
+// ctx（playerEffectsの集計に使ったコンテキスト）では無効で、on_critical条件で有効になるパッシブスキルのみを
+// 集計してplayerEffectsに加えます。集計済みの効果は再集計しないため、確率発動やOneShotの判定はやり直しません。
+func (e *BattleEngine) criticalEffects(state *BattleState, playerEffects domain.EffectResult, ctx *domain.EffectContext) domain.EffectResult {
+	critCtx := *ctx
+	critCtx.SetEvent(domain.EventOnCritical)
+	return state.Player.EffectTable.AggregateEvent(playerEffects, ctx, &critCtx)
+}
+
+// ==================== 属性 ====================
+
+// elementalDamage は属性倍率と属性別軽減率をダメージに適用します（最低1ダメージ保証）。
+func elementalDamage(damage int, rate, cut float64) int {
+	if cut > 1.0 {
+		cut = 1.0 // 最大100%軽減
+	}
+	return calculateDamage(int(float64(damage)*rate), cut)
+}
+
+// applyElementToEnemy はプレイヤーの属性攻撃に敵の弱点・耐性と属性別軽減を適用します。
+// 弱点を突いた場合はバトル状態に記録します。
+func (e *BattleEngine) applyElementToEnemy(state *BattleState, element domain.Element, enemyEffects domain.EffectResult, damage int, trace *CalculationTrace) int {
+	if element == domain.ElementNone {
+		return damage
+	}
+	affinity := state.Enemy.Type.ElementAffinity
+	if affinity.IsWeakTo(element) {
+		state.recordWeakness(state.Enemy.Type.ID, element)
+	}
+	rate, cut := affinity.Multiplier(element), enemyEffects.ElementCutFor(element)
+	damage = elementalDamage(damage, rate, cut)
+	trace.step("属性", float64(damage), "%s ×%.2f・属性軽減%.0f%%", element, rate, cut*100)
+	trace.addElementCut(state.Enemy.Name, enemyEffects, element)
+	return damage
+}
+
+// applyElementToPlayer は敵の属性攻撃に装備コアの弱点・耐性と属性別軽減を適用します。
+// 属性倍率は装備コアの倍率の平均で、コアの数によって弱点・耐性が累積しないようにします。
+func (e *BattleEngine) applyElementToPlayer(state *BattleState, element domain.Element, playerEffects domain.EffectResult, damage int, trace *CalculationTrace) int {
+	if element == domain.ElementNone {
+		return damage
+	}
+	rate := playerElementRate(state.EquippedAgents, element)
+	cut := playerEffects.ElementCutFor(element)
+	damage = elementalDamage(damage, rate, cut)
+	trace.step("属性", float64(damage), "%s ×%.2f・属性軽減%.0f%%", element, rate, cut*100)
+	trace.addElementCut(BattleEventPlayer, playerEffects, element)
+	return damage
+}
+
+// playerElementRate は装備コアの属性倍率の平均を返します（コアがない場合は1.0）。
+func playerElementRate(agents []*domain.AgentModel, element domain.Element) float64 {
+	total, count := 0.0, 0
+	for _, agent := range agents {
+		if agent != nil && agent.Core != nil {
+			total += agent.Core.Type.ElementAffinity.Multiplier(element)
+			count++
+		}
+	}
+	if count == 0 {
+		return 1.0
+	}
+	return total / float64(count)
+}
+
 // ApplyModuleEffectWithCombo はコンボカウントを考慮してモジュール効果を適用します。
 // スタック型パッシブスキル（ps_combo_master等）の効果を正しく計算します。
 func (e *BattleEngine) ApplyModuleEffectWithCombo(
@@ -791,8 +1128,8 @@ func (e *BattleEngine) CheckBattleEnd(state *BattleState) (bool, *BattleResult)
 		}
 	}
 
-	if !state.Enemy.IsAlive() {
-		// プレイヤー勝利
+	if state.AllEnemiesDefeated() {
+		// プレイヤー勝利（全ウェーブの敵を撃破）
 		return true, &BattleResult{
 			IsVictory: true,
 			Stats:     state.Stats,
@@ -871,7 +1208,7 @@ func (e *BattleEngine) CalculateModuleEffectWithPassive(
 	// 各効果のHP変化量を合計
 	for _, effect := range module.Type.Effects {
 		if effect.HPFormula != nil {
-			hpChange := e.calculateHPChange(&effect, agent.BaseStats, typingResult, defaultEffects)
+			hpChange := e.calculateHPChange(&effect, agent.BaseStats, typingResult, defaultEffects, nil)
 			if hpChange < 0 {
 				hpChange = -hpChange // ダメージの場合は絶対値
 			}
@@ -1006,9 +1343,11 @@ func (e *BattleEngine) EvaluateQuickRecovery(state *BattleState, agent *domain.A
 // ==================== チャージシステム（Phase 3.2） ====================
 
 // DeterminePatternBasedAction はパターンベースの次回行動を決定します。
-// 敵の行動パターンから現在の行動を取得し、NextEnemyAction形式で返します。
+// 敵の行動ルール（条件・重み・クールダウン）を評価し、ルールがなければ行動パターンから
+// 現在の行動を取得して、NextEnemyAction形式で返します。
+// ルールで選んだ行動にはクールダウンが設定されるため、1回の行動につき1回だけ呼び出してください。
 func (e *BattleEngine) DeterminePatternBasedAction(state *BattleState) NextEnemyAction {
-	action := state.Enemy.GetCurrentAction()
+	action := e.selectEnemyAction(state)
 
 	// ドメインの行動タイプをバトルエンジンの行動タイプに変換
 	var actionType EnemyActionType
@@ -1021,6 +1360,10 @@ func (e *BattleEngine) DeterminePatternBasedAction(state *BattleState) NextEnemy
 		actionType = EnemyActionDebuff
 	case domain.EnemyActionDefense:
 		actionType = EnemyActionDefense
+	case domain.EnemyActionHeal:
+		actionType = EnemyActionSelfHeal
+	case domain.EnemyActionDispel:
+		actionType = EnemyActionDispel
 	default:
 		actionType = EnemyActionAttack
 	}
@@ -1044,6 +1387,8 @@ func (e *BattleEngine) DeterminePatternBasedAction(state *BattleState) NextEnemy
 			nextAction.DefenseValue = action.ReductionRate
 		}
 		nextAction.DefenseDurationMs = int(action.Duration * 1000)
+	case domain.EnemyActionHeal:
+		nextAction.ExpectedValue = action.CalculateHeal(state.Enemy.MaxHP)
 	}
 
 	return nextAction
@@ -1052,7 +1397,13 @@ func (e *BattleEngine) DeterminePatternBasedAction(state *BattleState) NextEnemy
 // CalculatePatternDamage はパターンベース攻撃のダメージを計算します。
 // ダメージ = DamageBase + Level * DamagePerLevel
 func (e *BattleEngine) CalculatePatternDamage(state *BattleState, action domain.EnemyAction) int {
+	return e.calculatePatternDamage(state, action, nil)
+}
+
+// calculatePatternDamage はパターンベース攻撃のダメージを計算し、計算過程をトレースに記録します。
+func (e *BattleEngine) calculatePatternDamage(state *BattleState, action domain.EnemyAction, trace *CalculationTrace) int {
 	baseDamage := action.DamageBase + float64(state.Enemy.Level)*action.DamagePerLevel
+	trace.step("基本値", baseDamage, "%g + レベル%d × %g", action.DamageBase, state.Enemy.Level, action.DamagePerLevel)
 
 	// 敵のバフ効果を適用
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
@@ -1061,10 +1412,16 @@ func (e *BattleEngine) CalculatePatternDamage(state *BattleState, action domain.
 	// ダメージ乗算を適用
 	if enemyEffects.DamageMultiplier != 1.0 {
 		baseDamage *= enemyEffects.DamageMultiplier
+		trace.step("ダメージ倍率", baseDamage, "×%.2f", enemyEffects.DamageMultiplier)
+		trace.addEffects(state.Enemy.Name, enemyEffects, domain.ColDamageMultiplier)
 	}
 
 	// ダメージボーナスを適用
 	baseDamage += float64(enemyEffects.DamageBonus)
+	if enemyEffects.DamageBonus != 0 {
+		trace.step("ダメージボーナス", baseDamage, "%+d", enemyEffects.DamageBonus)
+		trace.addEffects(state.Enemy.Name, enemyEffects, domain.ColDamageBonus)
+	}
 
 	damage := int(baseDamage)
 	if damage < 1 {
@@ -1087,8 +1444,10 @@ func (e *BattleEngine) StartEnemyCharging(state *BattleState, now time.Time) {
 		return
 	}
 
-	// チャージ開始
-	state.Enemy.StartCharging(*action, now)
+	// チャージ開始（ボルテージ段階に到達している場合はチャージ時間が変化する）
+	charged := *action
+	charged.ChargeTime = state.Enemy.VoltageChargeTime(action.ChargeTime)
+	state.Enemy.StartCharging(charged, now)
 }
 
 // ActivateDefense はディフェンス行動を発動します。
@@ -1131,10 +1490,16 @@ func (e *BattleEngine) ExecuteChargedAction(state *BattleState) (damage int, mes
 		return 0, ""
 	}
 
+	// パリィの結果はこの行動にのみ適用する
+	defer func() { state.Enemy.Parry = domain.ParryNone }()
+	if isParried(state.Enemy) {
+		return 0, parryMessage(state.Enemy, *action)
+	}
+
 	switch action.ActionType {
 	case domain.EnemyActionAttack:
 		damage = e.ExecutePatternAttack(state, *action)
-		return damage, fmt.Sprintf("%s！%dダメージを受けた！", action.Name, damage)
+		return damage, fmt.Sprintf("%s！%dダメージを受けた！%s", action.Name, damage, parryDamageLabel(state.Enemy.Parry))
 
 	case domain.EnemyActionBuff:
 		e.ApplyPatternBuff(state, *action)
@@ -1146,6 +1511,14 @@ func (e *BattleEngine) ExecuteChargedAction(state *BattleState) (damage int, mes
 		}
 		e.ApplyPatternDebuff(state, *action)
 		return 0, fmt.Sprintf("%sが%sを発動！", state.Enemy.Name, action.Name)
+
+	case domain.EnemyActionHeal:
+		healed := e.ApplyPatternHeal(state, *action)
+		return 0, fmt.Sprintf("%sが%sでHPを%d回復した！", state.Enemy.Name, action.Name, healed)
+
+	case domain.EnemyActionDispel:
+		removed := e.ApplyPatternDispel(state)
+		return 0, fmt.Sprintf("%sが%sでバフを%d個解除した！", state.Enemy.Name, action.Name, removed)
 	}
 
 	return 0, ""
@@ -1153,7 +1526,8 @@ func (e *BattleEngine) ExecuteChargedAction(state *BattleState) (damage int, mes
 
 // ExecutePatternAttack はパターンベースの攻撃を実行します。
 func (e *BattleEngine) ExecutePatternAttack(state *BattleState, action domain.EnemyAction) int {
-	damage := e.CalculatePatternDamage(state, action)
+	trace := e.newTrace()
+	damage := e.calculatePatternDamage(state, action, trace)
 
 	// プレイヤーの防御効果を取得
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
@@ -1162,10 +1536,23 @@ func (e *BattleEngine) ExecutePatternAttack(state *BattleState, action domain.En
 	// ダメージ軽減を適用
 	if playerEffects.DamageCut > 0 {
 		damage = calculateDamage(damage, playerEffects.DamageCut)
+		trace.step("防御軽減", float64(damage), "被ダメ軽減%.0f%%（最低1）", playerEffects.DamageCut*100)
+		trace.addEffects(BattleEventPlayer, playerEffects, domain.ColDamageCut)
 	}
 
+	// 属性倍率を適用
+	damage = e.applyElementToPlayer(state, action.Element, playerEffects, damage, trace)
+
+	// パリィの結果を適用
+	damage = applyParryToDamage(state.Enemy.Parry, damage)
+	traceParry(trace, state.Enemy.Parry, damage)
+
 	state.Player.TakeDamage(damage)
 	state.Stats.TotalDamageTaken += damage
+	e.emit(state, BattleEvent{
+		Kind: BattleEventDamageTaken, Source: state.Enemy.Name, Target: BattleEventPlayer,
+		Amount: damage, Detail: joinEventDetail(action.Name, parryEventDetail(state.Enemy.Parry)), Trace: trace,
+	})
 
 	return damage
 }
@@ -1189,6 +1576,7 @@ func (e *BattleEngine) ApplyPatternBuff(state *BattleState, action domain.EnemyA
 
 	description := domain.DescribeEffectValues(values)
 	state.Enemy.EffectTable.AddBuff(description, action.Duration, values)
+	e.emitEffectAdded(state, action.Name, state.Enemy.Name, description)
 }
 
 // ApplyPatternDebuff はパターンベースのデバフを適用します。
@@ -1206,16 +1594,24 @@ func (e *BattleEngine) ApplyPatternDebuff(state *BattleState, action domain.Enem
 		values[domain.ColCooldownReduce] = action.EffectValue
 	case "damage_cut":
 		values[domain.ColDamageCut] = action.EffectValue
+	case "hide_letters", "time_shrink":
+		values[domain.EffectColumn(action.EffectType)] = action.EffectValue
+	case "mirror_text", "shuffle_text", "force_case":
+		values[domain.EffectColumn(action.EffectType)] = 1.0
+	case "poison", "burn", "stun", "silence":
+		e.applyAilmentToPlayer(state, action.Name, domain.EffectColumn(action.EffectType), action.EffectValue, action.Duration)
+		return
 	}
 
 	description := domain.DescribeEffectValues(values)
 	state.Player.EffectTable.AddDebuff(description, action.Duration, values)
+	e.emitEffectAdded(state, action.Name, BattleEventPlayer, description)
 }
 
 // CheckDebuffEvasion はデバフ回避を判定します。
 // 敵がデバフ回避ディフェンス中の場合、回避判定を行います。
 func (e *BattleEngine) CheckDebuffEvasion(state *BattleState) bool {
-	now := time.Now()
+	now := e.clock.Now()
 	if !state.Enemy.IsDefenseActive(now) {
 		return false
 	}
@@ -1230,7 +1626,7 @@ func (e *BattleEngine) CheckDebuffEvasion(state *BattleState) bool {
 // ApplyDefenseReduction はディフェンスによるダメージ軽減を適用します。
 // プレイヤーからの攻撃に対して、敵のディフェンス状態を考慮したダメージを計算します。
 func (e *BattleEngine) ApplyDefenseReduction(state *BattleState, baseDamage int, attackType string) int {
-	now := time.Now()
+	now := e.clock.Now()
 	if !state.Enemy.IsDefenseActive(now) {
 		return baseDamage
 	}
@@ -1294,8 +1690,8 @@ func (e *BattleEngine) GetDefenseInfo(state *BattleState, now time.Time) (active
 
 // ==================== 敵パッシブスキルシステム（Task 4） ====================
 
-// RegisterEnemyPassive は敵の通常パッシブをEffectTableに登録します。
-// バトル開始時に呼び出され、敵タイプに設定されているNormalPassiveを
+// RegisterEnemyPassive は敵の現在のフェーズのパッシブをEffectTableに登録します。
+// バトル開始時に呼び出され、最初のフェーズのパッシブ（NormalPassive）を
 // 一時ステータス修正として効果を適用します。
 // パッシブ未設定の場合はスキップします。
 func (e *BattleEngine) RegisterEnemyPassive(state *BattleState) {
@@ -1303,8 +1699,8 @@ func (e *BattleEngine) RegisterEnemyPassive(state *BattleState) {
 		return
 	}
 
-	// 敵タイプから通常パッシブを取得
-	normalPassive := state.Enemy.Type.NormalPassive
+	// 現在のフェーズのパッシブを取得
+	normalPassive := state.Enemy.GetCurrentPassive()
 	if normalPassive == nil {
 		return
 	}
@@ -1318,26 +1714,26 @@ func (e *BattleEngine) RegisterEnemyPassive(state *BattleState) {
 }
 
 // SwitchEnemyPassive はフェーズ遷移時に敵のパッシブを切り替えます。
-// 通常パッシブを無効化し、強化パッシブを登録します。
-// 強化フェーズ遷移後に呼び出されることを想定しています。
+// 前のフェーズのパッシブを無効化し、移行後のフェーズのパッシブを登録します。
+// フェーズ遷移後に呼び出されることを想定しています。
 func (e *BattleEngine) SwitchEnemyPassive(state *BattleState) {
 	if state.Enemy == nil {
 		return
 	}
 
-	// 通常パッシブを無効化（EffectTableからパッシブ効果を削除）
+	// 前のフェーズのパッシブを無効化（EffectTableからパッシブ効果を削除）
 	state.Enemy.EffectTable.RemoveBySourceType(domain.SourcePassive)
 
 	// ActivePassiveIDをクリア
 	state.Enemy.ActivePassiveID = ""
 
-	// 強化パッシブを登録
-	enhancedPassive := state.Enemy.Type.EnhancedPassive
+	// 移行後のフェーズのパッシブを登録
+	enhancedPassive := state.Enemy.GetCurrentPassive()
 	if enhancedPassive == nil {
 		return
 	}
 
-	// 強化パッシブスキルをEffectEntryに変換して登録
+	// パッシブスキルをEffectEntryに変換して登録
 	entry := enhancedPassive.ToEntry()
 	state.Enemy.EffectTable.AddEntry(entry)
 
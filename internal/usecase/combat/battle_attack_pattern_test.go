@@ -193,17 +193,17 @@ func TestBattleEngine_AdaptiveShield(t *testing.T) {
 	engine.RegisterPassiveSkills(state, agents)
 
 	// 1-2回目の物理攻撃（まだ軽減なし）
-	damage1 := engine.ProcessEnemyAttackDamage(state, "physical") // count=1
-	state.Player.HP = state.Player.MaxHP                          // HPリセット
-	damage2 := engine.ProcessEnemyAttackDamage(state, "physical") // count=2
-	state.Player.HP = state.Player.MaxHP                          // HPリセット
+	damage1 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone) // count=1
+	state.Player.HP = state.Player.MaxHP                                              // HPリセット
+	damage2 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone) // count=2
+	state.Player.HP = state.Player.MaxHP                                              // HPリセット
 
 	// 3回目の物理攻撃（count=3で軽減発動）
-	damage3 := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage3 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	state.Player.HP = state.Player.MaxHP // HPリセット
 
 	// 4回目の物理攻撃（引き続き軽減）
-	damage4 := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage4 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// Assert: 1-2回目は軽減なし、3回目以降は25%軽減
 	// damage1, damage2は同じはず（軽減なし）
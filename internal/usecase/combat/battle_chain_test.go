@@ -0,0 +1,110 @@
+// Package combat はバトルエンジンを提供します。
+// battle_chain_test.go はエージェントをまたぐチェイン（連続使用）のテストです。
+package combat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+)
+
+// newChainTestSession は1体につき1つのダメージモジュールを持つ3体のエージェントでバトルを開始します。
+// 1体目のモジュールは2チェイン以上で発動する追加ダメージのチェイン効果を持ちます。
+func newChainTestSession() (*BattleSession, *clock.ManualClock) {
+	session, c := newTestSession(1000, 1, 60*time.Second)
+
+	coreType := domain.CoreType{
+		ID:          "all_rounder",
+		Name:        "オールラウンダー",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		AllowedTags: []string{"physical_low"},
+	}
+	agents := make([]*domain.AgentModel, 3)
+	for i := range agents {
+		module := newTestDamageModule(fmt.Sprintf("m%d", i), "物理攻撃", []string{"physical_low"}, 1.0, "STR", "")
+		if i == 0 {
+			effect := domain.NewChainEffect(domain.ChainEffectDamageBonus, 20).WithMinChainLength(2)
+			module.ChainEffect = &effect
+		}
+		core := domain.NewCore(fmt.Sprintf("core_%d", i), "コア", 10, coreType, domain.PassiveSkill{})
+		agents[i] = domain.NewAgent(fmt.Sprintf("agent_%d", i), core, []*domain.ModuleModel{module})
+	}
+
+	session = NewBattleSession(session.Enemy(), session.Player(), agents, nil)
+	session.SetClock(c)
+	session.Start()
+	return session, c
+}
+
+// useSlot は指定スロットのモジュールを選択して正確に入力します。
+func useSlot(t *testing.T, session *BattleSession, slot int) {
+	t.Helper()
+	if !session.SelectModule(slot) {
+		t.Fatalf("スロット%dのモジュールを使用できません", slot)
+	}
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+}
+
+// TestBattleSession_ChainAcrossAgents はエージェントを切り替えながらの使用でチェイン数が伸び、
+// チェイン効果が発動して、猶予時間を過ぎると途切れることをテストします。
+func TestBattleSession_ChainAcrossAgents(t *testing.T) {
+	session, c := newChainTestSession()
+
+	useSlot(t, session, 0)
+	if session.state.ChainLength != 1 {
+		t.Errorf("1回目の使用後のチェイン数: 期待 1, 実際 %d", session.state.ChainLength)
+	}
+
+	advance(session, c, time.Second)
+	useSlot(t, session, 1)
+	if session.state.ChainLength != 2 {
+		t.Errorf("別エージェントの使用後のチェイン数: 期待 2, 実際 %d", session.state.ChainLength)
+	}
+	if !strings.Contains(session.Message(), "[2チェイン]") {
+		t.Errorf("メッセージにチェイン数が含まれるべき: %s", session.Message())
+	}
+	if event, ok := findEvent(session.BattleLog().Events(), BattleEventChain); !ok || event.Amount != 20 {
+		t.Errorf("2チェインでチェイン効果が発動するべき: %+v", event)
+	}
+
+	advance(session, c, time.Second)
+	useSlot(t, session, 2)
+	if track := session.ChainEffectManager().ChainTrack(); len(track) != 3 || track[2] != 2 {
+		t.Errorf("チェインの経過: 実際 %v", track)
+	}
+
+	advance(session, c, time.Duration((config.ChainWindowSeconds+0.5)*float64(time.Second)))
+	if session.state.ChainLength != 0 || session.ChainEffectManager().ChainLength() != 0 {
+		t.Errorf("猶予時間を過ぎるとチェインが途切れるべき: 実際 %d", session.state.ChainLength)
+	}
+}
+
+// TestBattleSession_ChainFeedsPassiveContext はチェイン数がパッシブスキルの評価に使われることをテストします。
+func TestBattleSession_ChainFeedsPassiveContext(t *testing.T) {
+	session, c := newChainTestSession()
+	session.Player().EffectTable.AddEntry(domain.PassiveSkill{
+		ID:               "ps_chain",
+		Name:             "チェインブースト",
+		TriggerType:      domain.PassiveTriggerConditional,
+		TriggerCondition: &domain.TriggerCondition{Type: domain.TriggerConditionChainLengthAtLeast, Value: 2},
+		Effects:          map[domain.EffectColumn]float64{domain.ColDoubleCast: 1.0},
+	}.ToEntry())
+
+	useSlot(t, session, 0)
+	if strings.Contains(session.Message(), "ダブルキャスト") {
+		t.Errorf("1チェインではパッシブスキルが無効であるべき: %s", session.Message())
+	}
+
+	advance(session, c, time.Second)
+	useSlot(t, session, 1)
+	if !strings.Contains(session.Message(), "ダブルキャスト発動") {
+		t.Errorf("2チェインでパッシブスキルが有効になるべき: %s", session.Message())
+	}
+}
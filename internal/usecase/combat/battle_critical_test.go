@@ -0,0 +1,116 @@
+// Package combat はバトル関連のユースケースを提供します。
+package combat
+
+import (
+	"testing"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+)
+
+// ===== クリティカルヒットのテスト =====
+
+// TestApplyModuleEffect_CriticalHit はクリティカル時にダメージが増加し、統計に記録されることをテストします。
+func TestApplyModuleEffect_CriticalHit(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+
+	// クリティカルを無効化して通常ダメージを計測
+	state.Player.EffectTable.AddBuff("クリ無効", 10.0, map[domain.EffectColumn]float64{
+		domain.ColCritRate: -10.0,
+	})
+	normal := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+	if state.Stats.CriticalHits != 0 {
+		t.Fatalf("クリティカル無効時にクリティカルが記録された: %d", state.Stats.CriticalHits)
+	}
+
+	// クリティカル率100%
+	state.Player.EffectTable.Clear()
+	state.Player.EffectTable.AddBuff("クリ確定", 10.0, map[domain.EffectColumn]float64{
+		domain.ColCritRate:   1.0,
+		domain.ColCritDamage: 0.5,
+	})
+	critical := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+
+	want := int(float64(normal) * (config.CriticalDamageMultiplier + 0.5))
+	if critical != want {
+		t.Errorf("クリティカルダメージが期待値と異なる: got %d, want %d", critical, want)
+	}
+	if state.Stats.CriticalHits != 1 {
+		t.Errorf("クリティカル回数: got %d, want 1", state.Stats.CriticalHits)
+	}
+}
+
+// TestApplyModuleEffect_OnCriticalPassive はon_critical条件のパッシブがクリティカル時のみ有効になることをテストします。
+func TestApplyModuleEffect_OnCriticalPassive(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+
+	passive := domain.PassiveSkill{
+		ID:          "ps_killer_instinct",
+		Name:        "キラーインスティンクト",
+		TriggerType: domain.PassiveTriggerConditional,
+		TriggerCondition: &domain.TriggerCondition{
+			Type: domain.TriggerConditionOnCritical,
+		},
+		Effects: map[domain.EffectColumn]float64{domain.ColCritDamage: 0.5},
+	}
+	state.Player.EffectTable.AddEntry(passive.ToEntry())
+
+	// 通常時はパッシブが無効
+	if effects := state.Player.EffectTable.Aggregate(domain.NewEffectContext(100, 100, 100, 100)); effects.CritDamage != 0 {
+		t.Errorf("クリティカル以外でon_criticalパッシブが有効: CritDamage=%v", effects.CritDamage)
+	}
+
+	state.Player.EffectTable.AddBuff("クリ無効", 10.0, map[domain.EffectColumn]float64{domain.ColCritRate: -10.0})
+	normal := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+
+	state.Player.EffectTable.RemoveBySourceType(domain.SourceBuff)
+	state.Player.EffectTable.AddBuff("クリ確定", 10.0, map[domain.EffectColumn]float64{domain.ColCritRate: 1.0})
+	critical := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+
+	want := int(float64(normal) * (config.CriticalDamageMultiplier + 0.5))
+	if critical != want {
+		t.Errorf("on_criticalパッシブが適用されていない: got %d, want %d", critical, want)
+	}
+}
+
+// TestApplyModuleEffect_CriticalKeepsOneShot はクリティカル時もモジュール使用時に発動した効果が維持されることをテストします。
+func TestApplyModuleEffect_CriticalKeepsOneShot(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+	state.Player.EffectTable.AddBuff("クリ確定", 10.0, map[domain.EffectColumn]float64{domain.ColCritRate: 1.0})
+
+	critical := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+
+	// 次のモジュール使用で1回だけ発動するダメージ2倍の効果
+	state.Player.EffectTable.AddEntry(domain.EffectEntry{
+		SourceType: domain.SourceChain,
+		SourceID:   "chain_double",
+		Name:       "チェイン:ダメージ2倍",
+		Values:     map[domain.EffectColumn]float64{domain.ColDamageMultiplier: 2.0},
+		OneShot:    true,
+	})
+	boosted := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+
+	if boosted != critical*2 {
+		t.Errorf("クリティカル時にOneShotの効果が失われています: got %d, want %d", boosted, critical*2)
+	}
+	if entry := state.Player.EffectTable.FindBySourceID("chain_double"); entry == nil || !entry.Triggered {
+		t.Error("OneShotの効果が発動済みになっていません")
+	}
+}
+
+// TestCriticalRate_LUK はLUKとcrit_rateからクリティカル率が計算されることをテストします。
+func TestCriticalRate_LUK(t *testing.T) {
+	engine := NewBattleEngine(nil)
+	stats := domain.Stats{LUK: 50}
+
+	effects := domain.NewEffectResult()
+	if got := engine.criticalRate(stats, effects); got != 50*config.CritRatePerLUK {
+		t.Errorf("LUKのみのクリティカル率: got %v, want %v", got, 50*config.CritRatePerLUK)
+	}
+
+	effects.CritRate = 0.15
+	want := 50*config.CritRatePerLUK + 0.15
+	if got := engine.criticalRate(stats, effects); got != want {
+		t.Errorf("crit_rate加算後のクリティカル率: got %v, want %v", got, want)
+	}
+}
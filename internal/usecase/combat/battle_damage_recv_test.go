@@ -81,7 +81,7 @@ func TestBattleEngine_DamageRecv_LastStand(t *testing.T) {
 	state.Player.HP = 20 // 20/100 = 20%
 
 	// Act: 敵の攻撃を処理
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// Assert: ダメージが1に固定されている
 	if damage != 1 {
@@ -158,7 +158,7 @@ func TestBattleEngine_DamageRecv_LastStand_HPAbove25(t *testing.T) {
 	state.Player.HP = 100 // 100/200 = 50%
 
 	// Act
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// Assert: 通常ダメージ（1より大きい）
 	if damage <= 1 {
@@ -231,7 +231,7 @@ func TestBattleEngine_DamageRecv_CounterCharge(t *testing.T) {
 	engine.RegisterPassiveSkills(state, agents)
 
 	// Act: 敵の攻撃を処理
-	engine.ProcessEnemyAttackDamage(state, "physical")
+	engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// Assert: プレイヤーのEffectTableに「次攻撃2倍」バフが追加されている
 	ctx := domain.NewEffectContext(state.Player.HP, state.Player.MaxHP, state.Enemy.HP, state.Enemy.MaxHP)
@@ -0,0 +1,182 @@
+// Package combat はバトル関連のユースケースを提供します。
+package combat
+
+import (
+	"math/rand"
+	"testing"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// ===== 属性ダメージのテスト =====
+
+// newElementTestBattle は属性テスト用のバトルを作成するヘルパー関数です。
+func newElementTestBattle(t *testing.T, enemyAffinity, coreAffinity domain.ElementAffinity) (*BattleEngine, *BattleState, *domain.AgentModel) {
+	t.Helper()
+
+	coreType := domain.CoreType{
+		ID:              "test_core",
+		Name:            "テストコア",
+		StatWeights:     map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		AllowedTags:     []string{"magic_low"},
+		ElementAffinity: coreAffinity,
+	}
+	core := domain.NewCore("core_001", "テストコア", 10, coreType, domain.PassiveSkill{})
+	agent := domain.NewAgent("agent_001", core, []*domain.ModuleModel{
+		newTestDamageModule("m1", "モジュール", []string{"magic_low"}, 1.0, "INT", ""),
+	})
+
+	enemyTypes := []domain.EnemyType{
+		{
+			ID:              "test_enemy",
+			Name:            "テスト敵",
+			BaseHP:          100000,
+			BaseAttackPower: 100,
+			AttackType:      "magic",
+			ElementAffinity: enemyAffinity,
+		},
+	}
+
+	engine := NewBattleEngine(enemyTypes)
+	engine.SetRng(rand.New(rand.NewSource(1)))
+	state, err := engine.InitializeBattle(1, []*domain.AgentModel{agent})
+	if err != nil {
+		t.Fatalf("バトル初期化に失敗: %v", err)
+	}
+	return engine, state, agent
+}
+
+// newElementModule は固定ダメージの属性攻撃モジュールを作成するヘルパー関数です。
+func newElementModule(element domain.Element) *domain.ModuleModel {
+	return domain.NewModuleFromType(domain.ModuleType{
+		ID:   "element_attack",
+		Name: "属性攻撃",
+		Tags: []string{"magic_low"},
+		Effects: []domain.ModuleEffect{
+			{
+				Target:      domain.TargetEnemy,
+				HPFormula:   &domain.HPFormula{Base: 100},
+				Probability: 1.0,
+				Element:     element,
+			},
+		},
+	}, nil)
+}
+
+// dealModuleDamage はモジュールを使用して与えたダメージを返します。
+func dealModuleDamage(engine *BattleEngine, state *BattleState, agent *domain.AgentModel, module *domain.ModuleModel) int {
+	typingResult := &typing.TypingResult{
+		Completed:      true,
+		WPM:            60.0,
+		Accuracy:       1.0,
+		SpeedFactor:    1.0,
+		AccuracyFactor: 1.0,
+	}
+	before := state.Enemy.HP
+	engine.ApplyModuleEffect(state, agent, module, typingResult)
+	return before - state.Enemy.HP
+}
+
+// TestApplyModuleEffect_ElementWeakness は弱点属性でダメージが増加し、弱点が記録されることをテストします。
+func TestApplyModuleEffect_ElementWeakness(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, domain.ElementAffinity{domain.ElementFire: 1.5}, nil)
+
+	neutral := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+	if len(state.DiscoveredWeaknesses) != 0 {
+		t.Errorf("無属性攻撃で弱点が記録された: %v", state.DiscoveredWeaknesses)
+	}
+
+	fire := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementFire))
+	if fire != int(float64(neutral)*1.5) {
+		t.Errorf("弱点ダメージが期待値と異なる: got %d, want %d", fire, int(float64(neutral)*1.5))
+	}
+	if got := state.DiscoveredWeaknesses[state.Enemy.Type.ID]; len(got) != 1 || got[0] != domain.ElementFire {
+		t.Errorf("弱点が記録されていない: %v", state.DiscoveredWeaknesses)
+	}
+
+	// 同じ弱点は重複して記録されない
+	dealModuleDamage(engine, state, agent, newElementModule(domain.ElementFire))
+	if len(state.DiscoveredWeaknesses[state.Enemy.Type.ID]) != 1 {
+		t.Errorf("弱点が重複して記録された: %v", state.DiscoveredWeaknesses)
+	}
+}
+
+// TestApplyModuleEffect_ElementResistance は耐性属性でダメージが減少することをテストします。
+func TestApplyModuleEffect_ElementResistance(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, domain.ElementAffinity{domain.ElementPoison: 0.5}, nil)
+
+	neutral := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementNone))
+	poison := dealModuleDamage(engine, state, agent, newElementModule(domain.ElementPoison))
+
+	if poison != int(float64(neutral)*0.5) {
+		t.Errorf("耐性ダメージが期待値と異なる: got %d, want %d", poison, int(float64(neutral)*0.5))
+	}
+	if len(state.DiscoveredWeaknesses) != 0 {
+		t.Errorf("耐性属性が弱点として記録された: %v", state.DiscoveredWeaknesses)
+	}
+}
+
+// TestProcessEnemyAttackDamage_CoreResistance は装備コアの耐性で属性攻撃が軽減されることをテストします。
+func TestProcessEnemyAttackDamage_CoreResistance(t *testing.T) {
+	engine, state, _ := newElementTestBattle(t, nil, domain.ElementAffinity{domain.ElementDark: 0.5})
+
+	neutral := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementNone)
+	dark := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementDark)
+
+	if dark != int(float64(neutral)*0.5) {
+		t.Errorf("コア耐性が適用されていない: got %d, want %d", dark, int(float64(neutral)*0.5))
+	}
+}
+
+// TestProcessEnemyAttackDamage_MultipleCores は複数コアの属性倍率が累積せず平均されることをテストします。
+func TestProcessEnemyAttackDamage_MultipleCores(t *testing.T) {
+	newCoreAgent := func(id string, affinity domain.ElementAffinity) *domain.AgentModel {
+		coreType := domain.CoreType{
+			ID:              "core_" + id,
+			Name:            "テストコア",
+			StatWeights:     map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+			ElementAffinity: affinity,
+		}
+		return domain.NewAgent(id, domain.NewCore("core_"+id, "テストコア", 10, coreType, domain.PassiveSkill{}), nil)
+	}
+	weak := domain.ElementAffinity{domain.ElementFire: 1.5}
+
+	// 3体とも炎弱点: 1.5³ではなく1.5倍
+	engine, state, _ := newElementTestBattle(t, nil, weak)
+	state.EquippedAgents = append(state.EquippedAgents, newCoreAgent("a2", weak), newCoreAgent("a3", weak))
+	neutral := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementNone)
+	fire := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementFire)
+	if want := int(float64(neutral) * 1.5); fire != want {
+		t.Errorf("炎弱点のコア3体: got %d, want %d", fire, want)
+	}
+
+	// 炎弱点1体と属性相性なし2体: 倍率は平均の(1.5+1.0+1.0)/3
+	engine, state, _ = newElementTestBattle(t, nil, weak)
+	state.EquippedAgents = append(state.EquippedAgents, newCoreAgent("a2", nil), newCoreAgent("a3", nil))
+	neutral = engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementNone)
+	fire = engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementFire)
+	if want := int(float64(neutral) * (3.5 / 3.0)); fire != want {
+		t.Errorf("炎弱点のコア1体と相性なし2体: got %d, want %d", fire, want)
+	}
+}
+
+// TestProcessEnemyAttackDamage_ElementCutBuff は属性別軽減バフが該当属性のみに効くことをテストします。
+func TestProcessEnemyAttackDamage_ElementCutBuff(t *testing.T) {
+	engine, state, _ := newElementTestBattle(t, nil, nil)
+
+	state.Player.EffectTable.AddBuff("炎耐性", 10.0, map[domain.EffectColumn]float64{
+		domain.ColFireCut: 0.4,
+	})
+
+	neutral := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementNone)
+	ice := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementIce)
+	fire := engine.ProcessEnemyAttackDamage(state, "magic", domain.ElementFire)
+
+	if ice != neutral {
+		t.Errorf("別属性に炎軽減が適用された: got %d, want %d", ice, neutral)
+	}
+	if fire != int(float64(neutral)*0.6) {
+		t.Errorf("炎軽減が適用されていない: got %d, want %d", fire, int(float64(neutral)*0.6))
+	}
+}
@@ -0,0 +1,188 @@
+// Package combat はバトルエンジンを提供します。
+// battle_log.go はバトル中に発生した出来事を型付きイベントとして記録するバトルログを担当します。
+package combat
+
+import (
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/combat/chain"
+)
+
+// BattleEventKind はバトルイベントの種別です。
+type BattleEventKind string
+
+const (
+	// BattleEventDamageDealt は敵へのダメージです。
+	BattleEventDamageDealt BattleEventKind = "damage_dealt"
+	// BattleEventDamageTaken はプレイヤーの被ダメージです。
+	BattleEventDamageTaken BattleEventKind = "damage_taken"
+	// BattleEventHeal は回復です。
+	BattleEventHeal BattleEventKind = "heal"
+	// BattleEventEvade はプレイヤーが敵の攻撃を回避したことを表します。
+	BattleEventEvade BattleEventKind = "evade"
+	// BattleEventBuffAdded はバフ・デバフ・状態異常の付与です。
+	BattleEventBuffAdded BattleEventKind = "buff_added"
+	// BattleEventBuffExpired はバフ・デバフ・状態異常の効果切れです。
+	BattleEventBuffExpired BattleEventKind = "buff_expired"
+	// BattleEventPassive はパッシブスキルの発動です。
+	BattleEventPassive BattleEventKind = "passive"
+	// BattleEventChain はチェイン効果の発動です。
+	BattleEventChain BattleEventKind = "chain"
+	// BattleEventPhaseChange は敵のフェーズ移行です。
+	BattleEventPhaseChange BattleEventKind = "phase_change"
+	// BattleEventParry はパリィの結果です。
+	BattleEventParry BattleEventKind = "parry"
+	// BattleEventVoltageDown はプレイヤーによる敵のボルテージの低下です。
+	BattleEventVoltageDown BattleEventKind = "voltage_down"
+	// BattleEventEnrage は敵のボルテージ段階への到達（激昂）です。
+	BattleEventEnrage BattleEventKind = "enrage"
+)
+
+// BattleEventPlayer はイベントの対象・発生源がプレイヤーであることを表す名前です。
+const BattleEventPlayer = "プレイヤー"
+
+// BattleEvent はバトル中に発生した1つの出来事です。
+type BattleEvent struct {
+	// At はバトル開始からの経過時間です。
+	At time.Duration
+
+	// Kind はイベントの種別です。
+	Kind BattleEventKind
+
+	// Source は発生源（モジュール名・敵名・パッシブ名など）です。
+	Source string
+
+	// Target は対象（プレイヤーまたは敵名）です。
+	Target string
+
+	// Amount はダメージ量・回復量です。数値を伴わないイベントでは0です。
+	Amount int
+
+	// Detail は補足情報（効果名・クリティカルなど）です。
+	Detail string
+
+	// Trace はダメージ・回復量の計算トレースです（記録が無効な場合はnil）。
+	Trace *CalculationTrace
+}
+
+// BattleLog はバトルイベントを一定件数まで保持するリングバッファです。
+// 容量を超えると古いイベントから上書きされます（パネル表示用）。
+// 書き出し用に、バトル開始からの全イベントも別に保持します。
+type BattleLog struct {
+	events []BattleEvent
+	start  int
+	count  int
+
+	// all はバトル開始からの全イベントです（容量による破棄をしません）。
+	all []BattleEvent
+}
+
+// NewBattleLog は指定された容量のバトルログを作成します。
+func NewBattleLog(capacity int) *BattleLog {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &BattleLog{events: make([]BattleEvent, capacity)}
+}
+
+// Add はイベントを追加します。容量を超えた場合は最も古いイベントを破棄します。
+func (l *BattleLog) Add(event BattleEvent) {
+	l.all = append(l.all, event)
+	capacity := len(l.events)
+	if l.count < capacity {
+		l.events[(l.start+l.count)%capacity] = event
+		l.count++
+		return
+	}
+	l.events[l.start] = event
+	l.start = (l.start + 1) % capacity
+}
+
+// Events は保持しているイベントを古い順に返します。
+func (l *BattleLog) Events() []BattleEvent {
+	events := make([]BattleEvent, 0, l.count)
+	for i := 0; i < l.count; i++ {
+		events = append(events, l.events[(l.start+i)%len(l.events)])
+	}
+	return events
+}
+
+// AllEvents はバトル開始からの全イベントを古い順に返します（リングバッファから破棄されたものを含みます）。
+func (l *BattleLog) AllEvents() []BattleEvent {
+	events := make([]BattleEvent, len(l.all))
+	copy(events, l.all)
+	return events
+}
+
+// Len は保持しているイベント数を返します。
+func (l *BattleLog) Len() int {
+	return l.count
+}
+
+// joinEventDetail は空でない補足情報を「・」で連結します。
+func joinEventDetail(details ...string) string {
+	joined := ""
+	for _, detail := range details {
+		if detail == "" {
+			continue
+		}
+		if joined != "" {
+			joined += "・"
+		}
+		joined += detail
+	}
+	return joined
+}
+
+// ==================== イベントの発行 ====================
+
+// SetBattleLog はイベントの記録先のバトルログを設定します。nilの場合は記録しません。
+func (e *BattleEngine) SetBattleLog(log *BattleLog) {
+	e.log = log
+}
+
+// emit はバトル開始からの経過時間を付けてイベントをバトルログに記録します。
+func (e *BattleEngine) emit(state *BattleState, event BattleEvent) {
+	if e.log == nil {
+		return
+	}
+	if state != nil && state.Stats != nil && !state.Stats.StartTime.IsZero() {
+		event.At = e.clock.Now().Sub(state.Stats.StartTime)
+	}
+	e.log.Add(event)
+}
+
+// emitEffectAdded は効果テーブルへのバフ・デバフ・状態異常の付与を記録します。
+func (e *BattleEngine) emitEffectAdded(state *BattleState, source, target, name string) {
+	e.emit(state, BattleEvent{Kind: BattleEventBuffAdded, Source: source, Target: target, Detail: name})
+}
+
+// emitExpired は直前の時間更新で効果切れになったバフ・デバフ・状態異常を記録します。
+// パッシブ・チェイン効果は表示上の効果ではないため記録しません。
+func (e *BattleEngine) emitExpired(state *BattleState, target string, table *domain.EffectTable) {
+	for _, entry := range table.LastExpired() {
+		switch entry.SourceType {
+		case domain.SourceBuff, domain.SourceDebuff, domain.SourceAilment:
+			e.emit(state, BattleEvent{Kind: BattleEventBuffExpired, Target: target, Detail: entry.Name})
+		}
+	}
+}
+
+// emit はセッションで発生したイベント（チェイン効果・パッシブなど）をバトルログに記録します。
+func (s *BattleSession) emit(event BattleEvent) {
+	s.engine.emit(s.state, event)
+}
+
+// emitPassive はエージェントのコアのパッシブスキルの発動を記録します。
+func (s *BattleSession) emitPassive(agent *domain.AgentModel, detail string) {
+	s.emit(BattleEvent{Kind: BattleEventPassive, Source: agent.Core.PassiveSkill.Name, Target: BattleEventPlayer, Detail: detail})
+}
+
+// chainSourceName はチェイン効果を登録したエージェントのコア名を返します。
+func (s *BattleSession) chainSourceName(effect *chain.TriggeredChainEffect) string {
+	if effect.SourceAgentIndex < 0 || effect.SourceAgentIndex >= len(s.state.EquippedAgents) {
+		return ""
+	}
+	return s.state.EquippedAgents[effect.SourceAgentIndex].Core.Name
+}
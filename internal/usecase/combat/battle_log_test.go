@@ -0,0 +1,127 @@
+// Package combat はバトルエンジンを提供します。
+// battle_log_test.go はバトルログの記録とバトルイベントの発行のテストです。
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// findEvent は指定した種別の最初のイベントを返します。
+func findEvent(events []BattleEvent, kind BattleEventKind) (BattleEvent, bool) {
+	for _, event := range events {
+		if event.Kind == kind {
+			return event, true
+		}
+	}
+	return BattleEvent{}, false
+}
+
+// TestBattleLog_RingBuffer は容量を超えると古いイベントから破棄されることをテストします。
+func TestBattleLog_RingBuffer(t *testing.T) {
+	log := NewBattleLog(3)
+	for i := 1; i <= 5; i++ {
+		log.Add(BattleEvent{Kind: BattleEventDamageDealt, Amount: i})
+	}
+
+	if log.Len() != 3 {
+		t.Fatalf("保持件数: 期待 3, 実際 %d", log.Len())
+	}
+	events := log.Events()
+	for i, want := range []int{3, 4, 5} {
+		if events[i].Amount != want {
+			t.Errorf("%d件目: 期待 %d, 実際 %d", i, want, events[i].Amount)
+		}
+	}
+
+	// 書き出し用の全イベントは破棄されない
+	all := log.AllEvents()
+	if len(all) != 5 {
+		t.Fatalf("全イベント数: 期待 5, 実際 %d", len(all))
+	}
+	for i, event := range all {
+		if event.Amount != i+1 {
+			t.Errorf("全イベントの%d件目: 期待 %d, 実際 %d", i, i+1, event.Amount)
+		}
+	}
+}
+
+// TestBattleSession_RecordsBattleEvents は敵の攻撃・モジュールの攻撃・効果切れがバトルログに記録されることをテストします。
+func TestBattleSession_RecordsBattleEvents(t *testing.T) {
+	session, c := newTestSession(1000, 10, 2*time.Second)
+	session.Player().EffectTable.AddBuff("攻撃UP", 1.0, map[domain.EffectColumn]float64{domain.ColDamageBonus: 5})
+
+	advance(session, c, 2*time.Second)
+	events := session.BattleLog().Events()
+
+	expired, ok := findEvent(events, BattleEventBuffExpired)
+	if !ok || expired.Target != BattleEventPlayer || expired.Detail != "攻撃UP" {
+		t.Errorf("バフの効果切れが記録されていません: %+v", events)
+	}
+	taken, ok := findEvent(events, BattleEventDamageTaken)
+	if !ok {
+		t.Fatalf("敵の攻撃による被ダメージが記録されていません: %+v", events)
+	}
+	if taken.Source != "スライム" || taken.Target != BattleEventPlayer || taken.Amount != 100-session.Player().HP {
+		t.Errorf("被ダメージイベント: 実際 %+v（プレイヤーHP %d）", taken, session.Player().HP)
+	}
+	if taken.At != 2*time.Second {
+		t.Errorf("イベントの経過時間: 期待 2s, 実際 %v", taken.At)
+	}
+
+	session.SelectModule(0)
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+	dealt, ok := findEvent(session.BattleLog().Events(), BattleEventDamageDealt)
+	if !ok {
+		t.Fatal("モジュールによる与ダメージが記録されていません")
+	}
+	if dealt.Source != "物理攻撃" || dealt.Target != "スライム" || dealt.Amount != 1000-session.Enemy().HP {
+		t.Errorf("与ダメージイベント: 実際 %+v（敵HP %d）", dealt, session.Enemy().HP)
+	}
+}
+
+// TestBattleSession_RecordsEvadeAndReflect は回避と反射ダメージがバトルログに記録されることをテストします。
+func TestBattleSession_RecordsEvadeAndReflect(t *testing.T) {
+	evading, ec := newTestSession(1000, 10, time.Second)
+	evading.Player().EffectTable.AddBuff("回避", 10, map[domain.EffectColumn]float64{domain.ColEvasion: 1.0})
+	advance(evading, ec, time.Second)
+	if evade, ok := findEvent(evading.BattleLog().Events(), BattleEventEvade); !ok || evade.Source != "スライム" {
+		t.Errorf("回避が記録されていません: %+v", evading.BattleLog().Events())
+	}
+	if _, ok := findEvent(evading.BattleLog().Events(), BattleEventDamageTaken); ok {
+		t.Error("回避した攻撃の被ダメージは記録されないべき")
+	}
+
+	reflecting, rc := newTestSession(1000, 10, time.Second)
+	reflecting.Player().EffectTable.AddBuff("反射", 10, map[domain.EffectColumn]float64{domain.ColReflect: 0.5})
+	advance(reflecting, rc, time.Second)
+	reflected, ok := findEvent(reflecting.BattleLog().Events(), BattleEventDamageDealt)
+	if !ok || reflected.Source != "反射" || reflected.Amount != 1000-reflecting.Enemy().HP {
+		t.Errorf("反射ダメージが記録されていません: %+v", reflecting.BattleLog().Events())
+	}
+}
+
+// TestBattleEngine_EmitsPhaseChange はフェーズ移行がバトルログに記録されることをテストします。
+func TestBattleEngine_EmitsPhaseChange(t *testing.T) {
+	engine, state := newEnemyPhaseTestBattle()
+	log := NewBattleLog(10)
+	engine.SetBattleLog(log)
+
+	state.Enemy.HP = state.Enemy.MaxHP / 2
+	if !engine.CheckPhaseTransition(state) {
+		t.Fatal("フェーズ移行が発生しませんでした")
+	}
+	engine.EnterEnemyPhase(state)
+
+	event, ok := findEvent(log.Events(), BattleEventPhaseChange)
+	if !ok || event.Source != state.Enemy.Name || event.Detail != state.Enemy.GetPhaseString() {
+		t.Errorf("フェーズ移行イベント: 実際 %+v", log.Events())
+	}
+	if buff, ok := findEvent(log.Events(), BattleEventBuffAdded); !ok || buff.Source != "骨の障壁" || buff.Target != state.Enemy.Name {
+		t.Errorf("移行時行動のバフ付与が記録されていません: %+v", log.Events())
+	}
+}
@@ -0,0 +1,95 @@
+// Package combat はバトル関連のユースケースを提供します。
+package combat
+
+import (
+	"testing"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// ===== 両対象（TargetBoth）効果のテスト =====
+
+// newBothModule は両対象の効果を持つモジュールを作成するヘルパー関数です。
+func newBothModule(effect domain.ModuleEffect) *domain.ModuleModel {
+	effect.Target = domain.TargetBoth
+	effect.Probability = 1.0
+	return domain.NewModuleFromType(domain.ModuleType{
+		ID:      "both_test",
+		Name:    "両対象テスト",
+		Tags:    []string{"magic_low"},
+		Effects: []domain.ModuleEffect{effect},
+	}, nil)
+}
+
+// disableCritical はテストのダメージを安定させるためクリティカルを無効化します。
+func disableCritical(state *BattleState) {
+	state.Player.EffectTable.AddBuff("クリ無効", 60.0, map[domain.EffectColumn]float64{
+		domain.ColCritRate: -10.0,
+	})
+}
+
+// TestApplyModuleEffect_TargetBothDrain は正の値の両対象HP効果で敵へのダメージと自己回復が行われることをテストします。
+func TestApplyModuleEffect_TargetBothDrain(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+	disableCritical(state)
+	state.Player.HP = state.Player.MaxHP / 2
+	playerHPBefore := state.Player.HP
+	enemyHPBefore := state.Enemy.HP
+
+	module := newBothModule(domain.ModuleEffect{HPFormula: &domain.HPFormula{Base: 30}})
+	total := dealModuleDamage(engine, state, agent, module)
+
+	if total != 30 || enemyHPBefore-state.Enemy.HP != 30 {
+		t.Errorf("ドレインのダメージが不正: total=%d, 敵HP減少=%d, want 30", total, enemyHPBefore-state.Enemy.HP)
+	}
+	if healed := state.Player.HP - playerHPBefore; healed != 30 {
+		t.Errorf("ドレインの回復量が不正: got %d, want 30", healed)
+	}
+	if state.Stats.TotalHealAmount != 30 {
+		t.Errorf("回復量が統計に記録されていない: got %d, want 30", state.Stats.TotalHealAmount)
+	}
+}
+
+// TestApplyModuleEffect_TargetBothReckless は負の値の両対象HP効果で敵と自分の両方がダメージを受けることをテストします。
+func TestApplyModuleEffect_TargetBothReckless(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+	disableCritical(state)
+	playerHPBefore := state.Player.HP
+	enemyHPBefore := state.Enemy.HP
+
+	module := newBothModule(domain.ModuleEffect{HPFormula: &domain.HPFormula{Base: -20}})
+	dealModuleDamage(engine, state, agent, module)
+
+	if got := enemyHPBefore - state.Enemy.HP; got != 20 {
+		t.Errorf("捨て身の敵へのダメージが不正: got %d, want 20", got)
+	}
+	if got := playerHPBefore - state.Player.HP; got != 20 {
+		t.Errorf("捨て身の自傷ダメージが不正: got %d, want 20", got)
+	}
+}
+
+// TestApplyModuleEffect_TargetBothField は両対象のカラム効果が自分と敵の両方に付与されることをテストします。
+func TestApplyModuleEffect_TargetBothField(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+
+	module := newBothModule(domain.ModuleEffect{
+		ColumnSpec: &domain.EffectColumnSpec{Column: domain.ColDamageMultiplier, Value: 1.3, Duration: 12.0},
+	})
+	baseAttack := engine.getBuffedAttackPower(state)
+	dealModuleDamage(engine, state, agent, module)
+
+	if buffs := state.Player.EffectTable.GetActiveBuffs(); len(buffs) != 1 {
+		t.Fatalf("プレイヤーにフィールド効果が付与されていない: %d件", len(buffs))
+	}
+	if buffs := state.Enemy.EffectTable.GetActiveBuffs(); len(buffs) != 1 {
+		t.Fatalf("敵にフィールド効果が付与されていない: %d件", len(buffs))
+	}
+
+	playerEffects := state.Player.EffectTable.Aggregate(domain.NewEffectContext(100, 100, 100, 100))
+	if playerEffects.DamageMultiplier != 1.3 {
+		t.Errorf("プレイヤーのダメージ倍率: got %v, want 1.3", playerEffects.DamageMultiplier)
+	}
+	if got, want := engine.getBuffedAttackPower(state), int(float64(baseAttack)*1.3); got != want {
+		t.Errorf("敵の攻撃力にフィールド効果が反映されていない: got %d, want %d", got, want)
+	}
+}
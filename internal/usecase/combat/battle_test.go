@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
 	"hirorocky/type-battle/internal/usecase/typing"
 )
 
@@ -197,6 +198,77 @@ func TestInitializeBattle_EnemyGeneration(t *testing.T) {
 	}
 }
 
+// TestInitializeBattle_ManualClock は注入した時計でチャージ判定が行われることをテストします。
+func TestInitializeBattle_ManualClock(t *testing.T) {
+	enemyTypes := []domain.EnemyType{
+		{
+			ID:              "slime",
+			Name:            "スライム",
+			BaseHP:          50,
+			BaseAttackPower: 5,
+			AttackType:      "physical",
+			ResolvedNormalActions: []domain.EnemyAction{
+				{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: 3 * time.Second},
+			},
+		},
+	}
+
+	coreType := domain.CoreType{
+		ID:          "all_rounder",
+		Name:        "オールラウンダー",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		AllowedTags: []string{"physical_low"},
+	}
+	core := domain.NewCore("core_001", "コア", 5, coreType, domain.PassiveSkill{ID: "test", Name: "テスト"})
+	modules := []*domain.ModuleModel{
+		newTestDamageModule("m1", "モジュール", []string{"physical_low"}, 1.0, "STR", ""),
+	}
+	agents := []*domain.AgentModel{domain.NewAgent("agent_001", core, modules)}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewManualClock(start)
+	engine := NewBattleEngine(enemyTypes)
+	engine.SetClock(c)
+	state, err := engine.InitializeBattle(1, agents)
+	if err != nil {
+		t.Fatalf("バトル初期化に失敗: %v", err)
+	}
+
+	if !state.Stats.StartTime.Equal(start) {
+		t.Errorf("バトル開始時刻: 期待 %v, 実際 %v", start, state.Stats.StartTime)
+	}
+	if engine.IsAttackReady(state) {
+		t.Error("時計を進めていないのにチャージが完了しています")
+	}
+
+	c.Advance(time.Second)
+	if remaining := engine.GetTimeUntilNextAttack(state); remaining != 2*time.Second {
+		t.Errorf("次の攻撃までの時間: 期待 2s, 実際 %v", remaining)
+	}
+
+	c.Advance(2 * time.Second)
+	if !engine.IsAttackReady(state) {
+		t.Error("チャージ時間経過後もチャージが完了していません")
+	}
+}
+
+// TestBattleStatistics_GetClearTime はクリア時間がバトルの時計で計測され、一時停止中の時間を含まないことをテストします。
+func TestBattleStatistics_GetClearTime(t *testing.T) {
+	base := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := clock.NewPausableClock(base)
+	stats := &BattleStatistics{StartTime: c.Now()}
+
+	base.Advance(10 * time.Second)
+	c.Pause()
+	base.Advance(time.Minute)
+	c.Resume()
+	base.Advance(5 * time.Second)
+
+	if got := stats.GetClearTime(c); got != 15*time.Second {
+		t.Errorf("クリア時間: 期待 15s, 実際 %v", got)
+	}
+}
+
 // ==================== 敵攻撃システムテスト（Task 7.2） ====================
 
 // TestEnemyAttack は敵の攻撃処理をテストします。
@@ -233,7 +305,7 @@ func TestEnemyAttack(t *testing.T) {
 	state, _ := engine.InitializeBattle(5, agents)
 
 	initialHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	if state.Player.HP >= initialHP {
 		t.Error("プレイヤーHPが減少していない")
@@ -281,7 +353,7 @@ func TestEnemyAttack_WithDefenseBuff(t *testing.T) {
 		domain.ColDamageCut: 0.3, // 30%軽減
 	})
 
-	damageWithBuff := engine.ProcessEnemyAttackDamage(state, "physical")
+	damageWithBuff := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// ダメージが軽減されていることを確認
 	// 基礎ダメージ × 0.7 程度になるはず
@@ -1020,7 +1092,7 @@ func TestPassiveSkillDamageReduction(t *testing.T) {
 
 	// 敵の攻撃を処理
 	initialHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// ダメージが軽減されていることを確認
 	// 敵の攻撃力は BaseAttackPower + (level * 2) = 100 + 10 = 110
@@ -1083,6 +1155,9 @@ func TestPassiveSkillSTRMultiplier(t *testing.T) {
 	state, _ := engine.InitializeBattle(1, agents)
 	engine.RegisterPassiveSkills(state, agents)
 
+	// クリティカルによるダメージの揺れを除外
+	state.Player.EffectTable.AddBuff("クリ無効", 10.0, map[domain.EffectColumn]float64{domain.ColCritRate: -10.0})
+
 	// タイピング結果
 	typingResult := &typing.TypingResult{
 		Completed:      true,
@@ -1153,13 +1228,13 @@ func TestPassiveSkillEffectContinuesDuringRecast(t *testing.T) {
 	engine.RegisterPassiveSkills(state, agents)
 
 	// 1回目の攻撃
-	damage1 := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage1 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// エフェクトの時間を経過させる（リキャスト中をシミュレート）
 	engine.UpdateEffects(state, 5.0) // 5秒経過
 
 	// 2回目の攻撃（リキャスト中でもパッシブスキルは有効）
-	damage2 := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage2 := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// 両方とも同じダメージ（パッシブスキルが継続適用されている）
 	// 敵の攻撃力は BaseAttackPower + (level * 2) = 100 + 10 = 110
@@ -1295,7 +1370,7 @@ func TestPassiveSkillIntegration_BattleInitToStatCalculation(t *testing.T) {
 	}
 
 	// Step 5: 実際のダメージ計算に適用されていることを確認
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	expectedDamage := int(float64(state.Enemy.AttackPower) * 0.8)
 	if damage != expectedDamage {
 		t.Errorf("ダメージ計算が不正: 期待 %d, 実際 %d (敵攻撃力 %d)", expectedDamage, damage, state.Enemy.AttackPower)
@@ -1415,7 +1490,7 @@ func TestPassiveSkillIntegration_MultipleAgentCoexistence(t *testing.T) {
 	// DamageBonusのチェックはスキップ（パッシブスキルの設定次第）
 
 	// 実際のダメージ計算で複数のパッシブ効果が適用されていることを確認
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	expectedDamage := int(float64(state.Enemy.AttackPower) * 0.85) // 15%軽減
 	if damage != expectedDamage {
 		t.Errorf("ダメージ計算で複数パッシブが適用されていない: 期待 %d, 実際 %d", expectedDamage, damage)
@@ -1466,7 +1541,7 @@ func TestPassiveSkillIntegration_RecastPersistence(t *testing.T) {
 	engine.RegisterPassiveSkills(state, agents)
 
 	// 初期ダメージを記録
-	initialDamage := engine.ProcessEnemyAttackDamage(state, "physical")
+	initialDamage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	expectedDamage := int(float64(state.Enemy.AttackPower) * 0.75)
 	if initialDamage != expectedDamage {
 		t.Errorf("初期ダメージが不正: 期待 %d, 実際 %d", expectedDamage, initialDamage)
@@ -1478,7 +1553,7 @@ func TestPassiveSkillIntegration_RecastPersistence(t *testing.T) {
 	})
 
 	// バフ適用中のダメージ（新システムではmax取りなので、max(25%, 10%) = 25%軽減）
-	buffedDamage := engine.ProcessEnemyAttackDamage(state, "physical")
+	buffedDamage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	// max取りなので元の25%軽減と同じになる
 	if buffedDamage != initialDamage {
 		t.Errorf("バフ適用中ダメージが不正: 期待 %d, 実際 %d（max取りなので元と同じはず）", initialDamage, buffedDamage)
@@ -1488,7 +1563,7 @@ func TestPassiveSkillIntegration_RecastPersistence(t *testing.T) {
 	engine.UpdateEffects(state, 5.0) // 5秒経過
 
 	// バフ切れ後のダメージ（パッシブスキルの25%軽減のみ）
-	afterBuffExpiredDamage := engine.ProcessEnemyAttackDamage(state, "physical")
+	afterBuffExpiredDamage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 	if afterBuffExpiredDamage != expectedDamage {
 		t.Errorf("バフ切れ後ダメージが不正: 期待 %d, 実際 %d (パッシブスキル効果が消えている可能性)", expectedDamage, afterBuffExpiredDamage)
 	}
@@ -278,7 +278,7 @@ func TestBattleEngine_VoltageDamageMultiplier_100Percent(t *testing.T) {
 
 	// ボルテージ100%（等倍）
 	initialPlayerHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// 敵攻撃力 = BaseAttackPower(10) + level(1)*2 = 12
 	// ボルテージ100% -> 乗算 x1.0 -> 最終ダメージ = 12
@@ -329,7 +329,7 @@ func TestBattleEngine_VoltageDamageMultiplier_150Percent(t *testing.T) {
 	state.Enemy.SetVoltage(150.0)
 
 	initialPlayerHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// 敵攻撃力 = BaseAttackPower(10) + level(1)*2 = 12
 	// ボルテージ150% -> 乗算 x1.5 -> 最終ダメージ = 18
@@ -380,7 +380,7 @@ func TestBattleEngine_VoltageDamageMultiplier_200Percent(t *testing.T) {
 	state.Enemy.SetVoltage(200.0)
 
 	initialPlayerHP := state.Player.HP
-	damage := engine.ProcessEnemyAttackDamage(state, "physical")
+	damage := engine.ProcessEnemyAttackDamage(state, "physical", domain.ElementNone)
 
 	// 敵攻撃力 = BaseAttackPower(10) + level(1)*2 = 12
 	// ボルテージ200% -> 乗算 x2.0 -> 最終ダメージ = 24
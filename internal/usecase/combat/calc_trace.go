@@ -0,0 +1,140 @@
+// Package combat はバトルエンジンを提供します。
+// calc_trace.go はモジュール使用と敵の攻撃のダメージ・回復量の計算過程を記録する計算トレースを担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+)
+
+// CalculationTrace は1回のダメージ・回復量の計算過程です。
+// 計算の各段階と、計算に寄与した効果テーブルのエントリを記録します。
+type CalculationTrace struct {
+	// Steps は計算の各段階（古い順）です。
+	Steps []TraceStep
+
+	// Effects は計算に寄与した効果テーブルのエントリです。
+	Effects []TraceEffect
+}
+
+// TraceStep は計算の1段階です。
+type TraceStep struct {
+	// Label は段階の名前（基本値・速度係数・防御軽減など）です。
+	Label string
+
+	// Formula は段階で適用した式や係数の説明です。
+	Formula string
+
+	// Value はこの段階を適用した後の値です。
+	Value float64
+}
+
+// TraceEffect は計算に寄与した効果テーブルのエントリです。
+type TraceEffect struct {
+	// Owner は効果テーブルの持ち主（プレイヤーまたは敵名）です。
+	Owner string
+
+	// Contribution は寄与した効果値と集計方法です。
+	Contribution domain.EffectContribution
+}
+
+// statTraceColumns はステータス参照名ごとのステータス修飾列です。
+var statTraceColumns = map[string][]domain.EffectColumn{
+	"STR": {domain.ColSTRBonus, domain.ColSTRMultiplier},
+	"INT": {domain.ColINTBonus, domain.ColINTMultiplier},
+	"WIL": {domain.ColWILBonus, domain.ColWILMultiplier},
+	"LUK": {domain.ColLUKBonus, domain.ColLUKMultiplier},
+}
+
+// step は計算の段階を記録します。トレースがnilの場合は何もしません。
+func (t *CalculationTrace) step(label string, value float64, format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, TraceStep{Label: label, Formula: fmt.Sprintf(format, args...), Value: value})
+}
+
+// addEffects は集計結果のうち指定した列に寄与したエントリを記録します。
+// 列の指定順に記録するため、集計時のエントリの順序によらず表示順が安定します。
+func (t *CalculationTrace) addEffects(owner string, result domain.EffectResult, cols ...domain.EffectColumn) {
+	if t == nil {
+		return
+	}
+	for _, col := range cols {
+		for _, contribution := range result.Contributions {
+			if contribution.Column == col {
+				t.Effects = append(t.Effects, TraceEffect{Owner: owner, Contribution: contribution})
+			}
+		}
+	}
+}
+
+// addElementCut は属性別被ダメ軽減列に寄与したエントリを記録します。
+func (t *CalculationTrace) addElementCut(owner string, result domain.EffectResult, element domain.Element) {
+	if col, ok := domain.ElementCutColumn(element); ok {
+		t.addEffects(owner, result, col)
+	}
+}
+
+// clone はここまでの計算過程を複製します。
+// 1つのHP変化量から複数の対象へダメージ・回復を適用する場合に使います。
+func (t *CalculationTrace) clone() *CalculationTrace {
+	if t == nil {
+		return nil
+	}
+	return &CalculationTrace{
+		Steps:   append([]TraceStep(nil), t.Steps...),
+		Effects: append([]TraceEffect(nil), t.Effects...),
+	}
+}
+
+// Summary は計算の各段階を「名前 値 → 名前 値」の形式で1行にまとめます。
+func (t *CalculationTrace) Summary() string {
+	if t == nil {
+		return ""
+	}
+	summary := ""
+	for i, step := range t.Steps {
+		if i > 0 {
+			summary += " → "
+		}
+		summary += fmt.Sprintf("%s %s", step.Label, step.FormatValue())
+	}
+	return summary
+}
+
+// FormatValue は段階適用後の値を表示用に整形します（整数なら小数点以下を省略）。
+func (s TraceStep) FormatValue() string {
+	if s.Value == float64(int(s.Value)) {
+		return fmt.Sprintf("%d", int(s.Value))
+	}
+	return fmt.Sprintf("%.2f", s.Value)
+}
+
+// traceParry はパリィの結果による被ダメージ補正を記録します。パリィしていない場合は記録しません。
+func traceParry(trace *CalculationTrace, parry domain.ParryResult, damage int) {
+	switch parry {
+	case domain.ParryPartial:
+		trace.step("パリィ", float64(damage), "×%.2f（最低1）", config.ParryPartialDamageRate)
+	case domain.ParryFailed:
+		trace.step("パリィ", float64(damage), "×%.2f（失敗）", config.ParryFailedDamageRate)
+	}
+}
+
+// ==================== トレースの生成 ====================
+
+// SetCalculationTrace は計算トレースの記録を切り替えます。
+// 記録した計算トレースはダメージ・回復のバトルイベントに添付されます。
+func (e *BattleEngine) SetCalculationTrace(enabled bool) {
+	e.tracing = enabled
+}
+
+// newTrace は計算トレースを作成します。記録が無効またはバトルログがない場合はnilを返します。
+func (e *BattleEngine) newTrace() *CalculationTrace {
+	if !e.tracing || e.log == nil {
+		return nil
+	}
+	return &CalculationTrace{}
+}
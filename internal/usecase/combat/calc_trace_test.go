@@ -0,0 +1,108 @@
+// Package combat はバトルエンジンを提供します。
+// calc_trace_test.go はダメージ・回復量の計算トレースのテストです。
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// traceLabels は計算トレースの段階名を返します。
+func traceLabels(trace *CalculationTrace) []string {
+	labels := make([]string, len(trace.Steps))
+	for i, step := range trace.Steps {
+		labels[i] = step.Label
+	}
+	return labels
+}
+
+// findTraceStep は指定した名前の段階を返します。
+func findTraceStep(trace *CalculationTrace, label string) (TraceStep, bool) {
+	for _, step := range trace.Steps {
+		if step.Label == label {
+			return step, true
+		}
+	}
+	return TraceStep{}, false
+}
+
+// TestBattleSession_TracesModuleDamage はモジュールの与ダメージに計算過程と寄与した効果が記録されることをテストします。
+func TestBattleSession_TracesModuleDamage(t *testing.T) {
+	session, _ := newTestSession(1000, 10, 10*time.Second)
+	session.SetCalculationTrace(true)
+	session.Player().EffectTable.AddBuff("攻撃UP", 10, map[domain.EffectColumn]float64{domain.ColDamageMultiplier: 1.5})
+	session.Enemy().EffectTable.AddBuff("防御UP", 10, map[domain.EffectColumn]float64{domain.ColDamageCut: 0.2})
+
+	session.SelectModule(0)
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+
+	dealt, ok := findEvent(session.BattleLog().Events(), BattleEventDamageDealt)
+	if !ok || dealt.Trace == nil {
+		t.Fatalf("与ダメージに計算トレースが添付されていません: %+v", session.BattleLog().Events())
+	}
+	for _, label := range []string{"ステータス", "基本値", "速度係数", "正確性係数", "ダメージ倍率", "防御軽減"} {
+		if _, ok := findTraceStep(dealt.Trace, label); !ok {
+			t.Errorf("計算トレースに「%s」がありません: %v", label, traceLabels(dealt.Trace))
+		}
+	}
+	last := dealt.Trace.Steps[len(dealt.Trace.Steps)-1]
+	if int(last.Value) != dealt.Amount {
+		t.Errorf("最後の段階の値が与ダメージと一致しません: 段階 %v, 与ダメージ %d", last.Value, dealt.Amount)
+	}
+
+	owners := map[string]domain.EffectContribution{}
+	for _, effect := range dealt.Trace.Effects {
+		owners[effect.Owner] = effect.Contribution
+	}
+	if c := owners[BattleEventPlayer]; c.Source != "攻撃UP" || c.Aggregation != domain.AggMult {
+		t.Errorf("プレイヤーの攻撃UPが寄与として記録されていません: %+v", dealt.Trace.Effects)
+	}
+	if c := owners["スライム"]; c.Source != "防御UP" || c.Aggregation != domain.AggMax {
+		t.Errorf("敵の防御UPが寄与として記録されていません: %+v", dealt.Trace.Effects)
+	}
+}
+
+// TestBattleSession_TracesEnemyDamage は敵の攻撃の被ダメージにボルテージ倍率と防御軽減が記録されることをテストします。
+func TestBattleSession_TracesEnemyDamage(t *testing.T) {
+	session, c := newTestSession(1000, 20, 2*time.Second)
+	session.SetCalculationTrace(true)
+	session.Enemy().SetVoltage(150)
+	session.Player().EffectTable.AddBuff("ガード", 10, map[domain.EffectColumn]float64{domain.ColDamageCut: 0.5})
+
+	advance(session, c, 2*time.Second)
+
+	taken, ok := findEvent(session.BattleLog().Events(), BattleEventDamageTaken)
+	if !ok || taken.Trace == nil {
+		t.Fatalf("被ダメージに計算トレースが添付されていません: %+v", session.BattleLog().Events())
+	}
+	if got := traceLabels(taken.Trace); len(got) != 3 || got[0] != "攻撃力" || got[1] != "防御軽減" || got[2] != "ボルテージ" {
+		t.Errorf("計算トレースの段階: 実際 %v", got)
+	}
+	if step, _ := findTraceStep(taken.Trace, "防御軽減"); step.Value != 10 {
+		t.Errorf("防御軽減後の値: 期待 10, 実際 %v", step.Value)
+	}
+	if step, _ := findTraceStep(taken.Trace, "ボルテージ"); step.Formula != "×1.50" || int(step.Value) != taken.Amount {
+		t.Errorf("ボルテージの段階: 実際 %+v（被ダメージ %d）", step, taken.Amount)
+	}
+	if len(taken.Trace.Effects) != 1 || taken.Trace.Effects[0].Contribution.Source != "ガード" {
+		t.Errorf("プレイヤーのガードが寄与として記録されていません: %+v", taken.Trace.Effects)
+	}
+}
+
+// TestBattleSession_CalculationTraceDisabled は計算トレースが無効な場合はイベントに添付されないことをテストします。
+func TestBattleSession_CalculationTraceDisabled(t *testing.T) {
+	session, c := newTestSession(1000, 10, 2*time.Second)
+	advance(session, c, 2*time.Second)
+
+	taken, ok := findEvent(session.BattleLog().Events(), BattleEventDamageTaken)
+	if !ok {
+		t.Fatal("被ダメージが記録されていません")
+	}
+	if taken.Trace != nil {
+		t.Errorf("計算トレースが無効な場合は添付されないべき: %+v", taken.Trace)
+	}
+}
@@ -0,0 +1,65 @@
+// Package chain はチェイン効果管理機能を提供します。
+// chain_counter.go はエージェントを切り替えながらの連続モジュール使用（チェイン数）の管理を担当します。
+package chain
+
+import "hirorocky/type-battle/internal/config"
+
+// ChainBonusMultiplier はチェイン数に応じたチェイン効果の倍率を返します。
+// 2チェインまでは等倍で、3チェイン目以降はChainBonusPerLinkずつ上昇します（上限MaxChainBonusMultiplier）。
+func ChainBonusMultiplier(length int) float64 {
+	if length <= 2 {
+		return 1.0
+	}
+	multiplier := 1.0 + config.ChainBonusPerLink*float64(length-2)
+	if multiplier > config.MaxChainBonusMultiplier {
+		return config.MaxChainBonusMultiplier
+	}
+	return multiplier
+}
+
+// RecordModuleUse はモジュールの使用をチェインに記録し、記録後のチェイン数を返します。
+// 直前と別のエージェントが猶予時間内に使用した場合はチェインが伸び、
+// 同じエージェントが続けて使用した場合はそのエージェントから新しいチェインを始めます。
+func (m *ChainEffectManager) RecordModuleUse(agentIndex int) int {
+	last := len(m.chainTrack) - 1
+	if last >= 0 && m.chainTrack[last] != agentIndex {
+		m.chainTrack = append(m.chainTrack, agentIndex)
+	} else {
+		m.chainTrack = []int{agentIndex}
+	}
+	m.chainElapsed = 0
+	return len(m.chainTrack)
+}
+
+// UpdateChain はチェインの経過時間を進め、猶予時間を過ぎた場合はチェインを途切れさせます。
+// チェインが途切れた場合はtrueを返します。
+func (m *ChainEffectManager) UpdateChain(deltaSeconds float64) bool {
+	if len(m.chainTrack) == 0 {
+		return false
+	}
+	m.chainElapsed += deltaSeconds
+	if m.chainElapsed <= config.ChainWindowSeconds {
+		return false
+	}
+	m.chainTrack = nil
+	m.chainElapsed = 0
+	return true
+}
+
+// ChainLength は現在のチェイン数を返します（0: チェインなし）。
+func (m *ChainEffectManager) ChainLength() int {
+	return len(m.chainTrack)
+}
+
+// ChainTrack は現在のチェインでモジュールを使用したエージェントのインデックスを使用順に返します。
+func (m *ChainEffectManager) ChainTrack() []int {
+	return append([]int(nil), m.chainTrack...)
+}
+
+// ChainTimeRemaining はチェインが途切れるまでの残り秒数を返します（チェインなしの場合は0）。
+func (m *ChainEffectManager) ChainTimeRemaining() float64 {
+	if len(m.chainTrack) == 0 {
+		return 0
+	}
+	return config.ChainWindowSeconds - m.chainElapsed
+}
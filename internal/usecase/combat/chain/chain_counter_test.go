@@ -0,0 +1,95 @@
+// Package chain はチェイン効果管理機能を提供します。
+package chain
+
+import (
+	"testing"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+)
+
+// TestRecordModuleUse_ChainAcrossAgents はエージェントを切り替えながらの使用でチェイン数が伸び、
+// 同じエージェントの連続使用で新しいチェインになることをテストします。
+func TestRecordModuleUse_ChainAcrossAgents(t *testing.T) {
+	cem := NewChainEffectManager()
+
+	for i, want := range []int{1, 2, 3} {
+		if got := cem.RecordModuleUse(i); got != want {
+			t.Errorf("エージェント%dの使用後のチェイン数: got %d, want %d", i, got, want)
+		}
+	}
+	if got := cem.RecordModuleUse(0); got != 4 {
+		t.Errorf("直前と別のエージェントならチェインが続くべき: got %d", got)
+	}
+	if track := cem.ChainTrack(); len(track) != 4 || track[3] != 0 {
+		t.Errorf("チェインの経過: got %v", track)
+	}
+
+	if got := cem.RecordModuleUse(0); got != 1 {
+		t.Errorf("同じエージェントの連続使用で新しいチェインになるべき: got %d", got)
+	}
+}
+
+// TestUpdateChain_WindowExpires は猶予時間を過ぎるとチェインが途切れることをテストします。
+func TestUpdateChain_WindowExpires(t *testing.T) {
+	cem := NewChainEffectManager()
+	cem.RecordModuleUse(0)
+	cem.RecordModuleUse(1)
+
+	if cem.UpdateChain(config.ChainWindowSeconds - 1) {
+		t.Error("猶予時間内にチェインが途切れるべきではありません")
+	}
+	if got := cem.ChainTimeRemaining(); got != 1 {
+		t.Errorf("残り時間: got %v, want 1", got)
+	}
+	if cem.RecordModuleUse(2) != 3 {
+		t.Error("猶予時間内の使用でチェインが続くべき")
+	}
+
+	if !cem.UpdateChain(config.ChainWindowSeconds + 0.1) {
+		t.Error("猶予時間を過ぎるとチェインが途切れるべき")
+	}
+	if cem.ChainLength() != 0 || cem.ChainTimeRemaining() != 0 {
+		t.Errorf("途切れた後のチェイン数: got %d", cem.ChainLength())
+	}
+}
+
+// TestChainBonusMultiplier はチェイン数に応じた倍率をテストします。
+func TestChainBonusMultiplier(t *testing.T) {
+	cases := []struct {
+		length int
+		want   float64
+	}{{0, 1.0}, {2, 1.0}, {3, 1.25}, {4, 1.5}, {20, config.MaxChainBonusMultiplier}}
+	for _, c := range cases {
+		if got := ChainBonusMultiplier(c.length); got != c.want {
+			t.Errorf("%dチェインの倍率: got %v, want %v", c.length, got, c.want)
+		}
+	}
+}
+
+// TestCheckAndTrigger_MinChainLength は必要チェイン数に満たない効果が待機したままになり、
+// 到達した時点でチェイン数に応じて強化されて発動することをテストします。
+func TestCheckAndTrigger_MinChainLength(t *testing.T) {
+	cem := NewChainEffectManager()
+	effect := domain.NewChainEffect(domain.ChainEffectDamageBonus, 20.0).WithMinChainLength(3)
+	cem.RegisterChainEffect(0, &effect, "slash_lv1")
+	damageFlags := ModuleEffectFlags{HasDamage: true}
+
+	cem.RecordModuleUse(0)
+	cem.RecordModuleUse(1)
+	if triggered := cem.CheckAndTrigger(1, damageFlags); len(triggered) != 0 {
+		t.Fatalf("2チェインでは発動しないべき: got %d", len(triggered))
+	}
+	if !cem.HasPendingEffect(0) {
+		t.Fatal("必要チェイン数に満たない効果は待機したままであるべき")
+	}
+
+	cem.RecordModuleUse(2)
+	triggered := cem.CheckAndTrigger(2, damageFlags)
+	if len(triggered) != 1 {
+		t.Fatalf("3チェインで発動するべき: got %d", len(triggered))
+	}
+	if triggered[0].ChainLength != 3 || triggered[0].EffectValue != 25.0 {
+		t.Errorf("3チェインの発動: got チェイン数 %d, 効果値 %v（want 3, 25）", triggered[0].ChainLength, triggered[0].EffectValue)
+	}
+}
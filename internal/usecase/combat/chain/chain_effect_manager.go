@@ -48,6 +48,9 @@ type TriggeredChainEffect struct {
 
 	// SourceAgentIndex は効果を登録したエージェントのインデックスです。
 	SourceAgentIndex int
+
+	// ChainLength は発動時のチェイン数です。
+	ChainLength int
 }
 
 // ChainEffectManager はチェイン効果の管理を担当する構造体です。
@@ -57,6 +60,12 @@ type ChainEffectManager struct {
 	// pendingEffects はエージェントインデックスごとの待機中チェイン効果です。
 	// 1エージェントにつき1つの待機中効果のみ保持します。
 	pendingEffects map[int]*PendingChainEffect
+
+	// chainTrack は現在のチェインでモジュールを使用したエージェントのインデックス（使用順）です。
+	chainTrack []int
+
+	// chainElapsed は直前のモジュール使用からの経過秒数です。
+	chainElapsed float64
 }
 
 // NewChainEffectManager は新しいChainEffectManagerを作成します。
@@ -85,9 +94,12 @@ func (m *ChainEffectManager) RegisterChainEffect(agentIndex int, effect *domain.
 // usingAgentIndexはモジュールを使用したエージェントのインデックスです。
 // effectFlagsは使用したモジュールが持つ効果の種別です。
 // 発動した効果のリストを返します。
+// 効果値は現在のチェイン数に応じて強化され、必要チェイン数に満たない効果は待機したままになります。
 func (m *ChainEffectManager) CheckAndTrigger(usingAgentIndex int, effectFlags ModuleEffectFlags) []TriggeredChainEffect {
 	triggered := make([]TriggeredChainEffect, 0)
 	expiredAgents := make([]int, 0)
+	chainLength := m.ChainLength()
+	multiplier := ChainBonusMultiplier(chainLength)
 
 	for agentIndex, pending := range m.pendingEffects {
 		// 同一エージェントのチェイン効果は発動しない
@@ -100,12 +112,18 @@ func (m *ChainEffectManager) CheckAndTrigger(usingAgentIndex int, effectFlags Mo
 			continue
 		}
 
+		// 必要チェイン数をチェック
+		if pending.Effect.MinChainLength > chainLength {
+			continue
+		}
+
 		// 発動
 		triggered = append(triggered, TriggeredChainEffect{
 			Effect:           pending.Effect,
-			EffectValue:      pending.Effect.Value,
+			EffectValue:      pending.Effect.Value * multiplier,
 			Message:          pending.Effect.Description,
 			SourceAgentIndex: agentIndex,
+			ChainLength:      chainLength,
 		})
 
 		// 発動後は削除対象
@@ -205,7 +223,9 @@ func (m *ChainEffectManager) GetPendingEffectForAgent(agentIndex int) *PendingCh
 	return m.pendingEffects[agentIndex]
 }
 
-// ClearAll は全ての待機中チェイン効果をクリアします。
+// ClearAll は全ての待機中チェイン効果とチェイン数をクリアします。
 func (m *ChainEffectManager) ClearAll() {
 	m.pendingEffects = make(map[int]*PendingChainEffect)
+	m.chainTrack = nil
+	m.chainElapsed = 0
 }
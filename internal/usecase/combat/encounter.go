@@ -0,0 +1,180 @@
+// Package combat はバトルエンジンを提供します。
+// encounter.go は複数敵・ウェーブ戦の状態管理（攻撃対象の選択、ウェーブの進行）を担当します。
+// BattleState.Enemy は常に選択中の攻撃対象を指すため、1対1バトル向けの処理はそのまま複数敵にも適用されます。
+
+package combat
+
+import (
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// ==================== 複数敵の参照 ====================
+
+// CurrentEnemies は現在のウェーブの敵を返します（撃破済みを含む）。
+// ウェーブが設定されていない1対1バトルではEnemyのみを返します。
+func (s *BattleState) CurrentEnemies() []*domain.EnemyModel {
+	if len(s.Waves) == 0 {
+		if s.Enemy == nil {
+			return nil
+		}
+		return []*domain.EnemyModel{s.Enemy}
+	}
+	return s.Waves[s.Wave]
+}
+
+// Leader は最後のウェーブの先頭の敵（報酬計算・撃破記録に使うリーダー）を返します。
+// ウェーブが設定されていない1対1バトルではEnemyを返します。
+func (s *BattleState) Leader() *domain.EnemyModel {
+	if len(s.Waves) == 0 || len(s.Waves[len(s.Waves)-1]) == 0 {
+		return s.Enemy
+	}
+	return s.Waves[len(s.Waves)-1][0]
+}
+
+// AliveEnemies は現在のウェーブで生存している敵を返します。
+func (s *BattleState) AliveEnemies() []*domain.EnemyModel {
+	var alive []*domain.EnemyModel
+	for _, enemy := range s.CurrentEnemies() {
+		if enemy.IsAlive() {
+			alive = append(alive, enemy)
+		}
+	}
+	return alive
+}
+
+// SpawnedEnemies はこれまでに出現した全ての敵を返します（現在のウェーブまで）。
+func (s *BattleState) SpawnedEnemies() []*domain.EnemyModel {
+	if len(s.Waves) == 0 {
+		return s.CurrentEnemies()
+	}
+	var spawned []*domain.EnemyModel
+	for _, wave := range s.Waves[:s.Wave+1] {
+		spawned = append(spawned, wave...)
+	}
+	return spawned
+}
+
+// WaveCount はウェーブの総数を返します。
+func (s *BattleState) WaveCount() int {
+	if len(s.Waves) == 0 {
+		return 1
+	}
+	return len(s.Waves)
+}
+
+// IsMultiEnemy は複数敵またはウェーブ戦のバトルかを返します。
+func (s *BattleState) IsMultiEnemy() bool {
+	return len(s.Waves) > 1 || len(s.CurrentEnemies()) > 1
+}
+
+// withEnemy は対象の敵をenemyに一時的に切り替えてfnを実行します。
+// 敵ごとのチャージ・行動・効果の処理に、1対1バトル向けの処理をそのまま使うためのものです。
+func (s *BattleState) withEnemy(enemy *domain.EnemyModel, fn func()) {
+	target := s.Enemy
+	s.Enemy = enemy
+	defer func() { s.Enemy = target }()
+	fn()
+}
+
+// ==================== 攻撃対象の選択 ====================
+
+// TargetIndex は選択中の攻撃対象の現在のウェーブ内でのインデックスを返します。
+func (s *BattleState) TargetIndex() int {
+	for i, enemy := range s.CurrentEnemies() {
+		if enemy == s.Enemy {
+			return i
+		}
+	}
+	return 0
+}
+
+// SetTarget は現在のウェーブのindex番目の敵を攻撃対象にします。
+// 範囲外または撃破済みの敵は選択できず、falseを返します。
+func (s *BattleState) SetTarget(index int) bool {
+	enemies := s.CurrentEnemies()
+	if index < 0 || index >= len(enemies) || !enemies[index].IsAlive() {
+		return false
+	}
+	s.Enemy = enemies[index]
+	return true
+}
+
+// CycleTarget は生存している敵の中で攻撃対象をdelta方向に切り替えます（ループ）。
+// 切り替え先がない場合はfalseを返します。
+func (s *BattleState) CycleTarget(delta int) bool {
+	enemies := s.CurrentEnemies()
+	n := len(enemies)
+	if n <= 1 || delta == 0 {
+		return false
+	}
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	current := s.TargetIndex()
+	for i := 1; i < n; i++ {
+		next := ((current+step*i)%n + n) % n
+		if enemies[next].IsAlive() {
+			s.Enemy = enemies[next]
+			return true
+		}
+	}
+	return false
+}
+
+// retarget は攻撃対象が撃破されていれば、生存している先頭の敵に切り替えます。
+func (s *BattleState) retarget() {
+	if s.Enemy != nil && s.Enemy.IsAlive() {
+		return
+	}
+	if alive := s.AliveEnemies(); len(alive) > 0 {
+		s.Enemy = alive[0]
+	}
+}
+
+// ==================== ウェーブ ====================
+
+// IsWaveCleared は現在のウェーブの敵を全て倒したかを返します。
+func (s *BattleState) IsWaveCleared() bool {
+	return len(s.AliveEnemies()) == 0
+}
+
+// HasNextWave は次のウェーブが残っているかを返します。
+func (s *BattleState) HasNextWave() bool {
+	return s.Wave+1 < len(s.Waves)
+}
+
+// AllEnemiesDefeated は全てのウェーブの敵を倒したかを返します。
+func (s *BattleState) AllEnemiesDefeated() bool {
+	return s.IsWaveCleared() && !s.HasNextWave()
+}
+
+// StartWave は現在のウェーブの敵それぞれについて、最初の行動の準備とチャージ開始、
+// 通常パッシブの登録を行い、先頭の敵を攻撃対象にします。
+func (e *BattleEngine) StartWave(state *BattleState, now time.Time) {
+	enemies := state.CurrentEnemies()
+	if len(enemies) == 0 {
+		return
+	}
+	for _, enemy := range enemies {
+		state.withEnemy(enemy, func() {
+			e.PrepareEnemyAction(state)
+			e.StartEnemyCharging(state, now)
+			e.RegisterEnemyPassive(state)
+		})
+	}
+	state.Enemy = enemies[0]
+}
+
+// AdvanceWave は次のウェーブに進み、敵の行動を開始します。
+// 次のウェーブがない場合はfalseを返します。
+func (e *BattleEngine) AdvanceWave(state *BattleState, now time.Time) bool {
+	if !state.HasNextWave() {
+		return false
+	}
+	state.Wave++
+	e.StartWave(state, now)
+	return true
+}
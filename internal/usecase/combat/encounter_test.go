@@ -0,0 +1,263 @@
+// Package combat はバトルエンジンを提供します。
+// encounter_test.go は複数敵・ウェーブ戦のテストです。
+
+package combat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// newTestEncounterSession はウェーブごとの敵の数を指定してテスト用の複数敵バトルセッションを作成します。
+func newTestEncounterSession(enemyHP int, chargeTime time.Duration, waveSizes ...int) (*BattleSession, *clock.ManualClock) {
+	enemyType := domain.EnemyType{
+		ID:              "slime",
+		Name:            "スライム",
+		BaseHP:          enemyHP,
+		BaseAttackPower: 1,
+		AttackType:      "physical",
+		ResolvedNormalActions: []domain.EnemyAction{
+			{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: chargeTime},
+		},
+	}
+
+	waves := make([][]*domain.EnemyModel, len(waveSizes))
+	for w, size := range waveSizes {
+		for i := 0; i < size; i++ {
+			id := fmt.Sprintf("enemy_%d_%d", w, i)
+			waves[w] = append(waves[w], domain.NewEnemy(id, "スライム", w+1, enemyHP, 1, enemyType))
+		}
+	}
+
+	player := domain.NewPlayer()
+	player.MaxHP = 100
+	player.HP = 100
+
+	session := NewEncounterSession(waves, player, newTestSessionAgents(), nil)
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	session.SetClock(c)
+	session.Start()
+	return session, c
+}
+
+// TestEncounterSession_TargetSelection は攻撃対象の選択と撃破済みの敵のスキップをテストします。
+func TestEncounterSession_TargetSelection(t *testing.T) {
+	session, _ := newTestEncounterSession(100, 30*time.Second, 3)
+	enemies := session.Enemies()
+
+	if !session.IsMultiEnemy() || session.TargetIndex() != 0 || session.Enemy() != enemies[0] {
+		t.Fatalf("初期の攻撃対象は先頭の敵: 実際 %d", session.TargetIndex())
+	}
+
+	if !session.CycleTarget(1) || session.Enemy() != enemies[1] {
+		t.Errorf("次の敵に切り替わっていない: 実際 %d", session.TargetIndex())
+	}
+
+	// 撃破済みの敵は選択できず、切り替え時にも飛ばされる
+	enemies[2].HP = 0
+	if session.SelectTarget(2) {
+		t.Error("撃破済みの敵を選択できてしまった")
+	}
+	if !session.CycleTarget(1) || session.TargetIndex() != 0 {
+		t.Errorf("撃破済みの敵を飛ばして先頭に戻るべき: 実際 %d", session.TargetIndex())
+	}
+	if !session.CycleTarget(-1) || session.TargetIndex() != 1 {
+		t.Errorf("逆方向の切り替えで撃破済みの敵を飛ばすべき: 実際 %d", session.TargetIndex())
+	}
+}
+
+// TestEncounterSession_RetargetOnDefeat は攻撃対象を倒すと生存している敵に切り替わることをテストします。
+func TestEncounterSession_RetargetOnDefeat(t *testing.T) {
+	session, c := newTestEncounterSession(100, 30*time.Second, 2)
+	enemies := session.Enemies()
+
+	enemies[0].HP = 0
+	advance(session, c, 100*time.Millisecond)
+
+	if session.IsOver() {
+		t.Fatal("生存している敵がいるのにバトルが終了した")
+	}
+	if session.Enemy() != enemies[1] {
+		t.Errorf("攻撃対象が生存している敵に切り替わっていない: 実際 %d", session.TargetIndex())
+	}
+}
+
+// TestEncounterSession_WaveProgression はウェーブの敵を全て倒すと次のウェーブが始まり、最終ウェーブで勝利することをテストします。
+func TestEncounterSession_WaveProgression(t *testing.T) {
+	session, c := newTestEncounterSession(100, 30*time.Second, 2, 1)
+
+	for _, enemy := range session.Enemies() {
+		enemy.HP = 0
+	}
+	advance(session, c, 100*time.Millisecond)
+
+	if session.IsOver() {
+		t.Fatal("次のウェーブがあるのにバトルが終了した")
+	}
+	if session.Wave() != 1 || session.WaveCount() != 2 {
+		t.Fatalf("ウェーブ: 期待 2/2, 実際 %d/%d", session.Wave()+1, session.WaveCount())
+	}
+	if !strings.Contains(session.Message(), "ウェーブ 2/2") {
+		t.Errorf("ウェーブ開始メッセージ: 実際 %q", session.Message())
+	}
+	next := session.Enemies()[0]
+	if session.Enemy() != next || next.WaitMode != domain.WaitModeCharging {
+		t.Error("次のウェーブの敵が攻撃対象になり、チャージを開始するべき")
+	}
+
+	next.HP = 0
+	advance(session, c, 100*time.Millisecond)
+	if !session.IsVictory() {
+		t.Error("最終ウェーブの敵を全て倒したら勝利するべき")
+	}
+
+	levels := session.EncounteredEnemyLevels()
+	if len(levels) != 1 || levels["slime"] != 2 {
+		t.Errorf("出現した敵の最高レベル: 実際 %v", levels)
+	}
+}
+
+// TestEncounterSession_Leader はリーダーが現在のウェーブによらず最後のウェーブの先頭の敵であることをテストします。
+func TestEncounterSession_Leader(t *testing.T) {
+	session, _ := newTestEncounterSession(100, 30*time.Second, 2, 1)
+
+	leader := session.Leader()
+	if leader == nil || leader.ID != "enemy_1_0" || leader.Level != 2 {
+		t.Fatalf("リーダー: 期待 enemy_1_0 Lv.2, 実際 %+v", leader)
+	}
+	if session.Wave() != 0 {
+		t.Errorf("リーダーの参照でウェーブが進んではならない: %d", session.Wave())
+	}
+
+	single, _ := newTestSession(100, 1, 30*time.Second)
+	if single.Leader() != single.Enemy() {
+		t.Error("1対1バトルのリーダーは敵自身であるべき")
+	}
+}
+
+// TestEncounterSession_AllEnemiesAct は現在のウェーブの全ての敵がそれぞれチャージして攻撃することをテストします。
+func TestEncounterSession_AllEnemiesAct(t *testing.T) {
+	session, c := newTestEncounterSession(100, time.Second, 2)
+
+	advance(session, c, 1100*time.Millisecond)
+
+	for i, enemy := range session.Enemies() {
+		if !enemy.ChargeStartTime.Equal(c.Now()) {
+			t.Errorf("敵%dが攻撃して次のチャージを開始していない", i)
+		}
+	}
+	if session.State().Stats.TotalDamageTaken == 0 {
+		t.Error("敵の攻撃でダメージを受けていない")
+	}
+}
+
+// TestApplyModuleEffect_TargetAll は全体攻撃・全体デバフが生存している敵全てに適用されることをテストします。
+func TestApplyModuleEffect_TargetAll(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+	disableCritical(state)
+	second := domain.NewEnemy("enemy_2", "テスト敵", 1, 1000, 10, state.Enemy.Type)
+	defeated := domain.NewEnemy("enemy_3", "テスト敵", 1, 1000, 10, state.Enemy.Type)
+	defeated.HP = 0
+	state.Waves = [][]*domain.EnemyModel{{state.Enemy, second, defeated}}
+	target := state.Enemy
+	firstHP, secondHP := target.HP, second.HP
+
+	module := domain.NewModuleFromType(domain.ModuleType{
+		ID:   "all_attack",
+		Name: "全体攻撃",
+		Tags: []string{"magic_low"},
+		Effects: []domain.ModuleEffect{
+			{Target: domain.TargetAll, HPFormula: &domain.HPFormula{Base: 30}, Probability: 1.0},
+			{Target: domain.TargetAll, ColumnSpec: &domain.EffectColumnSpec{Column: domain.ColDamageCut, Value: -0.1, Duration: 5.0}, Probability: 1.0},
+		},
+	}, nil)
+	typingResult := &typing.TypingResult{
+		Completed:      true,
+		WPM:            60.0,
+		Accuracy:       1.0,
+		SpeedFactor:    1.0,
+		AccuracyFactor: 1.0,
+	}
+	total := engine.ApplyModuleEffect(state, agent, module, typingResult)
+
+	if total != 60 || firstHP-state.Enemy.HP != 30 || secondHP-second.HP != 30 {
+		t.Errorf("全体攻撃のダメージ: total=%d, 敵1=%d, 敵2=%d, want 60/30/30", total, firstHP-state.Enemy.HP, secondHP-second.HP)
+	}
+	if defeated.HP != 0 {
+		t.Error("撃破済みの敵にダメージが入った")
+	}
+	if state.Enemy != target {
+		t.Error("全体攻撃の後に攻撃対象が変わった")
+	}
+
+	for i, enemy := range []*domain.EnemyModel{state.Enemy, second} {
+		if len(enemy.EffectTable.GetActiveDebuffs()) != 1 {
+			t.Errorf("敵%dに全体デバフが付与されていない", i+1)
+		}
+	}
+	if len(defeated.EffectTable.GetActiveDebuffs()) != 0 {
+		t.Error("撃破済みの敵にデバフが付与された")
+	}
+}
+
+// TestReplay_ReproducesEncounter は攻撃対象の切り替えとウェーブを含む複数敵バトルのリプレイをテストします。
+func TestReplay_ReproducesEncounter(t *testing.T) {
+	original, c := newTestEncounterSession(40, 2*time.Second, 2, 1)
+	tick := 100 * time.Millisecond
+
+	for i := 0; i < 3000 && !original.IsOver(); i++ {
+		switch {
+		case i%30 == 0:
+			original.CycleTarget(1)
+		case !original.IsTyping():
+			for slot := range original.Slots() {
+				if original.SelectModule(slot) {
+					break
+				}
+			}
+		default:
+			text := []rune(original.TypingText())
+			if index := original.TypingIndex(); index < len(text) {
+				original.ProcessTypingInput(text[index])
+			}
+		}
+		advance(original, c, tick)
+	}
+	if !original.IsOver() {
+		t.Fatal("記録用のバトルが決着しませんでした")
+	}
+
+	replay := original.Replay()
+	if len(replay.Waves) != 2 || len(replay.Waves[0]) != 2 {
+		t.Fatalf("ウェーブのスナップショット: 実際 %d", len(replay.Waves))
+	}
+	hasTarget := false
+	for _, event := range replay.Events {
+		if event.Kind == ReplayEventTarget {
+			hasTarget = true
+		}
+	}
+	if !hasTarget {
+		t.Error("攻撃対象の選択が記録されていません")
+	}
+
+	player := NewReplayPlayer(replay, replay.NewSession(nil))
+	player.Start()
+	for i := 0; i < 10000 && !player.Done(); i++ {
+		player.Advance(tick)
+	}
+
+	session := player.Session()
+	if session.IsVictory() != original.IsVictory() || session.Wave() != original.Wave() {
+		t.Errorf("再生の勝敗・ウェーブ: 期待 %v/%d, 実際 %v/%d", original.IsVictory(), original.Wave(), session.IsVictory(), session.Wave())
+	}
+	if *session.State().Stats != *original.State().Stats {
+		t.Errorf("再生の統計: 期待 %+v, 実際 %+v", *original.State().Stats, *session.State().Stats)
+	}
+}
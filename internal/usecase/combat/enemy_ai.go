@@ -0,0 +1,115 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_ai.go は敵の条件付き・重み付き行動選択を担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// ==================== 敵の行動選択 ====================
+
+// PrepareEnemyAction は対象の敵（state.Enemy）の次回行動を決定し、PendingActionに設定します。
+// 決定した行動はチャージ中に予告として表示されます。
+func (e *BattleEngine) PrepareEnemyAction(state *BattleState) {
+	next := e.DeterminePatternBasedAction(state)
+	state.Enemy.PrepareAction(*next.SourceAction)
+}
+
+// selectEnemyAction は対象の敵の次回行動を選びます。
+// 現在のフェーズで有効な行動ルールがない場合は行動パターンを順番に使います。
+// ルールがある場合は、条件を満たした条件付きルールを優先し、なければ条件なしルールから
+// 重み付きランダムで選びます。クールダウン中の行動は候補から除外されます。
+func (e *BattleEngine) selectEnemyAction(state *BattleState) domain.EnemyAction {
+	enemy := state.Enemy
+	rules := enemy.GetActionRules()
+	if len(rules) == 0 {
+		return enemy.GetCurrentAction()
+	}
+
+	ctx := e.enemyConditionContext(state)
+	var conditional, unconditional []domain.EnemyActionRule
+	for _, rule := range rules {
+		if enemy.IsActionOnCooldown(rule.ActionID) {
+			continue
+		}
+		if rule.Condition == nil {
+			unconditional = append(unconditional, rule)
+		} else if rule.Condition.IsSatisfied(ctx) {
+			conditional = append(conditional, rule)
+		}
+	}
+
+	candidates := conditional
+	if len(candidates) == 0 {
+		candidates = unconditional
+	}
+
+	// 候補を選んでから全体のクールダウンを進め、選んだ行動のクールダウンを設定する
+	enemy.TickActionCooldowns()
+	if len(candidates) == 0 {
+		// 全てクールダウン中の場合は行動パターンにフォールバック
+		return enemy.GetCurrentAction()
+	}
+	rule := e.pickWeightedRule(candidates)
+	enemy.SetActionCooldown(rule.ActionID, rule.CooldownTurns)
+	return rule.Action
+}
+
+// pickWeightedRule は重みに従って候補からルールを1つ選びます。
+func (e *BattleEngine) pickWeightedRule(candidates []domain.EnemyActionRule) domain.EnemyActionRule {
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	total := 0
+	for _, rule := range candidates {
+		total += rule.GetWeight()
+	}
+	roll := e.rng.Intn(total)
+	for _, rule := range candidates {
+		roll -= rule.GetWeight()
+		if roll < 0 {
+			return rule
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// enemyConditionContext は行動ルールの条件判定に使う状況を作成します。
+func (e *BattleEngine) enemyConditionContext(state *BattleState) domain.EnemyConditionContext {
+	playerHPRatio := 0.0
+	if state.Player.MaxHP > 0 {
+		playerHPRatio = float64(state.Player.HP) / float64(state.Player.MaxHP)
+	}
+	return domain.EnemyConditionContext{
+		SelfHPRatio:     state.Enemy.GetHPPercentage(),
+		PlayerHPRatio:   playerHPRatio,
+		PlayerBuffCount: len(state.Player.EffectTable.GetActiveBuffs()),
+		SelfDebuffCount: len(state.Enemy.EffectTable.GetActiveDebuffs()),
+		Voltage:         state.Enemy.GetVoltage(),
+	}
+}
+
+// ==================== 回復・バフ解除行動 ====================
+
+// ApplyPatternHeal はパターンベースの自己回復を適用し、実際の回復量を返します。
+// 回復量は最大HPにEffectValueを掛けた値です。
+func (e *BattleEngine) ApplyPatternHeal(state *BattleState, action domain.EnemyAction) int {
+	healed := state.Enemy.Heal(action.CalculateHeal(state.Enemy.MaxHP))
+	e.emit(state, BattleEvent{Kind: BattleEventHeal, Source: action.Name, Target: state.Enemy.Name, Amount: healed})
+	return healed
+}
+
+// ApplyPatternDispel はプレイヤーのバフを全て解除し、解除した数を返します。
+func (e *BattleEngine) ApplyPatternDispel(state *BattleState) int {
+	removed := state.Player.EffectTable.RemoveBySourceType(domain.SourceBuff)
+	if removed > 0 {
+		e.emit(state, BattleEvent{
+			Kind: BattleEventBuffExpired, Source: state.Enemy.Name, Target: BattleEventPlayer,
+			Detail: fmt.Sprintf("バフ%d個", removed),
+		})
+	}
+	return removed
+}
@@ -0,0 +1,162 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_ai_test.go は敵の条件付き・重み付き行動選択のテストです。
+package combat
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// テスト用の敵行動
+var (
+	aiTestSmash = domain.EnemyAction{
+		ID: "smash", Name: "叩きつけ", ActionType: domain.EnemyActionAttack,
+		AttackType: "physical", DamageBase: 5, ChargeTime: time.Second,
+	}
+	aiTestBite = domain.EnemyAction{
+		ID: "bite", Name: "噛みつき", ActionType: domain.EnemyActionAttack,
+		AttackType: "physical", DamageBase: 3, ChargeTime: time.Second,
+	}
+	aiTestRepair = domain.EnemyAction{
+		ID: "repair", Name: "修復", ActionType: domain.EnemyActionHeal,
+		EffectValue: 0.2, ChargeTime: time.Second,
+	}
+	aiTestPurge = domain.EnemyAction{
+		ID: "purge", Name: "浄化", ActionType: domain.EnemyActionDispel, ChargeTime: time.Second,
+	}
+)
+
+// newEnemyAITestBattle は行動ルールと行動パターンを持つ敵とのテスト用バトルを作成します。
+func newEnemyAITestBattle(rules []domain.EnemyActionRule, pattern []domain.EnemyAction) (*BattleEngine, *BattleState) {
+	enemyType := domain.EnemyType{
+		ID:                    "golem",
+		Name:                  "ゴーレム",
+		BaseHP:                100,
+		BaseAttackPower:       10,
+		AttackType:            "physical",
+		ResolvedNormalActions: pattern,
+		ActionRules:           rules,
+	}
+	player := domain.NewPlayer()
+	player.MaxHP = 100
+	player.HP = 100
+
+	engine := NewBattleEngine([]domain.EnemyType{enemyType})
+	engine.SetRng(rand.New(rand.NewSource(1)))
+	state := &BattleState{
+		Enemy:  domain.NewEnemy("enemy_1", "ゴーレム", 1, 100, 10, enemyType),
+		Player: player,
+		Level:  1,
+		Stats:  &BattleStatistics{},
+	}
+	return engine, state
+}
+
+// TestDeterminePatternBasedAction_ConditionalRule は条件を満たしたルールが優先され、
+// クールダウン中は選ばれないことをテストします。
+func TestDeterminePatternBasedAction_ConditionalRule(t *testing.T) {
+	engine, state := newEnemyAITestBattle([]domain.EnemyActionRule{
+		{ActionID: "repair", Action: aiTestRepair, Condition: &domain.EnemyActionCondition{Type: domain.ConditionSelfHPBelow, Value: 0.3}, CooldownTurns: 2},
+		{ActionID: "smash", Action: aiTestSmash},
+	}, nil)
+
+	if next := engine.DeterminePatternBasedAction(state); next.SourceAction.ID != "smash" {
+		t.Errorf("条件を満たさない間は条件なしルール: 実際 %s", next.SourceAction.ID)
+	}
+
+	state.Enemy.HP = 20
+	next := engine.DeterminePatternBasedAction(state)
+	if next.SourceAction.ID != "repair" || next.ActionType != EnemyActionSelfHeal || next.ExpectedValue != 20 {
+		t.Fatalf("HP30%%未満で回復を選ぶべき: 実際 %s (予測 %d)", next.SourceAction.ID, next.ExpectedValue)
+	}
+
+	// クールダウン2: 2回の行動を挟んでから再び選ばれる
+	expected := []string{"smash", "smash", "repair"}
+	for i, id := range expected {
+		if next := engine.DeterminePatternBasedAction(state); next.SourceAction.ID != id {
+			t.Errorf("%d回目: 期待 %s, 実際 %s", i+1, id, next.SourceAction.ID)
+		}
+	}
+}
+
+// TestDeterminePatternBasedAction_Weighted は条件なしルールが重みに従って選ばれることをテストします。
+func TestDeterminePatternBasedAction_Weighted(t *testing.T) {
+	engine, state := newEnemyAITestBattle([]domain.EnemyActionRule{
+		{ActionID: "smash", Action: aiTestSmash, Weight: 3},
+		{ActionID: "bite", Action: aiTestBite},
+	}, nil)
+
+	counts := map[string]int{}
+	for i := 0; i < 400; i++ {
+		counts[engine.DeterminePatternBasedAction(state).SourceAction.ID]++
+	}
+
+	if counts["smash"] < 250 || counts["smash"] > 350 || counts["bite"] == 0 {
+		t.Errorf("重み3:1の選択比率: 実際 %v", counts)
+	}
+}
+
+// TestDeterminePatternBasedAction_PhaseAndFallback はフェーズ限定ルールと、
+// 有効なルールがない場合の行動パターンへのフォールバックをテストします。
+func TestDeterminePatternBasedAction_PhaseAndFallback(t *testing.T) {
+	finisher := aiTestSmash
+	finisher.ID = "finisher"
+	engine, state := newEnemyAITestBattle([]domain.EnemyActionRule{
+		{
+			ActionID:  "finisher",
+			Action:    finisher,
+			Phases:    []domain.EnemyPhase{domain.PhaseEnhanced},
+			Condition: &domain.EnemyActionCondition{Type: domain.ConditionVoltageAbove, Value: 200},
+		},
+	}, []domain.EnemyAction{aiTestBite})
+
+	state.Enemy.SetVoltage(250)
+	if next := engine.DeterminePatternBasedAction(state); next.SourceAction.ID != "bite" {
+		t.Errorf("通常フェーズでは行動パターンを使うべき: 実際 %s", next.SourceAction.ID)
+	}
+
+	state.Enemy.TransitionToEnhanced()
+	if next := engine.DeterminePatternBasedAction(state); next.SourceAction.ID != "finisher" {
+		t.Errorf("強化フェーズでボルテージ200%%超ならフィニッシャー: 実際 %s", next.SourceAction.ID)
+	}
+
+	state.Enemy.SetVoltage(150)
+	if next := engine.DeterminePatternBasedAction(state); next.SourceAction.ID != "bite" {
+		t.Errorf("条件を満たすルールがなければ行動パターンにフォールバック: 実際 %s", next.SourceAction.ID)
+	}
+}
+
+// TestProcessEnemyTurn_HealAndDispel は回復・バフ解除行動の実行と、次回行動の予告をテストします。
+func TestProcessEnemyTurn_HealAndDispel(t *testing.T) {
+	engine, state := newEnemyAITestBattle([]domain.EnemyActionRule{
+		{ActionID: "purge", Action: aiTestPurge, Condition: &domain.EnemyActionCondition{Type: domain.ConditionPlayerBuffsAtLeast, Value: 2}},
+		{ActionID: "smash", Action: aiTestSmash},
+	}, nil)
+	state.Enemy.HP = 50
+	state.Enemy.PrepareAction(aiTestRepair)
+
+	// プレイヤーにバフが2つ付いた状態で回復行動を実行
+	state.Player.EffectTable.AddBuff("攻撃UP", 10, map[domain.EffectColumn]float64{domain.ColDamageBonus: 5})
+	state.Player.EffectTable.AddBuff("防御UP", 10, map[domain.EffectColumn]float64{domain.ColDamageCut: 0.1})
+	result := engine.ProcessEnemyTurn(state)
+
+	if result.Healed != 20 || state.Enemy.HP != 70 {
+		t.Errorf("最大HPの20%%回復: 回復量 %d, HP %d", result.Healed, state.Enemy.HP)
+	}
+
+	// 次回行動として予告されるのはバフ解除
+	if action := state.Enemy.GetNextAction(); action == nil || !action.IsDispel() {
+		t.Fatalf("バフ2つ以上でバフ解除が予告されるべき: 実際 %+v", action)
+	}
+
+	engine.ProcessEnemyTurn(state)
+	if len(state.Player.EffectTable.GetActiveBuffs()) != 0 {
+		t.Error("プレイヤーのバフが解除されていない")
+	}
+	if action := state.Enemy.GetNextAction(); action == nil || action.ID != "smash" {
+		t.Errorf("バフ解除後は条件なしルールに戻るべき: 実際 %+v", action)
+	}
+}
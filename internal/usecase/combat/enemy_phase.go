@@ -0,0 +1,60 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_phase.go は敵の多段階フェーズへの移行処理を担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// EnterEnemyPhase はフェーズ移行直後の処理を行い、表示用のメッセージを返します。
+// パッシブを新しいフェーズのものに切り替え、移行時行動が設定されていれば即座に実行します。
+// CheckPhaseTransitionがtrueを返した後に呼び出されることを想定しています。
+func (e *BattleEngine) EnterEnemyPhase(state *BattleState) string {
+	e.SwitchEnemyPassive(state)
+	e.emit(state, BattleEvent{Kind: BattleEventPhaseChange, Source: state.Enemy.Name, Detail: state.Enemy.GetPhaseString()})
+
+	message := fmt.Sprintf(" [敵が%sフェーズに突入！]", state.Enemy.GetPhaseString())
+	if actionMessage := e.ApplyPhaseTransitionAction(state); actionMessage != "" {
+		message += fmt.Sprintf(" [%s]", actionMessage)
+	}
+	return message
+}
+
+// ApplyPhaseTransitionAction は現在のフェーズの移行時行動をチャージなしで実行し、結果のメッセージを返します。
+// 移行時行動が設定されていない場合は空文字を返します。ディフェンス行動は移行時行動として扱いません。
+func (e *BattleEngine) ApplyPhaseTransitionAction(state *BattleState) string {
+	action := state.Enemy.GetPhaseDef().TransitionAction
+	if action == nil {
+		return ""
+	}
+	return e.executeInstantAction(state, *action)
+}
+
+// executeInstantAction は敵の行動をチャージなしで即座に実行し、結果のメッセージを返します。
+// フェーズ移行時行動・激昂時行動に使用します。ディフェンス行動は実行しません。
+func (e *BattleEngine) executeInstantAction(state *BattleState, action domain.EnemyAction) string {
+	switch action.ActionType {
+	case domain.EnemyActionAttack:
+		damage := e.ExecutePatternAttack(state, action)
+		return fmt.Sprintf("%s！%dダメージを受けた！", action.Name, damage)
+
+	case domain.EnemyActionBuff:
+		e.ApplyPatternBuff(state, action)
+		return fmt.Sprintf("%sを発動！", action.Name)
+
+	case domain.EnemyActionDebuff:
+		e.ApplyPatternDebuff(state, action)
+		return fmt.Sprintf("%sを発動！", action.Name)
+
+	case domain.EnemyActionHeal:
+		healed := e.ApplyPatternHeal(state, action)
+		return fmt.Sprintf("%sでHPを%d回復した！", action.Name, healed)
+
+	case domain.EnemyActionDispel:
+		removed := e.ApplyPatternDispel(state)
+		return fmt.Sprintf("%sでバフを%d個解除された！", action.Name, removed)
+	}
+	return ""
+}
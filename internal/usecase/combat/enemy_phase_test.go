@@ -0,0 +1,84 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_phase_test.go は敵の多段階フェーズへの移行処理のテストです。
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// newEnemyPhaseTestBattle は3段階のフェーズを持つ敵とのテスト用バトルを作成します。
+func newEnemyPhaseTestBattle() (*BattleEngine, *BattleState) {
+	barrier := domain.EnemyAction{
+		ID: "barrier", Name: "骨の障壁", ActionType: domain.EnemyActionBuff,
+		EffectType: "defense_up", EffectValue: 0.3, Duration: 10,
+	}
+	rebirth := domain.EnemyAction{ID: "rebirth", Name: "不死の再誕", ActionType: domain.EnemyActionHeal, EffectValue: 0.2}
+
+	engine, state := newEnemyAITestBattle(nil, nil)
+	state.Enemy.Type.Phases = []domain.EnemyPhaseDef{
+		{
+			ID: "chant", Name: "詠唱", ResolvedActions: []domain.EnemyAction{aiTestBite},
+			Passive: &domain.EnemyPassiveSkill{ID: "p_chant", Effects: map[domain.EffectColumn]float64{domain.ColDamageCut: 0.2}},
+		},
+		{
+			ID: "awakened", Name: "覚醒", HPThreshold: 0.6, ResolvedActions: []domain.EnemyAction{aiTestSmash},
+			Passive:          &domain.EnemyPassiveSkill{ID: "p_awakened", Effects: map[domain.EffectColumn]float64{domain.ColDamageMultiplier: 1.3}},
+			TransitionAction: &barrier,
+		},
+		{ID: "collapse", Name: "崩壊", HPThreshold: 0.25, TransitionAction: &rebirth},
+	}
+	return engine, state
+}
+
+// TestEnterEnemyPhase_TransitionAction はフェーズ移行時にパッシブが切り替わり、移行時行動が実行されることをテストします。
+func TestEnterEnemyPhase_TransitionAction(t *testing.T) {
+	engine, state := newEnemyPhaseTestBattle()
+	engine.RegisterEnemyPassive(state)
+	if state.Enemy.ActivePassiveID != "p_chant" {
+		t.Fatalf("最初のフェーズのパッシブが登録されるべき: 実際 %q", state.Enemy.ActivePassiveID)
+	}
+
+	state.Enemy.HP = 50
+	if !engine.CheckPhaseTransition(state) {
+		t.Fatal("HP50%で2番目のフェーズに移行すべき")
+	}
+	message := engine.EnterEnemyPhase(state)
+	if state.Enemy.ActivePassiveID != "p_awakened" {
+		t.Errorf("パッシブが切り替わるべき: 実際 %q", state.Enemy.ActivePassiveID)
+	}
+	if len(state.Enemy.EffectTable.FindBySourceType(domain.SourceBuff)) != 1 {
+		t.Error("移行時行動のバフが付与されるべき")
+	}
+	if !strings.Contains(message, "覚醒フェーズ") || !strings.Contains(message, "骨の障壁") {
+		t.Errorf("フェーズ移行メッセージ: %q", message)
+	}
+
+	// 最終フェーズは移行時に回復し、パッシブがないため解除される
+	state.Enemy.HP = 20
+	engine.CheckPhaseTransition(state)
+	engine.EnterEnemyPhase(state)
+	if state.Enemy.HP != 40 {
+		t.Errorf("移行時行動で最大HPの20%%回復すべき: HP %d", state.Enemy.HP)
+	}
+	if state.Enemy.ActivePassiveID != "" || len(state.Enemy.EffectTable.FindBySourceType(domain.SourcePassive)) != 0 {
+		t.Error("パッシブのないフェーズでは前のパッシブが解除されるべき")
+	}
+}
+
+// TestProcessEnemyTurn_PhaseChange は敵ターン中のフェーズ移行で次回行動が新しいフェーズの行動パターンになることをテストします。
+func TestProcessEnemyTurn_PhaseChange(t *testing.T) {
+	engine, state := newEnemyPhaseTestBattle()
+	state.Enemy.PrepareAction(aiTestBite)
+	state.Enemy.HP = 55
+
+	result := engine.ProcessEnemyTurn(state)
+	if !result.PhaseChanged || !strings.Contains(result.PhaseMessage, "覚醒フェーズ") {
+		t.Fatalf("フェーズ移行が結果に反映されるべき: %+v", result)
+	}
+	if action := state.Enemy.GetNextAction(); action == nil || action.ID != "smash" {
+		t.Errorf("新しいフェーズの行動が予告されるべき: 実際 %+v", action)
+	}
+}
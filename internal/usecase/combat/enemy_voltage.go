@@ -0,0 +1,75 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_voltage.go はプレイヤーによる敵のボルテージの低下と、ボルテージ段階への到達（激昂）を担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// LowerEnemyVoltage は対象の敵（state.Enemy）のボルテージを下げ、実際に下がった量を返します。
+// 下がったボルテージが段階の閾値を下回った場合は段階も下がり、激昂による行動の変化が解除されます。
+func (e *BattleEngine) LowerEnemyVoltage(state *BattleState, source string, amount float64) float64 {
+	enemy := state.Enemy
+	lowered := e.voltageManager.Lower(enemy, amount)
+	if lowered <= 0 {
+		return 0
+	}
+	enemy.VoltageStage = enemy.Type.VoltageStageFor(enemy.GetVoltage())
+	e.emit(state, BattleEvent{
+		Kind: BattleEventVoltageDown, Source: source, Target: enemy.Name,
+		Amount: int(lowered), Detail: fmt.Sprintf("%.0f%%", enemy.GetVoltage()),
+	})
+	return lowered
+}
+
+// applyModuleVoltage はモジュール効果のうちボルテージの低下を適用します。
+// ボルテージに関する効果でない場合はfalseを返し、通常のバフ/デバフとして扱わせます。
+func (e *BattleEngine) applyModuleVoltage(state *BattleState, source string, effect *domain.ModuleEffect) bool {
+	if effect.ColumnSpec.Column != domain.ColVoltageDown {
+		return false
+	}
+	if effect.Target == domain.TargetAll {
+		for _, enemy := range state.AliveEnemies() {
+			state.withEnemy(enemy, func() {
+				e.LowerEnemyVoltage(state, source, effect.ColumnSpec.Value)
+			})
+		}
+		return true
+	}
+	// 敵のボルテージを下げる効果のため、全体以外は攻撃対象の敵に適用する
+	e.LowerEnemyVoltage(state, source, effect.ColumnSpec.Value)
+	return true
+}
+
+// CheckVoltageThreshold は対象の敵（state.Enemy）のボルテージ段階を更新します。
+// 新たな段階に到達した場合は激昂時行動をチャージなしで実行し、表示用のメッセージを返します。
+func (e *BattleEngine) CheckVoltageThreshold(state *BattleState) string {
+	enemy := state.Enemy
+	previous := enemy.VoltageStage
+	enemy.VoltageStage = enemy.Type.VoltageStageFor(enemy.GetVoltage())
+
+	message := ""
+	for i := previous; i < enemy.VoltageStage; i++ {
+		threshold := enemy.Type.VoltageThresholds[i]
+		e.emit(state, BattleEvent{Kind: BattleEventEnrage, Source: enemy.Name, Detail: fmt.Sprintf("%s（%.0f%%）", threshold.Name, threshold.Voltage)})
+		message += fmt.Sprintf(" [%sが%sした！]", enemy.Name, threshold.Name)
+		if threshold.EnrageAction == nil {
+			continue
+		}
+		if actionMessage := e.executeInstantAction(state, *threshold.EnrageAction); actionMessage != "" {
+			message += fmt.Sprintf(" [%s]", actionMessage)
+		}
+	}
+	return message
+}
+
+// recordPeakVoltage は生存している敵のボルテージの最大値をバトル統計に記録します。
+func (e *BattleEngine) recordPeakVoltage(state *BattleState) {
+	for _, enemy := range state.AliveEnemies() {
+		if voltage := enemy.GetVoltage(); voltage > state.Stats.PeakVoltage {
+			state.Stats.PeakVoltage = voltage
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package combat はバトルエンジンを提供します。
+// enemy_voltage_test.go はボルテージの低下・激昂・ピークボルテージの記録のテストです。
+package combat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// countEvents は指定した種別のイベント数を返します。
+func countEvents(events []BattleEvent, kind BattleEventKind) int {
+	count := 0
+	for _, event := range events {
+		if event.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// TestBattleSession_PerfectTypingLowersVoltage は正確性100%の入力で攻撃対象の敵のボルテージが下がることをテストします。
+func TestBattleSession_PerfectTypingLowersVoltage(t *testing.T) {
+	session, _ := newTestSession(1000, 10, 10*time.Second)
+	session.Enemy().SetVoltage(150)
+
+	session.SelectModule(0)
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+
+	if got := session.Enemy().GetVoltage(); got != 140 {
+		t.Errorf("ボルテージ: 期待 140, 実際 %v", got)
+	}
+	if !strings.Contains(session.Message(), "ボルテージ-10%") {
+		t.Errorf("メッセージにボルテージの低下が含まれるべき: %s", session.Message())
+	}
+	event, ok := findEvent(session.BattleLog().Events(), BattleEventVoltageDown)
+	if !ok || event.Source != "完璧な入力" || event.Amount != 10 {
+		t.Errorf("ボルテージ低下イベント: 実際 %+v", event)
+	}
+}
+
+// TestBattleSession_PerfectTypingAtInitialVoltage はボルテージが初期値の場合は下がらないことをテストします。
+func TestBattleSession_PerfectTypingAtInitialVoltage(t *testing.T) {
+	session, _ := newTestSession(1000, 10, 10*time.Second)
+
+	session.SelectModule(0)
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+
+	if got := session.Enemy().GetVoltage(); got != 100 {
+		t.Errorf("ボルテージは初期値を下回らないべき: 実際 %v", got)
+	}
+	if _, ok := findEvent(session.BattleLog().Events(), BattleEventVoltageDown); ok {
+		t.Error("ボルテージが下がらない場合はイベントを記録しないべき")
+	}
+}
+
+// TestApplyModuleEffect_VoltageDown はモジュールのボルテージ低下効果が初期値を下限として適用されることをテストします。
+func TestApplyModuleEffect_VoltageDown(t *testing.T) {
+	engine, state, agent := newElementTestBattle(t, nil, nil)
+	module := newAilmentModule(domain.TargetEnemy, domain.ColVoltageDown, 30.0)
+
+	state.Enemy.SetVoltage(200)
+	dealModuleDamage(engine, state, agent, module)
+	if got := state.Enemy.GetVoltage(); got != 170 {
+		t.Errorf("ボルテージ: 期待 170, 実際 %v", got)
+	}
+	if len(state.Enemy.EffectTable.FindBySourceType(domain.SourceDebuff)) != 0 {
+		t.Error("ボルテージ低下はデバフとして登録されるべきではありません")
+	}
+
+	state.Enemy.SetVoltage(110)
+	dealModuleDamage(engine, state, agent, module)
+	if got := state.Enemy.GetVoltage(); got != 100 {
+		t.Errorf("ボルテージは初期値を下回らないべき: 実際 %v", got)
+	}
+}
+
+// TestBattleSession_VoltageThresholdEnrage はボルテージが閾値に達すると激昂時行動が1度だけ実行され、
+// チャージ時間が短くなることをテストします。
+func TestBattleSession_VoltageThresholdEnrage(t *testing.T) {
+	session, c := newTestSession(1000, 10, 10*time.Second)
+	enemy := session.Enemy()
+	enemy.Type.VoltageThresholds = []domain.VoltageThreshold{
+		{Voltage: 150, Name: "激昂", ChargeTimeMultiplier: 0.5, EnrageAction: &domain.EnemyAction{
+			ID: "roar", Name: "咆哮", ActionType: domain.EnemyActionAttack, AttackType: "physical", DamageBase: 5,
+		}},
+	}
+
+	enemy.SetVoltage(150)
+	advance(session, c, 100*time.Millisecond)
+
+	if enemy.VoltageStage != 1 {
+		t.Errorf("ボルテージ段階: 期待 1, 実際 %d", enemy.VoltageStage)
+	}
+	if !strings.Contains(session.Message(), "スライムが激昂した！") {
+		t.Errorf("メッセージに激昂が含まれるべき: %s", session.Message())
+	}
+	if session.Player().HP >= 100 {
+		t.Errorf("激昂時行動の攻撃でプレイヤーがダメージを受けるべき: HP %d", session.Player().HP)
+	}
+
+	advance(session, c, 100*time.Millisecond)
+	if got := countEvents(session.BattleLog().Events(), BattleEventEnrage); got != 1 {
+		t.Errorf("激昂は閾値を超えたときに1度だけ発生するべき: 実際 %d回", got)
+	}
+
+	// 次の行動のチャージ時間が倍率で短くなる
+	advance(session, c, 10*time.Second)
+	if enemy.CurrentChargeTime != 5*time.Second {
+		t.Errorf("激昂中のチャージ時間: 期待 5s, 実際 %v", enemy.CurrentChargeTime)
+	}
+}
+
+// TestBattleSession_VoltageThresholdRetrigger はボルテージを下げて段階を解除すると再び激昂できることをテストします。
+func TestBattleSession_VoltageThresholdRetrigger(t *testing.T) {
+	session, c := newTestSession(1000, 10, 10*time.Second)
+	enemy := session.Enemy()
+	enemy.Type.VoltageThresholds = []domain.VoltageThreshold{{Voltage: 150, Name: "激昂"}}
+
+	enemy.SetVoltage(150)
+	advance(session, c, 100*time.Millisecond)
+	session.engine.LowerEnemyVoltage(session.state, "テスト", 20)
+	if enemy.VoltageStage != 0 {
+		t.Errorf("閾値を下回ると段階が解除されるべき: 実際 %d", enemy.VoltageStage)
+	}
+
+	enemy.SetVoltage(160)
+	advance(session, c, 100*time.Millisecond)
+	if got := countEvents(session.BattleLog().Events(), BattleEventEnrage); got != 2 {
+		t.Errorf("再び閾値を超えると激昂するべき: 実際 %d回", got)
+	}
+}
+
+// TestBattleSession_RecordsPeakVoltage はバトル中のボルテージの最大値が統計に記録されることをテストします。
+func TestBattleSession_RecordsPeakVoltage(t *testing.T) {
+	session, c := newTestSession(1000, 10, 10*time.Second)
+
+	session.Enemy().SetVoltage(180)
+	advance(session, c, 100*time.Millisecond)
+	session.engine.LowerEnemyVoltage(session.state, "テスト", 50)
+	advance(session, c, 100*time.Millisecond)
+
+	if got := session.state.Stats.PeakVoltage; got != 180 {
+		t.Errorf("ピークボルテージ: 期待 180, 実際 %v", got)
+	}
+}
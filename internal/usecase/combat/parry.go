@@ -0,0 +1,74 @@
+// Package combat はバトルエンジンを提供します。
+// parry.go は敵のチャージ中の行動に対するパリィの判定と効果を担当します。
+package combat
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// ResolveParry はパリィのタイピング結果から結果を判定し、敵に記録します。
+// ミスなしで完了すれば完全パリィ、ミスありで完了すればパリィ（軽減）、
+// 完了できなければパリィ失敗です。
+func (e *BattleEngine) ResolveParry(enemy *domain.EnemyModel, result *typing.TypingResult) domain.ParryResult {
+	parry := domain.ParryFailed
+	if result.Completed {
+		parry = domain.ParryPartial
+		if result.Accuracy >= 1.0 {
+			parry = domain.ParryPerfect
+		}
+	}
+	enemy.Parry = parry
+	return parry
+}
+
+// isParried はチャージ中の行動が完全パリィで無効化されたかを返します。
+func isParried(enemy *domain.EnemyModel) bool {
+	return enemy.Parry == domain.ParryPerfect
+}
+
+// parryMessage は完全パリィで無効化した行動のメッセージを返します。
+func parryMessage(enemy *domain.EnemyModel, action domain.EnemyAction) string {
+	return fmt.Sprintf("%sの%sを完全にパリィした！", enemy.Name, action.Name)
+}
+
+// applyParryToDamage はパリィの結果に応じて被ダメージを補正します。
+// パリィで軽減しても、ダメージが発生していれば最低1を保証します。
+func applyParryToDamage(parry domain.ParryResult, damage int) int {
+	switch parry {
+	case domain.ParryPartial:
+		reduced := int(float64(damage) * config.ParryPartialDamageRate)
+		if reduced < 1 && damage > 0 {
+			return 1
+		}
+		return reduced
+	case domain.ParryFailed:
+		return int(float64(damage) * config.ParryFailedDamageRate)
+	}
+	return damage
+}
+
+// parryDamageLabel は被ダメージメッセージに添えるパリィの結果の表示を返します。
+func parryDamageLabel(parry domain.ParryResult) string {
+	switch parry {
+	case domain.ParryPartial:
+		return " [パリィで軽減]"
+	case domain.ParryFailed:
+		return " [パリィ失敗！]"
+	}
+	return ""
+}
+
+// parryEventDetail はバトルログの被ダメージイベントに添えるパリィの結果を返します。
+func parryEventDetail(parry domain.ParryResult) string {
+	switch parry {
+	case domain.ParryPartial:
+		return "パリィで軽減"
+	case domain.ParryFailed:
+		return "パリィ失敗"
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+// Package combat はバトルエンジンを提供します。
+// parry_test.go はチャージ中の敵の行動に対するパリィのテストです。
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// newTestParrySession はチャージ2秒・パリィ受付1秒の攻撃を行う敵とのテスト用バトルセッションを作成します。
+func newTestParrySession() (*BattleSession, *clock.ManualClock) {
+	session, c := newTestSession(1000, 10, 2*time.Second)
+	enemy := session.Enemy()
+	enemy.Type.ResolvedNormalActions[0].ParryWindow = time.Second
+	enemy.PendingAction.ParryWindow = time.Second
+	return session, c
+}
+
+// typeParryText はパリィのチャレンジテキストを入力します。
+func typeParryText(session *BattleSession) {
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+}
+
+// TestResolveParry はパリィのタイピング結果から結果を判定することをテストします。
+func TestResolveParry(t *testing.T) {
+	engine := NewBattleEngine(nil)
+	tests := []struct {
+		name   string
+		result typing.TypingResult
+		want   domain.ParryResult
+	}{
+		{"ミスなしは完全パリィ", typing.TypingResult{Completed: true, Accuracy: 1.0}, domain.ParryPerfect},
+		{"ミスありは軽減", typing.TypingResult{Completed: true, Accuracy: 0.8}, domain.ParryPartial},
+		{"未完了は失敗", typing.TypingResult{Completed: false, Timeout: true}, domain.ParryFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enemy := domain.NewEnemy("e", "敵", 1, 100, 10, domain.EnemyType{})
+			if got := engine.ResolveParry(enemy, &tt.result); got != tt.want || enemy.Parry != tt.want {
+				t.Errorf("パリィの結果: 期待 %v, 実際 %v（敵の記録 %v）", tt.want, got, enemy.Parry)
+			}
+		})
+	}
+}
+
+// TestApplyParryToDamage はパリィの結果による被ダメージ補正をテストします。
+func TestApplyParryToDamage(t *testing.T) {
+	if got := applyParryToDamage(domain.ParryNone, 10); got != 10 {
+		t.Errorf("パリィなし: 期待 10, 実際 %d", got)
+	}
+	if got := applyParryToDamage(domain.ParryPartial, 10); got != 5 {
+		t.Errorf("軽減: 期待 5, 実際 %d", got)
+	}
+	if got := applyParryToDamage(domain.ParryPartial, 1); got != 1 {
+		t.Errorf("軽減してもダメージは最低1: 実際 %d", got)
+	}
+	if got := applyParryToDamage(domain.ParryFailed, 10); got != 15 {
+		t.Errorf("失敗: 期待 15, 実際 %d", got)
+	}
+}
+
+// TestBattleSession_ParryWindow はチャージ終盤の受付時間内のみパリィを開始できることをテストします。
+func TestBattleSession_ParryWindow(t *testing.T) {
+	session, c := newTestParrySession()
+
+	advance(session, c, 500*time.Millisecond)
+	if session.ParryableEnemy() != nil || session.StartParry() {
+		t.Fatal("受付時間より前にパリィを開始できてはいけない")
+	}
+
+	// モジュールのタイピング中でもパリィに切り替えられる
+	session.SelectModule(0)
+	advance(session, c, 700*time.Millisecond)
+	if !session.StartParry() {
+		t.Fatal("受付時間内はパリィを開始できるべき")
+	}
+	if !session.IsParrying() || session.ParryTarget() != session.Enemy() {
+		t.Error("パリィ中は対象の敵が設定されるべき")
+	}
+	if remaining := session.TypingRemaining(); remaining != 800*time.Millisecond {
+		t.Errorf("パリィの制限時間はチャージ残り時間: 期待 800ms, 実際 %v", remaining)
+	}
+	if session.SelectModule(0) {
+		t.Error("パリィ中はモジュールを選択できないべき")
+	}
+}
+
+// TestBattleSession_ParryOutcome はパリィの結果に応じて敵の行動のダメージが変わることをテストします。
+func TestBattleSession_ParryOutcome(t *testing.T) {
+	baseline, bc := newTestParrySession()
+	advance(baseline, bc, 2*time.Second)
+	baseDamage := 100 - baseline.Player().HP
+	if baseDamage <= 0 {
+		t.Fatal("パリィなしで敵の攻撃を受けるべき")
+	}
+
+	tests := []struct {
+		name  string
+		parry func(session *BattleSession)
+		want  domain.ParryResult
+	}{
+		{"完全パリィ", typeParryText, domain.ParryPerfect},
+		{"ミスありで軽減", func(session *BattleSession) {
+			session.ProcessTypingInput('#')
+			typeParryText(session)
+		}, domain.ParryPartial},
+		{"キャンセルで失敗", func(session *BattleSession) { session.CancelTyping() }, domain.ParryFailed},
+		{"時間切れで失敗", func(session *BattleSession) {}, domain.ParryFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, c := newTestParrySession()
+			advance(session, c, 1500*time.Millisecond)
+			if !session.StartParry() {
+				t.Fatal("パリィを開始できませんでした")
+			}
+			tt.parry(session)
+			// 時間切れの場合はチャージ完了のTickまで結果が決まらない
+			if !session.IsParrying() && session.Enemy().Parry != tt.want {
+				t.Errorf("パリィの結果: 期待 %v, 実際 %v", tt.want, session.Enemy().Parry)
+			}
+
+			advance(session, c, 500*time.Millisecond)
+			if session.IsParrying() {
+				t.Error("チャージ完了時にはパリィが終了しているべき")
+			}
+			want := applyParryToDamage(tt.want, baseDamage)
+			if tt.want == domain.ParryPerfect {
+				want = 0
+			}
+			if damage := 100 - session.Player().HP; damage != want {
+				t.Errorf("被ダメージ: 期待 %d, 実際 %d（パリィなし %d）", want, damage, baseDamage)
+			}
+			if session.Enemy().Parry != domain.ParryNone {
+				t.Error("行動後はパリィの結果がリセットされるべき")
+			}
+		})
+	}
+}
+
+// TestReplay_ReproducesParry はパリィを含むバトルのリプレイが同じ結果になることをテストします。
+func TestReplay_ReproducesParry(t *testing.T) {
+	original, c := newTestParrySession()
+	advance(original, c, 1500*time.Millisecond)
+	original.StartParry()
+	original.ProcessTypingInput('#')
+	typeParryText(original)
+	advance(original, c, time.Second)
+
+	replay := original.Replay()
+	recorded := false
+	for _, event := range replay.Events {
+		recorded = recorded || event.Kind == ReplayEventParry
+	}
+	if !recorded {
+		t.Fatal("パリィの開始がリプレイに記録されていません")
+	}
+
+	player := NewReplayPlayer(replay, replay.NewSession(nil))
+	player.Start()
+	for i := 0; i < 100 && !player.Done(); i++ {
+		player.Advance(100 * time.Millisecond)
+	}
+
+	if hp := player.Session().Player().HP; hp != original.Player().HP {
+		t.Errorf("再生後のプレイヤーHP: 期待 %d, 実際 %d", original.Player().HP, hp)
+	}
+}
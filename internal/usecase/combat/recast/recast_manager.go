@@ -25,12 +25,16 @@ type RecastState struct {
 type RecastManager struct {
 	// states はエージェントインデックスごとのリキャスト状態マップです。
 	states map[int]*RecastState
+
+	// paused はリキャストの進行が一時停止しているエージェント（スタン中など）の集合です。
+	paused map[int]bool
 }
 
 // NewRecastManager は新しいRecastManagerを作成します。
 func NewRecastManager() *RecastManager {
 	return &RecastManager{
 		states: make(map[int]*RecastState),
+		paused: make(map[int]bool),
 	}
 }
 
@@ -51,12 +55,15 @@ func (m *RecastManager) StartRecast(agentIndex int, duration time.Duration) {
 }
 
 // UpdateRecast はリキャスト時間を更新し、完了したエージェントのインデックスを返します。
-// deltaはフレーム間の経過時間です。
+// deltaはフレーム間の経過時間です。一時停止中のエージェントのリキャストは進行しません。
 func (m *RecastManager) UpdateRecast(delta time.Duration) []int {
 	completed := make([]int, 0)
 	deltaSeconds := delta.Seconds()
 
 	for agentIndex, state := range m.states {
+		if m.paused[agentIndex] {
+			continue
+		}
 		state.RemainingSeconds -= deltaSeconds
 
 		if state.RemainingSeconds <= 0 {
@@ -140,3 +147,18 @@ func (m *RecastManager) ReduceAllRecasts(reduction time.Duration) {
 		}
 	}
 }
+
+// SetPaused は指定エージェントのリキャストの一時停止状態を設定します。
+// 一時停止中はUpdateRecastでリキャスト残り時間が減少しません。
+func (m *RecastManager) SetPaused(agentIndex int, paused bool) {
+	if paused {
+		m.paused[agentIndex] = true
+		return
+	}
+	delete(m.paused, agentIndex)
+}
+
+// IsPaused は指定エージェントのリキャストが一時停止中かを判定します。
+func (m *RecastManager) IsPaused(agentIndex int) bool {
+	return m.paused[agentIndex]
+}
@@ -350,3 +350,30 @@ func TestCancelRecast(t *testing.T) {
 		t.Error("キャンセル後も状態が残っています")
 	}
 }
+
+// TestSetPaused は一時停止中のエージェントのリキャストが進行しないことをテストします。
+func TestSetPaused(t *testing.T) {
+	rm := NewRecastManager()
+	rm.StartRecast(0, 2.0*time.Second)
+	rm.StartRecast(1, 2.0*time.Second)
+
+	rm.SetPaused(0, true)
+	if !rm.IsPaused(0) || rm.IsPaused(1) {
+		t.Fatal("エージェント0のみ一時停止中であるべきです")
+	}
+
+	completed := rm.UpdateRecast(3.0 * time.Second)
+	if len(completed) != 1 || completed[0] != 1 {
+		t.Errorf("一時停止していないエージェント1のみ完了すべき: got %v", completed)
+	}
+	if state := rm.GetRecastState(0); state == nil || state.RemainingSeconds != 2.0 {
+		t.Errorf("一時停止中のリキャストは進行しないべき: got %+v", state)
+	}
+
+	// 一時停止を解除すると再び進行する
+	rm.SetPaused(0, false)
+	completed = rm.UpdateRecast(2.0 * time.Second)
+	if len(completed) != 1 || completed[0] != 0 {
+		t.Errorf("一時停止解除後はリキャストが完了すべき: got %v", completed)
+	}
+}
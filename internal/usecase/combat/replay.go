@@ -0,0 +1,296 @@
+// Package combat はバトルエンジンを提供します。
+// replay.go はバトルのリプレイ（記録と再生）を担当します。
+// 乱数シード・編成・敵のスナップショットと、時刻付きの入力列を記録し、
+// 同じ入力を手動時計で再投入することで同じバトルを再現します。
+
+package combat
+
+import (
+	"fmt"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// ==================== 乱数シード ====================
+
+// ReplaySeeds はバトルで使用する乱数生成器のシードです。
+type ReplaySeeds struct {
+	// Engine はBattleEngine（回避・確率効果・ダブルキャスト等）のシードです。
+	Engine int64
+
+	// Challenge はChallengeGenerator（出題）のシードです。
+	Challenge int64
+
+	// PlayerEffects はプレイヤーのEffectTable（確率付き効果）のシードです。
+	PlayerEffects int64
+
+	// EnemyEffects は敵のEffectTable（確率付き効果）のシードです。
+	EnemyEffects int64
+}
+
+// newReplaySeeds は現在時刻から新しいシードの組を作成します。
+func newReplaySeeds() ReplaySeeds {
+	base := time.Now().UnixNano()
+	return ReplaySeeds{
+		Engine:        base,
+		Challenge:     base + 1,
+		PlayerEffects: base + 2,
+		EnemyEffects:  base + 3,
+	}
+}
+
+// ==================== 入力イベント ====================
+
+// ReplayEventKind はリプレイに記録する入力の種別です。
+type ReplayEventKind string
+
+const (
+	// ReplayEventTick は時間経過（BattleSession.Tick）です。
+	ReplayEventTick ReplayEventKind = "tick"
+
+	// ReplayEventChallenge はモジュール選択によるタイピングチャレンジ開始です。
+	ReplayEventChallenge ReplayEventKind = "challenge"
+
+	// ReplayEventKey はタイピングの1打です。
+	ReplayEventKey ReplayEventKind = "key"
+
+	// ReplayEventCancel はタイピングのキャンセルです。
+	ReplayEventCancel ReplayEventKind = "cancel"
+
+	// ReplayEventPause は一時停止です。
+	ReplayEventPause ReplayEventKind = "pause"
+
+	// ReplayEventResume は一時停止の解除です。
+	ReplayEventResume ReplayEventKind = "resume"
+
+	// ReplayEventTarget は攻撃対象の選択です（複数敵バトル）。
+	ReplayEventTarget ReplayEventKind = "target"
+
+	// ReplayEventParry はパリィのタイピング開始です。
+	ReplayEventParry ReplayEventKind = "parry"
+
+	// ReplayEventRetreat はバトルからの撤退です。
+	ReplayEventRetreat ReplayEventKind = "retreat"
+)
+
+// ReplayEvent はリプレイに記録する1件の入力です。
+type ReplayEvent struct {
+	// At はバトル開始からの経過時間です（一時停止中の時間を含む実時間）。
+	At time.Duration
+
+	// Kind は入力の種別です。
+	Kind ReplayEventKind
+
+	// Delta はTickで進めた時間です（ReplayEventTickのみ）。
+	Delta time.Duration
+
+	// Slot は選択したモジュールスロットです（ReplayEventChallengeのみ）。
+	Slot int
+
+	// Challenge は出題されたチャレンジです（ReplayEventChallenge・ReplayEventParryのみ）。
+	// 辞書や苦手キー統計が変わっても同じ出題を再現できるよう、生成結果をそのまま記録します。
+	Challenge *typing.Challenge
+
+	// Rune は入力された文字です（ReplayEventKeyのみ）。
+	Rune rune
+
+	// Target は選択した攻撃対象の現在のウェーブ内でのインデックスです（ReplayEventTargetのみ）。
+	Target int
+}
+
+// ==================== リプレイ ====================
+
+// EnemySnapshot はバトル開始時の敵の状態です。
+type EnemySnapshot struct {
+	// Type は敵タイプです。
+	Type domain.EnemyType
+
+	// Name は敵の表示名です。
+	Name string
+
+	// Level は敵のレベルです。
+	Level int
+
+	// MaxHP は敵の最大HPです。
+	MaxHP int
+
+	// AttackPower は敵の攻撃力です。
+	AttackPower int
+}
+
+// newEnemySnapshot は敵の現在のステータスからスナップショットを作成します。
+func newEnemySnapshot(enemy *domain.EnemyModel) EnemySnapshot {
+	return EnemySnapshot{
+		Type:        enemy.Type,
+		Name:        enemy.Name,
+		Level:       enemy.Level,
+		MaxHP:       enemy.MaxHP,
+		AttackPower: enemy.AttackPower,
+	}
+}
+
+// newEnemy はスナップショットから敵を作成します。
+func (e EnemySnapshot) newEnemy(id string) *domain.EnemyModel {
+	return domain.NewEnemy(id, e.Name, e.Level, e.MaxHP, e.AttackPower, e.Type)
+}
+
+// Replay は1戦分のリプレイです。
+type Replay struct {
+	// RecordedAt はバトル開始時刻です。
+	RecordedAt time.Time
+
+	// Seeds は乱数シードです。
+	Seeds ReplaySeeds
+
+	// Enemy は敵のスナップショットです（複数敵バトルでは最初のウェーブの先頭の敵）。
+	Enemy EnemySnapshot
+
+	// Waves は複数敵バトルのウェーブごとの敵のスナップショットです（1対1バトルでは空）。
+	Waves [][]EnemySnapshot
+
+	// PlayerMaxHP はプレイヤーの最大HPです。
+	PlayerMaxHP int
+
+	// PlayerHP は開始時のプレイヤーHPです（0の場合は最大HP）。
+	// エンドレスタワーのように前のバトルからHPを持ち越した場合に記録されます。
+	PlayerHP int
+
+	// Agents は装備していたエージェントです。
+	Agents []*domain.AgentModel
+
+	// Events は時刻順の入力列です。
+	Events []ReplayEvent
+
+	// Victory は勝利したかどうかです。
+	Victory bool
+
+	// Retreated は撤退によってバトルが終了したかどうかです。
+	Retreated bool
+
+	// Duration はバトル開始から記録終了までの時間です。
+	Duration time.Duration
+}
+
+// NewSession はリプレイのスナップショットから再生用のバトルセッションを作成します。
+// 敵とプレイヤーは記録時と同じステータスで新しく作成されます。
+func (r *Replay) NewSession(dictionary *typing.Dictionary) *BattleSession {
+	waves := [][]*domain.EnemyModel{{r.Enemy.newEnemy("replay_enemy")}}
+	if len(r.Waves) > 0 {
+		waves = make([][]*domain.EnemyModel, len(r.Waves))
+		for w, snapshots := range r.Waves {
+			for i, snapshot := range snapshots {
+				waves[w] = append(waves[w], snapshot.newEnemy(fmt.Sprintf("replay_enemy_%d_%d", w, i)))
+			}
+		}
+	}
+
+	player := domain.NewPlayer()
+	player.MaxHP = r.PlayerMaxHP
+	player.PrepareForBattle()
+	if r.PlayerHP > 0 && r.PlayerHP < player.MaxHP {
+		player.HP = r.PlayerHP
+	}
+
+	session := NewEncounterSession(waves, player, r.Agents, dictionary)
+	session.SetSeeds(r.Seeds)
+	return session
+}
+
+// ==================== 再生 ====================
+
+// ReplayPlayer はリプレイの入力列をバトルセッションに再投入する構造体です。
+// 手動時計を記録時の時刻に合わせながら入力を適用するため、再生速度に関係なく同じ結果になります。
+type ReplayPlayer struct {
+	replay  *Replay
+	session *BattleSession
+	clock   *clock.ManualClock
+	start   time.Time
+
+	// elapsed は再生済みの経過時間です。
+	elapsed time.Duration
+
+	// next は次に適用するイベントのインデックスです。
+	next int
+}
+
+// NewReplayPlayer はリプレイ再生を作成します。
+// sessionはReplay.NewSessionで作成し、パッシブスキル定義などの設定を済ませてから渡してください。
+func NewReplayPlayer(replay *Replay, session *BattleSession) *ReplayPlayer {
+	c := clock.NewManualClock(replay.RecordedAt)
+	session.SetClock(c)
+	return &ReplayPlayer{
+		replay:  replay,
+		session: session,
+		clock:   c,
+		start:   replay.RecordedAt,
+	}
+}
+
+// Start はバトル開始時の処理を行います（BattleSession.Startに相当）。
+func (p *ReplayPlayer) Start() {
+	p.session.Start()
+}
+
+// Advance は再生をdだけ進め、その間に記録された入力を順に適用します。
+func (p *ReplayPlayer) Advance(d time.Duration) {
+	p.elapsed += d
+	for p.next < len(p.replay.Events) && p.replay.Events[p.next].At <= p.elapsed {
+		p.apply(p.replay.Events[p.next])
+		p.next++
+	}
+	if p.Done() {
+		p.elapsed = p.replay.Duration
+	}
+}
+
+// apply は1件の入力を記録時の時刻で適用します。
+func (p *ReplayPlayer) apply(event ReplayEvent) {
+	p.clock.Set(p.start.Add(event.At))
+	switch event.Kind {
+	case ReplayEventTick:
+		p.session.Tick(event.Delta)
+	case ReplayEventChallenge:
+		if event.Challenge != nil {
+			p.session.StartChallenge(event.Slot, event.Challenge)
+		}
+	case ReplayEventKey:
+		p.session.ProcessTypingInput(event.Rune)
+	case ReplayEventCancel:
+		p.session.CancelTyping()
+	case ReplayEventPause:
+		p.session.Pause()
+	case ReplayEventResume:
+		p.session.Resume()
+	case ReplayEventTarget:
+		p.session.SelectTarget(event.Target)
+	case ReplayEventParry:
+		if event.Challenge != nil {
+			p.session.StartParryChallenge(event.Challenge)
+		}
+	case ReplayEventRetreat:
+		p.session.Retreat()
+	}
+}
+
+// Done は全ての入力を適用し終えたかを返します。
+func (p *ReplayPlayer) Done() bool {
+	return p.next >= len(p.replay.Events)
+}
+
+// Elapsed は再生済みの経過時間を返します。
+func (p *ReplayPlayer) Elapsed() time.Duration {
+	return p.elapsed
+}
+
+// Replay は再生中のリプレイを返します。
+func (p *ReplayPlayer) Replay() *Replay {
+	return p.replay
+}
+
+// Session は再生中のバトルセッションを返します。
+func (p *ReplayPlayer) Session() *BattleSession {
+	return p.session
+}
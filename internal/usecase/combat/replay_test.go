@@ -0,0 +1,159 @@
+// Package combat はバトルエンジンを提供します。
+// replay_test.go はバトルのリプレイ記録と再生のテストです。
+
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// playRecordedBattle はモジュール使用・ミスタイプ・キャンセル・一時停止を含む1戦を実行します。
+func playRecordedBattle(t *testing.T) *BattleSession {
+	t.Helper()
+	session, c := newTestSession(150, 8, 2*time.Second)
+	tick := 100 * time.Millisecond
+
+	for i := 0; i < 2000 && !session.IsOver(); i++ {
+		switch {
+		case i == 5:
+			session.Pause()
+		case i == 8:
+			session.Resume()
+		case i == 12 && session.IsTyping():
+			session.CancelTyping()
+		case !session.IsTyping():
+			for slot := range session.Slots() {
+				if session.SelectModule(slot) {
+					break
+				}
+			}
+		case session.IsTyping():
+			text := []rune(session.TypingText())
+			if index := session.TypingIndex(); index < len(text) {
+				if i%7 == 0 {
+					session.ProcessTypingInput('#')
+				}
+				session.ProcessTypingInput(text[index])
+			}
+		}
+
+		c.Advance(tick)
+		if !session.IsPaused() {
+			session.Tick(tick)
+		}
+	}
+
+	if !session.IsOver() {
+		t.Fatal("記録用のバトルが決着しませんでした")
+	}
+	return session
+}
+
+// TestReplay_ReproducesBattle は記録したリプレイを再生すると同じ結果になることをテストします。
+func TestReplay_ReproducesBattle(t *testing.T) {
+	original := playRecordedBattle(t)
+	replay := original.Replay()
+
+	if len(replay.Events) == 0 {
+		t.Fatal("入力が記録されていません")
+	}
+	kinds := make(map[ReplayEventKind]bool)
+	for _, event := range replay.Events {
+		kinds[event.Kind] = true
+	}
+	for _, kind := range []ReplayEventKind{ReplayEventTick, ReplayEventChallenge, ReplayEventKey, ReplayEventCancel, ReplayEventPause, ReplayEventResume} {
+		if !kinds[kind] {
+			t.Errorf("%s イベントが記録されていません", kind)
+		}
+	}
+
+	// 再生速度（1回に進める時間）を変えても結果は同じ
+	for _, step := range []time.Duration{100 * time.Millisecond, 400 * time.Millisecond} {
+		player := NewReplayPlayer(replay, replay.NewSession(nil))
+		player.Start()
+		for i := 0; i < 10000 && !player.Done(); i++ {
+			player.Advance(step)
+		}
+
+		session := player.Session()
+		if !session.IsOver() {
+			t.Fatalf("再生（%v刻み）が決着しませんでした", step)
+		}
+		if session.IsVictory() != original.IsVictory() {
+			t.Errorf("再生（%v刻み）の勝敗: 期待 %v, 実際 %v", step, original.IsVictory(), session.IsVictory())
+		}
+		if session.Enemy().HP != original.Enemy().HP || session.Player().HP != original.Player().HP {
+			t.Errorf("再生（%v刻み）のHP: 期待 敵%d/自%d, 実際 敵%d/自%d", step,
+				original.Enemy().HP, original.Player().HP, session.Enemy().HP, session.Player().HP)
+		}
+		if *session.State().Stats != *original.State().Stats {
+			t.Errorf("再生（%v刻み）の統計: 期待 %+v, 実際 %+v", step, *original.State().Stats, *session.State().Stats)
+		}
+		if player.Elapsed() != replay.Duration {
+			t.Errorf("再生（%v刻み）の経過時間: 期待 %v, 実際 %v", step, replay.Duration, player.Elapsed())
+		}
+	}
+}
+
+// TestReplay_SnapshotsStartState はリプレイが開始時のステータスとシードを保持することをテストします。
+func TestReplay_SnapshotsStartState(t *testing.T) {
+	session, _ := newTestSession(150, 8, 2*time.Second)
+	seeds := ReplaySeeds{Engine: 1, Challenge: 2, PlayerEffects: 3, EnemyEffects: 4}
+	session.SetSeeds(seeds)
+	session.Enemy().TakeDamage(50)
+
+	replay := session.Replay()
+	if replay.Seeds != seeds {
+		t.Errorf("シード: 期待 %+v, 実際 %+v", seeds, replay.Seeds)
+	}
+	if replay.Enemy.MaxHP != 150 || replay.Enemy.Type.ID != "slime" {
+		t.Errorf("敵スナップショット: 実際 %+v", replay.Enemy)
+	}
+	if replay.PlayerMaxHP != 100 {
+		t.Errorf("プレイヤー最大HP: 期待 100, 実際 %d", replay.PlayerMaxHP)
+	}
+
+	restored := replay.NewSession(nil)
+	if restored.Enemy().HP != 150 {
+		t.Errorf("再生用の敵HP: 期待 150, 実際 %d", restored.Enemy().HP)
+	}
+	if restored.Player().HP != 100 {
+		t.Errorf("再生用のプレイヤーHP: 期待 100, 実際 %d", restored.Player().HP)
+	}
+}
+
+// TestReplay_CarriedOverPlayerHP は前のバトルから持ち越したHPがリプレイに記録・再現されることをテストします。
+func TestReplay_CarriedOverPlayerHP(t *testing.T) {
+	enemyType := domain.EnemyType{ID: "slime", Name: "スライム", BaseHP: 150, BaseAttackPower: 8, AttackType: "physical"}
+	player := domain.NewPlayer()
+	player.MaxHP = 100
+	player.HP = 60
+
+	session := NewBattleSession(domain.NewEnemy("enemy_001", "スライム", 1, 150, 8, enemyType), player, newTestSessionAgents(), nil)
+	replay := session.Replay()
+	if replay.PlayerHP != 60 {
+		t.Errorf("開始時のプレイヤーHP: 期待 60, 実際 %d", replay.PlayerHP)
+	}
+
+	restored := replay.NewSession(nil)
+	if restored.Player().HP != 60 || restored.Player().MaxHP != 100 {
+		t.Errorf("再生用のプレイヤーHP: 期待 60/100, 実際 %d/%d", restored.Player().HP, restored.Player().MaxHP)
+	}
+}
+
+// TestReplay_Retreated は撤退したバトルのリプレイに撤退の結果が記録されることをテストします。
+func TestReplay_Retreated(t *testing.T) {
+	session, _ := newTestSession(150, 8, 2*time.Second)
+	if session.Replay().Retreated {
+		t.Error("撤退前のリプレイが撤退扱いになっています")
+	}
+
+	session.Retreat()
+	replay := session.Replay()
+	if !replay.Retreated || replay.Victory {
+		t.Errorf("撤退の結果: 撤退 %v, 勝利 %v", replay.Retreated, replay.Victory)
+	}
+}
@@ -0,0 +1,1634 @@
+// Package combat はバトルエンジンを提供します。
+// session.go はリアルタイムバトルの進行（状態機械）を担当します。
+// 入力（モジュール選択・キー入力）と時間経過（Tick）を受け取ってバトルを進め、
+// TUIに依存せずに1戦を最後まで実行できます。
+
+package combat
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/combat/chain"
+	"hirorocky/type-battle/internal/usecase/combat/recast"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// chainEffectDuration はチェイン効果の持続時間（秒）です。
+const chainEffectDuration = 10.0
+
+// ==================== モジュールスロット ====================
+
+// ModuleSlot はモジュールスロットを表します。
+type ModuleSlot struct {
+	Module            *domain.ModuleModel
+	Agent             *domain.AgentModel
+	AgentIndex        int
+	ModuleIndex       int
+	CooldownRemaining float64
+	CooldownTotal     float64
+}
+
+// IsReady はモジュールが使用可能かを返します。
+func (s *ModuleSlot) IsReady() bool {
+	return s.CooldownRemaining <= 0
+}
+
+// ==================== HP変化イベント ====================
+
+// HPChangeTarget はHP変化の対象を表す型です。
+type HPChangeTarget string
+
+const (
+	// HPChangePlayer はプレイヤーのHP変化です。
+	HPChangePlayer HPChangeTarget = "player"
+
+	// HPChangeEnemy は敵のHP変化です。
+	HPChangeEnemy HPChangeTarget = "enemy"
+)
+
+// HPChangeEvent はバトル中に発生したダメージ・回復を表す構造体です。
+// UIはフローティングダメージ表示などに使用します。
+type HPChangeEvent struct {
+	// Target はHPが変化した対象です。
+	Target HPChangeTarget
+
+	// Amount は変化量です。
+	Amount int
+
+	// IsHeal は回復かどうかです。
+	IsHeal bool
+
+	// IsCritical はクリティカルヒットによるダメージかどうかです。
+	IsCritical bool
+}
+
+// ==================== BattleSession ====================
+
+// BattleSession は1戦分のリアルタイムバトルを管理する構造体です。
+// モジュール選択、タイピング、敵のチャージ・攻撃、クールダウン・リキャスト、
+// チェイン効果、パッシブスキルの発動までを扱います。
+type BattleSession struct {
+	// バトルエンジンと状態
+	engine *BattleEngine
+	state  *BattleState
+
+	// 時刻取得（一時停止中は時刻が止まる）
+	clock *clock.PausableClock
+
+	// モジュールスロット
+	slots []ModuleSlot
+
+	// タイピングシステム
+	generator *typing.ChallengeGenerator
+	evaluator *typing.Evaluator
+	keyStats  *typing.KeyStats
+
+	// タイピング状態
+	typingState          *typing.ChallengeState
+	typingStartTime      time.Time
+	typingTimeLimit      time.Duration
+	activeSlot           int
+	autoCorrectRemaining int
+
+	// パリィ中の対象の敵（nilはモジュールのタイピング）
+	parryTarget *domain.EnemyModel
+
+	// リキャスト・チェイン効果管理
+	recastManager      *recast.RecastManager
+	chainEffectManager *chain.ChainEffectManager
+
+	// パッシブスキル関連
+	comboCount            int  // ミスなし連続タイピング回数
+	typoRecoveryUsed      bool // ps_typo_recovery使用済みフラグ（チャレンジ毎にリセット）
+	secondChanceUsed      bool // ps_second_chance使用済みフラグ（チャレンジ毎にリセット）
+	firstStrikeAgentIndex int  // ps_first_strike発動エージェント（-1は無効）
+
+	// 終了状態（retreatedは撤退による終了）
+	over      bool
+	victory   bool
+	retreated bool
+
+	// 表示用
+	message   string
+	hpChanges []HPChangeEvent
+
+	// バトルログ（ダメージ・回復・効果・パッシブなどの出来事）
+	log *BattleLog
+
+	// リプレイ記録（入力の時刻は一時停止で止まらない元の時計で計測する）
+	seeds         ReplaySeeds
+	baseClock     clock.Clock
+	recordStart   time.Time
+	enemySnapshot EnemySnapshot
+	waveSnapshots [][]EnemySnapshot
+	playerMaxHP   int
+	playerHP      int
+	events        []ReplayEvent
+}
+
+// NewBattleSession は新しいBattleSessionを作成します。
+// dictionaryがnilの場合はデフォルト辞書を使用します。
+func NewBattleSession(enemy *domain.EnemyModel, player *domain.PlayerModel, agents []*domain.AgentModel, dictionary *typing.Dictionary) *BattleSession {
+	return NewEncounterSession([][]*domain.EnemyModel{{enemy}}, player, agents, dictionary)
+}
+
+// NewEncounterSession は複数の敵・ウェーブで構成されるBattleSessionを作成します。
+// wavesは出現順のウェーブで、前のウェーブの敵を全て倒すと次のウェーブが出現します。
+// dictionaryがnilの場合はデフォルト辞書を使用します。
+func NewEncounterSession(waves [][]*domain.EnemyModel, player *domain.PlayerModel, agents []*domain.AgentModel, dictionary *typing.Dictionary) *BattleSession {
+	if dictionary == nil {
+		dictionary = defaultDictionary()
+	}
+
+	var enemyTypes []domain.EnemyType
+	level := 0
+	for _, wave := range waves {
+		for _, enemy := range wave {
+			enemyTypes = append(enemyTypes, enemy.Type)
+			if enemy.Level > level {
+				level = enemy.Level
+			}
+		}
+	}
+	enemy := waves[0][0]
+
+	baseClock := clock.NewSystemClock()
+	s := &BattleSession{
+		engine:                NewBattleEngine(enemyTypes),
+		clock:                 clock.NewPausableClock(baseClock),
+		baseClock:             baseClock,
+		recordStart:           baseClock.Now(),
+		generator:             typing.NewChallengeGenerator(dictionary),
+		evaluator:             typing.NewEvaluator(),
+		recastManager:         recast.NewRecastManager(),
+		chainEffectManager:    chain.NewChainEffectManager(),
+		firstStrikeAgentIndex: -1,
+		log:                   NewBattleLog(config.BattleLogCapacity),
+	}
+
+	// タイピング判定とバトルエンジンもセッションと同じ時計で計測する
+	s.evaluator.SetClock(s.clock)
+	s.engine.SetClock(s.clock)
+	s.engine.SetBattleLog(s.log)
+
+	// リプレイ用のスナップショット（開始時のステータス）
+	s.enemySnapshot = newEnemySnapshot(enemy)
+	for _, wave := range waves {
+		snapshots := make([]EnemySnapshot, len(wave))
+		for i, e := range wave {
+			snapshots[i] = newEnemySnapshot(e)
+		}
+		s.waveSnapshots = append(s.waveSnapshots, snapshots)
+	}
+	s.playerMaxHP = player.MaxHP
+	if player.HP < player.MaxHP {
+		s.playerHP = player.HP
+	}
+
+	s.state = &BattleState{
+		Enemy:          enemy,
+		Waves:          waves,
+		Player:         player,
+		EquippedAgents: agents,
+		Level:          level,
+		Stats: &BattleStatistics{
+			StartTime: s.clock.Now(),
+		},
+	}
+
+	// 最初のウェーブの敵の行動を準備してチャージ開始し、パッシブスキルを登録
+	s.engine.StartWave(s.state, s.clock.Now())
+
+	// モジュールスロットを初期化
+	for agentIdx, agent := range agents {
+		for modIdx, module := range agent.Modules {
+			s.slots = append(s.slots, ModuleSlot{
+				Module:            module,
+				Agent:             agent,
+				AgentIndex:        agentIdx,
+				ModuleIndex:       modIdx,
+				CooldownRemaining: 0,
+				CooldownTotal:     config.DefaultModuleCooldown,
+			})
+		}
+	}
+
+	// 乱数シードを決めておき、リプレイに記録する
+	s.SetSeeds(newReplaySeeds())
+
+	return s
+}
+
+// defaultDictionary はデフォルトのタイピング辞書を作成します。
+func defaultDictionary() *typing.Dictionary {
+	return &typing.Dictionary{
+		// Easy: 3-6文字の単語
+		Easy: []string{
+			"cat", "dog", "run", "jump", "fire", "ice",
+			"hit", "cut", "heal", "buff", "fast", "slow",
+			"axe", "bow", "sun", "moon", "star", "wind",
+			"red", "blue", "gold", "dark", "life", "mana",
+		},
+		// Medium: 7-11文字の単語
+		Medium: []string{
+			"warrior", "monster", "defense", "attack",
+			"healing", "protect", "thunder", "blizzard",
+			"fireball", "critical", "accuracy", "strength",
+			"powerful", "ultimate", "blessing", "cursed",
+		},
+		// Hard: 12-20文字の単語
+		Hard: []string{
+			"thunderstorm", "annihilation", "resurrection",
+			"extraordinary", "invulnerable", "battleground",
+			"concentration", "determination", "acceleration",
+			"purification", "hallucination", "obliteration",
+		},
+	}
+}
+
+// ==================== 設定 ====================
+
+// SetClock はバトルの時刻取得に使う時計を設定します。
+// タイピング判定とバトルエンジンにも同じ時計を設定し、
+// バトル開始時刻と敵の最初の待機（チャージ/ディフェンス）の開始時刻を新しい時計に合わせます。
+// バトル開始前（NewBattleSession直後）に呼び出してください。
+func (s *BattleSession) SetClock(c clock.Clock) {
+	s.clock = clock.NewPausableClock(c)
+	s.evaluator.SetClock(s.clock)
+	s.engine.SetClock(s.clock)
+
+	now := s.clock.Now()
+	s.baseClock = c
+	s.recordStart = now
+	s.state.Stats.StartTime = now
+	for _, enemy := range s.state.CurrentEnemies() {
+		switch enemy.WaitMode {
+		case domain.WaitModeCharging:
+			enemy.ChargeStartTime = now
+		case domain.WaitModeDefending:
+			enemy.DefenseStartTime = now
+		}
+	}
+}
+
+// SetPassiveSkills はパッシブスキル定義を設定します。
+func (s *BattleSession) SetPassiveSkills(skills map[string]domain.PassiveSkill) {
+	s.engine.SetPassiveSkills(skills)
+}
+
+// SetSeeds はバトルエンジン・チャレンジ生成・プレイヤーと敵のEffectTableの乱数シードを設定します。
+// 複数の敵がいる場合、出現順でk番目（0始まり）の敵にはEnemyEffects+kを設定します。
+// シードはリプレイに記録され、再生時に同じシードを設定することで確率判定を再現します。
+// バトル開始前（NewBattleSession直後）に呼び出してください。
+func (s *BattleSession) SetSeeds(seeds ReplaySeeds) {
+	s.seeds = seeds
+	s.engine.SetRng(rand.New(rand.NewSource(seeds.Engine)))
+	s.generator.SetRng(rand.New(rand.NewSource(seeds.Challenge)))
+	if table := s.state.Player.EffectTable; table != nil {
+		table.SetSeed(seeds.PlayerEffects)
+	}
+	k := int64(0)
+	for _, wave := range s.state.Waves {
+		for _, enemy := range wave {
+			if enemy.EffectTable != nil {
+				enemy.EffectTable.SetSeed(seeds.EnemyEffects + k)
+			}
+			k++
+		}
+	}
+}
+
+// SetRng はバトルエンジンとチャレンジ生成に使う乱数生成器を設定します。
+// シード固定のシミュレーションで同じ戦闘を再現するために使用します。
+// 設定した乱数生成器はリプレイに記録されません。
+func (s *BattleSession) SetRng(rng *rand.Rand) {
+	s.engine.SetRng(rng)
+	s.generator.SetRng(rng)
+}
+
+// SetTypingMode はタイピングチャレンジの入力方式を設定します。
+func (s *BattleSession) SetTypingMode(mode typing.InputMode) {
+	s.generator.SetInputMode(mode)
+}
+
+// SetKeyStats は苦手キー統計を設定します。
+// チャレンジ終了時に入力記録が統計へ反映され、アダプティブ出題に使用されます。
+func (s *BattleSession) SetKeyStats(stats *typing.KeyStats) {
+	s.keyStats = stats
+	s.generator.SetKeyStats(stats)
+}
+
+// SetAdaptiveWords は苦手キーを含む単語を優先して出題するかを設定します。
+func (s *BattleSession) SetAdaptiveWords(enabled bool) {
+	s.generator.SetAdaptive(enabled)
+}
+
+// ==================== バトル進行 ====================
+
+// Start はバトル開始時の処理を行います。
+// ps_first_strikeの発動を評価します。
+func (s *BattleSession) Start() {
+	for agentIdx, agent := range s.state.EquippedAgents {
+		if s.engine.EvaluateFirstStrike(s.state, agent) {
+			s.firstStrikeAgentIndex = agentIdx
+			s.message = fmt.Sprintf("[ファーストストライク！ %sが即発動可能！]", agent.Core.Name)
+			s.emitPassive(agent, "即発動可能")
+			return
+		}
+	}
+}
+
+// Tick はdeltaだけ時間を進めてバトルを更新します。
+// クールダウン・リキャスト・バフ/デバフ・ボルテージはdeltaで、
+// タイピングの制限時間と敵のチャージ・ディフェンスは時計の現在時刻で判定します。
+// 一時停止中および終了後は何もしません。
+func (s *BattleSession) Tick(delta time.Duration) {
+	if s.over || s.IsPaused() {
+		return
+	}
+	s.record(ReplayEvent{Kind: ReplayEventTick, Delta: delta})
+
+	// 勝敗判定
+	if s.CheckGameOver() {
+		return
+	}
+
+	deltaSeconds := delta.Seconds()
+
+	// クールダウンを更新
+	s.UpdateCooldowns(deltaSeconds)
+
+	// リキャストを更新（チェイン効果の期限切れも処理）
+	s.UpdateRecasts(deltaSeconds)
+
+	// チェインの猶予時間を更新
+	if s.chainEffectManager.UpdateChain(deltaSeconds) {
+		s.state.ChainLength = 0
+	}
+
+	// パリィ対象の敵が倒された場合はパリィを終了
+	if s.IsParrying() && !s.parryTarget.IsAlive() {
+		s.typingState = nil
+		s.parryTarget = nil
+	}
+
+	// 制限時間縮小の妨害を適用
+	if s.IsTyping() {
+		s.shrinkTypingTime(deltaSeconds)
+	}
+
+	// タイピング中の時間切れチェック（セカンドチャンス発動時はこのTickを終了）
+	if s.IsTyping() && s.checkTypingTimeout() {
+		return
+	}
+
+	// 生存している敵ごとにディフェンス終了と攻撃（チャージ完了）をチェック
+	now := s.clock.Now()
+	for _, enemy := range s.state.AliveEnemies() {
+		// 同じTick内で倒された敵（反射ダメージ等）は行動しない
+		if !enemy.IsAlive() {
+			continue
+		}
+
+		// スタン中の敵はチャージが進行しない
+		if enemy.EffectTable.HasAilment(domain.ColStun) {
+			enemy.DelayCharge(delta)
+			continue
+		}
+
+		acted := false
+		s.state.withEnemy(enemy, func() {
+			acted = s.updateEnemy(now)
+		})
+
+		// 攻撃後の敗北判定
+		if acted && s.CheckGameOver() {
+			return
+		}
+	}
+
+	// バフ・デバフ・状態異常の持続時間とボルテージを更新
+	s.engine.UpdateEffects(s.state, deltaSeconds)
+
+	// 毒・火傷の継続ダメージによるフェーズ変化をチェック
+	s.checkPhaseTransitions()
+
+	// ボルテージの上昇による激昂をチェック
+	s.checkVoltageThresholds()
+}
+
+// updateEnemy は対象の敵（state.Enemy）のディフェンス終了とチャージ完了を判定し、
+// チャージが完了していれば敵のターンを処理してtrueを返します。
+func (s *BattleSession) updateEnemy(now time.Time) bool {
+	enemy := s.state.Enemy
+
+	// ディフェンス終了チェック
+	if enemy.WaitMode == domain.WaitModeDefending && !enemy.IsDefenseActive(now) {
+		enemy.EndDefense()
+		// 次の行動を決定してチャージ開始
+		s.engine.PrepareEnemyAction(s.state)
+		if action := enemy.GetNextAction(); action != nil {
+			s.engine.StartEnemyCharging(s.state, now)
+		}
+		s.message = fmt.Sprintf("%sのディフェンスが終了した", enemy.Name)
+	}
+
+	// 敵攻撃チェック（チャージ完了判定）
+	if !enemy.IsChargeComplete(now) {
+		return false
+	}
+	s.processEnemyTurn()
+	return true
+}
+
+// checkTypingTimeout はタイピングの時間切れを判定します。
+// ps_second_chanceが発動した場合は同じチャレンジで再挑戦し（1回/チャレンジ）、trueを返します。
+// パリィの時間切れはパリィ失敗になります。
+func (s *BattleSession) checkTypingTimeout() bool {
+	if clock.Since(s.clock, s.typingStartTime) < s.typingTimeLimit {
+		return false
+	}
+
+	if s.IsParrying() {
+		s.failParry()
+		return false
+	}
+
+	if !s.secondChanceUsed {
+		agent := s.slots[s.activeSlot].Agent
+		if s.engine.EvaluateSecondChance(s.state, agent) {
+			s.secondChanceUsed = true
+			// タイピング状態をリセットして再挑戦
+			s.typingStartTime = s.clock.Now()
+			s.recordKeyStats()
+			s.typingState = s.evaluator.StartChallenge(s.typingState.Challenge)
+			s.message = "[セカンドチャンス発動！ 再挑戦！]"
+			s.emitPassive(agent, "再挑戦")
+			return true
+		}
+	}
+
+	s.CancelTyping()
+	s.message = "タイムアップ！"
+	return false
+}
+
+// CheckGameOver は勝敗を判定し、決着していれば終了状態にします。
+// 現在のウェーブの敵を全て倒した場合、次のウェーブがあればそのウェーブを開始します。
+// 攻撃対象が倒されていれば生存している敵に切り替えます。
+func (s *BattleSession) CheckGameOver() bool {
+	// プレイヤー敗北
+	if s.state.Player.HP <= 0 {
+		s.over = true
+		s.victory = false
+		s.message = "敗北..."
+		return true
+	}
+
+	if s.state.IsWaveCleared() {
+		// 次のウェーブへ
+		if s.engine.AdvanceWave(s.state, s.clock.Now()) {
+			s.message = fmt.Sprintf("ウェーブ %d/%d 開始！", s.state.Wave+1, s.state.WaveCount())
+			return false
+		}
+
+		// プレイヤー勝利
+		s.over = true
+		s.victory = true
+		s.message = "勝利！"
+		return true
+	}
+
+	s.state.retarget()
+	return false
+}
+
+// Retreat はバトルから撤退して終了状態にします。
+// 撤退は敗北とは区別され、ここまでのバトル統計はそのまま残ります。
+// 決着済みの場合は何もせずfalseを返します。
+func (s *BattleSession) Retreat() bool {
+	if s.over {
+		return false
+	}
+	s.record(ReplayEvent{Kind: ReplayEventRetreat})
+	if s.typingState != nil {
+		s.recordKeyStats()
+		s.typingState = nil
+		s.parryTarget = nil
+	}
+	s.over = true
+	s.victory = false
+	s.retreated = true
+	s.message = "撤退した..."
+	return true
+}
+
+// ==================== 敵ターン ====================
+
+// processEnemyTurn は敵のターンを処理します。
+func (s *BattleSession) processEnemyTurn() {
+	result := s.engine.ProcessEnemyTurn(s.state)
+	enemy := s.state.Enemy
+
+	switch result.ActionType {
+	case domain.EnemyActionAttack:
+		s.message = fmt.Sprintf("%sの攻撃！ %s", enemy.Name, result.Message)
+		if result.Damage > 0 {
+			s.addHPChange(HPChangePlayer, result.Damage, false)
+		}
+		// ps_quick_recovery: 被ダメージ時にリキャスト短縮
+		s.evaluateQuickRecovery()
+
+	case domain.EnemyActionBuff:
+		s.message = fmt.Sprintf("%sが%s！", enemy.Name, result.Message)
+
+	case domain.EnemyActionDebuff:
+		s.message = fmt.Sprintf("%sが%s", enemy.Name, result.Message)
+
+	case domain.EnemyActionDefense:
+		s.message = fmt.Sprintf("%sが%s！", enemy.Name, result.Message)
+
+	case domain.EnemyActionHeal:
+		s.message = fmt.Sprintf("%sが%s", enemy.Name, result.Message)
+		if result.Healed > 0 {
+			s.addHPChange(HPChangeEnemy, result.Healed, true)
+		}
+
+	case domain.EnemyActionDispel:
+		s.message = fmt.Sprintf("%sの%s", enemy.Name, result.Message)
+
+	default:
+		s.message = "敵の行動"
+	}
+
+	// フェーズ変化をメッセージに反映
+	if result.PhaseChanged {
+		s.message += result.PhaseMessage
+	}
+}
+
+// evaluateQuickRecovery はps_quick_recoveryの発動を評価し、リキャストを短縮します。
+func (s *BattleSession) evaluateQuickRecovery() {
+	for _, agent := range s.state.EquippedAgents {
+		reduction := s.engine.EvaluateQuickRecovery(s.state, agent)
+		if reduction > 0 {
+			s.recastManager.ReduceAllRecasts(time.Duration(reduction) * time.Second)
+			s.message += " [クイックリカバリー発動！]"
+			s.emitPassive(agent, fmt.Sprintf("リキャスト%.0f秒短縮", reduction))
+		}
+	}
+}
+
+// ==================== クールダウン ====================
+
+// UpdateCooldowns はクールダウンを更新します。
+func (s *BattleSession) UpdateCooldowns(deltaSeconds float64) {
+	for i := range s.slots {
+		if s.slots[i].CooldownRemaining > 0 {
+			s.slots[i].CooldownRemaining -= deltaSeconds
+			if s.slots[i].CooldownRemaining < 0 {
+				s.slots[i].CooldownRemaining = 0
+			}
+		}
+	}
+}
+
+// StartCooldown はモジュールのクールダウンを開始します。
+// EffectTableからCooldownReduceを取得して初期値を短縮します。
+func (s *BattleSession) StartCooldown(slotIndex int, duration float64) {
+	if slotIndex < 0 || slotIndex >= len(s.slots) {
+		return
+	}
+
+	// CooldownReduce を適用（正=短縮、負=延長）
+	// 30%短縮の場合、CooldownReduce=0.3 → duration * (1 - 0.3) = 70%
+	effects := s.playerEffects()
+	reducedDuration := duration * (1.0 - effects.CooldownReduce)
+
+	// 最低10%は残す（極端な短縮対策）
+	minDuration := duration * 0.1
+	if reducedDuration < minDuration {
+		reducedDuration = minDuration
+	}
+
+	s.slots[slotIndex].CooldownRemaining = reducedDuration
+	s.slots[slotIndex].CooldownTotal = duration // 表示用に元の値を保持
+}
+
+// ==================== リキャスト・チェイン効果 ====================
+
+// UpdateRecasts はリキャスト時間を更新し、終了したエージェントのチェイン効果を破棄します。
+func (s *BattleSession) UpdateRecasts(deltaSeconds float64) {
+	delta := time.Duration(deltaSeconds * float64(time.Second))
+
+	// スタン中のエージェントはリキャストが進行しない
+	for i := range s.state.EquippedAgents {
+		s.recastManager.SetPaused(i, s.state.Player.EffectTable.HasAgentAilment(domain.ColStun, i))
+	}
+	completedAgents := s.recastManager.UpdateRecast(delta)
+
+	// リキャスト完了したエージェントのチェイン効果を破棄
+	for _, agentIndex := range completedAgents {
+		s.chainEffectManager.ExpireEffectsForAgent(agentIndex)
+	}
+}
+
+// IsModuleUsable は指定スロットのモジュールが使用可能かを判定します。
+// モジュールのクールダウンとエージェントのリキャスト状態を両方チェックします。
+// スタン・沈黙中のエージェントのモジュールは使用できません。
+func (s *BattleSession) IsModuleUsable(slotIndex int) bool {
+	if slotIndex < 0 || slotIndex >= len(s.slots) {
+		return false
+	}
+
+	slot := s.slots[slotIndex]
+
+	// モジュールのクールダウンチェック
+	if !slot.IsReady() {
+		return false
+	}
+
+	// 状態異常によるロックチェック
+	if s.IsAgentLocked(slot.AgentIndex) {
+		return false
+	}
+
+	// エージェントのリキャストチェック
+	return s.recastManager.IsAgentReady(slot.AgentIndex)
+}
+
+// IsAgentLocked はエージェントがスタンまたは沈黙により行動できないかを判定します。
+func (s *BattleSession) IsAgentLocked(agentIndex int) bool {
+	table := s.state.Player.EffectTable
+	return table.HasAgentAilment(domain.ColStun, agentIndex) || table.HasAgentAilment(domain.ColSilence, agentIndex)
+}
+
+// startAgentRecast はエージェントのリキャストを開始し、チェイン効果を登録します。
+func (s *BattleSession) startAgentRecast(agentIndex int, module *domain.ModuleModel) {
+	// モジュールのクールダウン秒数を使用してリキャストを開始
+	cooldownDuration := time.Duration(module.CooldownSeconds() * float64(time.Second))
+	s.recastManager.StartRecast(agentIndex, cooldownDuration)
+
+	// チェイン効果を登録
+	if module.ChainEffect != nil {
+		s.chainEffectManager.RegisterChainEffect(agentIndex, module.ChainEffect, module.TypeID)
+	}
+}
+
+// triggerChainEffects はモジュール使用時に他エージェントのチェイン効果を発動します。
+func (s *BattleSession) triggerChainEffects(usingAgentIndex int, effectFlags chain.ModuleEffectFlags) {
+	triggered := s.chainEffectManager.CheckAndTrigger(usingAgentIndex, effectFlags)
+	for _, effect := range triggered {
+		s.applyTriggeredChainEffect(&effect)
+	}
+}
+
+// applyTriggeredChainEffect は発動したチェイン効果を適用します。
+func (s *BattleSession) applyTriggeredChainEffect(effect *chain.TriggeredChainEffect) {
+	player := s.state.Player
+	enemy := s.state.Enemy
+	event := BattleEvent{Kind: BattleEventChain, Source: s.chainSourceName(effect), Detail: effect.Message}
+	if multiplier := chain.ChainBonusMultiplier(effect.ChainLength); multiplier > 1.0 {
+		event.Detail += fmt.Sprintf("・%dチェイン ×%.2f", effect.ChainLength, multiplier)
+	}
+	defer func() { s.emit(event) }()
+
+	// 効果タイプに応じた処理
+	switch effect.Effect.Type {
+	case domain.ChainEffectDamageBonus:
+		// 追加ダメージ（敵へのダメージ）- 即時適用
+		bonusDamage := int(effect.EffectValue)
+		enemy.HP -= bonusDamage
+		if enemy.HP < 0 {
+			enemy.HP = 0
+		}
+		s.addHPChange(HPChangeEnemy, bonusDamage, false)
+		s.message = fmt.Sprintf("チェイン発動！ %s (+%dダメージ)", effect.Message, bonusDamage)
+		event.Target, event.Amount = enemy.Name, bonusDamage
+
+	case domain.ChainEffectHealBonus:
+		// 追加回復 - 即時適用
+		bonusHeal := int(effect.EffectValue)
+		player.HP += bonusHeal
+		if player.HP > player.MaxHP {
+			player.HP = player.MaxHP
+		}
+		s.addHPChange(HPChangePlayer, bonusHeal, true)
+		s.message = fmt.Sprintf("チェイン発動！ %s (+%d回復)", effect.Message, bonusHeal)
+		event.Target, event.Amount = BattleEventPlayer, bonusHeal
+
+	case domain.ChainEffectBuffExtend, domain.ChainEffectBuffDuration:
+		// バフ延長 - 即時適用
+		if player.EffectTable != nil {
+			player.EffectTable.ExtendBuffDurations(effect.EffectValue)
+			s.message = fmt.Sprintf("チェイン発動！ %s", effect.Message)
+		}
+
+	case domain.ChainEffectDebuffExtend, domain.ChainEffectDebuffDuration:
+		// デバフ延長 - 即時適用
+		if enemy.EffectTable != nil {
+			enemy.EffectTable.ExtendDebuffDurations(effect.EffectValue)
+			s.message = fmt.Sprintf("チェイン発動！ %s", effect.Message)
+		}
+
+	default:
+		// 持続効果は EffectTable に登録
+		s.registerChainEffectToTable(effect)
+		s.message = fmt.Sprintf("チェイン発動！ %s", effect.Message)
+	}
+}
+
+// registerChainEffectToTable はチェイン効果を EffectTable に登録します。
+func (s *BattleSession) registerChainEffectToTable(effect *chain.TriggeredChainEffect) {
+	player := s.state.Player
+	if player.EffectTable == nil {
+		return
+	}
+
+	// チェイン効果の値を EffectColumn にマッピング
+	values := make(map[domain.EffectColumn]float64)
+	flags := make(map[domain.EffectColumn]bool)
+
+	switch effect.Effect.Type {
+	// 攻撃強化カテゴリ
+	case domain.ChainEffectDamageAmp:
+		values[domain.ColDamageMultiplier] = 1.0 + effect.EffectValue/100.0
+	case domain.ChainEffectArmorPierce:
+		flags[domain.ColArmorPierce] = true
+	case domain.ChainEffectLifeSteal:
+		values[domain.ColLifeSteal] = effect.EffectValue / 100.0
+
+	// 防御強化カテゴリ
+	case domain.ChainEffectDamageCut:
+		values[domain.ColDamageCut] = effect.EffectValue / 100.0
+	case domain.ChainEffectEvasion:
+		values[domain.ColEvasion] = effect.EffectValue / 100.0
+	case domain.ChainEffectReflect:
+		values[domain.ColReflect] = effect.EffectValue / 100.0
+	case domain.ChainEffectRegen:
+		values[domain.ColRegen] = effect.EffectValue
+
+	// 回復強化カテゴリ
+	case domain.ChainEffectHealAmp:
+		values[domain.ColHealMultiplier] = 1.0 + effect.EffectValue/100.0
+	case domain.ChainEffectOverheal:
+		flags[domain.ColOverheal] = true
+
+	// タイピングカテゴリ
+	case domain.ChainEffectTimeExtend:
+		values[domain.ColTimeExtend] = effect.EffectValue
+	case domain.ChainEffectAutoCorrect:
+		values[domain.ColAutoCorrect] = effect.EffectValue
+
+	// リキャストカテゴリ
+	case domain.ChainEffectCooldownReduce:
+		values[domain.ColCooldownReduce] = effect.EffectValue / 100.0
+
+	// 特殊カテゴリ
+	case domain.ChainEffectDoubleCast:
+		values[domain.ColDoubleCast] = effect.EffectValue / 100.0
+	}
+
+	// EffectEntry を作成して登録
+	duration := chainEffectDuration
+	entry := domain.EffectEntry{
+		SourceType:  domain.SourceChain,
+		SourceID:    string(effect.Effect.Type),
+		SourceIndex: effect.SourceAgentIndex,
+		Name:        effect.Effect.Description,
+		Duration:    &duration,
+		Values:      values,
+		Flags:       flags,
+	}
+
+	player.EffectTable.AddEntry(entry)
+}
+
+// ==================== タイピング ====================
+
+// SelectModule は指定スロットのモジュールを使用し、タイピングチャレンジを開始します。
+// モジュールの難易度に応じたチャレンジを生成します。使用できない場合はfalseを返します。
+func (s *BattleSession) SelectModule(slotIndex int) bool {
+	if s.over || s.IsTyping() || !s.IsModuleUsable(slotIndex) {
+		return false
+	}
+
+	module := s.slots[slotIndex].Module
+	difficulty := typing.GetDifficultyForModuleLevel(module.Difficulty())
+	timeLimit := typing.GetDefaultTimeLimit(difficulty)
+	challenge := s.generator.Generate(difficulty, timeLimit)
+	if challenge == nil {
+		return false
+	}
+
+	s.StartChallenge(slotIndex, challenge)
+	return true
+}
+
+// StartTypingChallenge は指定スロットのモジュールでタイピングチャレンジを開始します。
+func (s *BattleSession) StartTypingChallenge(slotIndex int, text string, timeLimit time.Duration) {
+	s.StartChallenge(slotIndex, &typing.Challenge{
+		Text:      text,
+		TimeLimit: timeLimit,
+	})
+}
+
+// StartChallenge は生成済みのチャレンジで指定スロットのタイピングを開始します。
+// EffectTableからTimeExtendとAutoCorrectを取得して適用します。
+// 敵のデバフによる妨害（文字シャッフル・大文字混じりなど）もここでチャレンジに適用します。
+// ローマ字モードのチャレンジは読みに対するローマ字入力で判定されます。
+func (s *BattleSession) StartChallenge(slotIndex int, challenge *typing.Challenge) {
+	recorded := *challenge
+	s.record(ReplayEvent{Kind: ReplayEventChallenge, Slot: slotIndex, Challenge: &recorded})
+
+	s.activeSlot = slotIndex
+	s.typingStartTime = s.clock.Now()
+	// パッシブスキル使用フラグをリセット（チャレンジ毎）
+	s.typoRecoveryUsed = false
+	s.secondChanceUsed = false
+
+	effects := s.playerEffects()
+
+	// TimeExtend を適用（正負どちらも可能）
+	finalTimeLimit := challenge.TimeLimit
+	if effects.TimeExtend != 0 {
+		extension := time.Duration(effects.TimeExtend * float64(time.Second))
+		finalTimeLimit = challenge.TimeLimit + extension
+		// 最低1秒を保証
+		if finalTimeLimit < time.Second {
+			finalTimeLimit = time.Second
+		}
+	}
+
+	s.typingTimeLimit = finalTimeLimit
+	s.autoCorrectRemaining = effects.AutoCorrect
+
+	// 妨害を適用（リプレイでは妨害前のチャレンジから同じ乱数で再現される）
+	if disruption := typingDisruption(effects); disruption.IsActive() {
+		challenge = disruption.Apply(challenge, s.engine.rng)
+	}
+
+	// Evaluator用のチャレンジ状態を初期化
+	started := *challenge
+	started.TimeLimit = finalTimeLimit
+	s.typingState = s.evaluator.StartChallenge(&started)
+}
+
+// typingDisruption はプレイヤーの効果からタイピングの妨害を組み立てます。
+func typingDisruption(effects domain.EffectResult) typing.Disruption {
+	return typing.Disruption{
+		VisibleAhead:  effects.VisibleLetters,
+		Mirrored:      effects.MirrorText,
+		Shuffled:      effects.ShuffleText,
+		CaseSensitive: effects.ForceCase,
+		TimeShrink:    effects.TimeShrink,
+	}
+}
+
+// shrinkTypingTime は制限時間縮小の妨害により、経過時間に応じて制限時間を短くします。
+func (s *BattleSession) shrinkTypingTime(deltaSeconds float64) {
+	shrink := s.typingState.Challenge.Disruption.TimeShrink
+	if shrink <= 0 {
+		return
+	}
+	s.typingTimeLimit -= time.Duration(deltaSeconds * shrink * float64(time.Second))
+	if s.typingTimeLimit < 0 {
+		s.typingTimeLimit = 0
+	}
+}
+
+// ProcessTypingInput はタイピング入力を処理します。
+// AutoCorrectが有効な場合、ミスを無視します。
+// ps_typo_recoveryが発動した場合、時間を延長します。
+func (s *BattleSession) ProcessTypingInput(r rune) {
+	if s.typingState == nil || s.evaluator.IsCompleted(s.typingState) {
+		return
+	}
+	s.record(ReplayEvent{Kind: ReplayEventKey, Rune: r})
+
+	accepted := s.evaluator.Accepts(s.typingState, r)
+	// AutoCorrectが残っている場合はミスを無視（ミスを記録しない、インデックスも進めない）
+	if !accepted && s.autoCorrectRemaining > 0 {
+		s.autoCorrectRemaining--
+		return
+	}
+
+	s.typingState = s.evaluator.ProcessInput(s.typingState, r)
+
+	if !accepted {
+		if !s.IsParrying() {
+			s.tryTypoRecovery()
+		}
+		return
+	}
+	if s.evaluator.IsCompleted(s.typingState) {
+		s.CompleteTyping()
+	}
+}
+
+// tryTypoRecovery はps_typo_recoveryを評価し、発動時に制限時間を延長します（1回/チャレンジ）。
+func (s *BattleSession) tryTypoRecovery() {
+	if s.typoRecoveryUsed {
+		return
+	}
+	agent := s.slots[s.activeSlot].Agent
+	timeExtension := s.engine.EvaluateTypoRecovery(s.state, agent)
+	if timeExtension > 0 {
+		s.typoRecoveryUsed = true
+		s.typingTimeLimit += time.Duration(timeExtension * float64(time.Second))
+		s.message = fmt.Sprintf("[タイポリカバリー発動！ +%.0f秒]", timeExtension)
+		s.emitPassive(agent, fmt.Sprintf("制限時間+%.0f秒", timeExtension))
+	}
+}
+
+// CompleteTyping はタイピングを完了し、モジュール効果を適用します。
+// 勝敗の判定は次のTickで行われます。
+// パッシブスキル（ps_combo_master, ps_echo_skill, ps_miracle_heal）を統合。
+// DoubleCastが有効な場合、確率判定を行い成功すれば効果を2回適用します。
+func (s *BattleSession) CompleteTyping() {
+	if s.typingState == nil {
+		return
+	}
+	if s.IsParrying() {
+		s.completeParry()
+		return
+	}
+	s.typoRecoveryUsed = false // チャレンジ完了時にリセット
+
+	// タイピング結果を評価
+	typingResult := s.evaluator.CompleteChallenge(s.typingState)
+	s.recordKeyStats()
+	s.typingState = nil
+
+	// コンボカウントの更新（ps_combo_master用）
+	if typingResult.Accuracy >= 1.0 {
+		s.comboCount++
+	} else {
+		s.comboCount = 0
+	}
+
+	// バトル統計に記録
+	s.engine.RecordTypingResult(s.state, typingResult)
+
+	// モジュール効果を適用
+	slot := s.slots[s.activeSlot]
+	agent := slot.Agent
+	module := slot.Module
+	agentIndex := slot.AgentIndex
+	player := s.state.Player
+
+	// モジュールの効果フラグを取得
+	effectFlags := moduleEffectFlags(module)
+
+	// チェイン数を更新し、他エージェントの待機中チェイン効果を発動（モジュール効果適用前）
+	s.state.ChainLength = s.chainEffectManager.RecordModuleUse(agentIndex)
+	s.triggerChainEffects(agentIndex, effectFlags)
+
+	// DoubleCast判定（確率判定）
+	doubleCastTriggered := false
+	if effects := s.playerEffects(); effects.DoubleCast > 0 {
+		doubleCastTriggered = s.engine.rng.Float64() < effects.DoubleCast
+	}
+
+	// ps_echo_skill判定（スキル2回発動）
+	echoSkillRepeat := s.engine.EvaluateEchoSkill(s.state, agent)
+	echoSkillTriggered := echoSkillRepeat > 1
+
+	// ps_miracle_heal判定（回復スキル時HP全回復）
+	miracleHealTriggered := s.engine.EvaluateMiracleHeal(s.state, agent, module)
+
+	// クリティカル判定・ドレイン回復表示のため適用前の統計を保持
+	criticalsBefore := s.state.Stats.CriticalHits
+	healBefore := s.state.Stats.TotalHealAmount
+
+	// コンボ対応版のモジュール効果適用（ps_combo_master）
+	effectAmount := s.engine.ApplyModuleEffectWithCombo(s.state, agent, module, typingResult, s.comboCount)
+
+	// ps_echo_skill発動時は追加で効果を適用
+	for i := 1; i < echoSkillRepeat; i++ {
+		effectAmount += s.engine.ApplyModuleEffectWithCombo(s.state, agent, module, typingResult, s.comboCount)
+	}
+
+	// DoubleCast発動時は2回目も適用
+	if doubleCastTriggered {
+		effectAmount += s.engine.ApplyModuleEffectWithCombo(s.state, agent, module, typingResult, s.comboCount)
+	}
+
+	// ps_miracle_heal発動時はHP全回復
+	if miracleHealTriggered {
+		player.HP = player.MaxHP
+		s.emitPassive(agent, "HP全回復")
+	}
+	if echoSkillTriggered {
+		s.emitPassive(agent, fmt.Sprintf("%sを%d回発動", module.Name(), echoSkillRepeat))
+	}
+	if doubleCastTriggered {
+		s.emit(BattleEvent{Kind: BattleEventPassive, Source: "ダブルキャスト", Target: BattleEventPlayer, Detail: module.Name()})
+	}
+
+	// ダメージ/回復イベント
+	critical := s.state.Stats.CriticalHits > criticalsBefore
+	if effectAmount > 0 {
+		if effectFlags.HasDamage {
+			s.addDamageChange(HPChangeEnemy, effectAmount, critical)
+			// ドレイン・ライフスティールによる回復
+			if healed := s.state.Stats.TotalHealAmount - healBefore; healed > 0 {
+				s.addHPChange(HPChangePlayer, healed, true)
+			}
+		} else if effectFlags.HasHeal {
+			s.addHPChange(HPChangePlayer, effectAmount, true)
+		}
+	}
+
+	// メッセージ
+	s.message = formatEffectMessage(module, effectAmount, typingResult, effectFlags)
+	if s.comboCount > 0 {
+		s.message += fmt.Sprintf(" [コンボ:%d]", s.comboCount)
+	}
+	if s.state.ChainLength > 1 {
+		s.message += fmt.Sprintf(" [%dチェイン]", s.state.ChainLength)
+	}
+	if echoSkillTriggered {
+		s.message += " [エコースキル発動！]"
+	}
+	if miracleHealTriggered {
+		s.message += " [ミラクルヒール発動！]"
+	}
+	if doubleCastTriggered {
+		s.message += " [ダブルキャスト発動！]"
+	}
+	if critical {
+		s.message += " [クリティカル！]"
+	}
+
+	// 完璧な入力（正確性100%）で攻撃対象の敵のボルテージを下げる
+	if typingResult.Accuracy >= 1.0 && s.state.Enemy.IsAlive() {
+		if lowered := s.engine.LowerEnemyVoltage(s.state, "完璧な入力", config.PerfectAccuracyVoltageDrop); lowered > 0 {
+			s.message += fmt.Sprintf(" [ボルテージ-%.0f%%]", lowered)
+		}
+	}
+
+	// クールダウンを開始
+	s.StartCooldown(s.activeSlot, slot.CooldownTotal)
+
+	// エージェントのリキャストを開始し、チェイン効果を登録
+	s.startAgentRecast(agentIndex, module)
+
+	// フェーズ変化をチェック（全体攻撃で複数の敵のHPが減ることがあるため生存している敵全てを判定）
+	s.checkPhaseTransitions()
+}
+
+// checkPhaseTransitions は生存している敵全てのフェーズ変化を判定し、
+// 移行した敵のパッシブ切り替えと移行時行動を実行します。
+func (s *BattleSession) checkPhaseTransitions() {
+	for _, enemy := range s.state.AliveEnemies() {
+		s.state.withEnemy(enemy, func() {
+			if s.engine.CheckPhaseTransition(s.state) {
+				// 敵のパッシブを切り替え、移行時行動を実行
+				s.message += s.engine.EnterEnemyPhase(s.state)
+			}
+		})
+	}
+}
+
+// checkVoltageThresholds は生存している敵全てのボルテージ段階を判定し、
+// 新たな段階に到達した敵の激昂時行動を実行します。
+func (s *BattleSession) checkVoltageThresholds() {
+	enraged := false
+	for _, enemy := range s.state.AliveEnemies() {
+		s.state.withEnemy(enemy, func() {
+			if message := s.engine.CheckVoltageThreshold(s.state); message != "" {
+				s.message += message
+				enraged = true
+			}
+		})
+	}
+	// 激昂時行動の攻撃による敗北判定
+	if enraged {
+		s.CheckGameOver()
+	}
+}
+
+// CancelTyping はタイピングをキャンセルします。
+// パリィ中のキャンセルはパリィ失敗になります。
+func (s *BattleSession) CancelTyping() {
+	if s.typingState != nil {
+		s.record(ReplayEvent{Kind: ReplayEventCancel})
+	}
+	if s.IsParrying() {
+		s.failParry()
+		return
+	}
+	s.recordKeyStats()
+	s.typingState = nil
+	s.message = "タイピングキャンセル"
+}
+
+// ==================== パリィ ====================
+
+// ParryableEnemy はパリィ受付時間内の行動をチャージしている生存中の敵を返します（いなければnil）。
+func (s *BattleSession) ParryableEnemy() *domain.EnemyModel {
+	now := s.clock.Now()
+	for _, enemy := range s.state.AliveEnemies() {
+		if enemy.IsInParryWindow(now) {
+			return enemy
+		}
+	}
+	return nil
+}
+
+// StartParry はパリィ受付時間内の敵に対するパリィのタイピングを開始します。
+// モジュールのタイピング中であればキャンセルしてパリィに切り替えます。
+// 受付時間内の敵がいない場合やパリィ中の場合はfalseを返します。
+func (s *BattleSession) StartParry() bool {
+	if s.over || s.IsParrying() {
+		return false
+	}
+	enemy := s.ParryableEnemy()
+	if enemy == nil {
+		return false
+	}
+	challenge := s.generator.GenerateParry(enemy.GetChargeRemainingTime(s.clock.Now()))
+	if challenge == nil {
+		return false
+	}
+
+	if s.IsTyping() {
+		s.CancelTyping()
+	}
+	return s.StartParryChallenge(challenge)
+}
+
+// StartParryChallenge は生成済みのチャレンジでパリィのタイピングを開始します。
+// 制限時間は対象の敵のチャージ残り時間で、TimeExtendや妨害は適用しません。
+func (s *BattleSession) StartParryChallenge(challenge *typing.Challenge) bool {
+	enemy := s.ParryableEnemy()
+	if enemy == nil {
+		return false
+	}
+	recorded := *challenge
+	s.record(ReplayEvent{Kind: ReplayEventParry, Challenge: &recorded})
+
+	s.parryTarget = enemy
+	s.typingStartTime = s.clock.Now()
+	s.typingTimeLimit = enemy.GetChargeRemainingTime(s.typingStartTime)
+	s.autoCorrectRemaining = 0
+
+	started := *challenge
+	started.TimeLimit = s.typingTimeLimit
+	s.typingState = s.evaluator.StartChallenge(&started)
+	s.message = fmt.Sprintf("%sの%sをパリィせよ！", enemy.Name, enemy.PendingAction.Name)
+	return true
+}
+
+// completeParry はパリィのタイピングを完了し、結果を対象の敵に記録します。
+func (s *BattleSession) completeParry() {
+	enemy := s.parryTarget
+	result := s.evaluator.CompleteChallenge(s.typingState)
+	s.recordKeyStats()
+	s.typingState = nil
+	s.parryTarget = nil
+
+	parry := s.engine.ResolveParry(enemy, result)
+	s.emit(BattleEvent{Kind: BattleEventParry, Source: BattleEventPlayer, Target: enemy.Name, Detail: parry.String()})
+	switch parry {
+	case domain.ParryPerfect:
+		s.message = fmt.Sprintf("完全パリィ！ %sの行動を無効化する！", enemy.Name)
+	case domain.ParryPartial:
+		s.message = fmt.Sprintf("パリィ！ %sの攻撃を受け流す構え", enemy.Name)
+	}
+}
+
+// failParry はパリィを失敗として終了します。
+// 対象の敵がまだチャージ中であれば、その行動で受けるダメージが増加します。
+func (s *BattleSession) failParry() {
+	enemy := s.parryTarget
+	s.recordKeyStats()
+	s.typingState = nil
+	s.parryTarget = nil
+
+	if enemy.IsAlive() && enemy.WaitMode == domain.WaitModeCharging {
+		enemy.Parry = domain.ParryFailed
+	}
+	s.emit(BattleEvent{Kind: BattleEventParry, Source: BattleEventPlayer, Target: enemy.Name, Detail: domain.ParryFailed.String()})
+	s.message = "パリィ失敗！ 無防備な状態で攻撃を受ける！"
+}
+
+// recordKeyStats は現在のチャレンジの入力記録を苦手キー統計に反映します。
+func (s *BattleSession) recordKeyStats() {
+	if s.keyStats == nil || s.typingState == nil {
+		return
+	}
+	s.keyStats.RecordKeystrokes(s.typingState.Keystrokes)
+}
+
+// formatEffectMessage は効果メッセージをフォーマットします。
+func formatEffectMessage(module *domain.ModuleModel, effectAmount int, result *typing.TypingResult, flags chain.ModuleEffectFlags) string {
+	var action string
+	if flags.HasDamage {
+		action = fmt.Sprintf("%dダメージを与えた！", effectAmount)
+	} else if flags.HasHeal {
+		action = fmt.Sprintf("%d回復した！", effectAmount)
+	} else if flags.HasBuff {
+		action = fmt.Sprintf("%sを付与した！", module.Name())
+	} else if flags.HasDebuff {
+		action = fmt.Sprintf("敵に%sを付与した！", module.Name())
+	} else {
+		action = "効果を発動した！"
+	}
+
+	return fmt.Sprintf("%s (WPM:%.0f 正確性:%.0f%%)", action, result.WPM, result.Accuracy*100)
+}
+
+// moduleEffectFlags はモジュールが持つ効果の種別フラグを取得します。
+func moduleEffectFlags(module *domain.ModuleModel) chain.ModuleEffectFlags {
+	flags := chain.ModuleEffectFlags{}
+
+	for _, effect := range module.Type.Effects {
+		if effect.IsDamageEffect() {
+			flags.HasDamage = true
+		}
+		if effect.IsHealEffect() {
+			flags.HasHeal = true
+		}
+		if effect.IsBuffEffect() {
+			flags.HasBuff = true
+		}
+		if effect.IsDebuffEffect() {
+			flags.HasDebuff = true
+		}
+	}
+
+	return flags
+}
+
+// playerEffects はプレイヤーに適用中の効果の集計値を返します。
+func (s *BattleSession) playerEffects() domain.EffectResult {
+	player := s.state.Player
+	enemy := s.state.Enemy
+	if player.EffectTable == nil {
+		return domain.EffectResult{}
+	}
+	ctx := domain.NewEffectContext(player.HP, player.MaxHP, enemy.HP, enemy.MaxHP)
+	ctx.ChainLength = s.state.ChainLength
+	return player.EffectTable.Aggregate(ctx)
+}
+
+// addHPChange はHP変化イベントを追加します。
+func (s *BattleSession) addHPChange(target HPChangeTarget, amount int, isHeal bool) {
+	s.hpChanges = append(s.hpChanges, HPChangeEvent{Target: target, Amount: amount, IsHeal: isHeal})
+}
+
+// addDamageChange はクリティカル判定付きのダメージイベントを追加します。
+func (s *BattleSession) addDamageChange(target HPChangeTarget, amount int, critical bool) {
+	s.hpChanges = append(s.hpChanges, HPChangeEvent{Target: target, Amount: amount, IsCritical: critical})
+}
+
+// ==================== 攻撃対象の選択 ====================
+
+// SelectTarget は現在のウェーブのindex番目の敵を攻撃対象にします。
+// 範囲外または撃破済みの敵は選択できず、falseを返します。
+func (s *BattleSession) SelectTarget(index int) bool {
+	if s.over || !s.state.SetTarget(index) {
+		return false
+	}
+	s.record(ReplayEvent{Kind: ReplayEventTarget, Target: index})
+	return true
+}
+
+// CycleTarget は生存している敵の中で攻撃対象をdelta方向（正=右、負=左）に切り替えます。
+// 切り替え先がない場合はfalseを返します。
+func (s *BattleSession) CycleTarget(delta int) bool {
+	if s.over || !s.state.CycleTarget(delta) {
+		return false
+	}
+	s.record(ReplayEvent{Kind: ReplayEventTarget, Target: s.state.TargetIndex()})
+	return true
+}
+
+// ==================== 一時停止 ====================
+
+// Pause はバトルを一時停止します。
+// 敵のチャージ・ディフェンス、タイピングの制限時間、クールダウン・リキャスト、
+// バフ・デバフの持続時間、ボルテージの全てが停止します。
+func (s *BattleSession) Pause() {
+	if !s.IsPaused() {
+		s.record(ReplayEvent{Kind: ReplayEventPause})
+	}
+	s.clock.Pause()
+}
+
+// Resume は一時停止を解除し、停止した時点からバトルを再開します。
+func (s *BattleSession) Resume() {
+	if s.IsPaused() {
+		s.record(ReplayEvent{Kind: ReplayEventResume})
+	}
+	s.clock.Resume()
+}
+
+// TogglePause は一時停止と再開を切り替えます。
+func (s *BattleSession) TogglePause() {
+	if s.IsPaused() {
+		s.Resume()
+	} else {
+		s.Pause()
+	}
+}
+
+// IsPaused はバトルが一時停止中かどうかを返します。
+func (s *BattleSession) IsPaused() bool {
+	return s.clock.IsPaused()
+}
+
+// ==================== リプレイ記録 ====================
+
+// record は入力をバトル開始からの経過時間付きで記録します。
+func (s *BattleSession) record(event ReplayEvent) {
+	event.At = s.baseClock.Now().Sub(s.recordStart)
+	s.events = append(s.events, event)
+}
+
+// Replay はここまでの記録からリプレイを作成します。
+// 決着後に呼び出すと1戦分のリプレイになります。
+func (s *BattleSession) Replay() *Replay {
+	events := make([]ReplayEvent, len(s.events))
+	copy(events, s.events)
+
+	var duration time.Duration
+	if n := len(events); n > 0 {
+		duration = events[n-1].At
+	}
+
+	// 1対1バトルのリプレイは従来どおりEnemyのみを記録する
+	var waves [][]EnemySnapshot
+	if s.state.IsMultiEnemy() {
+		waves = s.waveSnapshots
+	}
+
+	return &Replay{
+		RecordedAt:  s.recordStart,
+		Seeds:       s.seeds,
+		Enemy:       s.enemySnapshot,
+		Waves:       waves,
+		PlayerMaxHP: s.playerMaxHP,
+		PlayerHP:    s.playerHP,
+		Agents:      s.state.EquippedAgents,
+		Events:      events,
+		Victory:     s.IsVictory(),
+		Retreated:   s.IsRetreated(),
+		Duration:    duration,
+	}
+}
+
+// ==================== 状態参照 ====================
+
+// Now はバトルの時計の現在時刻を返します。
+func (s *BattleSession) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Engine はバトルエンジンを返します。
+func (s *BattleSession) Engine() *BattleEngine {
+	return s.engine
+}
+
+// State はバトル状態を返します。
+func (s *BattleSession) State() *BattleState {
+	return s.state
+}
+
+// Enemy は敵を返します。複数敵バトルでは選択中の攻撃対象を返します。
+func (s *BattleSession) Enemy() *domain.EnemyModel {
+	return s.state.Enemy
+}
+
+// Enemies は現在のウェーブの敵を返します（撃破済みを含む）。
+func (s *BattleSession) Enemies() []*domain.EnemyModel {
+	return s.state.CurrentEnemies()
+}
+
+// Leader は最後のウェーブの先頭の敵（リーダー）を返します。
+func (s *BattleSession) Leader() *domain.EnemyModel {
+	return s.state.Leader()
+}
+
+// Wave は現在のウェーブのインデックス（0始まり）を返します。
+func (s *BattleSession) Wave() int {
+	return s.state.Wave
+}
+
+// WaveCount はウェーブの総数を返します。
+func (s *BattleSession) WaveCount() int {
+	return s.state.WaveCount()
+}
+
+// IsMultiEnemy は複数敵またはウェーブ戦のバトルかを返します。
+func (s *BattleSession) IsMultiEnemy() bool {
+	return s.state.IsMultiEnemy()
+}
+
+// TargetIndex は選択中の攻撃対象の現在のウェーブ内でのインデックスを返します。
+func (s *BattleSession) TargetIndex() int {
+	return s.state.TargetIndex()
+}
+
+// ExpectedDamage は敵の次の攻撃の予想ダメージ（バフ・デバフ反映済み）を返します。
+func (s *BattleSession) ExpectedDamage(enemy *domain.EnemyModel) int {
+	damage := 0
+	s.state.withEnemy(enemy, func() {
+		damage = s.engine.GetExpectedDamage(s.state)
+	})
+	return damage
+}
+
+// EncounteredEnemyLevels はこれまでに出現した敵の敵タイプIDごとの最高レベルを返します（敵図鑑・撃破記録用）。
+func (s *BattleSession) EncounteredEnemyLevels() map[string]int {
+	levels := make(map[string]int)
+	for _, enemy := range s.state.SpawnedEnemies() {
+		if enemy.Level > levels[enemy.Type.ID] {
+			levels[enemy.Type.ID] = enemy.Level
+		}
+	}
+	return levels
+}
+
+// Player はプレイヤーを返します。
+func (s *BattleSession) Player() *domain.PlayerModel {
+	return s.state.Player
+}
+
+// Agents は装備中のエージェントを返します。
+func (s *BattleSession) Agents() []*domain.AgentModel {
+	return s.state.EquippedAgents
+}
+
+// Slots はモジュールスロットを返します。
+func (s *BattleSession) Slots() []ModuleSlot {
+	return s.slots
+}
+
+// RecastManager はリキャスト管理を返します。
+func (s *BattleSession) RecastManager() *recast.RecastManager {
+	return s.recastManager
+}
+
+// ChainEffectManager はチェイン効果管理を返します。
+func (s *BattleSession) ChainEffectManager() *chain.ChainEffectManager {
+	return s.chainEffectManager
+}
+
+// ComboCount はミスなし連続タイピング回数を返します。
+func (s *BattleSession) ComboCount() int {
+	return s.comboCount
+}
+
+// FirstStrikeAgentIndex はps_first_strikeが発動したエージェントのインデックスを返します（未発動は-1）。
+func (s *BattleSession) FirstStrikeAgentIndex() int {
+	return s.firstStrikeAgentIndex
+}
+
+// Message は直近のバトルメッセージを返します。
+func (s *BattleSession) Message() string {
+	return s.message
+}
+
+// SetCalculationTrace はダメージ・回復量の計算トレースをバトルログに記録するかを設定します。
+func (s *BattleSession) SetCalculationTrace(enabled bool) {
+	s.engine.SetCalculationTrace(enabled)
+}
+
+// BattleLog はバトル中に発生した出来事を記録したバトルログを返します。
+func (s *BattleSession) BattleLog() *BattleLog {
+	return s.log
+}
+
+// TakeHPChanges は前回の呼び出し以降に発生したHP変化イベントを返し、記録をクリアします。
+func (s *BattleSession) TakeHPChanges() []HPChangeEvent {
+	changes := s.hpChanges
+	s.hpChanges = nil
+	return changes
+}
+
+// IsOver はバトルが決着したかを返します。
+func (s *BattleSession) IsOver() bool {
+	return s.over
+}
+
+// IsVictory は勝利したかを返します。
+func (s *BattleSession) IsVictory() bool {
+	return s.over && s.victory
+}
+
+// IsRetreated は撤退によってバトルが終了したかを返します。
+func (s *BattleSession) IsRetreated() bool {
+	return s.over && s.retreated
+}
+
+// Result はバトル結果を返します。
+func (s *BattleSession) Result() *BattleResult {
+	return &BattleResult{
+		IsVictory:   s.IsVictory(),
+		IsRetreated: s.IsRetreated(),
+		Stats:       s.state.Stats,
+	}
+}
+
+// IsTyping はタイピングチャレンジ中かを返します。
+func (s *BattleSession) IsTyping() bool {
+	return s.typingState != nil
+}
+
+// TypingState は現在のチャレンジ状態を返します（タイピング中でなければnil）。
+func (s *BattleSession) TypingState() *typing.ChallengeState {
+	return s.typingState
+}
+
+// AcceptsTypingInput は入力が現在のチャレンジで正しい入力として受理されるかを返します（状態は変更しません）。
+func (s *BattleSession) AcceptsTypingInput(r rune) bool {
+	if s.typingState == nil {
+		return false
+	}
+	return s.evaluator.Accepts(s.typingState, r)
+}
+
+// IsParrying はパリィのタイピング中かを返します。
+func (s *BattleSession) IsParrying() bool {
+	return s.parryTarget != nil
+}
+
+// ParryTarget はパリィ中の対象の敵を返します（パリィ中でなければnil）。
+func (s *BattleSession) ParryTarget() *domain.EnemyModel {
+	return s.parryTarget
+}
+
+// ActiveSlot はタイピング中（または直前）に使用したモジュールスロットのインデックスを返します。
+func (s *BattleSession) ActiveSlot() int {
+	return s.activeSlot
+}
+
+// TypingText は現在のチャレンジの表示テキストを返します。
+func (s *BattleSession) TypingText() string {
+	if s.typingState == nil {
+		return ""
+	}
+	return s.typingState.Challenge.Text
+}
+
+// TypingIndex は現在のチャレンジの入力済み単位数を返します。
+func (s *BattleSession) TypingIndex() int {
+	if s.typingState == nil {
+		return 0
+	}
+	return s.typingState.CurrentIndex
+}
+
+// TypingDisruption は現在のチャレンジに適用されている妨害を返します。
+func (s *BattleSession) TypingDisruption() typing.Disruption {
+	if s.typingState == nil {
+		return typing.Disruption{}
+	}
+	return s.typingState.Challenge.Disruption
+}
+
+// TypingMistakes は現在のチャレンジの誤入力位置を返します。
+func (s *BattleSession) TypingMistakes() []int {
+	if s.typingState == nil {
+		return nil
+	}
+	return s.typingState.Mistakes
+}
+
+// TypingLength は現在のチャレンジの入力単位数を返します。
+func (s *BattleSession) TypingLength() int {
+	if s.typingState == nil {
+		return 0
+	}
+	return s.typingState.Challenge.Length()
+}
+
+// TypingTimeLimit は現在のチャレンジの制限時間を返します（効果・パッシブによる延長込み）。
+func (s *BattleSession) TypingTimeLimit() time.Duration {
+	return s.typingTimeLimit
+}
+
+// TypingRemaining は現在のチャレンジの残り時間を返します。
+func (s *BattleSession) TypingRemaining() time.Duration {
+	remaining := s.typingTimeLimit - clock.Since(s.clock, s.typingStartTime)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// RomajiMatcher は現在のチャレンジのローマ字判定を返します（ローマ字モード以外ではnil）。
+func (s *BattleSession) RomajiMatcher() *typing.RomajiMatcher {
+	if s.typingState == nil {
+		return nil
+	}
+	return s.typingState.Romaji()
+}
@@ -0,0 +1,340 @@
+// Package combat はバトルエンジンを提供します。
+// session_test.go はTUIを介さないリアルタイムバトル進行のテストです。
+
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+)
+
+// newTestSession は手動時計を設定したテスト用バトルセッションを作成します。
+func newTestSession(enemyHP, enemyAttack int, chargeTime time.Duration) (*BattleSession, *clock.ManualClock) {
+	enemyType := domain.EnemyType{
+		ID:              "slime",
+		Name:            "スライム",
+		BaseHP:          enemyHP,
+		BaseAttackPower: enemyAttack,
+		AttackType:      "physical",
+		ResolvedNormalActions: []domain.EnemyAction{
+			{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: chargeTime},
+		},
+	}
+	enemy := domain.NewEnemy("enemy_001", "スライム", 1, enemyHP, enemyAttack, enemyType)
+
+	player := domain.NewPlayer()
+	player.MaxHP = 100
+	player.HP = 100
+
+	session := NewBattleSession(enemy, player, newTestSessionAgents(), nil)
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	session.SetClock(c)
+	session.Start()
+	return session, c
+}
+
+// newTestSessionAgents はテスト用バトルセッションの装備エージェントを作成します。
+func newTestSessionAgents() []*domain.AgentModel {
+	coreType := domain.CoreType{
+		ID:          "all_rounder",
+		Name:        "オールラウンダー",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		AllowedTags: []string{"physical_low"},
+	}
+	core := domain.NewCore("core_001", "コア", 10, coreType, domain.PassiveSkill{})
+	modules := []*domain.ModuleModel{
+		newTestDamageModule("m1", "物理攻撃", []string{"physical_low"}, 1.0, "STR", ""),
+		newTestDamageModule("m2", "物理攻撃", []string{"physical_low"}, 1.0, "STR", ""),
+	}
+	for _, module := range modules {
+		module.Type.CooldownSeconds = 2.0
+	}
+	return []*domain.AgentModel{domain.NewAgent("agent_001", core, modules)}
+}
+
+// advance は時計とバトルを指定時間だけ進めます。
+func advance(session *BattleSession, c *clock.ManualClock, d time.Duration) {
+	c.Advance(d)
+	session.Tick(d)
+}
+
+// TestBattleSession_HeadlessVictory はモジュール使用とタイピングだけで1戦を最後まで実行できることをテストします。
+func TestBattleSession_HeadlessVictory(t *testing.T) {
+	session, c := newTestSession(60, 1, 30*time.Second)
+	tick := 100 * time.Millisecond
+
+	for i := 0; i < 1000 && !session.IsOver(); i++ {
+		if !session.IsTyping() {
+			for slot := range session.Slots() {
+				if session.SelectModule(slot) {
+					break
+				}
+			}
+		}
+		if session.IsTyping() {
+			for _, r := range session.TypingText() {
+				session.ProcessTypingInput(r)
+			}
+		}
+		advance(session, c, tick)
+	}
+
+	if !session.IsOver() {
+		t.Fatal("バトルが決着しませんでした")
+	}
+	if !session.IsVictory() {
+		t.Errorf("勝利していません: 敵HP %d, プレイヤーHP %d", session.Enemy().HP, session.Player().HP)
+	}
+
+	result := session.Result()
+	if !result.IsVictory {
+		t.Error("バトル結果が勝利になっていません")
+	}
+	if result.Stats.TotalTypingCount == 0 {
+		t.Error("タイピング結果が統計に記録されていません")
+	}
+
+	var enemyDamage int
+	for _, change := range session.TakeHPChanges() {
+		if change.Target == HPChangeEnemy && !change.IsHeal {
+			enemyDamage += change.Amount
+		}
+	}
+	if enemyDamage < 60 {
+		t.Errorf("敵へのダメージイベント合計: 期待 60以上, 実際 %d", enemyDamage)
+	}
+	if len(session.TakeHPChanges()) != 0 {
+		t.Error("取得済みのHP変化イベントが残っています")
+	}
+}
+
+// TestBattleSession_HeadlessDefeat は敵のチャージ完了で攻撃を受けて敗北することをテストします。
+func TestBattleSession_HeadlessDefeat(t *testing.T) {
+	session, c := newTestSession(1000, 500, 2*time.Second)
+
+	advance(session, c, time.Second)
+	if session.Player().HP != 100 {
+		t.Fatal("チャージ完了前に敵が攻撃しました")
+	}
+
+	advance(session, c, time.Second)
+	if session.Player().HP > 0 {
+		t.Fatalf("敵の攻撃後もHPが残っています: %d", session.Player().HP)
+	}
+	if !session.IsOver() || session.IsVictory() {
+		t.Error("敗北判定になっていません")
+	}
+
+	changes := session.TakeHPChanges()
+	if len(changes) != 1 || changes[0].Target != HPChangePlayer || changes[0].IsHeal {
+		t.Errorf("プレイヤーへのダメージイベント: 実際 %+v", changes)
+	}
+
+	// 決着後は時間を進めても何も起きない
+	advance(session, c, time.Minute)
+	if session.Message() != "敗北..." {
+		t.Errorf("決着後のメッセージ: 期待 敗北..., 実際 %s", session.Message())
+	}
+}
+
+// TestBattleSession_Retreat は撤退で敗北とは区別して終了し、ここまでの統計が残ることをテストします。
+func TestBattleSession_Retreat(t *testing.T) {
+	session, c := newTestSession(1000, 1, 30*time.Second)
+
+	session.SelectModule(0)
+	for _, r := range session.TypingText() {
+		session.ProcessTypingInput(r)
+	}
+	advance(session, c, time.Second)
+	session.SelectModule(1)
+
+	if !session.Retreat() {
+		t.Fatal("撤退できませんでした")
+	}
+	if !session.IsOver() || session.IsVictory() || !session.IsRetreated() {
+		t.Errorf("撤退後の状態: 終了 %v, 勝利 %v, 撤退 %v", session.IsOver(), session.IsVictory(), session.IsRetreated())
+	}
+	if session.IsTyping() {
+		t.Error("撤退後もタイピング中になっています")
+	}
+	result := session.Result()
+	if !result.IsRetreated || result.Stats.TotalTypingCount != 1 {
+		t.Errorf("撤退時のバトル結果: 実際 %+v（統計 %+v）", result, *result.Stats)
+	}
+
+	// 決着後は撤退できない
+	if session.Retreat() {
+		t.Error("決着後に撤退できてしまいました")
+	}
+
+	// リプレイでも撤退が再現される
+	replay := session.Replay()
+	player := NewReplayPlayer(replay, replay.NewSession(nil))
+	player.Start()
+	for i := 0; i < 1000 && !player.Done(); i++ {
+		player.Advance(100 * time.Millisecond)
+	}
+	if !player.Session().IsRetreated() {
+		t.Error("リプレイで撤退が再現されていません")
+	}
+}
+
+// TestBattleSession_HeadlessDefeatIsNotRetreat は敗北が撤退として扱われないことをテストします。
+func TestBattleSession_HeadlessDefeatIsNotRetreat(t *testing.T) {
+	session, c := newTestSession(1000, 500, time.Second)
+	advance(session, c, time.Second)
+	if !session.IsOver() || session.IsRetreated() {
+		t.Errorf("敗北の状態: 終了 %v, 撤退 %v", session.IsOver(), session.IsRetreated())
+	}
+}
+
+// TestBattleSession_TypingTimeout はタイピングの制限時間切れをテストします。
+func TestBattleSession_TypingTimeout(t *testing.T) {
+	session, c := newTestSession(1000, 1, 30*time.Second)
+	session.StartTypingChallenge(0, "test", time.Second)
+
+	advance(session, c, 500*time.Millisecond)
+	if !session.IsTyping() {
+		t.Fatal("制限時間前にタイピングが終了しました")
+	}
+	if remaining := session.TypingRemaining(); remaining != 500*time.Millisecond {
+		t.Errorf("残り時間: 期待 500ms, 実際 %v", remaining)
+	}
+
+	advance(session, c, 500*time.Millisecond)
+	if session.IsTyping() {
+		t.Error("制限時間切れ後もタイピング中です")
+	}
+	if session.Message() != "タイムアップ！" {
+		t.Errorf("メッセージ: 期待 タイムアップ！, 実際 %s", session.Message())
+	}
+}
+
+// TestBattleSession_RecastBlocksSelection はリキャスト中のエージェントのモジュールを選択できないことをテストします。
+func TestBattleSession_RecastBlocksSelection(t *testing.T) {
+	session, c := newTestSession(1000, 1, 30*time.Second)
+
+	session.StartTypingChallenge(0, "a", 10*time.Second)
+	session.ProcessTypingInput('a')
+	if session.IsTyping() {
+		t.Fatal("入力完了後もタイピング中です")
+	}
+
+	if session.SelectModule(1) {
+		t.Error("リキャスト中のエージェントのモジュールが選択できました")
+	}
+
+	// リキャストが終わると再び選択できる
+	for i := 0; i < 100 && !session.RecastManager().IsAgentReady(0); i++ {
+		advance(session, c, 100*time.Millisecond)
+	}
+	if !session.SelectModule(1) {
+		t.Error("リキャスト終了後もモジュールを選択できません")
+	}
+}
+
+// TestBattleSession_PauseStopsTick は一時停止中にTickしてもバトルが進まないことをテストします。
+func TestBattleSession_PauseStopsTick(t *testing.T) {
+	session, c := newTestSession(1000, 500, 2*time.Second)
+	session.Slots()[0].CooldownRemaining = 3.0
+
+	session.Pause()
+	advance(session, c, time.Minute)
+
+	if session.Player().HP != 100 {
+		t.Error("一時停止中に敵が攻撃しました")
+	}
+	if session.Slots()[0].CooldownRemaining != 3.0 {
+		t.Errorf("一時停止中にクールダウンが進みました: %f", session.Slots()[0].CooldownRemaining)
+	}
+
+	session.Resume()
+	advance(session, c, time.Second)
+	if session.Slots()[0].CooldownRemaining != 2.0 {
+		t.Errorf("再開後のクールダウン: 期待 2.0, 実際 %f", session.Slots()[0].CooldownRemaining)
+	}
+}
+
+// TestBattleSession_StunAndSilence はスタン中のエージェントのリキャストが止まり、スタン・沈黙中はモジュールを選択できないことをテストします。
+func TestBattleSession_StunAndSilence(t *testing.T) {
+	session, c := newTestSession(1000, 1, 30*time.Second)
+	session.StartTypingChallenge(0, "a", 10*time.Second)
+	session.ProcessTypingInput('a')
+
+	table := session.Player().EffectTable
+	table.AddAilment(domain.ColStun, 0, 3.0, 0)
+	remaining := session.RecastManager().GetRecastState(0).RemainingSeconds
+
+	advance(session, c, time.Second)
+	if got := session.RecastManager().GetRecastState(0).RemainingSeconds; got != remaining {
+		t.Errorf("スタン中はリキャストが進行しないべき: %f → %f", remaining, got)
+	}
+
+	// リキャストが終わってもスタン中は選択できない
+	session.RecastManager().CancelRecast(0)
+	if session.SelectModule(1) {
+		t.Error("スタン中のエージェントのモジュールが選択できました")
+	}
+
+	table.RemoveAilments()
+	table.AddAilment(domain.ColSilence, 0, 1.0, 0)
+	if session.SelectModule(1) {
+		t.Error("沈黙中のエージェントのモジュールが選択できました")
+	}
+
+	// 沈黙が切れると再び選択できる
+	advance(session, c, 1500*time.Millisecond)
+	if !session.SelectModule(1) {
+		t.Error("沈黙終了後もモジュールを選択できません")
+	}
+}
+
+// TestBattleSession_StunnedEnemyDelaysCharge はスタン中の敵のチャージが進行しないことをテストします。
+func TestBattleSession_StunnedEnemyDelaysCharge(t *testing.T) {
+	session, c := newTestSession(1000, 500, 2*time.Second)
+	session.Enemy().EffectTable.AddAilment(domain.ColStun, 0, 3.0, -1)
+
+	advance(session, c, 3*time.Second)
+	if session.Player().HP != 100 {
+		t.Fatal("スタン中の敵が攻撃しました")
+	}
+
+	advance(session, c, 2*time.Second)
+	if session.Player().HP == 100 {
+		t.Error("スタン終了後にチャージが完了して攻撃すべき")
+	}
+}
+
+// TestBattleSession_TypingDisruption は敵のデバフによる妨害がチャレンジの出題と制限時間に反映されることをテストします。
+func TestBattleSession_TypingDisruption(t *testing.T) {
+	session, c := newTestSession(1000, 1, 30*time.Second)
+	session.Player().EffectTable.AddDebuff("妨害", 30.0, map[domain.EffectColumn]float64{
+		domain.ColShuffleText: 1.0,
+		domain.ColTimeShrink:  1.0,
+		domain.ColHideLetters: 2.0,
+	})
+
+	session.StartTypingChallenge(0, "abcdef", 10*time.Second)
+	if session.TypingText() == "abcdef" {
+		t.Error("文字シャッフルでテキストが並べ替えられるべき")
+	}
+	disruption := session.TypingDisruption()
+	if !disruption.Shuffled || disruption.VisibleAhead != 2 || disruption.TimeShrink != 1.0 {
+		t.Errorf("チャレンジに妨害が記録されるべき: %+v", disruption)
+	}
+
+	// 1秒あたり1秒縮むため、2秒経過で残り時間は6秒
+	advance(session, c, 2*time.Second)
+	if remaining := session.TypingRemaining(); remaining != 6*time.Second {
+		t.Errorf("制限時間縮小後の残り時間: 期待 6s, 実際 %v", remaining)
+	}
+
+	// 縮小により実経過時間が元の制限時間より前でも時間切れになる
+	advance(session, c, 3*time.Second)
+	if session.IsTyping() {
+		t.Error("縮んだ制限時間を過ぎたら時間切れになるべき")
+	}
+}
@@ -56,6 +56,26 @@ func (m *VoltageManager) Update(enemy *domain.EnemyModel, deltaSeconds float64)
 	enemy.SetVoltage(newVoltage)
 }
 
+// Lower はボルテージを指定量だけ下げ、実際に下がった量を返します。
+// ボルテージは初期値（100%）を下回りません。
+func (m *VoltageManager) Lower(enemy *domain.EnemyModel, amount float64) float64 {
+	if enemy == nil || amount <= 0 {
+		return 0
+	}
+
+	newVoltage := enemy.GetVoltage() - amount
+	if newVoltage < VoltageInitial {
+		newVoltage = VoltageInitial
+	}
+	lowered := enemy.GetVoltage() - newVoltage
+	if lowered <= 0 {
+		return 0
+	}
+
+	enemy.SetVoltage(newVoltage)
+	return lowered
+}
+
 // Reset はボルテージを100%にリセットします。
 func (m *VoltageManager) Reset(enemy *domain.EnemyModel) {
 	if enemy == nil {
@@ -198,3 +198,24 @@ func TestVoltageManager_Reset_NilEnemy(t *testing.T) {
 	// panicしないことを確認
 	manager.Reset(nil)
 }
+
+// TestVoltageManager_Lower はボルテージの低下と初期値での下限をテストします。
+func TestVoltageManager_Lower(t *testing.T) {
+	enemy := domain.NewEnemy("1", "テスト敵", 1, 100, 10, domain.EnemyType{ID: "test_enemy"})
+	enemy.SetVoltage(150.0)
+
+	manager := NewVoltageManager()
+
+	if lowered := manager.Lower(enemy, 30.0); lowered != 30.0 || enemy.GetVoltage() != 120.0 {
+		t.Errorf("expected lowered 30.0 to 120.0, got lowered %.1f to %.1f", lowered, enemy.GetVoltage())
+	}
+
+	// 初期値（100%）を下回らない
+	if lowered := manager.Lower(enemy, 50.0); lowered != 20.0 || enemy.GetVoltage() != VoltageInitial {
+		t.Errorf("expected lowered 20.0 to %.1f, got lowered %.1f to %.1f", VoltageInitial, lowered, enemy.GetVoltage())
+	}
+
+	if lowered := manager.Lower(enemy, 10.0); lowered != 0 {
+		t.Errorf("expected no change at initial voltage, got lowered %.1f", lowered)
+	}
+}
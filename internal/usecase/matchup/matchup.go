@@ -0,0 +1,292 @@
+// Package matchup はバトル開始前の相性分析を提供します。
+// matchup.go は装備エージェントと敵タイプから推定DPS・撃破時間・耐久時間を見積もります。
+// 見積もりは制限時間ちょうど・正確性100%で入力した場合（速度係数・正確性係数1.0）を基準とし、
+// クリティカル・チェイン・パッシブスキルは含みません。
+package matchup
+
+import (
+	"fmt"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/spawning"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// ModuleEstimate はモジュール1つの見積もりです。
+type ModuleEstimate struct {
+	// Module は対象のモジュールです。
+	Module *domain.ModuleModel
+
+	// RawDamage は敵の軽減・属性相性を適用する前の1回あたりの期待ダメージです。
+	RawDamage float64
+
+	// Damage は敵のパッシブ・属性相性を適用した1回あたりの期待ダメージです。
+	Damage float64
+
+	// TypingSeconds はタイピングにかかる時間（難易度ごとの制限時間）です。
+	TypingSeconds float64
+
+	// CycleSeconds はタイピングとリキャストを合わせた1回の使用間隔です。
+	CycleSeconds float64
+
+	// DPS は1秒あたりの期待ダメージです。
+	DPS float64
+
+	// Weakened は敵のパッシブまたは耐性でダメージが減るかを表します。
+	Weakened bool
+
+	// Notes はダメージが増減する理由です（例: "ぷるぷるボディ 被ダメ軽減50%"）。
+	Notes []string
+}
+
+// AgentEstimate はエージェント1体の見積もりです。
+type AgentEstimate struct {
+	// Agent は対象のエージェントです。
+	Agent *domain.AgentModel
+
+	// Modules はダメージを与えるモジュールの見積もりです。
+	Modules []ModuleEstimate
+
+	// Best はDPSが最も高いモジュールのModulesでのインデックスです（-1は攻撃手段なし）。
+	// 使用したモジュールのリキャスト中はエージェント全体が使えないため、最良のモジュールを使い続ける想定です。
+	Best int
+
+	// DPS はエージェントの推定DPS（最良のモジュールのDPS）です。
+	DPS float64
+}
+
+// Analysis は装備エージェントと敵の相性分析の結果です。
+type Analysis struct {
+	// EnemyLevel は分析した敵のレベルです。
+	EnemyLevel int
+
+	// EnemyHP は敵の最大HPです。
+	EnemyHP int
+
+	// EnemyDPS は行動ルール（なければ行動パターン1周分）から見積もった敵の1秒あたりのダメージです。
+	EnemyDPS float64
+
+	// PlayerMaxHP は装備エージェントから計算したプレイヤーの最大HPです。
+	PlayerMaxHP int
+
+	// Agents はエージェントごとの見積もりです。
+	Agents []AgentEstimate
+
+	// TeamDPS は全エージェントを合わせた推定DPSです。
+	// タイピングは同時に1つしかできないため、タイピング時間の合計が1秒あたり1秒を超える場合は按分します。
+	TeamDPS float64
+
+	// TimeToKill は敵の撃破までの推定秒数です（0は撃破できない）。
+	TimeToKill float64
+
+	// TimeToDie はプレイヤーが倒されるまでの推定秒数です（0は被ダメージなし）。
+	TimeToDie float64
+}
+
+// Analyze は装備エージェントと指定レベルの敵タイプの相性を分析します。
+// 敵のパッシブ・行動パターンは最初のフェーズのものを使用します。
+func Analyze(agents []*domain.AgentModel, enemyType domain.EnemyType, level int) *Analysis {
+	enemy := spawning.NewEnemyGenerator([]domain.EnemyType{enemyType}).GenerateWithType(level, enemyType.ID)
+	passive := enemy.GetPhaseDef().Passive
+
+	analysis := &Analysis{
+		EnemyLevel:  enemy.Level,
+		EnemyHP:     enemy.MaxHP,
+		EnemyDPS:    estimateEnemyDPS(enemy, passive),
+		PlayerMaxHP: domain.CalculateMaxHP(agents),
+	}
+
+	typingShare := 0.0
+	for _, agent := range agents {
+		if agent == nil {
+			continue
+		}
+		estimate := estimateAgent(agent, enemy.Type.ElementAffinity, passive)
+		analysis.Agents = append(analysis.Agents, estimate)
+		if estimate.Best >= 0 {
+			best := estimate.Modules[estimate.Best]
+			analysis.TeamDPS += estimate.DPS
+			typingShare += best.TypingSeconds / best.CycleSeconds
+		}
+	}
+	if typingShare > 1.0 {
+		analysis.TeamDPS /= typingShare
+	}
+
+	if analysis.TeamDPS > 0 {
+		analysis.TimeToKill = float64(analysis.EnemyHP) / analysis.TeamDPS
+	}
+	if analysis.EnemyDPS > 0 {
+		analysis.TimeToDie = float64(analysis.PlayerMaxHP) / analysis.EnemyDPS
+	}
+	return analysis
+}
+
+// IsFavorable は倒されるより先に敵を撃破できる見込みかを返します。
+func (a *Analysis) IsFavorable() bool {
+	if a.TimeToKill <= 0 {
+		return false
+	}
+	return a.TimeToDie <= 0 || a.TimeToKill < a.TimeToDie
+}
+
+// estimateAgent はエージェントのダメージを与えるモジュールを見積もります。
+func estimateAgent(agent *domain.AgentModel, affinity domain.ElementAffinity, passive *domain.EnemyPassiveSkill) AgentEstimate {
+	estimate := AgentEstimate{Agent: agent, Best: -1}
+	for _, module := range agent.Modules {
+		if module == nil {
+			continue
+		}
+		moduleEstimate, ok := estimateModule(module, agent.BaseStats, affinity, passive)
+		if !ok {
+			continue
+		}
+		estimate.Modules = append(estimate.Modules, moduleEstimate)
+		if moduleEstimate.DPS > estimate.DPS {
+			estimate.DPS = moduleEstimate.DPS
+			estimate.Best = len(estimate.Modules) - 1
+		}
+	}
+	return estimate
+}
+
+// estimateModule はモジュールの1回あたりの期待ダメージとDPSを見積もります。
+// 敵にダメージを与える効果を持たないモジュールの場合はfalseを返します。
+func estimateModule(module *domain.ModuleModel, stats domain.Stats, affinity domain.ElementAffinity, passive *domain.EnemyPassiveSkill) (ModuleEstimate, bool) {
+	estimate := ModuleEstimate{Module: module}
+	dealsDamage := false
+
+	for _, effect := range module.Effects() {
+		if effect.HPFormula == nil || effect.Target == domain.TargetSelf {
+			continue
+		}
+		dealsDamage = true
+
+		raw := float64(absInt(effect.CalculateHPChange(stats))) * effect.AdjustedProbability(stats.LUK)
+		damage, notes := applyEnemyDefense(raw, effect.Element, affinity, passive)
+		estimate.RawDamage += raw
+		estimate.Damage += damage
+		estimate.Notes = appendUnique(estimate.Notes, notes...)
+	}
+	if !dealsDamage {
+		return estimate, false
+	}
+
+	estimate.Weakened = estimate.Damage < estimate.RawDamage
+	estimate.TypingSeconds = typing.GetDefaultTimeLimit(typing.GetDifficultyForModuleLevel(module.Difficulty())).Seconds()
+	estimate.CycleSeconds = estimate.TypingSeconds + module.CooldownSeconds()
+	if estimate.CycleSeconds > 0 {
+		estimate.DPS = estimate.Damage / estimate.CycleSeconds
+	}
+	return estimate, true
+}
+
+// applyEnemyDefense は敵のパッシブの被ダメ軽減・属性別軽減と属性相性をダメージに適用し、増減の理由を返します。
+func applyEnemyDefense(damage float64, element domain.Element, affinity domain.ElementAffinity, passive *domain.EnemyPassiveSkill) (float64, []string) {
+	var notes []string
+
+	if passive != nil {
+		if cut := passive.Effects[domain.ColDamageCut]; cut > 0 {
+			damage *= 1.0 - clampRate(cut)
+			notes = append(notes, fmt.Sprintf("%s 被ダメ軽減%.0f%%", passive.Name, cut*100))
+		}
+		if col, ok := domain.ElementCutColumn(element); ok {
+			if cut := passive.Effects[col]; cut > 0 {
+				damage *= 1.0 - clampRate(cut)
+				notes = append(notes, fmt.Sprintf("%s %s軽減%.0f%%", passive.Name, element, cut*100))
+			}
+		}
+	}
+
+	if rate := affinity.Multiplier(element); rate != 1.0 {
+		damage *= rate
+		if rate < 1.0 {
+			notes = append(notes, fmt.Sprintf("%s耐性 ×%g", element, rate))
+		} else {
+			notes = append(notes, fmt.Sprintf("%s弱点 ×%g", element, rate))
+		}
+	}
+	return damage, notes
+}
+
+// estimateEnemyDPS は敵の攻撃ダメージをチャージ時間の合計で割って見積もります。
+// 行動ルールを持つ敵はバトル開始時の候補（条件なしルール）を重みで加重平均し、
+// 候補がなければ行動パターン1周分を使います（行動選択で行動パターンにフォールバックするのと同じ）。
+// パッシブのダメージ倍率を適用し、プレイヤー側の軽減は含みません。
+func estimateEnemyDPS(enemy *domain.EnemyModel, passive *domain.EnemyPassiveSkill) float64 {
+	multiplier := 1.0
+	if passive != nil {
+		if mult, ok := passive.Effects[domain.ColDamageMultiplier]; ok && mult > 0 {
+			multiplier = mult
+		}
+	}
+
+	var totalDamage, totalSeconds float64
+	add := func(action domain.EnemyAction, weight float64) {
+		totalSeconds += action.ChargeTime.Seconds() * weight
+		if action.IsAttack() {
+			totalDamage += (action.DamageBase + float64(enemy.Level)*action.DamagePerLevel) * multiplier * weight
+		}
+	}
+
+	if candidates := openingRuleCandidates(enemy); len(candidates) > 0 {
+		for _, rule := range candidates {
+			add(rule.Action, float64(rule.GetWeight()))
+		}
+	} else {
+		for _, action := range enemy.GetCurrentPattern() {
+			add(action, 1.0)
+		}
+	}
+	if totalSeconds <= 0 {
+		return 0
+	}
+	return totalDamage / totalSeconds
+}
+
+// openingRuleCandidates はバトル開始時に選ばれうる行動ルール（現在のフェーズで有効な条件なしルール）を返します。
+func openingRuleCandidates(enemy *domain.EnemyModel) []domain.EnemyActionRule {
+	var candidates []domain.EnemyActionRule
+	for _, rule := range enemy.GetActionRules() {
+		if rule.Condition == nil {
+			candidates = append(candidates, rule)
+		}
+	}
+	return candidates
+}
+
+// clampRate は軽減率を0.0〜1.0に収めます。
+func clampRate(rate float64) float64 {
+	if rate < 0 {
+		return 0
+	}
+	if rate > 1.0 {
+		return 1.0
+	}
+	return rate
+}
+
+// absInt は整数の絶対値を返します。
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// appendUnique は重複を除いて要素を追加します。
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,182 @@
+// Package matchup はバトル開始前の相性分析を提供します。
+// matchup_test.go は相性分析の見積もりのテストです。
+package matchup
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+)
+
+// newTestSlimeType は被ダメ軽減パッシブと炎弱点を持つテスト用の敵タイプを作成します。
+// レベルLの敵HPは50×L、行動パターン1周（3秒）の攻撃ダメージは5+L です。
+func newTestSlimeType() domain.EnemyType {
+	return domain.EnemyType{
+		ID:              "slime",
+		Name:            "スライム",
+		BaseHP:          50,
+		BaseAttackPower: 5,
+		AttackType:      "physical",
+		ElementAffinity: domain.ElementAffinity{domain.ElementFire: 1.5},
+		NormalPassive: &domain.EnemyPassiveSkill{
+			ID:      "slime_normal",
+			Name:    "ぷるぷるボディ",
+			Effects: map[domain.EffectColumn]float64{domain.ColDamageCut: 0.5},
+		},
+		ResolvedNormalActions: []domain.EnemyAction{
+			{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", DamageBase: 5, DamagePerLevel: 1, ChargeTime: time.Second},
+			{ID: "defense", ActionType: domain.EnemyActionDefense, DefenseType: domain.DefensePhysicalCut, ReductionRate: 0.4, Duration: 3},
+			{ID: "buff", ActionType: domain.EnemyActionBuff, EffectType: "damage_mult", EffectValue: 1.3, ChargeTime: 2 * time.Second},
+		},
+	}
+}
+
+// newTestModule は難易度1（タイピング5秒）・リキャスト5秒のテスト用モジュールを作成します。
+func newTestModule(id, name string, effect domain.ModuleEffect) *domain.ModuleModel {
+	return domain.NewModuleFromType(domain.ModuleType{
+		ID:              id,
+		Name:            name,
+		CooldownSeconds: 5.0,
+		Difficulty:      1,
+		Effects:         []domain.ModuleEffect{effect},
+	}, nil)
+}
+
+// newTestAgent は物理攻撃・炎攻撃・回復のモジュールを持つSTR20/INT10のテスト用エージェントを作成します。
+func newTestAgent(id string) *domain.AgentModel {
+	coreType := domain.CoreType{
+		ID:          "all_rounder",
+		Name:        "オールラウンダー",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+	}
+	core := domain.NewCore("core_"+id, "コア", 10, coreType, domain.PassiveSkill{})
+	agent := domain.NewAgent(id, core, []*domain.ModuleModel{
+		newTestModule("slash", "斬撃", domain.ModuleEffect{
+			Target: domain.TargetEnemy, HPFormula: &domain.HPFormula{StatCoef: 1.0, StatRef: "STR"}, Probability: 1.0,
+		}),
+		newTestModule("fireball", "火球", domain.ModuleEffect{
+			Target: domain.TargetEnemy, HPFormula: &domain.HPFormula{StatCoef: 2.0, StatRef: "INT"}, Probability: 1.0, Element: domain.ElementFire,
+		}),
+		newTestModule("heal", "ヒール", domain.ModuleEffect{
+			Target: domain.TargetSelf, HPFormula: &domain.HPFormula{Base: 10, StatCoef: 1.0, StatRef: "WIL"}, Probability: 1.0,
+		}),
+	})
+	agent.BaseStats = domain.Stats{STR: 20, INT: 10, WIL: 10, LUK: domain.BaseLUK}
+	return agent
+}
+
+// approx は浮動小数点数がほぼ等しいかを判定します。
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestAnalyze_ModuleEstimates はモジュールごとの期待ダメージと敵のパッシブによる弱体化の判定をテストします。
+func TestAnalyze_ModuleEstimates(t *testing.T) {
+	analysis := Analyze([]*domain.AgentModel{newTestAgent("a1")}, newTestSlimeType(), 2)
+
+	if len(analysis.Agents) != 1 {
+		t.Fatalf("エージェント数: 期待 1, 実際 %d", len(analysis.Agents))
+	}
+	agent := analysis.Agents[0]
+	if len(agent.Modules) != 2 {
+		t.Fatalf("ダメージを与えるモジュールのみ見積もるべき: 実際 %d件", len(agent.Modules))
+	}
+
+	slash := agent.Modules[0]
+	if slash.RawDamage != 20 || slash.Damage != 10 || !slash.Weakened {
+		t.Errorf("斬撃: 期待 20→10（弱体化）, 実際 %v→%v（弱体化 %v）", slash.RawDamage, slash.Damage, slash.Weakened)
+	}
+	if len(slash.Notes) != 1 || !strings.Contains(slash.Notes[0], "ぷるぷるボディ") || !strings.Contains(slash.Notes[0], "50%") {
+		t.Errorf("斬撃の弱体化理由: 実際 %v", slash.Notes)
+	}
+	if slash.CycleSeconds != 10 || !approx(slash.DPS, 1.0) {
+		t.Errorf("斬撃の使用間隔/DPS: 期待 10秒/1.0, 実際 %v秒/%v", slash.CycleSeconds, slash.DPS)
+	}
+
+	// 炎弱点（×1.5）でパッシブの軽減を上回る
+	fireball := agent.Modules[1]
+	if fireball.Damage != 15 || !fireball.Weakened {
+		t.Errorf("火球: 期待 15（軽減あり）, 実際 %v（弱体化 %v）", fireball.Damage, fireball.Weakened)
+	}
+	if agent.Best != 1 || !approx(agent.DPS, 1.5) {
+		t.Errorf("最良のモジュール: 期待 火球/DPS 1.5, 実際 %d/%v", agent.Best, agent.DPS)
+	}
+}
+
+// TestAnalyze_TimeEstimates は敵DPS・撃破時間・耐久時間の見積もりをテストします。
+func TestAnalyze_TimeEstimates(t *testing.T) {
+	agents := []*domain.AgentModel{newTestAgent("a1")}
+	analysis := Analyze(agents, newTestSlimeType(), 2)
+
+	if analysis.EnemyHP != 100 {
+		t.Errorf("敵HP: 期待 100, 実際 %d", analysis.EnemyHP)
+	}
+	// 攻撃（1秒）7ダメージ + 即時ディフェンス + バフ（2秒） = 7ダメージ / 3秒
+	if !approx(analysis.EnemyDPS, 7.0/3.0) {
+		t.Errorf("敵DPS: 期待 %v, 実際 %v", 7.0/3.0, analysis.EnemyDPS)
+	}
+	if !approx(analysis.TimeToKill, 100/1.5) {
+		t.Errorf("撃破時間: 期待 %v, 実際 %v", 100/1.5, analysis.TimeToKill)
+	}
+	if analysis.PlayerMaxHP != domain.CalculateMaxHP(agents) || !approx(analysis.TimeToDie, float64(analysis.PlayerMaxHP)*3.0/7.0) {
+		t.Errorf("耐久時間: 最大HP %d, 実際 %v", analysis.PlayerMaxHP, analysis.TimeToDie)
+	}
+	if analysis.IsFavorable() != (analysis.TimeToKill < analysis.TimeToDie) {
+		t.Errorf("見込みの判定が撃破時間と耐久時間の比較と一致しません")
+	}
+}
+
+// TestAnalyze_TeamDPSLimitedByTyping はタイピング時間の合計が実時間を超える場合にチームDPSが按分されることをテストします。
+func TestAnalyze_TeamDPSLimitedByTyping(t *testing.T) {
+	slime := newTestSlimeType()
+
+	// 2体: タイピング5秒/使用間隔10秒ずつで合計ちょうど1.0なので按分しない
+	two := Analyze([]*domain.AgentModel{newTestAgent("a1"), newTestAgent("a2")}, slime, 2)
+	if !approx(two.TeamDPS, 3.0) {
+		t.Errorf("2体のチームDPS: 期待 3.0, 実際 %v", two.TeamDPS)
+	}
+
+	// 3体: タイピングの合計が1.5倍になるため按分される
+	three := Analyze([]*domain.AgentModel{newTestAgent("a1"), newTestAgent("a2"), newTestAgent("a3")}, slime, 2)
+	if !approx(three.TeamDPS, 3.0) {
+		t.Errorf("3体のチームDPS: 期待 3.0, 実際 %v", three.TeamDPS)
+	}
+
+	// 攻撃手段がない場合は撃破できない
+	healer := newTestAgent("healer")
+	healer.Modules = healer.Modules[2:]
+	none := Analyze([]*domain.AgentModel{healer}, slime, 2)
+	if none.TimeToKill != 0 || none.IsFavorable() || none.Agents[0].Best != -1 {
+		t.Errorf("攻撃手段がない場合: 撃破時間 %v, 有利 %v", none.TimeToKill, none.IsFavorable())
+	}
+}
+
+// TestAnalyze_EnemyDPSFromActionRules は行動ルールを持つ敵のDPSを条件なしルールの重みで見積もることをテストします。
+func TestAnalyze_EnemyDPSFromActionRules(t *testing.T) {
+	slime := newTestSlimeType()
+	attack := slime.ResolvedNormalActions[0]
+	buff := slime.ResolvedNormalActions[2]
+	heavy := domain.EnemyAction{ID: "heavy", ActionType: domain.EnemyActionAttack, AttackType: "physical", DamageBase: 100, ChargeTime: time.Second}
+	slime.ActionRules = []domain.EnemyActionRule{
+		{ActionID: "attack", Action: attack, Weight: 3},
+		{ActionID: "buff", Action: buff},
+		// 条件付きルール・他のフェーズのルールはバトル開始時の候補に含めない
+		{ActionID: "heavy", Action: heavy, Condition: &domain.EnemyActionCondition{Type: domain.ConditionSelfHPBelow, Value: 0.5}},
+		{ActionID: "heavy", Action: heavy, Phases: []domain.EnemyPhase{domain.PhaseEnhanced}},
+	}
+
+	analysis := Analyze([]*domain.AgentModel{newTestAgent("a1")}, slime, 2)
+	// 攻撃（1秒・7ダメージ）×重み3 + バフ（2秒）×重み1 = 21ダメージ / 5秒
+	if !approx(analysis.EnemyDPS, 21.0/5.0) {
+		t.Errorf("行動ルールからの敵DPS: 期待 %v, 実際 %v", 21.0/5.0, analysis.EnemyDPS)
+	}
+
+	// 条件付きルールしかない場合は行動パターンで見積もる
+	slime.ActionRules = slime.ActionRules[2:3]
+	if got := Analyze([]*domain.AgentModel{newTestAgent("a1")}, slime, 2).EnemyDPS; !approx(got, 7.0/3.0) {
+		t.Errorf("条件付きルールのみの敵DPS: 期待 %v, 実際 %v", 7.0/3.0, got)
+	}
+}
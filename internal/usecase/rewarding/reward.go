@@ -7,7 +7,9 @@ import (
 	"math/rand"
 	"time"
 
+	"hirorocky/type-battle/internal/config"
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/spawning"
 )
 
 // ==================== チェイン効果プール ====================
@@ -44,6 +46,9 @@ type ChainEffectDefinition struct {
 
 	// MinDropLevel はこのチェイン効果がドロップする最低敵レベルです。
 	MinDropLevel int
+
+	// MinChainLength は発動に必要な最低チェイン数です。
+	MinChainLength int
 }
 
 // ChainEffectPool はチェイン効果のプール管理を担当する構造体です。
@@ -108,7 +113,7 @@ func (p *ChainEffectPool) GenerateRandomEffect() *domain.ChainEffect {
 		value,
 		selected.Description,
 		selected.ShortDescription,
-	)
+	).WithMinChainLength(selected.MinChainLength)
 	return &effect
 }
 
@@ -140,6 +145,12 @@ type BattleStatistics struct {
 
 	// TotalHealAmount は総回復量です。
 	TotalHealAmount int
+
+	// CriticalHits はクリティカルヒット回数です。
+	CriticalHits int
+
+	// PeakVoltage はバトル中に敵が到達したボルテージの最大値です（100.0 = 100%）。
+	PeakVoltage float64
 }
 
 // GetAverageWPM は平均WPMを返します。
@@ -177,6 +188,10 @@ type RewardResult struct {
 
 	// EnemyLevel は撃破した敵のレベルです。
 	EnemyLevel int
+
+	// VoltageBonus はピークボルテージによるドロップレベルの加算値です。
+	// ドロップレベルは敵の最大レベルを超えないため、上限で切り詰めた後の実際の加算値になります。
+	VoltageBonus int
 }
 
 // InventoryWarning はインベントリ警告を表す構造体です。
@@ -433,17 +448,29 @@ func (c *RewardCalculator) CalculateGuaranteedReward(
 		DroppedModules:   make([]*domain.ModuleModel, 0),
 	}
 
+	// 高いボルテージを耐え抜いた場合はドロップのレベルが上がる（敵の最大レベルが上限）
+	dropLevel := enemyLevel
+	if stats != nil {
+		dropLevel += VoltageRewardBonus(stats.PeakVoltage)
+	}
+	if dropLevel > spawning.MaxEnemyLevel {
+		dropLevel = spawning.MaxEnemyLevel
+	}
+	if dropLevel > enemyLevel {
+		result.VoltageBonus = dropLevel - enemyLevel
+	}
+
 	// 確定ドロップ処理
 	switch enemyType.DropItemCategory {
 	case "core":
-		core := c.RollCoreDropWithTypeID(enemyType.DropItemTypeID, enemyLevel)
+		core := c.RollCoreDropWithTypeID(enemyType.DropItemTypeID, dropLevel)
 		if core == nil {
 			panic("敵タイプ " + enemyType.ID + " のコアTypeID " + enemyType.DropItemTypeID + " が見つかりません")
 		}
 		result.DroppedCores = append(result.DroppedCores, core)
 
 	case "module":
-		module := c.RollModuleDropWithTypeID(enemyType.DropItemTypeID, enemyLevel)
+		module := c.RollModuleDropWithTypeID(enemyType.DropItemTypeID, dropLevel)
 		if module == nil {
 			panic("敵タイプ " + enemyType.ID + " のモジュールTypeID " + enemyType.DropItemTypeID + " が見つかりません")
 		}
@@ -456,6 +483,48 @@ func (c *RewardCalculator) CalculateGuaranteedReward(
 	return result
 }
 
+// VoltageRewardBonus はピークボルテージに応じたドロップレベルの加算値を返します。
+// 100%を上回るVoltageRewardStepごとに1加算され、MaxVoltageRewardBonusが上限です。
+func VoltageRewardBonus(peakVoltage float64) int {
+	bonus := int((peakVoltage - 100.0) / config.VoltageRewardStep) // 100.0 はボルテージの初期値
+	if bonus < 0 {
+		return 0
+	}
+	if bonus > config.MaxVoltageRewardBonus {
+		return config.MaxVoltageRewardBonus
+	}
+	return bonus
+}
+
+// CalculateMilestoneReward はエンドレスタワーのマイルストーン報酬を計算します。
+// 指定レベルでドロップ可能なコア特性とモジュールから1つずつランダムに選びます。
+// ドロップ可能なものがない種類は報酬に含まれません。
+func (c *RewardCalculator) CalculateMilestoneReward(level int) *RewardResult {
+	result := &RewardResult{
+		IsVictory:        true,
+		ShowRewardScreen: true,
+		EnemyLevel:       level,
+		DroppedCores:     make([]*domain.CoreModel, 0),
+		DroppedModules:   make([]*domain.ModuleModel, 0),
+	}
+
+	if coreTypes := c.GetEligibleCoreTypes(level); len(coreTypes) > 0 {
+		selected := coreTypes[c.rng.Intn(len(coreTypes))]
+		if core := c.RollCoreDropWithTypeID(selected.ID, level); core != nil {
+			result.DroppedCores = append(result.DroppedCores, core)
+		}
+	}
+
+	if moduleTypes := c.GetEligibleModuleTypes(level); len(moduleTypes) > 0 {
+		selected := moduleTypes[c.rng.Intn(len(moduleTypes))]
+		if module := c.RollModuleDropWithTypeID(selected.ID, level); module != nil {
+			result.DroppedModules = append(result.DroppedModules, module)
+		}
+	}
+
+	return result
+}
+
 // RollCoreDropWithTypeID は指定されたTypeIDのコアを生成します。
 // コアレベルは敵レベルと同じになります。
 func (c *RewardCalculator) RollCoreDropWithTypeID(typeID string, enemyLevel int) *domain.CoreModel {
@@ -557,7 +626,7 @@ func (c *RewardCalculator) generateLevelBasedChainEffect(enemyLevel int) *domain
 		value,
 		selected.Description,
 		selected.ShortDescription,
-	)
+	).WithMinChainLength(selected.MinChainLength)
 	return &effect
 }
 
@@ -1028,3 +1028,127 @@ func TestRollModuleDropWithTypeID_NoChainEffectPool(t *testing.T) {
 		t.Error("チェイン効果プールがない場合はチェイン効果がnilであるべき")
 	}
 }
+
+// TestCalculateMilestoneReward はマイルストーン報酬でドロップ可能なコアとモジュールが1つずつ得られることをテストします。
+func TestCalculateMilestoneReward(t *testing.T) {
+	coreTypes := []domain.CoreType{
+		{
+			ID:           "attack_balance",
+			Name:         "攻撃バランス",
+			MinDropLevel: 1,
+			StatWeights:  map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		},
+		{
+			ID:           "healer",
+			Name:         "ヒーラー",
+			MinDropLevel: 50,
+			StatWeights:  map[string]float64{"STR": 0.5, "INT": 1.5, "WIL": 0.8, "LUK": 1.2},
+		},
+	}
+	moduleTypes := []ModuleDropInfo{
+		{ID: "physical_lv1", Name: "物理攻撃Lv1", MinDropLevel: 1},
+		{ID: "heal_lv3", Name: "大回復", MinDropLevel: 50},
+	}
+
+	calculator := NewRewardCalculator(coreTypes, moduleTypes, nil)
+
+	for i := 0; i < 10; i++ {
+		result := calculator.CalculateMilestoneReward(10)
+
+		if len(result.DroppedCores) != 1 || len(result.DroppedModules) != 1 {
+			t.Fatalf("コアとモジュールが1つずつドロップするべき: コア%d, モジュール%d", len(result.DroppedCores), len(result.DroppedModules))
+		}
+		if core := result.DroppedCores[0]; core.Type.ID != "attack_balance" || core.Level != 10 {
+			t.Errorf("ドロップ可能なコアがレベル10で得られるべき: %s Lv.%d", core.Type.ID, core.Level)
+		}
+		if module := result.DroppedModules[0]; module.TypeID != "physical_lv1" {
+			t.Errorf("ドロップ可能なモジュールが得られるべき: %s", module.TypeID)
+		}
+	}
+
+	// ドロップ可能なものがない場合は空の報酬
+	empty := NewRewardCalculator(nil, nil, nil).CalculateMilestoneReward(10)
+	if len(empty.DroppedCores) != 0 || len(empty.DroppedModules) != 0 {
+		t.Error("ドロップ可能なものがない場合は報酬が空であるべき")
+	}
+}
+
+// TestVoltageRewardBonus はピークボルテージに応じたドロップレベルの加算値をテストします。
+func TestVoltageRewardBonus(t *testing.T) {
+	cases := []struct {
+		peak float64
+		want int
+	}{{0, 0}, {100, 0}, {149, 0}, {150, 1}, {260, 3}, {1000, 5}}
+	for _, c := range cases {
+		if got := VoltageRewardBonus(c.peak); got != c.want {
+			t.Errorf("ピークボルテージ%.0f%%: got %d, want %d", c.peak, got, c.want)
+		}
+	}
+}
+
+// TestCalculateGuaranteedReward_VoltageBonus は高いピークボルテージでドロップのレベルが上がることをテストします。
+func TestCalculateGuaranteedReward_VoltageBonus(t *testing.T) {
+	coreTypes := []domain.CoreType{
+		{
+			ID:           "attack_balance",
+			Name:         "攻撃バランス",
+			MinDropLevel: 1,
+			AllowedTags:  []string{"physical_low"},
+			StatWeights:  map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		},
+	}
+	calculator := NewRewardCalculator(coreTypes, nil, nil)
+	enemyType := domain.EnemyType{
+		ID:               "slime",
+		Name:             "スライム",
+		DropItemCategory: "core",
+		DropItemTypeID:   "attack_balance",
+	}
+
+	result := calculator.CalculateGuaranteedReward(&BattleStatistics{PeakVoltage: 210}, 10, enemyType)
+
+	if result.VoltageBonus != 2 {
+		t.Errorf("ボルテージ報酬: got %d, want 2", result.VoltageBonus)
+	}
+	if result.EnemyLevel != 10 {
+		t.Errorf("敵レベルは変化しないべき: got %d", result.EnemyLevel)
+	}
+	if len(result.DroppedCores) != 1 || result.DroppedCores[0].Level != 12 {
+		t.Errorf("コアのレベルはボルテージ報酬分だけ上がるべき: got %+v", result.DroppedCores)
+	}
+}
+
+// TestCalculateGuaranteedReward_VoltageBonusAtMaxLevel は最大レベルの敵ではボルテージ報酬でドロップのレベルが上限を超えないことをテストします。
+func TestCalculateGuaranteedReward_VoltageBonusAtMaxLevel(t *testing.T) {
+	coreTypes := []domain.CoreType{
+		{
+			ID:           "attack_balance",
+			Name:         "攻撃バランス",
+			MinDropLevel: 1,
+			AllowedTags:  []string{"physical_low"},
+			StatWeights:  map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		},
+	}
+	moduleTypes := []ModuleDropInfo{
+		{ID: "physical_strike_lv1", Name: "物理打撃Lv1", Tags: []string{"physical_low"}, MinDropLevel: 1},
+	}
+	calculator := NewRewardCalculator(coreTypes, moduleTypes, nil)
+	stats := &BattleStatistics{PeakVoltage: 1000}
+
+	coreResult := calculator.CalculateGuaranteedReward(stats, 100, domain.EnemyType{
+		ID: "dragon", DropItemCategory: "core", DropItemTypeID: "attack_balance",
+	})
+	if len(coreResult.DroppedCores) != 1 || coreResult.DroppedCores[0].Level != 100 {
+		t.Errorf("コアのレベルは最大レベル100を超えないべき: got %+v", coreResult.DroppedCores)
+	}
+	if coreResult.VoltageBonus != 0 {
+		t.Errorf("最大レベルではボルテージ報酬の加算はないべき: got %d", coreResult.VoltageBonus)
+	}
+
+	moduleResult := calculator.CalculateGuaranteedReward(stats, 98, domain.EnemyType{
+		ID: "dragon", DropItemCategory: "module", DropItemTypeID: "physical_strike_lv1",
+	})
+	if moduleResult.VoltageBonus != 2 {
+		t.Errorf("ボルテージ報酬は最大レベルまでに切り詰められるべき: got %d, want 2", moduleResult.VoltageBonus)
+	}
+}
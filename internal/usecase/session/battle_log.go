@@ -0,0 +1,46 @@
+// Package game_state はゲーム全体の状態管理を提供するユースケースです。
+// このファイルはバトルログとJSON Lines書き出し形式の変換を担当します。
+package session
+
+import (
+	"hirorocky/type-battle/internal/infra/savedata"
+	"hirorocky/type-battle/internal/usecase/combat"
+)
+
+// BattleLogToSaveData はバトルログのイベントを書き出し形式に変換します。
+func BattleLogToSaveData(events []combat.BattleEvent) []savedata.BattleLogEventSave {
+	saved := make([]savedata.BattleLogEventSave, len(events))
+	for i, event := range events {
+		saved[i] = savedata.BattleLogEventSave{
+			AtNs:   int64(event.At),
+			Kind:   string(event.Kind),
+			Source: event.Source,
+			Target: event.Target,
+			Amount: event.Amount,
+			Detail: event.Detail,
+			Trace:  calculationTraceToSaveData(event.Trace),
+		}
+	}
+	return saved
+}
+
+// calculationTraceToSaveData は計算トレースを書き出し形式に変換します。
+func calculationTraceToSaveData(trace *combat.CalculationTrace) *savedata.CalculationTraceSave {
+	if trace == nil {
+		return nil
+	}
+	saved := &savedata.CalculationTraceSave{Steps: make([]savedata.TraceStepSave, len(trace.Steps))}
+	for i, step := range trace.Steps {
+		saved.Steps[i] = savedata.TraceStepSave{Label: step.Label, Formula: step.Formula, Value: step.Value}
+	}
+	for _, effect := range trace.Effects {
+		saved.Effects = append(saved.Effects, savedata.TraceEffectSave{
+			Owner:       effect.Owner,
+			Source:      effect.Contribution.Source,
+			Column:      string(effect.Contribution.Column),
+			Value:       effect.Contribution.Value,
+			Aggregation: effect.Contribution.Aggregation.String(),
+		})
+	}
+	return saved
+}
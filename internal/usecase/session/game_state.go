@@ -8,6 +8,7 @@ import (
 	"hirorocky/type-battle/internal/usecase/rewarding"
 	"hirorocky/type-battle/internal/usecase/spawning"
 	"hirorocky/type-battle/internal/usecase/synthesize"
+	"hirorocky/type-battle/internal/usecase/typing"
 )
 
 // GameState はゲーム全体の状態を保持する構造体です。
@@ -49,9 +50,15 @@ type GameState struct {
 	// encounteredEnemies はエンカウントした敵のIDリストです（敵図鑑用）。
 	encounteredEnemies []string
 
+	// discoveredWeaknesses は発見済みの敵の弱点属性です（敵タイプID→属性リスト、敵図鑑用）。
+	discoveredWeaknesses map[string][]domain.Element
+
 	// defeatedEnemies は撃破済み敵の情報を管理します。
 	// キーは敵タイプID、値は撃破した最高レベルです。
 	defeatedEnemies map[string]int
+
+	// keyStats は文字・バイグラムごとの入力統計です（アダプティブ出題用）。
+	keyStats *typing.KeyStats
 }
 
 // NewGameState はマスタデータを使用して新しいGameStateを作成します。
@@ -91,6 +98,7 @@ func NewGameState(
 		tempStorage:      &rewarding.TempStorage{},
 		enemyGenerator:   enemyGen,
 		defeatedEnemies:  make(map[string]int),
+		keyStats:         typing.NewKeyStats(),
 	}
 }
 
@@ -124,15 +132,21 @@ func (g *GameState) Settings() *Settings {
 	return g.settings
 }
 
+// KeyStats は文字・バイグラムごとの入力統計を返します。
+func (g *GameState) KeyStats() *typing.KeyStats {
+	return g.keyStats
+}
+
 // EnemyGenerator は敵生成器を返します。
 func (g *GameState) EnemyGenerator() *spawning.EnemyGenerator {
 	return g.enemyGenerator
 }
 
-// UpdateEnemyGenerator は敵生成器を敵タイプで更新します。
-func (g *GameState) UpdateEnemyGenerator(enemyTypes []domain.EnemyType) {
+// UpdateEnemyGenerator は敵生成器を敵タイプとエンカウント定義で更新します。
+func (g *GameState) UpdateEnemyGenerator(enemyTypes []domain.EnemyType, encounters []domain.Encounter) {
 	if len(enemyTypes) > 0 {
 		g.enemyGenerator = spawning.NewEnemyGenerator(enemyTypes)
+		g.enemyGenerator.SetEncounters(encounters)
 	}
 }
 
@@ -165,6 +179,11 @@ func (g *GameState) RecordBattleDefeat(level int) {
 	g.statistics.RecordBattleResult(false, level)
 }
 
+// RecordBattleRetreat はバトルからの撤退を記録します。
+func (g *GameState) RecordBattleRetreat() {
+	g.statistics.RecordBattleRetreat()
+}
+
 // RecordTypingResult はタイピング結果を記録します。
 func (g *GameState) RecordTypingResult(wpm int, accuracy float64, characters int, correct int, missed int) {
 	g.statistics.RecordTypingResult(wpm, accuracy, characters, correct, missed)
@@ -209,6 +228,25 @@ func (g *GameState) CheckBattleAchievementsWithNoDamage(noDamage bool) {
 	)
 }
 
+// RecordCriticalHits はバトル中のクリティカルヒット回数を記録し、関連実績をチェックします。
+func (g *GameState) RecordCriticalHits(count int) {
+	if count <= 0 {
+		return
+	}
+	g.statistics.RecordCriticalHits(count)
+	g.achievements.CheckCriticalAchievements(g.statistics.Battle().TotalCriticalHits)
+}
+
+// RecordTowerFloor はエンドレスタワーで到達した階を記録します。
+func (g *GameState) RecordTowerFloor(floor int) {
+	g.statistics.RecordTowerFloor(floor)
+}
+
+// TowerBestFloor はエンドレスタワーの最高到達階を返します。
+func (g *GameState) TowerBestFloor() int {
+	return g.statistics.Battle().TowerBestFloor
+}
+
 // AddEncounteredEnemy は敵をエンカウント済みとして記録します（敵図鑑用）。
 func (g *GameState) AddEncounteredEnemy(enemyID string) {
 	// 空のIDは無視
@@ -229,6 +267,36 @@ func (g *GameState) GetEncounteredEnemies() []string {
 	return g.encounteredEnemies
 }
 
+// RecordDiscoveredWeaknesses はバトルで突いた敵の弱点属性を記録します（敵図鑑用）。
+func (g *GameState) RecordDiscoveredWeaknesses(enemyID string, elements []domain.Element) {
+	if enemyID == "" || len(elements) == 0 {
+		return
+	}
+	if g.discoveredWeaknesses == nil {
+		g.discoveredWeaknesses = make(map[string][]domain.Element)
+	}
+	for _, element := range elements {
+		if !containsElement(g.discoveredWeaknesses[enemyID], element) {
+			g.discoveredWeaknesses[enemyID] = append(g.discoveredWeaknesses[enemyID], element)
+		}
+	}
+}
+
+// GetDiscoveredWeaknesses は発見済みの敵の弱点属性を返します。
+func (g *GameState) GetDiscoveredWeaknesses() map[string][]domain.Element {
+	return g.discoveredWeaknesses
+}
+
+// containsElement は属性リストに指定属性が含まれるかを判定します。
+func containsElement(elements []domain.Element, element domain.Element) bool {
+	for _, e := range elements {
+		if e == element {
+			return true
+		}
+	}
+	return false
+}
+
 // GetEquippedAgents は装備中のエージェント一覧を返します。
 func (g *GameState) GetEquippedAgents() []*domain.AgentModel {
 	return g.agentManager.GetEquippedAgents()
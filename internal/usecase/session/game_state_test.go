@@ -3,8 +3,10 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/typing"
 )
 
 // TestNewGameState は新しいGameStateの作成をテストします。
@@ -82,6 +84,24 @@ func TestRecordBattleDefeat(t *testing.T) {
 	}
 }
 
+// TestRecordBattleRetreat はバトル撤退が敗北とは別に記録され、保存・復元されることをテストします。
+func TestRecordBattleRetreat(t *testing.T) {
+	gs := NewGameStateForTest()
+
+	gs.RecordBattleDefeat(1)
+	gs.RecordBattleRetreat()
+
+	battle := gs.Statistics().Battle()
+	if battle.Retreats != 1 || battle.Losses != 1 || battle.TotalBattles != 2 {
+		t.Errorf("Retreats/Losses/TotalBattles expected 1/1/2, got %d/%d/%d", battle.Retreats, battle.Losses, battle.TotalBattles)
+	}
+
+	restored := GameStateFromSaveData(gs.ToSaveData(), &DomainDataSources{})
+	if got := restored.Statistics().Battle().Retreats; got != 1 {
+		t.Errorf("restored Retreats expected 1, got %d", got)
+	}
+}
+
 // TestRecordTypingResult はタイピング結果の記録をテストします。
 func TestRecordTypingResult(t *testing.T) {
 	gs := NewGameStateForTest()
@@ -149,3 +169,80 @@ func TestPreparePlayerForBattle(t *testing.T) {
 		t.Errorf("HP should equal MaxHP after preparation")
 	}
 }
+
+// TestTypingModeSaveRoundTrip はタイピング入力モードの保存と復元をテストします。
+func TestTypingModeSaveRoundTrip(t *testing.T) {
+	gs := NewGameStateForTest()
+	if gs.Settings().TypingMode() != TypingModeEnglish {
+		t.Errorf("TypingMode expected english, got %s", gs.Settings().TypingMode())
+	}
+
+	gs.Settings().SetTypingMode(TypingModeRomaji)
+	saveData := gs.ToSaveData()
+	if saveData.Settings.TypingMode != "romaji" {
+		t.Errorf("saved TypingMode expected romaji, got %s", saveData.Settings.TypingMode)
+	}
+
+	restored := GameStateFromSaveData(saveData, &DomainDataSources{})
+	if restored.Settings().TypingMode() != TypingModeRomaji {
+		t.Errorf("restored TypingMode expected romaji, got %s", restored.Settings().TypingMode())
+	}
+}
+
+// TestKeyStatsSaveRoundTrip は苦手キー統計と出題方式設定の保存と復元をテストします。
+func TestKeyStatsSaveRoundTrip(t *testing.T) {
+	gs := NewGameStateForTest()
+	gs.Settings().SetAdaptiveWords(true)
+	gs.KeyStats().Chars["q"] = &typing.KeyStat{Count: 4, Errors: 1, TotalLatency: 900 * time.Millisecond, LatencyCount: 3}
+	gs.KeyStats().Bigrams["qu"] = &typing.KeyStat{Count: 2, Errors: 2}
+
+	restored := GameStateFromSaveData(gs.ToSaveData(), &DomainDataSources{})
+
+	if !restored.Settings().AdaptiveWords() {
+		t.Error("AdaptiveWords should be restored as true")
+	}
+	q := restored.KeyStats().Chars["q"]
+	if q == nil || q.Count != 4 || q.Errors != 1 || q.LatencyCount != 3 || q.TotalLatency != 900*time.Millisecond {
+		t.Errorf("restored char stat mismatch: %+v", q)
+	}
+	if qu := restored.KeyStats().Bigrams["qu"]; qu == nil || qu.Errors != 2 {
+		t.Errorf("restored bigram stat mismatch: %+v", qu)
+	}
+}
+
+// TestDiscoveredWeaknessesSaveRoundTrip は発見済み弱点の記録と保存・復元をテストします。
+func TestDiscoveredWeaknessesSaveRoundTrip(t *testing.T) {
+	gs := NewGameStateForTest()
+	gs.RecordDiscoveredWeaknesses("slime", []domain.Element{domain.ElementFire})
+	gs.RecordDiscoveredWeaknesses("slime", []domain.Element{domain.ElementFire, domain.ElementIce})
+	gs.RecordDiscoveredWeaknesses("bat", nil)
+
+	if got := gs.GetDiscoveredWeaknesses()["slime"]; len(got) != 2 {
+		t.Errorf("slime weaknesses expected 2 (no duplicates), got %v", got)
+	}
+	if _, ok := gs.GetDiscoveredWeaknesses()["bat"]; ok {
+		t.Error("empty weakness list should not be recorded")
+	}
+
+	restored := GameStateFromSaveData(gs.ToSaveData(), &DomainDataSources{})
+	got := restored.GetDiscoveredWeaknesses()["slime"]
+	if len(got) != 2 || got[0] != domain.ElementFire || got[1] != domain.ElementIce {
+		t.Errorf("restored weaknesses mismatch: %v", got)
+	}
+}
+
+// TestTowerBestFloorSaveRoundTrip はタワー最高到達階の記録と保存・復元をテストします。
+func TestTowerBestFloorSaveRoundTrip(t *testing.T) {
+	gs := NewGameStateForTest()
+	gs.RecordTowerFloor(12)
+	gs.RecordTowerFloor(7)
+
+	if gs.TowerBestFloor() != 12 {
+		t.Errorf("best floor should keep the highest floor, got %d", gs.TowerBestFloor())
+	}
+
+	restored := GameStateFromSaveData(gs.ToSaveData(), &DomainDataSources{})
+	if restored.TowerBestFloor() != 12 {
+		t.Errorf("restored best floor mismatch: %d", restored.TowerBestFloor())
+	}
+}
@@ -4,6 +4,7 @@ package session
 
 import (
 	"log/slog"
+	"time"
 
 	"hirorocky/type-battle/internal/domain"
 	"hirorocky/type-battle/internal/infra/savedata"
@@ -11,6 +12,7 @@ import (
 	"hirorocky/type-battle/internal/usecase/rewarding"
 	"hirorocky/type-battle/internal/usecase/spawning"
 	"hirorocky/type-battle/internal/usecase/synthesize"
+	"hirorocky/type-battle/internal/usecase/typing"
 )
 
 // DomainDataSources はセーブデータ復元時に使用するドメイン型データソースです。
@@ -18,6 +20,7 @@ type DomainDataSources struct {
 	CoreTypes              []domain.CoreType
 	ModuleTypes            []rewarding.ModuleDropInfo
 	EnemyTypes             []domain.EnemyType
+	Encounters             []domain.Encounter
 	PassiveSkills          map[string]domain.PassiveSkill
 	ChainEffectDefinitions []rewarding.ChainEffectDefinition
 }
@@ -59,26 +62,7 @@ func (g *GameState) ToSaveData() *savedata.SaveData {
 	// エージェントを保存（コア情報を直接埋め込み、モジュールはオブジェクト配列）
 	agentInstances := make([]savedata.AgentInstanceSave, 0)
 	for _, ag := range g.agentManager.GetAgents() {
-		modules := make([]savedata.ModuleInstanceSave, len(ag.Modules))
-		for i, m := range ag.Modules {
-			modules[i] = savedata.ModuleInstanceSave{
-				TypeID: m.TypeID,
-			}
-			if m.ChainEffect != nil {
-				modules[i].ChainEffect = &savedata.ChainEffectSave{
-					Type:  string(m.ChainEffect.Type),
-					Value: m.ChainEffect.Value,
-				}
-			}
-		}
-		agentInstances = append(agentInstances, savedata.AgentInstanceSave{
-			ID: ag.ID,
-			Core: savedata.CoreInstanceSave{
-				CoreTypeID: ag.Core.TypeID,
-				Level:      ag.Core.Level,
-			},
-			Modules: modules,
-		})
+		agentInstances = append(agentInstances, agentToSaveData(ag))
 	}
 	saveData.Inventory.AgentInstances = agentInstances
 
@@ -100,17 +84,35 @@ func (g *GameState) ToSaveData() *savedata.SaveData {
 	saveData.Statistics.TotalBattles = stats.Battle().TotalBattles
 	saveData.Statistics.Victories = stats.Battle().Wins
 	saveData.Statistics.Defeats = stats.Battle().Losses
+	saveData.Statistics.Retreats = stats.Battle().Retreats
 	saveData.Statistics.HighestWPM = float64(stats.Typing().MaxWPM)
 	saveData.Statistics.AverageWPM = stats.GetAverageWPM()
 	saveData.Statistics.PerfectAccuracyCount = stats.Typing().PerfectAccuracyCount
 	saveData.Statistics.TotalCharactersTyped = stats.Typing().TotalCharacters
+	saveData.Statistics.TotalCriticalHits = stats.Battle().TotalCriticalHits
+	saveData.Statistics.TowerBestFloor = stats.Battle().TowerBestFloor
 	saveData.Statistics.EncounteredEnemies = g.encounteredEnemies
+	if len(g.discoveredWeaknesses) > 0 {
+		saveData.Statistics.DiscoveredWeaknesses = make(map[string][]string, len(g.discoveredWeaknesses))
+		for enemyID, elements := range g.discoveredWeaknesses {
+			names := make([]string, len(elements))
+			for i, element := range elements {
+				names[i] = string(element)
+			}
+			saveData.Statistics.DiscoveredWeaknesses[enemyID] = names
+		}
+	}
 
 	// 実績（ドメイン型を経由してセーブデータ型に変換）
 	saveData.Achievements = savedata.AchievementStateToSaveData(g.achievements.GetUnlockedIDs())
 
 	// 設定
 	saveData.Settings.KeyBindings = g.settings.Keybinds()
+	saveData.Settings.TypingMode = string(g.settings.TypingMode())
+	saveData.Settings.AdaptiveWords = g.settings.AdaptiveWords()
+
+	// 苦手キー統計
+	saveData.Statistics.KeyStats = keyStatsToSaveData(g.keyStats)
 
 	// 撃破済み敵情報を保存
 	saveData.Statistics.DefeatedEnemies = g.GetDefeatedEnemies()
@@ -175,7 +177,7 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 							modSave.ChainEffect.Value,
 							chainEffectDef.Description,
 							chainEffectDef.ShortDescription,
-						)
+						).WithMinChainLength(chainEffectDef.MinChainLength)
 						chainEffect = &ce
 					}
 				}
@@ -217,42 +219,7 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 	// セーブデータからエージェントを再構築（コア情報は各エージェントに埋め込まれている）
 	if data.Inventory != nil {
 		for _, agentSave := range data.Inventory.AgentInstances {
-			// エージェント内のコア情報からコアを再構築（v1.0.0形式）
-			coreType := findCoreType(coreTypes, agentSave.Core.CoreTypeID)
-			passiveSkill := findPassiveSkill(passiveSkills, coreType.PassiveSkillID)
-			core := domain.NewCoreWithTypeID(
-				agentSave.Core.CoreTypeID,
-				agentSave.Core.Level,
-				coreType,
-				passiveSkill,
-			)
-
-			// モジュールを再構築（オブジェクト配列形式）
-			modules := make([]*domain.ModuleModel, 0, len(agentSave.Modules))
-			for _, modSave := range agentSave.Modules {
-				moduleDropInfo := findModuleDropInfo(moduleTypes, modSave.TypeID)
-				if moduleDropInfo != nil {
-					// チェイン効果を復元
-					var chainEffect *domain.ChainEffect
-					if modSave.ChainEffect != nil {
-						effectType := domain.ChainEffectType(modSave.ChainEffect.Type)
-						chainEffectDef := findChainEffectDefinition(chainEffectDefs, effectType)
-						if chainEffectDef != nil {
-							ce := domain.NewChainEffectWithTemplate(
-								effectType,
-								modSave.ChainEffect.Value,
-								chainEffectDef.Description,
-								chainEffectDef.ShortDescription,
-							)
-							chainEffect = &ce
-						}
-					}
-					modules = append(modules, moduleDropInfo.ToDomainWithChainEffect(chainEffect))
-				}
-			}
-
-			// エージェントを再構築
-			agentModel := domain.NewAgent(agentSave.ID, core, modules)
+			agentModel := agentFromSaveData(agentSave, sources)
 			if err := agentMgr.AddAgent(agentModel); err != nil {
 				slog.Error("エージェント追加に失敗",
 					slog.String("agent_id", agentModel.ID),
@@ -292,11 +259,14 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 			TotalBattles:         data.Statistics.TotalBattles,
 			Victories:            data.Statistics.Victories,
 			Defeats:              data.Statistics.Defeats,
+			Retreats:             data.Statistics.Retreats,
 			MaxLevelReached:      data.Statistics.MaxLevelReached,
 			HighestWPM:           data.Statistics.HighestWPM,
 			AverageWPM:           data.Statistics.AverageWPM,
 			PerfectAccuracyCount: data.Statistics.PerfectAccuracyCount,
 			TotalCharactersTyped: data.Statistics.TotalCharactersTyped,
+			TotalCriticalHits:    data.Statistics.TotalCriticalHits,
+			TowerBestFloor:       data.Statistics.TowerBestFloor,
 		}
 		statsMgr.LoadFromSaveData(statsSaveData)
 	}
@@ -308,6 +278,10 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 			settings.SetKeybind(action, key)
 		}
 	}
+	if data.Settings != nil {
+		settings.SetTypingMode(TypingMode(data.Settings.TypingMode))
+		settings.SetAdaptiveWords(data.Settings.AdaptiveWords)
+	}
 
 	// RewardCalculatorを作成
 	rewardCalc := rewarding.NewRewardCalculator(coreTypes, moduleTypes, passiveSkills)
@@ -320,15 +294,27 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 
 	// EnemyGeneratorを作成
 	enemyGen := spawning.NewEnemyGenerator(enemyTypes)
+	enemyGen.SetEncounters(sources.Encounters)
 
 	// 最高到達レベル、エンカウント敵リスト、撃破済み敵情報を取得
 	maxLevelReached := 0
 	var encounteredEnemies []string
 	var defeatedEnemies map[string]int
+	var discoveredWeaknesses map[string][]domain.Element
+	keyStats := typing.NewKeyStats()
 	if data.Statistics != nil {
 		maxLevelReached = data.Statistics.MaxLevelReached
 		encounteredEnemies = data.Statistics.EncounteredEnemies
 		defeatedEnemies = data.Statistics.DefeatedEnemies
+		keyStats = keyStatsFromSaveData(data.Statistics.KeyStats)
+		for enemyID, names := range data.Statistics.DiscoveredWeaknesses {
+			if discoveredWeaknesses == nil {
+				discoveredWeaknesses = make(map[string][]domain.Element)
+			}
+			for _, name := range names {
+				discoveredWeaknesses[enemyID] = append(discoveredWeaknesses[enemyID], domain.Element(name))
+			}
+		}
 	}
 
 	gs := &GameState{
@@ -344,6 +330,9 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 		enemyGenerator:     enemyGen,
 		encounteredEnemies: encounteredEnemies,
 		defeatedEnemies:    make(map[string]int),
+		keyStats:           keyStats,
+
+		discoveredWeaknesses: discoveredWeaknesses,
 	}
 
 	// 撃破済み敵情報を復元
@@ -354,6 +343,115 @@ func GameStateFromSaveData(data *savedata.SaveData, sources *DomainDataSources)
 	return gs
 }
 
+// keyStatsToSaveData は苦手キー統計をセーブデータ形式に変換します。
+func keyStatsToSaveData(stats *typing.KeyStats) *savedata.KeyStatsSaveData {
+	if stats == nil {
+		return nil
+	}
+	convert := func(src map[string]*typing.KeyStat) map[string]savedata.KeyStatSaveData {
+		result := make(map[string]savedata.KeyStatSaveData, len(src))
+		for key, stat := range src {
+			result[key] = savedata.KeyStatSaveData{
+				Count:          stat.Count,
+				Errors:         stat.Errors,
+				TotalLatencyMs: stat.TotalLatency.Milliseconds(),
+				LatencyCount:   stat.LatencyCount,
+			}
+		}
+		return result
+	}
+	return &savedata.KeyStatsSaveData{
+		Chars:   convert(stats.Chars),
+		Bigrams: convert(stats.Bigrams),
+	}
+}
+
+// keyStatsFromSaveData はセーブデータから苦手キー統計を復元します。
+func keyStatsFromSaveData(data *savedata.KeyStatsSaveData) *typing.KeyStats {
+	stats := typing.NewKeyStats()
+	if data == nil {
+		return stats
+	}
+	convert := func(src map[string]savedata.KeyStatSaveData, dst map[string]*typing.KeyStat) {
+		for key, stat := range src {
+			dst[key] = &typing.KeyStat{
+				Count:        stat.Count,
+				Errors:       stat.Errors,
+				TotalLatency: time.Duration(stat.TotalLatencyMs) * time.Millisecond,
+				LatencyCount: stat.LatencyCount,
+			}
+		}
+	}
+	convert(data.Chars, stats.Chars)
+	convert(data.Bigrams, stats.Bigrams)
+	return stats
+}
+
+// agentToSaveData はエージェントをセーブデータ形式に変換します。
+// コアはTypeIDとLevel、モジュールはTypeIDとChainEffectを保存します。
+func agentToSaveData(ag *domain.AgentModel) savedata.AgentInstanceSave {
+	modules := make([]savedata.ModuleInstanceSave, len(ag.Modules))
+	for i, m := range ag.Modules {
+		modules[i] = savedata.ModuleInstanceSave{
+			TypeID: m.TypeID,
+		}
+		if m.ChainEffect != nil {
+			modules[i].ChainEffect = &savedata.ChainEffectSave{
+				Type:  string(m.ChainEffect.Type),
+				Value: m.ChainEffect.Value,
+			}
+		}
+	}
+	return savedata.AgentInstanceSave{
+		ID: ag.ID,
+		Core: savedata.CoreInstanceSave{
+			CoreTypeID: ag.Core.TypeID,
+			Level:      ag.Core.Level,
+		},
+		Modules: modules,
+	}
+}
+
+// agentFromSaveData はセーブデータからエージェントを再構築します。
+// マスタデータに存在しないモジュールは除外されます。
+func agentFromSaveData(agentSave savedata.AgentInstanceSave, sources *DomainDataSources) *domain.AgentModel {
+	// エージェント内のコア情報からコアを再構築（v1.0.0形式）
+	coreType := findCoreType(sources.CoreTypes, agentSave.Core.CoreTypeID)
+	passiveSkill := findPassiveSkill(sources.PassiveSkills, coreType.PassiveSkillID)
+	core := domain.NewCoreWithTypeID(
+		agentSave.Core.CoreTypeID,
+		agentSave.Core.Level,
+		coreType,
+		passiveSkill,
+	)
+
+	// モジュールを再構築（オブジェクト配列形式）
+	modules := make([]*domain.ModuleModel, 0, len(agentSave.Modules))
+	for _, modSave := range agentSave.Modules {
+		moduleDropInfo := findModuleDropInfo(sources.ModuleTypes, modSave.TypeID)
+		if moduleDropInfo != nil {
+			// チェイン効果を復元
+			var chainEffect *domain.ChainEffect
+			if modSave.ChainEffect != nil {
+				effectType := domain.ChainEffectType(modSave.ChainEffect.Type)
+				chainEffectDef := findChainEffectDefinition(sources.ChainEffectDefinitions, effectType)
+				if chainEffectDef != nil {
+					ce := domain.NewChainEffectWithTemplate(
+						effectType,
+						modSave.ChainEffect.Value,
+						chainEffectDef.Description,
+						chainEffectDef.ShortDescription,
+					).WithMinChainLength(chainEffectDef.MinChainLength)
+					chainEffect = &ce
+				}
+			}
+			modules = append(modules, moduleDropInfo.ToDomainWithChainEffect(chainEffect))
+		}
+	}
+
+	return domain.NewAgent(agentSave.ID, core, modules)
+}
+
 // findCoreType はコア特性リストから指定IDのコア特性を検索します。
 func findCoreType(coreTypes []domain.CoreType, coreTypeID string) domain.CoreType {
 	for _, ct := range coreTypes {
@@ -0,0 +1,191 @@
+// Package game_state はゲーム全体の状態管理を提供するユースケースです。
+// このファイルはバトルリプレイとセーブデータ形式の変換を担当します。
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"hirorocky/type-battle/internal/infra/savedata"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// ReplayToSaveData はバトルリプレイをリプレイファイル形式に変換します。
+// 編成はセーブデータと同じくTypeID・Level・ChainEffectで保存します。
+func ReplayToSaveData(replay *combat.Replay) *savedata.ReplayData {
+	data := &savedata.ReplayData{
+		RecordedAt: replay.RecordedAt,
+		Victory:    replay.Victory,
+		Retreated:  replay.Retreated,
+		DurationNs: int64(replay.Duration),
+		Seeds: savedata.ReplaySeedsSave{
+			Engine:        replay.Seeds.Engine,
+			Challenge:     replay.Seeds.Challenge,
+			PlayerEffects: replay.Seeds.PlayerEffects,
+			EnemyEffects:  replay.Seeds.EnemyEffects,
+		},
+		Enemy:       enemySnapshotToSaveData(replay.Enemy),
+		PlayerMaxHP: replay.PlayerMaxHP,
+		PlayerHP:    replay.PlayerHP,
+		Agents:      make([]savedata.AgentInstanceSave, 0, len(replay.Agents)),
+		Events:      make([]savedata.ReplayEventSave, len(replay.Events)),
+	}
+
+	for _, wave := range replay.Waves {
+		waveSave := make([]savedata.ReplayEnemySave, len(wave))
+		for i, snapshot := range wave {
+			waveSave[i] = enemySnapshotToSaveData(snapshot)
+		}
+		data.Waves = append(data.Waves, waveSave)
+	}
+
+	for _, agent := range replay.Agents {
+		data.Agents = append(data.Agents, agentToSaveData(agent))
+	}
+
+	for i, event := range replay.Events {
+		eventSave := savedata.ReplayEventSave{
+			AtNs:    int64(event.At),
+			Kind:    string(event.Kind),
+			DeltaNs: int64(event.Delta),
+			Slot:    event.Slot,
+			Target:  event.Target,
+		}
+		if event.Kind == combat.ReplayEventKey {
+			eventSave.Key = string(event.Rune)
+		}
+		if event.Challenge != nil {
+			eventSave.Challenge = challengeToSaveData(event.Challenge)
+		}
+		data.Events[i] = eventSave
+	}
+
+	return data
+}
+
+// ReplayFromSaveData はリプレイファイルからバトルリプレイを復元します。
+// 敵タイプと編成はマスタデータから再構築するため、sourcesが必要です。
+func ReplayFromSaveData(data *savedata.ReplayData, sources *DomainDataSources) (*combat.Replay, error) {
+	if sources == nil {
+		return nil, fmt.Errorf("マスタデータソースが必要です")
+	}
+
+	replay := &combat.Replay{
+		RecordedAt: data.RecordedAt,
+		Victory:    data.Victory,
+		Retreated:  data.Retreated,
+		Duration:   time.Duration(data.DurationNs),
+		Seeds: combat.ReplaySeeds{
+			Engine:        data.Seeds.Engine,
+			Challenge:     data.Seeds.Challenge,
+			PlayerEffects: data.Seeds.PlayerEffects,
+			EnemyEffects:  data.Seeds.EnemyEffects,
+		},
+		PlayerMaxHP: data.PlayerMaxHP,
+		PlayerHP:    data.PlayerHP,
+		Events:      make([]combat.ReplayEvent, len(data.Events)),
+	}
+
+	// 敵タイプを検索
+	enemy, err := enemySnapshotFromSaveData(data.Enemy, sources)
+	if err != nil {
+		return nil, err
+	}
+	replay.Enemy = enemy
+
+	for _, waveSave := range data.Waves {
+		wave := make([]combat.EnemySnapshot, len(waveSave))
+		for i, enemySave := range waveSave {
+			if wave[i], err = enemySnapshotFromSaveData(enemySave, sources); err != nil {
+				return nil, err
+			}
+		}
+		replay.Waves = append(replay.Waves, wave)
+	}
+
+	if len(data.Agents) == 0 {
+		return nil, fmt.Errorf("リプレイにエージェントが記録されていません")
+	}
+	for _, agentSave := range data.Agents {
+		replay.Agents = append(replay.Agents, agentFromSaveData(agentSave, sources))
+	}
+
+	for i, eventSave := range data.Events {
+		event := combat.ReplayEvent{
+			At:     time.Duration(eventSave.AtNs),
+			Kind:   combat.ReplayEventKind(eventSave.Kind),
+			Delta:  time.Duration(eventSave.DeltaNs),
+			Slot:   eventSave.Slot,
+			Target: eventSave.Target,
+		}
+		if runes := []rune(eventSave.Key); len(runes) > 0 {
+			event.Rune = runes[0]
+		}
+		if eventSave.Challenge != nil {
+			event.Challenge = challengeFromSaveData(eventSave.Challenge)
+		}
+		replay.Events[i] = event
+	}
+
+	return replay, nil
+}
+
+// enemySnapshotToSaveData は敵のスナップショットをセーブデータ形式に変換します。
+func enemySnapshotToSaveData(snapshot combat.EnemySnapshot) savedata.ReplayEnemySave {
+	return savedata.ReplayEnemySave{
+		TypeID:      snapshot.Type.ID,
+		Name:        snapshot.Name,
+		Level:       snapshot.Level,
+		MaxHP:       snapshot.MaxHP,
+		AttackPower: snapshot.AttackPower,
+	}
+}
+
+// enemySnapshotFromSaveData はセーブデータから敵のスナップショットを復元します。
+// 敵タイプはマスタデータから検索します。
+func enemySnapshotFromSaveData(data savedata.ReplayEnemySave, sources *DomainDataSources) (combat.EnemySnapshot, error) {
+	for _, enemyType := range sources.EnemyTypes {
+		if enemyType.ID == data.TypeID {
+			return combat.EnemySnapshot{
+				Type:        enemyType,
+				Name:        data.Name,
+				Level:       data.Level,
+				MaxHP:       data.MaxHP,
+				AttackPower: data.AttackPower,
+			}, nil
+		}
+	}
+	return combat.EnemySnapshot{}, fmt.Errorf("敵タイプが見つかりません: %s", data.TypeID)
+}
+
+// challengeToSaveData はタイピングチャレンジをセーブデータ形式に変換します。
+// 入力方式は設定と同じ文字列（english/romaji）で保存します。
+func challengeToSaveData(challenge *typing.Challenge) *savedata.ReplayChallengeSave {
+	mode := TypingModeEnglish
+	if challenge.Mode == typing.InputModeRomaji {
+		mode = TypingModeRomaji
+	}
+	return &savedata.ReplayChallengeSave{
+		Text:        challenge.Text,
+		Reading:     challenge.Reading,
+		TimeLimitNs: int64(challenge.TimeLimit),
+		Difficulty:  int(challenge.Difficulty),
+		Mode:        string(mode),
+	}
+}
+
+// challengeFromSaveData はセーブデータからタイピングチャレンジを復元します。
+func challengeFromSaveData(data *savedata.ReplayChallengeSave) *typing.Challenge {
+	mode := typing.InputModeDirect
+	if TypingMode(data.Mode) == TypingModeRomaji {
+		mode = typing.InputModeRomaji
+	}
+	return &typing.Challenge{
+		Text:       data.Text,
+		Reading:    data.Reading,
+		TimeLimit:  time.Duration(data.TimeLimitNs),
+		Difficulty: typing.Difficulty(data.Difficulty),
+		Mode:       mode,
+	}
+}
@@ -0,0 +1,124 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/infra/savedata"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/rewarding"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// newReplayTestSources はリプレイ復元テスト用のマスタデータを作成します。
+func newReplayTestSources() *DomainDataSources {
+	return &DomainDataSources{
+		CoreTypes: []domain.CoreType{
+			{ID: "all_rounder", Name: "オールラウンダー", StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0}},
+		},
+		ModuleTypes: []rewarding.ModuleDropInfo{
+			{
+				ID:   "test_module",
+				Name: "テストモジュール",
+				Tags: []string{"physical_low"},
+				Effects: []domain.ModuleEffect{
+					{Target: domain.TargetEnemy, HPFormula: &domain.HPFormula{StatCoef: 1.0, StatRef: "STR"}, Probability: 1.0},
+				},
+			},
+		},
+		EnemyTypes: []domain.EnemyType{
+			{ID: "slime", Name: "スライム", BaseHP: 100, BaseAttackPower: 10},
+		},
+	}
+}
+
+// TestReplaySaveDataRoundTrip はリプレイのセーブデータ変換と復元をテストします。
+func TestReplaySaveDataRoundTrip(t *testing.T) {
+	sources := newReplayTestSources()
+	agent := agentFromSaveData(savedata.AgentInstanceSave{
+		ID:      "agent_1",
+		Core:    savedata.CoreInstanceSave{CoreTypeID: "all_rounder", Level: 5},
+		Modules: []savedata.ModuleInstanceSave{{TypeID: "test_module"}},
+	}, sources)
+
+	original := &combat.Replay{
+		RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Seeds:      combat.ReplaySeeds{Engine: 1, Challenge: 2, PlayerEffects: 3, EnemyEffects: 4},
+		Enemy:      combat.EnemySnapshot{Type: sources.EnemyTypes[0], Name: "スライム", Level: 3, MaxHP: 150, AttackPower: 12},
+		Waves: [][]combat.EnemySnapshot{
+			{
+				{Type: sources.EnemyTypes[0], Name: "スライム", Level: 3, MaxHP: 150, AttackPower: 12},
+				{Type: sources.EnemyTypes[0], Name: "スライム", Level: 4, MaxHP: 200, AttackPower: 14},
+			},
+			{{Type: sources.EnemyTypes[0], Name: "スライム", Level: 5, MaxHP: 250, AttackPower: 16}},
+		},
+		PlayerMaxHP: 120,
+		PlayerHP:    70,
+		Agents:      []*domain.AgentModel{agent},
+		Events: []combat.ReplayEvent{
+			{At: 100 * time.Millisecond, Kind: combat.ReplayEventTick, Delta: 100 * time.Millisecond},
+			{At: 150 * time.Millisecond, Kind: combat.ReplayEventChallenge, Slot: 0, Challenge: &typing.Challenge{
+				Text: "ねこ", Reading: "neko", TimeLimit: 5 * time.Second, Difficulty: typing.DifficultyMedium, Mode: typing.InputModeRomaji,
+			}},
+			{At: 300 * time.Millisecond, Kind: combat.ReplayEventKey, Rune: 'n'},
+			{At: 350 * time.Millisecond, Kind: combat.ReplayEventTarget, Target: 1},
+			{At: 400 * time.Millisecond, Kind: combat.ReplayEventCancel},
+		},
+		Victory:  true,
+		Duration: 400 * time.Millisecond,
+	}
+
+	restored, err := ReplayFromSaveData(ReplayToSaveData(original), sources)
+	if err != nil {
+		t.Fatalf("リプレイの復元に失敗: %v", err)
+	}
+
+	if restored.Seeds != original.Seeds {
+		t.Errorf("シード: 期待 %+v, 実際 %+v", original.Seeds, restored.Seeds)
+	}
+	if restored.Enemy.Type.ID != "slime" || restored.Enemy.MaxHP != 150 || restored.Enemy.AttackPower != 12 {
+		t.Errorf("敵スナップショット: 実際 %+v", restored.Enemy)
+	}
+	if len(restored.Waves) != 2 || len(restored.Waves[0]) != 2 || restored.Waves[0][1].Level != 4 || restored.Waves[1][0].MaxHP != 250 {
+		t.Errorf("ウェーブのスナップショット: 実際 %+v", restored.Waves)
+	}
+	if restored.PlayerMaxHP != 120 || restored.PlayerHP != 70 || !restored.Victory || restored.Duration != original.Duration {
+		t.Errorf("リプレイ情報: 実際 MaxHP=%d HP=%d Victory=%v Duration=%v", restored.PlayerMaxHP, restored.PlayerHP, restored.Victory, restored.Duration)
+	}
+	if restored.Retreated {
+		t.Error("勝利したリプレイが撤退扱いで復元されました")
+	}
+	original.Victory, original.Retreated = false, true
+	if retreated, err := ReplayFromSaveData(ReplayToSaveData(original), sources); err != nil || !retreated.Retreated || retreated.Victory {
+		t.Errorf("撤退したリプレイの復元: 実際 %+v, エラー %v", retreated, err)
+	}
+	original.Victory, original.Retreated = true, false
+	if len(restored.Agents) != 1 || restored.Agents[0].Core.Level != 5 || len(restored.Agents[0].Modules) != 1 {
+		t.Fatalf("エージェントが復元されていません: %+v", restored.Agents)
+	}
+
+	if len(restored.Events) != len(original.Events) {
+		t.Fatalf("イベント数: 期待 %d, 実際 %d", len(original.Events), len(restored.Events))
+	}
+	for i, event := range original.Events {
+		got := restored.Events[i]
+		if got.At != event.At || got.Kind != event.Kind || got.Delta != event.Delta || got.Slot != event.Slot || got.Rune != event.Rune || got.Target != event.Target {
+			t.Errorf("イベント%d: 期待 %+v, 実際 %+v", i, event, got)
+		}
+	}
+	if challenge := restored.Events[1].Challenge; challenge == nil || *challenge != *original.Events[1].Challenge {
+		t.Errorf("チャレンジ: 期待 %+v, 実際 %+v", original.Events[1].Challenge, challenge)
+	}
+}
+
+// TestReplayFromSaveData_UnknownEnemy は未知の敵タイプのリプレイがエラーになることをテストします。
+func TestReplayFromSaveData_UnknownEnemy(t *testing.T) {
+	data := &savedata.ReplayData{
+		Enemy:  savedata.ReplayEnemySave{TypeID: "unknown"},
+		Agents: []savedata.AgentInstanceSave{{ID: "agent_1"}},
+	}
+	if _, err := ReplayFromSaveData(data, newReplayTestSources()); err == nil {
+		t.Error("未知の敵タイプでエラーになりませんでした")
+	}
+}
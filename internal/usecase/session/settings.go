@@ -10,6 +10,12 @@ type Settings struct {
 
 	// difficulty は難易度設定です。
 	difficulty Difficulty
+
+	// typingMode はタイピングの入力モード設定です。
+	typingMode TypingMode
+
+	// adaptiveWords は苦手キーを含む単語を優先して出題するかどうかです。
+	adaptiveWords bool
 }
 
 // Difficulty は難易度を表す型です。
@@ -26,6 +32,17 @@ const (
 	DifficultyHard Difficulty = "hard"
 )
 
+// TypingMode はタイピングの入力モードを表す型です。
+type TypingMode string
+
+const (
+	// TypingModeEnglish は英単語をそのまま入力するモードです。
+	TypingModeEnglish TypingMode = "english"
+
+	// TypingModeRomaji は日本語の読みをローマ字で入力するモードです。
+	TypingModeRomaji TypingMode = "romaji"
+)
+
 // DefaultKeybinds はデフォルトのキーバインド設定です。
 var DefaultKeybinds = map[string]string{
 	"select":     "enter",
@@ -47,6 +64,7 @@ func NewSettings() *Settings {
 		keybinds:    keybinds,
 		soundVolume: 100,
 		difficulty:  DifficultyNormal,
+		typingMode:  TypingModeEnglish,
 	}
 }
 
@@ -94,11 +112,37 @@ func (s *Settings) SetDifficulty(difficulty Difficulty) {
 	s.difficulty = difficulty
 }
 
+// TypingMode はタイピングの入力モードを返します。
+func (s *Settings) TypingMode() TypingMode {
+	return s.typingMode
+}
+
+// SetTypingMode はタイピングの入力モードを設定します。
+// 未知の値が指定された場合は英単語モードになります。
+func (s *Settings) SetTypingMode(mode TypingMode) {
+	if mode != TypingModeRomaji {
+		mode = TypingModeEnglish
+	}
+	s.typingMode = mode
+}
+
+// AdaptiveWords は苦手キー優先出題が有効かを返します。
+func (s *Settings) AdaptiveWords() bool {
+	return s.adaptiveWords
+}
+
+// SetAdaptiveWords は苦手キー優先出題の有効/無効を設定します。
+func (s *Settings) SetAdaptiveWords(enabled bool) {
+	s.adaptiveWords = enabled
+}
+
 // ToScreensSettingsData は画面用のSettingsDataに変換します。
 func (s *Settings) ToScreensSettingsData() map[string]interface{} {
 	return map[string]interface{}{
-		"keybinds":     s.keybinds,
-		"sound_volume": s.soundVolume,
-		"difficulty":   string(s.difficulty),
+		"keybinds":       s.keybinds,
+		"sound_volume":   s.soundVolume,
+		"difficulty":     string(s.difficulty),
+		"typing_mode":    string(s.typingMode),
+		"adaptive_words": s.adaptiveWords,
 	}
 }
@@ -45,6 +45,9 @@ type BattleStatisticsData struct {
 	// Losses は敗北数です。
 	Losses int
 
+	// Retreats は撤退数です（敗北数には含みません）。
+	Retreats int
+
 	// MaxLevelReached は到達した最高レベルです。
 	MaxLevelReached int
 
@@ -59,6 +62,12 @@ type BattleStatisticsData struct {
 
 	// TotalHealingDone は回復した総量です。
 	TotalHealingDone int
+
+	// TotalCriticalHits はクリティカルヒットの総数です。
+	TotalCriticalHits int
+
+	// TowerBestFloor はエンドレスタワーの最高到達階です。
+	TowerBestFloor int
 }
 
 // NewStatisticsManager は新しいStatisticsManagerを作成します。
@@ -110,6 +119,13 @@ func (m *StatisticsManager) RecordBattleResult(victory bool, level int) {
 	}
 }
 
+// RecordBattleRetreat はバトルからの撤退を記録します。
+// 撤退は総バトル数に含めますが、敗北数とは別に数えます。
+func (m *StatisticsManager) RecordBattleRetreat() {
+	m.battle.TotalBattles++
+	m.battle.Retreats++
+}
+
 // RecordDamageDealt は与えたダメージを記録します。
 func (m *StatisticsManager) RecordDamageDealt(damage int) {
 	m.battle.TotalDamageDealt += damage
@@ -125,6 +141,18 @@ func (m *StatisticsManager) RecordHealing(amount int) {
 	m.battle.TotalHealingDone += amount
 }
 
+// RecordCriticalHits はクリティカルヒット回数を記録します。
+func (m *StatisticsManager) RecordCriticalHits(count int) {
+	m.battle.TotalCriticalHits += count
+}
+
+// RecordTowerFloor はエンドレスタワーで到達した階を記録し、最高到達階を更新します。
+func (m *StatisticsManager) RecordTowerFloor(floor int) {
+	if floor > m.battle.TowerBestFloor {
+		m.battle.TowerBestFloor = floor
+	}
+}
+
 // GetAverageWPM は平均WPMを返します。
 func (m *StatisticsManager) GetAverageWPM() float64 {
 	if m.typing.TotalSessions == 0 {
@@ -171,11 +199,14 @@ type StatisticsSaveData struct {
 	TotalBattles         int
 	Victories            int
 	Defeats              int
+	Retreats             int
 	MaxLevelReached      int
 	HighestWPM           float64
 	AverageWPM           float64
 	PerfectAccuracyCount int
 	TotalCharactersTyped int
+	TotalCriticalHits    int
+	TowerBestFloor       int
 }
 
 // LoadFromSaveData はセーブデータから統計を復元します。
@@ -186,6 +217,7 @@ func (m *StatisticsManager) LoadFromSaveData(data *StatisticsSaveData) {
 	m.battle.TotalBattles = data.TotalBattles
 	m.battle.Wins = data.Victories
 	m.battle.Losses = data.Defeats
+	m.battle.Retreats = data.Retreats
 	m.battle.MaxLevelReached = data.MaxLevelReached
 	m.typing.MaxWPM = int(data.HighestWPM)
 	// 平均WPMからTotalWPMを逆算
@@ -195,4 +227,6 @@ func (m *StatisticsManager) LoadFromSaveData(data *StatisticsSaveData) {
 	}
 	m.typing.PerfectAccuracyCount = data.PerfectAccuracyCount
 	m.typing.TotalCharacters = data.TotalCharactersTyped
+	m.battle.TotalCriticalHits = data.TotalCriticalHits
+	m.battle.TowerBestFloor = data.TowerBestFloor
 }
@@ -0,0 +1,135 @@
+// Package simulation はバランス調整用のバトルシミュレーションを提供します。
+// report.go はシミュレーション結果の集計を担当します。
+package simulation
+
+import (
+	"math"
+	"sort"
+
+	"hirorocky/type-battle/internal/usecase/combat"
+)
+
+// Summary は数値の分布の要約を表す構造体です。
+type Summary struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+}
+
+// ModuleUsage はモジュールごとの使用回数を表す構造体です。
+type ModuleUsage struct {
+	// AgentIndex はモジュールを装備しているエージェントの位置です。
+	AgentIndex int `json:"agent_index"`
+
+	// ModuleID はモジュール種別IDです。
+	ModuleID string `json:"module_id"`
+
+	// Name はモジュールの表示名です。
+	Name string `json:"name"`
+
+	// Uses は全バトルでの使用回数（タイピング完了回数）の合計です。
+	Uses int `json:"uses"`
+
+	// PerBattle は1戦あたりの平均使用回数です。
+	PerBattle float64 `json:"per_battle"`
+}
+
+// Report はシミュレーションの集計結果を表す構造体です。
+type Report struct {
+	// Battles は実行したバトル数です。
+	Battles int `json:"battles"`
+
+	// Wins は勝利数です。
+	Wins int `json:"wins"`
+
+	// TimedOut は打ち切り時間までに決着しなかったバトル数です（敗北に含む）。
+	TimedOut int `json:"timed_out"`
+
+	// WinRate は勝率（0.0〜1.0）です。
+	WinRate float64 `json:"win_rate"`
+
+	// TimeToKill は勝利したバトルの撃破までの秒数です。
+	TimeToKill Summary `json:"time_to_kill_seconds"`
+
+	// DamageTaken は全バトルで受けたダメージ量です。
+	DamageTaken Summary `json:"damage_taken"`
+
+	// ModuleUsage はモジュールスロット順の使用回数です。
+	ModuleUsage []ModuleUsage `json:"module_usage"`
+}
+
+// newReport は各バトルの結果を集計します。
+func newReport(outcomes []battleOutcome, slots []combat.ModuleSlot) *Report {
+	report := &Report{Battles: len(outcomes)}
+
+	var killTimes, damages []float64
+	uses := make([]int, len(slots))
+	for _, outcome := range outcomes {
+		if outcome.victory {
+			report.Wins++
+			killTimes = append(killTimes, outcome.duration.Seconds())
+		}
+		if outcome.timedOut {
+			report.TimedOut++
+		}
+		damages = append(damages, float64(outcome.damageTaken))
+		for i, n := range outcome.moduleUses {
+			uses[i] += n
+		}
+	}
+
+	if report.Battles > 0 {
+		report.WinRate = float64(report.Wins) / float64(report.Battles)
+	}
+	report.TimeToKill = summarize(killTimes)
+	report.DamageTaken = summarize(damages)
+
+	report.ModuleUsage = make([]ModuleUsage, len(slots))
+	for i, slot := range slots {
+		report.ModuleUsage[i] = ModuleUsage{
+			AgentIndex: slot.AgentIndex,
+			ModuleID:   slot.Module.TypeID,
+			Name:       slot.Module.Name(),
+			Uses:       uses[i],
+		}
+		if report.Battles > 0 {
+			report.ModuleUsage[i].PerBattle = float64(uses[i]) / float64(report.Battles)
+		}
+	}
+
+	return report
+}
+
+// summarize は値の平均・中央値・90パーセンタイル・最大値を計算します。
+// 値が空の場合はゼロ値を返します。
+func summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, v := range sorted {
+		total += v
+	}
+
+	return Summary{
+		Mean:   total / float64(len(sorted)),
+		Median: percentile(sorted, 0.5),
+		P90:    percentile(sorted, 0.9),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile はソート済みの値から指定割合の位置の値を返します（最近傍法）。
+func percentile(sorted []float64, p float64) float64 {
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
@@ -0,0 +1,261 @@
+// Package simulation はバランス調整用のバトルシミュレーションを提供します。
+// simulation.go は合成タイピストによるバトルの一括実行を担当します。
+// 各バトルはcombat.BattleSessionを手動時計で進めるため、実時間を待たずに実行できます。
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"hirorocky/type-battle/internal/config"
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/clock"
+	"hirorocky/type-battle/internal/usecase/combat"
+	"hirorocky/type-battle/internal/usecase/spawning"
+	"hirorocky/type-battle/internal/usecase/typing"
+)
+
+// DefaultMaxBattleDuration は1戦あたりの打ち切り時間のデフォルト値です。
+const DefaultMaxBattleDuration = 10 * time.Minute
+
+// simulationEpoch はシミュレーション用の手動時計の開始時刻です。
+var simulationEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Config はシミュレーションの設定を表す構造体です。
+type Config struct {
+	// EnemyType は対戦する敵タイプです。
+	EnemyType domain.EnemyType
+
+	// EnemyLevel は敵のレベルです。
+	EnemyLevel int
+
+	// Agents はプレイヤーが装備するエージェントです。
+	Agents []*domain.AgentModel
+
+	// Typist はモジュールを操作する合成タイピストです。
+	Typist TypistProfile
+
+	// Battles は実行するバトル数です。
+	Battles int
+
+	// Seed は乱数シードです。同じ設定・シードなら同じ結果になります。
+	Seed int64
+
+	// MaxBattleDuration は1戦あたりの打ち切り時間です（0の場合はDefaultMaxBattleDuration）。
+	// 打ち切られたバトルは敗北として集計されます。
+	MaxBattleDuration time.Duration
+
+	// Dictionary はタイピングチャレンジの辞書です（nilの場合はデフォルト辞書）。
+	Dictionary *typing.Dictionary
+
+	// PassiveSkills はパッシブスキル定義です。
+	PassiveSkills map[string]domain.PassiveSkill
+}
+
+// validate は設定が有効かを検証します。
+func (c *Config) validate() error {
+	if len(c.Agents) == 0 {
+		return fmt.Errorf("エージェントが指定されていません")
+	}
+	if c.Battles <= 0 {
+		return fmt.Errorf("バトル数は1以上を指定してください")
+	}
+	if c.EnemyLevel < spawning.MinEnemyLevel || c.EnemyLevel > spawning.MaxEnemyLevel {
+		return fmt.Errorf("敵レベルは%d〜%dを指定してください（指定: %d）", spawning.MinEnemyLevel, spawning.MaxEnemyLevel, c.EnemyLevel)
+	}
+	return c.Typist.Validate()
+}
+
+// battleOutcome は1戦分の結果です。
+type battleOutcome struct {
+	victory     bool
+	timedOut    bool
+	duration    time.Duration
+	damageTaken int
+	moduleUses  []int
+}
+
+// Run は設定に従ってバトルを繰り返し実行し、集計結果を返します。
+// i戦目は Seed+i を乱数シードとして実行されます。
+func Run(cfg Config) (*Report, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.MaxBattleDuration <= 0 {
+		cfg.MaxBattleDuration = DefaultMaxBattleDuration
+	}
+
+	generator := spawning.NewEnemyGenerator([]domain.EnemyType{cfg.EnemyType})
+	outcomes := make([]battleOutcome, 0, cfg.Battles)
+	var slots []combat.ModuleSlot
+	for i := 0; i < cfg.Battles; i++ {
+		rng := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+		enemy := generator.GenerateWithType(cfg.EnemyLevel, cfg.EnemyType.ID)
+		session := newSession(&cfg, enemy, rng)
+		if slots == nil {
+			slots = session.Slots()
+		}
+		// 敵・バトルの乱数とタイピストの乱数は分けておく
+		typistRng := rand.New(rand.NewSource(rng.Int63()))
+		outcomes = append(outcomes, runBattle(session, cfg.Typist, typistRng, cfg.MaxBattleDuration))
+	}
+
+	return newReport(outcomes, slots), nil
+}
+
+// newSession はシード固定の乱数を設定したバトルセッションを作成します。
+func newSession(cfg *Config, enemy *domain.EnemyModel, rng *rand.Rand) *combat.BattleSession {
+	player := domain.NewPlayer()
+	player.RecalculateHP(cfg.Agents)
+	player.PrepareForBattle()
+
+	session := combat.NewBattleSession(enemy, player, cfg.Agents, cfg.Dictionary)
+	session.SetRng(rng)
+	if cfg.PassiveSkills != nil {
+		session.SetPassiveSkills(cfg.PassiveSkills)
+	}
+	return session
+}
+
+// runBattle は合成タイピストで1戦を最後まで実行します。
+// バトルはconfig.BattleTickInterval刻みで進め、その間の打鍵は打鍵時刻に時計を合わせて入力します。
+func runBattle(session *combat.BattleSession, typist TypistProfile, rng *rand.Rand, maxDuration time.Duration) battleOutcome {
+	c := clock.NewManualClock(simulationEpoch)
+	session.SetClock(c)
+	session.Start()
+
+	bot := &typistBot{
+		session:    session,
+		typist:     typist,
+		rng:        rng,
+		nextAction: simulationEpoch.Add(typist.ReactionDelay),
+		moduleUses: make([]int, len(session.Slots())),
+	}
+
+	deadline := simulationEpoch.Add(maxDuration)
+	for !session.IsOver() && c.Now().Before(deadline) {
+		stepEnd := c.Now().Add(config.BattleTickInterval)
+		bot.actUntil(c, stepEnd)
+
+		wasTyping := session.IsTyping()
+		c.Set(stepEnd)
+		session.Tick(config.BattleTickInterval)
+		// 制限時間切れなどでタイピングが終わった場合も反応時間を置いて次の行動へ
+		if wasTyping && !session.IsTyping() {
+			bot.nextAction = c.Now().Add(typist.ReactionDelay)
+		}
+	}
+
+	result := session.Result()
+	return battleOutcome{
+		victory:     session.IsOver() && result.IsVictory,
+		timedOut:    !session.IsOver(),
+		duration:    c.Now().Sub(simulationEpoch),
+		damageTaken: result.Stats.TotalDamageTaken,
+		moduleUses:  bot.moduleUses,
+	}
+}
+
+// typistBot は合成タイピストの操作（モジュール選択と打鍵）を表す構造体です。
+type typistBot struct {
+	session *combat.BattleSession
+	typist  TypistProfile
+	rng     *rand.Rand
+
+	// nextAction は次にモジュール選択または打鍵を行う時刻です。
+	nextAction time.Time
+
+	// moduleUses はスロットごとのモジュール使用回数です（タイピング完了で1回）。
+	moduleUses []int
+
+	// typingState と unitIndex は入力中の書記素クラスタを表し、unitOffset はそのクラスタ内で入力済みのルーン数です。
+	// 複数のルーンで構成されるクラスタは、ルーンを順に入力し終えるまで入力位置が進みません。
+	typingState *typing.ChallengeState
+	unitIndex   int
+	unitOffset  int
+}
+
+// actUntil は指定時刻までに行うべき操作を、操作時刻に時計を合わせながら実行します。
+func (b *typistBot) actUntil(c *clock.ManualClock, until time.Time) {
+	// 敵を倒した直後は次のTickで勝利が確定するため、それ以上操作しない
+	for !b.session.IsOver() && b.session.Enemy().IsAlive() && b.nextAction.Before(until) {
+		c.Set(b.nextAction)
+		if b.session.IsTyping() {
+			b.typeKey(c.Now())
+			continue
+		}
+		if b.selectModule() {
+			b.nextAction = c.Now().Add(b.typist.keyInterval(b.rng))
+			continue
+		}
+		// 使用可能なモジュールがなければ次のtickで再試行
+		b.nextAction = until
+		return
+	}
+}
+
+// selectModule は使用可能なモジュールのうち使用回数が最も少ないものを選択します。
+func (b *typistBot) selectModule() bool {
+	candidate := -1
+	for i := range b.session.Slots() {
+		if !b.session.IsModuleUsable(i) {
+			continue
+		}
+		if candidate < 0 || b.moduleUses[i] < b.moduleUses[candidate] {
+			candidate = i
+		}
+	}
+	return candidate >= 0 && b.session.SelectModule(candidate)
+}
+
+// typeKey は1打を入力します。ミスタイプの場合は次の打鍵で正しいキーを打ち直します。
+// 入力位置は書記素クラスタ単位のため、チャレンジテキストをtyping.SplitGraphemesで分割して期待する文字を求めます。
+func (b *typistBot) typeKey(now time.Time) {
+	units := typing.SplitGraphemes(b.session.TypingText())
+	index := b.session.TypingIndex()
+	if index >= len(units) {
+		b.nextAction = now.Add(b.typist.keyInterval(b.rng))
+		return
+	}
+
+	// 新しいチャレンジまたは次のクラスタに進んだ場合はクラスタの先頭から入力する
+	if state := b.session.TypingState(); state != b.typingState || index != b.unitIndex {
+		b.typingState = state
+		b.unitIndex = index
+		b.unitOffset = 0
+	}
+	unit := []rune(units[index])
+	if b.unitOffset >= len(unit) {
+		b.unitOffset = 0
+	}
+
+	expected := unit[b.unitOffset]
+	input := expected
+	if b.typist.misses(b.rng) {
+		input = wrongKey(expected)
+	}
+
+	slot := b.session.ActiveSlot()
+	b.session.ProcessTypingInput(input)
+	if input == expected {
+		b.unitOffset++
+	} else {
+		// ミスタイプでクラスタの途中までの入力は破棄される
+		b.unitOffset = 0
+	}
+	if !b.session.IsTyping() {
+		b.moduleUses[slot]++
+		b.nextAction = now.Add(b.typist.ReactionDelay)
+		return
+	}
+	b.nextAction = now.Add(b.typist.keyInterval(b.rng))
+}
+
+// wrongKey は期待される文字とは異なる文字を返します。
+func wrongKey(expected rune) rune {
+	if expected == 'x' {
+		return 'z'
+	}
+	return 'x'
+}
@@ -0,0 +1,206 @@
+// Package simulation はバランス調整用のバトルシミュレーションを提供します。
+// simulation_test.go は合成タイピストによるバトルシミュレーションのテストです。
+package simulation
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"hirorocky/type-battle/internal/domain"
+	"hirorocky/type-battle/internal/usecase/spawning"
+)
+
+// newTestConfig はテスト用のシミュレーション設定を作成します。
+func newTestConfig(enemyHP, enemyAttack int) Config {
+	enemyType := domain.EnemyType{
+		ID:              "slime",
+		Name:            "スライム",
+		BaseHP:          enemyHP,
+		BaseAttackPower: enemyAttack,
+		AttackType:      "physical",
+		ResolvedNormalActions: []domain.EnemyAction{
+			{ID: "attack", ActionType: domain.EnemyActionAttack, AttackType: "physical", ChargeTime: 3 * time.Second},
+		},
+	}
+
+	coreType := domain.CoreType{
+		ID:          "all_rounder",
+		Name:        "オールラウンダー",
+		StatWeights: map[string]float64{"STR": 1.0, "INT": 1.0, "WIL": 1.0, "LUK": 1.0},
+		AllowedTags: []string{"physical_low"},
+	}
+	core := domain.NewCore("core_001", "コア", 10, coreType, domain.PassiveSkill{})
+	modules := []*domain.ModuleModel{
+		newTestModule("strike_a", "斬撃A"),
+		newTestModule("strike_b", "斬撃B"),
+	}
+
+	return Config{
+		EnemyType:  enemyType,
+		EnemyLevel: 1,
+		Agents:     []*domain.AgentModel{domain.NewAgent("agent_001", core, modules)},
+		Typist: TypistProfile{
+			MeanWPM:       60,
+			Accuracy:      0.95,
+			ReactionDelay: 300 * time.Millisecond,
+		},
+		Battles: 20,
+		Seed:    42,
+	}
+}
+
+// newTestModule はテスト用の物理攻撃モジュールを作成します。
+func newTestModule(id, name string) *domain.ModuleModel {
+	return domain.NewModuleFromType(domain.ModuleType{
+		ID:              id,
+		Name:            name,
+		Icon:            "⚔",
+		Tags:            []string{"physical_low"},
+		CooldownSeconds: 1.0,
+		Difficulty:      1,
+		Effects: []domain.ModuleEffect{
+			{Target: domain.TargetEnemy, HPFormula: &domain.HPFormula{Base: 0, StatCoef: 1.0, StatRef: "STR"}, Probability: 1.0},
+		},
+	}, nil)
+}
+
+// TestRun_Deterministic は同じシードで同じ結果になることをテストします。
+func TestRun_Deterministic(t *testing.T) {
+	cfg := newTestConfig(200, 10)
+
+	first, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("シミュレーションに失敗しました: %v", err)
+	}
+	second, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("シミュレーションに失敗しました: %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("同じシードで結果が異なります:\n%+v\n%+v", first, second)
+	}
+}
+
+// TestRun_FastTypistBeatsWeakEnemy は速いタイピストが弱い敵に全勝することをテストします。
+func TestRun_FastTypistBeatsWeakEnemy(t *testing.T) {
+	cfg := newTestConfig(1000, 1)
+	cfg.Typist.MeanWPM = 100
+	cfg.Typist.Accuracy = 1.0
+
+	report, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("シミュレーションに失敗しました: %v", err)
+	}
+
+	if report.Battles != cfg.Battles {
+		t.Errorf("バトル数: 期待 %d, 実際 %d", cfg.Battles, report.Battles)
+	}
+	if report.WinRate != 1.0 {
+		t.Errorf("勝率: 期待 1.0, 実際 %f", report.WinRate)
+	}
+	if report.TimeToKill.Mean <= 0 || report.TimeToKill.Max < report.TimeToKill.Median {
+		t.Errorf("撃破時間の集計が不正です: %+v", report.TimeToKill)
+	}
+
+	if len(report.ModuleUsage) != 2 {
+		t.Fatalf("モジュール使用回数の件数: 期待 2, 実際 %d", len(report.ModuleUsage))
+	}
+	for _, usage := range report.ModuleUsage {
+		if usage.Uses == 0 {
+			t.Errorf("%s が一度も使用されていません", usage.ModuleID)
+		}
+	}
+}
+
+// TestRun_StrongEnemyDefeatsSlowTypist は遅いタイピストが強い敵に敗北することをテストします。
+func TestRun_StrongEnemyDefeatsSlowTypist(t *testing.T) {
+	cfg := newTestConfig(5000, 200)
+	cfg.Typist.MeanWPM = 20
+
+	report, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("シミュレーションに失敗しました: %v", err)
+	}
+
+	if report.Wins != 0 {
+		t.Errorf("勝利数: 期待 0, 実際 %d", report.Wins)
+	}
+	if report.DamageTaken.Mean <= 0 {
+		t.Error("被ダメージが集計されていません")
+	}
+	if report.TimeToKill != (Summary{}) {
+		t.Errorf("勝利がない場合の撃破時間: 期待 ゼロ値, 実際 %+v", report.TimeToKill)
+	}
+}
+
+// TestTypistBot_MultiRuneGrapheme は複数のルーンで構成される書記素クラスタをルーン順に入力できることをテストします。
+func TestTypistBot_MultiRuneGrapheme(t *testing.T) {
+	cfg := newTestConfig(1000, 1)
+	enemy := spawning.NewEnemyGenerator([]domain.EnemyType{cfg.EnemyType}).GenerateWithType(1, cfg.EnemyType.ID)
+	session := newSession(&cfg, enemy, rand.New(rand.NewSource(1)))
+
+	bot := &typistBot{
+		session:    session,
+		typist:     TypistProfile{MeanWPM: 60, Accuracy: 1.0},
+		rng:        rand.New(rand.NewSource(1)),
+		moduleUses: make([]int, len(session.Slots())),
+	}
+
+	// "e\u0301"（結合文字のアクセント付きe）は2ルーンで1文字
+	session.StartTypingChallenge(0, "e\u0301x", 10*time.Second)
+	now := time.Now()
+	for i := 0; i < 3 && session.IsTyping(); i++ {
+		bot.typeKey(now)
+	}
+
+	if session.IsTyping() {
+		t.Fatalf("3打で入力が完了するべき: 入力位置 %d / %d", session.TypingIndex(), session.TypingLength())
+	}
+	if bot.moduleUses[0] != 1 {
+		t.Errorf("モジュール使用回数: 期待 1, 実際 %d", bot.moduleUses[0])
+	}
+	if result := session.Result(); result.Stats.TotalTypingCount != 1 || result.Stats.GetAverageAccuracy() != 1.0 {
+		t.Errorf("ミスなしで完了するべき: %+v", result.Stats)
+	}
+}
+
+// TestRun_InvalidConfig は不正な設定でエラーになることをテストします。
+func TestRun_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{"エージェントなし", func(cfg *Config) { cfg.Agents = nil }},
+		{"バトル数0", func(cfg *Config) { cfg.Battles = 0 }},
+		{"敵レベル0", func(cfg *Config) { cfg.EnemyLevel = 0 }},
+		{"WPM0", func(cfg *Config) { cfg.Typist.MeanWPM = 0 }},
+		{"正確性1超", func(cfg *Config) { cfg.Typist.Accuracy = 1.5 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig(100, 1)
+			tt.modify(&cfg)
+			if _, err := Run(cfg); err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+// TestSummarize は分布の要約の計算をテストします。
+func TestSummarize(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10}
+	got := summarize(values)
+	want := Summary{Mean: 5.5, Median: 5, P90: 9, Max: 10}
+	if got != want {
+		t.Errorf("要約: 期待 %+v, 実際 %+v", want, got)
+	}
+
+	if summarize(nil) != (Summary{}) {
+		t.Error("空の値の要約がゼロ値ではありません")
+	}
+}
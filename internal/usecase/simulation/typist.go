@@ -0,0 +1,53 @@
+// Package simulation はバランス調整用のバトルシミュレーションを提供します。
+// typist.go は合成タイピストプロファイルと打鍵タイミングの生成を担当します。
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// charactersPerWord はWPM計算における1単語あたりの文字数です。
+const charactersPerWord = 5
+
+// keyIntervalJitter は打鍵間隔のゆらぎの割合です（±20%）。
+const keyIntervalJitter = 0.2
+
+// TypistProfile は合成タイピストの能力を表す構造体です。
+type TypistProfile struct {
+	// MeanWPM は平均タイピング速度（1単語=5文字換算）です。
+	MeanWPM float64
+
+	// Accuracy は1打ごとに正しいキーを打つ確率（0.0〜1.0）です。
+	Accuracy float64
+
+	// ReactionDelay はタイピング終了から次のモジュールを選択するまでの待ち時間です。
+	ReactionDelay time.Duration
+}
+
+// Validate はプロファイルの値が有効かを検証します。
+func (p TypistProfile) Validate() error {
+	if p.MeanWPM <= 0 {
+		return fmt.Errorf("WPMは0より大きい値を指定してください")
+	}
+	if p.Accuracy <= 0 || p.Accuracy > 1 {
+		return fmt.Errorf("正確性は0より大きく1以下の値を指定してください")
+	}
+	if p.ReactionDelay < 0 {
+		return fmt.Errorf("反応時間は0以上を指定してください")
+	}
+	return nil
+}
+
+// keyInterval は平均WPMからゆらぎを加えた1打の間隔を返します。
+func (p TypistProfile) keyInterval(rng *rand.Rand) time.Duration {
+	mean := float64(time.Minute) / (p.MeanWPM * charactersPerWord)
+	jitter := 1 + (rng.Float64()*2-1)*keyIntervalJitter
+	return time.Duration(mean * jitter)
+}
+
+// misses は次の1打がミスタイプになるかを判定します。
+func (p TypistProfile) misses(rng *rand.Rand) bool {
+	return rng.Float64() >= p.Accuracy
+}
@@ -29,6 +29,9 @@ type EnemyGenerator struct {
 	// enemyTypes は敵タイプ定義リストです（ドメイン型）。
 	enemyTypes []domain.EnemyType
 
+	// encounters はエンカウント（複数敵・ウェーブ戦）定義リストです。
+	encounters []domain.Encounter
+
 	// rng は乱数生成器です。
 	rng *rand.Rand
 }
@@ -105,6 +108,64 @@ func (g *EnemyGenerator) GetEnemyTypes() []domain.EnemyType {
 	return g.enemyTypes
 }
 
+// SetEncounters はエンカウント定義を設定します。
+func (g *EnemyGenerator) SetEncounters(encounters []domain.Encounter) {
+	g.encounters = encounters
+}
+
+// GetEncounters は全てのエンカウント定義を返します。
+func (g *EnemyGenerator) GetEncounters() []domain.Encounter {
+	return g.encounters
+}
+
+// GenerateEncounter は指定されたエンカウントのウェーブごとの敵を生成します。
+// 敵タイプが見つからないメンバーはスキップし、敵のいないウェーブは含めません。
+// エンカウントが見つからない場合はnilを返します。
+func (g *EnemyGenerator) GenerateEncounter(encounterID string) [][]*domain.EnemyModel {
+	for _, encounter := range g.encounters {
+		if encounter.ID != encounterID {
+			continue
+		}
+		var waves [][]*domain.EnemyModel
+		for _, wave := range encounter.Waves {
+			var enemies []*domain.EnemyModel
+			for _, member := range wave.Members {
+				if enemyType, ok := g.findEnemyType(member.EnemyTypeID); ok {
+					enemies = append(enemies, g.generateFromType(member.Level, enemyType))
+				}
+			}
+			if len(enemies) > 0 {
+				waves = append(waves, enemies)
+			}
+		}
+		return waves
+	}
+	return nil
+}
+
+// findEnemyType は敵タイプをIDで検索します。
+func (g *EnemyGenerator) findEnemyType(typeID string) (domain.EnemyType, bool) {
+	for _, enemyType := range g.enemyTypes {
+		if enemyType.ID == typeID {
+			return enemyType, true
+		}
+	}
+	return domain.EnemyType{}, false
+}
+
+// generateFromType は指定された敵タイプとレベルで敵を生成します。
+func (g *EnemyGenerator) generateFromType(level int, enemyType domain.EnemyType) *domain.EnemyModel {
+	level = g.clampLevel(level)
+	return domain.NewEnemy(
+		uuid.New().String(),
+		fmt.Sprintf("%s Lv.%d", enemyType.Name, level),
+		level,
+		g.calculateHP(enemyType.BaseHP, level),
+		g.calculateAttackPower(enemyType.BaseAttackPower, level),
+		enemyType,
+	)
+}
+
 // SetSeed は乱数シードを設定します（テスト用）。
 func (g *EnemyGenerator) SetSeed(seed int64) {
 	g.rng = rand.New(rand.NewSource(seed))
@@ -314,6 +314,46 @@ func TestEnemyGenerator_GetEnemyTypes(t *testing.T) {
 	}
 }
 
+// TestEnemyGenerator_GenerateEncounter はエンカウントのウェーブごとの敵生成をテストします。
+func TestEnemyGenerator_GenerateEncounter(t *testing.T) {
+	enemyTypes := []domain.EnemyType{
+		{ID: "slime", Name: "スライム", BaseHP: 100, BaseAttackPower: 10},
+		{ID: "bat", Name: "コウモリ", BaseHP: 80, BaseAttackPower: 12},
+	}
+
+	gen := NewEnemyGenerator(enemyTypes)
+	gen.SetEncounters([]domain.Encounter{
+		{
+			ID:   "pack",
+			Name: "群れ",
+			Waves: []domain.EncounterWave{
+				{Members: []domain.EncounterMember{{EnemyTypeID: "slime", Level: 3}, {EnemyTypeID: "bat", Level: 4}}},
+				{Members: []domain.EncounterMember{{EnemyTypeID: "unknown", Level: 5}}},
+				{Members: []domain.EncounterMember{{EnemyTypeID: "slime", Level: 5}}},
+			},
+		},
+	})
+
+	waves := gen.GenerateEncounter("pack")
+	// 敵タイプが見つからないメンバーのみのウェーブは含めない
+	if len(waves) != 2 || len(waves[0]) != 2 || len(waves[1]) != 1 {
+		t.Fatalf("ウェーブ構成が不正: %v", waves)
+	}
+	if waves[0][1].Type.ID != "bat" || waves[0][1].Level != 4 {
+		t.Errorf("2体目の敵: got %s Lv.%d, want bat Lv.4", waves[0][1].Type.ID, waves[0][1].Level)
+	}
+	if waves[1][0].MaxHP != 500 {
+		t.Errorf("最終ウェーブの敵のHP: got %d, want 500", waves[1][0].MaxHP)
+	}
+	if waves[0][0].ID == waves[1][0].ID {
+		t.Error("同じ敵タイプの敵IDが重複しています")
+	}
+
+	if gen.GenerateEncounter("missing") != nil {
+		t.Error("存在しないエンカウントはnilを返すべき")
+	}
+}
+
 // ==================== Task 6.1: 敵生成フロー統合テスト ====================
 
 // TestEnemyGenerator_GenerateWithType_ActionPatternIntegration は行動パターン付き敵タイプからの生成をテストします。
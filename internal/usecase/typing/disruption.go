@@ -0,0 +1,133 @@
+// Package typing はタイピングシステムを提供します。
+// disruption.go は敵のデバフによるタイピングチャレンジの妨害を担当します。
+package typing
+
+import (
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// HiddenMark は先読み封じで隠された入力単位の表示です。
+const HiddenMark = "?"
+
+// Disruption は敵のデバフによるタイピングチャレンジの妨害を表す構造体です。
+// 表示の妨害（先読み封じ・鏡文字）は描画時に、出題の妨害（文字シャッフル・大文字混じり）は
+// チャレンジ開始時に、制限時間の縮小はチャレンジ進行中に適用されます。
+type Disruption struct {
+	// VisibleAhead は表示される未入力の入力単位数です（0は制限なし）。
+	VisibleAhead int
+
+	// Mirrored はテキストを左右反転して表示するかどうかです。
+	Mirrored bool
+
+	// Shuffled はテキストの文字を並べ替えて出題するかどうかです。
+	Shuffled bool
+
+	// CaseSensitive は大文字小文字を厳密に判定するかどうかです。
+	// 直接入力モードでは大文字混じりのテキストで出題します。
+	CaseSensitive bool
+
+	// TimeShrink は制限時間が1秒あたりに縮む秒数です。
+	TimeShrink float64
+}
+
+// IsActive はいずれかの妨害が有効かを判定します。
+func (d Disruption) IsActive() bool {
+	return d.VisibleAhead > 0 || d.Mirrored || d.Shuffled || d.CaseSensitive || d.TimeShrink > 0
+}
+
+// Apply はチャレンジに妨害を適用した新しいチャレンジを返します（元のチャレンジは変更しません）。
+// 直接入力モードでは文字シャッフルと大文字混じりの出題を行います。
+// ローマ字モードでは読みを変更せず、大文字小文字の区別のみを判定に反映します。
+func (d Disruption) Apply(challenge *Challenge, rng *rand.Rand) *Challenge {
+	disrupted := *challenge
+	disrupted.Disruption = d
+	if challenge.Mode == InputModeRomaji {
+		return &disrupted
+	}
+
+	units := SplitGraphemes(challenge.Text)
+	if d.Shuffled {
+		shuffleUnits(units, rng)
+	}
+	if d.CaseSensitive {
+		randomizeCase(units, rng)
+	}
+	disrupted.Text = strings.Join(units, "")
+	return &disrupted
+}
+
+// MaskUnits は先読み封じを反映した表示用の入力単位を返します。
+// 現在位置からVisibleAhead個より先の単位はHiddenMarkに置き換えます。
+func (d Disruption) MaskUnits(units []string, currentIndex int) []string {
+	if d.VisibleAhead <= 0 {
+		return units
+	}
+	masked := make([]string, len(units))
+	for i, unit := range units {
+		if i >= currentIndex+d.VisibleAhead {
+			masked[i] = HiddenMark
+			continue
+		}
+		masked[i] = unit
+	}
+	return masked
+}
+
+// shuffleUnits は入力単位を並べ替えます。
+// 元と同じ並びになった場合は数回まで並べ替えをやり直します。
+func shuffleUnits(units []string, rng *rand.Rand) {
+	if len(units) < 2 {
+		return
+	}
+	original := strings.Join(units, "")
+	for attempt := 0; attempt < 3; attempt++ {
+		rng.Shuffle(len(units), func(i, j int) {
+			units[i], units[j] = units[j], units[i]
+		})
+		if strings.Join(units, "") != original {
+			return
+		}
+	}
+}
+
+// randomizeCase は英字の大文字小文字をランダムに反転します。
+// 1文字も反転しなかった場合は最初の英字を反転し、必ず大文字小文字の混在が起きるようにします。
+func randomizeCase(units []string, rng *rand.Rand) {
+	first := -1
+	flipped := false
+	for i, unit := range units {
+		toggled := toggleCase(unit)
+		if toggled == unit {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if rng.Intn(2) == 0 {
+			units[i] = toggled
+			flipped = true
+		}
+	}
+	if !flipped && first >= 0 {
+		units[first] = toggleCase(units[first])
+	}
+}
+
+// toggleCase は1文字の入力単位の大文字小文字を反転します。
+// 大文字小文字の区別がない文字や複数ルーンの単位はそのまま返します。
+func toggleCase(unit string) string {
+	runes := []rune(unit)
+	if len(runes) != 1 {
+		return unit
+	}
+	r := runes[0]
+	switch {
+	case unicode.IsUpper(r):
+		return string(unicode.ToLower(r))
+	case unicode.IsLower(r):
+		return string(unicode.ToUpper(r))
+	}
+	return unit
+}
@@ -0,0 +1,96 @@
+// Package typing はタイピングシステムを提供します。
+// disruption_test.go はタイピング妨害のテストです。
+
+package typing
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ==================== タイピング妨害テスト ====================
+
+// sortedUnits は入力単位を並べ替えた文字列を返します（文字の構成比較用）。
+func sortedUnits(text string) string {
+	units := SplitGraphemes(text)
+	sort.Strings(units)
+	return strings.Join(units, "")
+}
+
+// TestDisruption_ApplyShuffle は文字シャッフルで文字の構成を保ったまま並びが変わることをテストします。
+func TestDisruption_ApplyShuffle(t *testing.T) {
+	challenge := &Challenge{Text: "keyboard", TimeLimit: 5 * time.Second}
+	disrupted := Disruption{Shuffled: true}.Apply(challenge, rand.New(rand.NewSource(1)))
+
+	if disrupted.Text == challenge.Text {
+		t.Error("シャッフル後のテキストが元と同じです")
+	}
+	if sortedUnits(disrupted.Text) != sortedUnits(challenge.Text) {
+		t.Errorf("シャッフルで文字の構成が変わりました: %q → %q", challenge.Text, disrupted.Text)
+	}
+	if challenge.Text != "keyboard" {
+		t.Error("元のチャレンジが変更されました")
+	}
+	if !disrupted.Disruption.Shuffled {
+		t.Error("チャレンジに妨害が記録されるべきです")
+	}
+}
+
+// TestDisruption_ApplyCaseSensitive は大文字小文字の強制で大文字混じりのテキストになり、厳密に判定されることをテストします。
+func TestDisruption_ApplyCaseSensitive(t *testing.T) {
+	challenge := &Challenge{Text: "a-b", TimeLimit: 5 * time.Second}
+	disrupted := Disruption{CaseSensitive: true}.Apply(challenge, rand.New(rand.NewSource(1)))
+
+	if disrupted.Text == challenge.Text || strings.ToLower(disrupted.Text) != challenge.Text {
+		t.Fatalf("大文字混じりのテキストになるべき: %q", disrupted.Text)
+	}
+
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(disrupted)
+	for _, r := range strings.ToLower(disrupted.Text) {
+		evaluator.ProcessInput(state, r)
+	}
+	if len(state.Mistakes) == 0 {
+		t.Error("小文字のみの入力はミスと判定されるべきです")
+	}
+}
+
+// TestDisruption_RomajiCaseSensitive はローマ字モードで大文字小文字の強制により大文字入力が拒否されることをテストします。
+func TestDisruption_RomajiCaseSensitive(t *testing.T) {
+	challenge := &Challenge{Text: "か", Reading: "か", Mode: InputModeRomaji, TimeLimit: 5 * time.Second}
+	evaluator := NewEvaluator()
+
+	normal := evaluator.StartChallenge(challenge)
+	if !evaluator.Accepts(normal, 'K') {
+		t.Error("通常は大文字入力も受理されるべきです")
+	}
+
+	disrupted := Disruption{CaseSensitive: true}.Apply(challenge, rand.New(rand.NewSource(1)))
+	if disrupted.Reading != challenge.Reading {
+		t.Error("ローマ字モードでは読みを変更しないべきです")
+	}
+	state := evaluator.StartChallenge(disrupted)
+	if evaluator.Accepts(state, 'K') {
+		t.Error("大文字小文字の強制中は大文字入力が拒否されるべきです")
+	}
+	if !evaluator.Accepts(state, 'k') {
+		t.Error("小文字入力は受理されるべきです")
+	}
+}
+
+// TestDisruption_MaskUnits は先読み封じで現在位置から指定数より先の文字が隠されることをテストします。
+func TestDisruption_MaskUnits(t *testing.T) {
+	units := SplitGraphemes("typing")
+
+	masked := Disruption{VisibleAhead: 2}.MaskUnits(units, 1)
+	if got := strings.Join(masked, ""); got != "typ???" {
+		t.Errorf("先読み封じの表示: 期待 %q, 実際 %q", "typ???", got)
+	}
+
+	if got := strings.Join(Disruption{}.MaskUnits(units, 1), ""); got != "typing" {
+		t.Errorf("妨害なしでは全て表示されるべき: %q", got)
+	}
+}
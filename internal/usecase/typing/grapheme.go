@@ -0,0 +1,114 @@
+// Package typing はタイピングシステムを提供します。
+// grapheme.go はチャレンジテキストの書記素クラスタ単位の扱いを担当します。
+
+package typing
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SplitGraphemes はテキストを書記素クラスタ（見た目上の1文字）単位に分割します。
+// アクセント付き文字や絵文字、CJK文字も1単位として扱います。
+func SplitGraphemes(text string) []string {
+	units := make([]string, 0, len(text))
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		units = append(units, graphemes.Str())
+	}
+	return units
+}
+
+// GraphemeCount はテキストの書記素クラスタ数を返します。
+func GraphemeCount(text string) int {
+	return uniseg.GraphemeClusterCount(text)
+}
+
+// inputMatcher は入力方式ごとの1打判定を抽象化するインターフェースです。
+type inputMatcher interface {
+	Accepts(r rune) bool
+	Input(r rune) (accepted bool, completed int)
+	Expected() rune
+	Index() int
+	IsComplete() bool
+}
+
+// graphemeMatcher は書記素クラスタ単位で直接入力を判定する構造体です。
+// 複数のルーンで構成されるクラスタは、ルーンを順に入力し終えた時点で1文字確定とします。
+// 入力はテキストのルーン列どおりのほか、NFC（合成済みの文字）に正規化した形でも比較するため、
+// 分解形式（"e"+U+0301）のテキストもキーボードが送る合成済みの1打（"é"）で入力できます。
+type graphemeMatcher struct {
+	units   []string
+	index   int
+	pending string
+}
+
+// newGraphemeMatcher はテキストから新しいgraphemeMatcherを作成します。
+func newGraphemeMatcher(text string) *graphemeMatcher {
+	return &graphemeMatcher{
+		units: SplitGraphemes(text),
+	}
+}
+
+// Accepts は入力を処理した場合に受理されるかを判定します（状態は変更しません）。
+func (m *graphemeMatcher) Accepts(r rune) bool {
+	if m.IsComplete() {
+		return false
+	}
+	return matchesGraphemePrefix(m.units[m.index], m.pending+string(r))
+}
+
+// matchesGraphemePrefix は入力がクラスタの先頭部分に一致するかを、そのままとNFC正規化後の両方で判定します。
+func matchesGraphemePrefix(unit, input string) bool {
+	return strings.HasPrefix(unit, input) || strings.HasPrefix(norm.NFC.String(unit), norm.NFC.String(input))
+}
+
+// matchesGrapheme は入力がクラスタ全体に一致するかを、そのままとNFC正規化後の両方で判定します。
+func matchesGrapheme(unit, input string) bool {
+	return unit == input || norm.NFC.String(unit) == norm.NFC.String(input)
+}
+
+// Input は1打の入力を処理します。
+// 受理されたかどうかと、この入力で確定した書記素クラスタ数を返します。
+func (m *graphemeMatcher) Input(r rune) (accepted bool, completed int) {
+	if !m.Accepts(r) {
+		m.pending = ""
+		return false, 0
+	}
+
+	m.pending += string(r)
+	if !matchesGrapheme(m.units[m.index], m.pending) {
+		return true, 0
+	}
+	m.pending = ""
+	m.index++
+	return true, 1
+}
+
+// Expected は次に入力すべき文字を返します（完了時は0）。
+// クラスタの入力前はNFCに正規化した文字（キーボードが送る合成済みの文字）を返します。
+func (m *graphemeMatcher) Expected() rune {
+	if m.IsComplete() {
+		return 0
+	}
+	unit := norm.NFC.String(m.units[m.index])
+	if m.pending != "" {
+		unit = strings.TrimPrefix(m.units[m.index], m.pending)
+	}
+	for _, r := range unit {
+		return r
+	}
+	return 0
+}
+
+// Index は確定済みの書記素クラスタ数を返します。
+func (m *graphemeMatcher) Index() int {
+	return m.index
+}
+
+// IsComplete は全ての書記素クラスタが確定したかを判定します。
+func (m *graphemeMatcher) IsComplete() bool {
+	return m.index >= len(m.units)
+}
@@ -0,0 +1,136 @@
+// Package typing はタイピングシステムを提供します。
+// grapheme_test.go は書記素クラスタ単位の判定のテストです。
+
+package typing
+
+import (
+	"testing"
+	"time"
+)
+
+// ==================== 書記素クラスタ単位の判定テスト ====================
+
+// TestSplitGraphemes は書記素クラスタ分割をテストします。
+func TestSplitGraphemes(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"hello", 5},
+		{"café", 4},
+		{"größe", 5},
+		{"日本語", 3},
+		{"e\u0301t\u00e9", 3}, // 結合文字 e + U+0301 は1文字
+		{"👍🏽ok", 3},
+	}
+
+	for _, tt := range tests {
+		if got := len(SplitGraphemes(tt.text)); got != tt.expected {
+			t.Errorf("%q: 期待 %d, 実際 %d", tt.text, tt.expected, got)
+		}
+		if got := GraphemeCount(tt.text); got != tt.expected {
+			t.Errorf("%q GraphemeCount: 期待 %d, 実際 %d", tt.text, tt.expected, got)
+		}
+	}
+}
+
+// TestEvaluator_NonASCIIInput は非ASCII文字を含むテキストの入力判定をテストします。
+func TestEvaluator_NonASCIIInput(t *testing.T) {
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(&Challenge{Text: "café", TimeLimit: 10 * time.Second})
+
+	for _, r := range "cafe" {
+		evaluator.ProcessInput(state, r)
+	}
+	if state.CurrentIndex != 3 {
+		t.Errorf("CurrentIndex: 期待 3, 実際 %d", state.CurrentIndex)
+	}
+	if len(state.Mistakes) != 1 || state.Mistakes[0] != 3 {
+		t.Errorf("Mistakes: 期待 [3], 実際 %v", state.Mistakes)
+	}
+
+	evaluator.ProcessInput(state, 'é')
+	if !evaluator.IsCompleted(state) {
+		t.Error("チャレンジが完了していない")
+	}
+	if state.CorrectCount != 4 {
+		t.Errorf("CorrectCount: 期待 4, 実際 %d", state.CorrectCount)
+	}
+	if evaluator.GetProgress(state) != 1.0 {
+		t.Errorf("進捗: 期待 1.0, 実際 %f", evaluator.GetProgress(state))
+	}
+}
+
+// TestEvaluator_MultiRuneGrapheme は複数ルーンからなる書記素の入力判定をテストします。
+func TestEvaluator_MultiRuneGrapheme(t *testing.T) {
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(&Challenge{Text: "e\u0301!", TimeLimit: 10 * time.Second})
+
+	evaluator.ProcessInput(state, 'e')
+	if state.CurrentIndex != 0 {
+		t.Errorf("結合文字の入力途中で進んではいけない: CurrentIndex %d", state.CurrentIndex)
+	}
+	if !evaluator.Accepts(state, '\u0301') || evaluator.Accepts(state, '!') {
+		t.Error("結合文字の続きの判定が正しくない")
+	}
+
+	evaluator.ProcessInput(state, '\u0301')
+	evaluator.ProcessInput(state, '!')
+	if !evaluator.IsCompleted(state) {
+		t.Error("チャレンジが完了していない")
+	}
+	if state.CorrectCount != 2 || state.TotalInputCount != 2 {
+		t.Errorf("正解数/総入力数: 期待 2/2, 実際 %d/%d", state.CorrectCount, state.TotalInputCount)
+	}
+}
+
+// TestEvaluator_DecomposedText は分解形式（NFD）のテキストを合成済みの文字で入力できることをテストします。
+func TestEvaluator_DecomposedText(t *testing.T) {
+	evaluator := NewEvaluator()
+	// "café"（"e"+U+0301）をキーボードの送る合成済みの"é"で入力する
+	state := evaluator.StartChallenge(&Challenge{Text: "cafe\u0301", TimeLimit: 10 * time.Second})
+
+	for _, r := range "caf" {
+		evaluator.ProcessInput(state, r)
+	}
+	if expected := state.matcher.Expected(); expected != 'é' {
+		t.Errorf("期待文字: 期待 é, 実際 %q", expected)
+	}
+	if !evaluator.Accepts(state, 'é') {
+		t.Fatal("合成済みの文字が受理されません")
+	}
+	evaluator.ProcessInput(state, 'é')
+	if !evaluator.IsCompleted(state) || state.CorrectCount != 4 || len(state.Mistakes) != 0 {
+		t.Errorf("完了 %v, 正解数 %d, ミス %v", evaluator.IsCompleted(state), state.CorrectCount, state.Mistakes)
+	}
+
+	// 分解形式のテキストはルーン列どおりの入力でも確定できる
+	state = evaluator.StartChallenge(&Challenge{Text: "e\u0301", TimeLimit: 10 * time.Second})
+	evaluator.ProcessInput(state, 'e')
+	if state.CurrentIndex != 0 || state.matcher.Expected() != '\u0301' {
+		t.Errorf("結合文字の入力途中: CurrentIndex %d, 期待文字 %q", state.CurrentIndex, state.matcher.Expected())
+	}
+	evaluator.ProcessInput(state, '\u0301')
+	if !evaluator.IsCompleted(state) {
+		t.Error("結合文字を続けた入力で完了していない")
+	}
+}
+
+// TestGenerateChallenge_NonASCIILength は長さ判定が書記素単位で行われることをテストします。
+func TestGenerateChallenge_NonASCIILength(t *testing.T) {
+	// "größe"は5文字（7バイト）のためEasy（3-6文字）に含まれる
+	dict := &Dictionary{
+		Easy: []string{"größe", "überraschungsei"},
+	}
+	generator := NewChallengeGenerator(dict)
+
+	for i := 0; i < 10; i++ {
+		challenge := generator.Generate(DifficultyEasy, 5*time.Second)
+		if challenge == nil || challenge.Text != "größe" {
+			t.Fatalf("Easyの長さ判定が書記素単位になっていない: %+v", challenge)
+		}
+		if challenge.Length() != 5 {
+			t.Errorf("Length: 期待 5, 実際 %d", challenge.Length())
+		}
+	}
+}
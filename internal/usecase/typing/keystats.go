@@ -0,0 +1,175 @@
+// Package typing はタイピングシステムを提供します。
+// keystats.go は文字・バイグラムごとの苦手度の集計と、それに基づく出題の重み付けを担当します。
+
+package typing
+
+import (
+	"time"
+)
+
+const (
+	// keyStatsMinSamples は苦手度を評価するのに必要な最小入力回数です。
+	keyStatsMinSamples = 3
+
+	// adaptiveWeightScale は苦手度を出題の重みに反映する倍率です。
+	// 苦手度0の単語は重み1、苦手度1の単語は重み1+adaptiveWeightScaleになります。
+	adaptiveWeightScale = 4.0
+
+	// bigramWeaknessFactor は単語の苦手度に占めるバイグラム苦手度の比率です。
+	bigramWeaknessFactor = 0.5
+)
+
+// KeyStat は1つのキー（文字またはバイグラム）の入力統計です。
+type KeyStat struct {
+	// Count は入力回数です。
+	Count int
+
+	// Errors はミス回数です。
+	Errors int
+
+	// TotalLatency は正しく入力できた際の所要時間の合計です。
+	TotalLatency time.Duration
+
+	// LatencyCount は所要時間を記録した回数です。
+	LatencyCount int
+}
+
+// ErrorRate はミス率（0.0〜1.0）を返します。
+func (s KeyStat) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// AverageLatency は正しく入力できた際の平均所要時間を返します。
+func (s KeyStat) AverageLatency() time.Duration {
+	if s.LatencyCount == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.LatencyCount)
+}
+
+// KeyStats はプレイヤーの文字・バイグラムごとの入力統計を保持する構造体です。
+// セッションをまたいでセーブデータに永続化されます。
+type KeyStats struct {
+	// Chars は文字ごとの統計です。
+	Chars map[string]*KeyStat
+
+	// Bigrams はバイグラムごとの統計です。
+	Bigrams map[string]*KeyStat
+}
+
+// NewKeyStats は空のKeyStatsを作成します。
+func NewKeyStats() *KeyStats {
+	return &KeyStats{
+		Chars:   make(map[string]*KeyStat),
+		Bigrams: make(map[string]*KeyStat),
+	}
+}
+
+// RecordKeystrokes は1チャレンジ分の入力記録を統計に反映します。
+// ミスは期待された文字に、所要時間は正しく入力できた文字に計上します。
+func (k *KeyStats) RecordKeystrokes(events []KeystrokeEvent) {
+	var prev *KeystrokeEvent
+	for i := range events {
+		ev := &events[i]
+		if ev.Expected == 0 {
+			prev = nil
+			continue
+		}
+
+		record(k.Chars, string(statKey(ev)), ev.Correct, ev.Interval, prev != nil)
+
+		if prev != nil && prev.Correct {
+			record(k.Bigrams, string(statKey(prev))+string(statKey(ev)), ev.Correct, ev.Interval, true)
+		}
+		prev = ev
+	}
+}
+
+// statKey は入力記録を計上する文字を返します。
+// 正しい入力は実際に押された文字（ローマ字の別表記"si"の"i"など）、ミスは期待された文字です。
+func statKey(ev *KeystrokeEvent) rune {
+	if ev.Correct {
+		return ev.Typed
+	}
+	return ev.Expected
+}
+
+// record は統計マップの1キーに入力結果を加算します。
+// hasLatencyがfalseの場合（チャレンジ最初の1打など）は所要時間を記録しません。
+func record(stats map[string]*KeyStat, key string, correct bool, latency time.Duration, hasLatency bool) {
+	stat, ok := stats[key]
+	if !ok {
+		stat = &KeyStat{}
+		stats[key] = stat
+	}
+	stat.Count++
+	if !correct {
+		stat.Errors++
+		return
+	}
+	if hasLatency {
+		stat.TotalLatency += latency
+		stat.LatencyCount++
+	}
+}
+
+// WordWeakness は単語に含まれる文字・バイグラムの苦手度（0以上）を返します。
+// 入力回数が少ないキーは評価対象外です。単語長の影響を避けるため平均値で評価します。
+func (k *KeyStats) WordWeakness(keys string) float64 {
+	runes := []rune(keys)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	charAvg := averageLatency(k.Chars)
+	bigramAvg := averageLatency(k.Bigrams)
+
+	var charScore float64
+	for _, r := range runes {
+		charScore += weakness(k.Chars[string(r)], charAvg)
+	}
+	charScore /= float64(len(runes))
+
+	var bigramScore float64
+	if len(runes) > 1 {
+		for i := 1; i < len(runes); i++ {
+			bigramScore += weakness(k.Bigrams[string(runes[i-1:i+1])], bigramAvg)
+		}
+		bigramScore /= float64(len(runes) - 1)
+	}
+
+	return charScore + bigramScore*bigramWeaknessFactor
+}
+
+// weakness は1キーの苦手度を返します。
+// ミス率と、全体平均に対する所要時間の超過率の和です。
+func weakness(stat *KeyStat, overallLatency time.Duration) float64 {
+	if stat == nil || stat.Count < keyStatsMinSamples {
+		return 0
+	}
+	score := stat.ErrorRate()
+	if overallLatency > 0 && stat.LatencyCount > 0 {
+		ratio := float64(stat.AverageLatency())/float64(overallLatency) - 1
+		if ratio > 0 {
+			score += ratio
+		}
+	}
+	return score
+}
+
+// averageLatency は統計全体の平均所要時間を返します。
+func averageLatency(stats map[string]*KeyStat) time.Duration {
+	var total time.Duration
+	count := 0
+	for _, stat := range stats {
+		total += stat.TotalLatency
+		count += stat.LatencyCount
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
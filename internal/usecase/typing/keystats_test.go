@@ -0,0 +1,141 @@
+// Package typing はタイピングシステムを提供します。
+// keystats_test.go は苦手キー統計とアダプティブ出題のテストです。
+
+package typing
+
+import (
+	"testing"
+	"time"
+)
+
+// ==================== 苦手キー統計テスト ====================
+
+// TestKeyStats_RecordKeystrokes は入力記録の統計反映をテストします。
+func TestKeyStats_RecordKeystrokes(t *testing.T) {
+	ms := time.Millisecond
+	events := []KeystrokeEvent{
+		{Expected: 'a', Typed: 'a', Correct: true, Interval: 500 * ms},
+		{Expected: 'b', Typed: 'x', Correct: false, Interval: 100 * ms},
+		{Expected: 'b', Typed: 'b', Correct: true, Interval: 300 * ms},
+	}
+
+	stats := NewKeyStats()
+	stats.RecordKeystrokes(events)
+
+	a := stats.Chars["a"]
+	if a == nil || a.Count != 1 || a.LatencyCount != 0 {
+		t.Errorf("a: 最初の1打は所要時間を記録しない: %+v", a)
+	}
+
+	b := stats.Chars["b"]
+	if b == nil || b.Count != 2 || b.Errors != 1 {
+		t.Fatalf("b: 期待 2回/ミス1, 実際 %+v", b)
+	}
+	if b.AverageLatency() != 300*ms {
+		t.Errorf("b: 平均所要時間 期待 300ms, 実際 %v", b.AverageLatency())
+	}
+	if b.ErrorRate() != 0.5 {
+		t.Errorf("b: ミス率 期待 0.5, 実際 %f", b.ErrorRate())
+	}
+
+	ab := stats.Bigrams["ab"]
+	if ab == nil || ab.Count != 1 || ab.Errors != 1 {
+		t.Errorf("ab: 期待 1回/ミス1, 実際 %+v", ab)
+	}
+}
+
+// TestKeyStats_RecordRomajiAlternative はローマ字の別表記の入力が実際に押したキーに計上されることをテストします。
+func TestKeyStats_RecordRomajiAlternative(t *testing.T) {
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(&Challenge{Text: "寿司", Reading: "すし", Mode: InputModeRomaji, TimeLimit: 10 * time.Second})
+
+	// ガイドは"sushi"だが、「し」を"si"で入力する
+	for _, r := range "susi" {
+		evaluator.ProcessInput(state, r)
+	}
+	if !evaluator.IsCompleted(state) {
+		t.Fatal("\"susi\" で完了していない")
+	}
+
+	stats := NewKeyStats()
+	stats.RecordKeystrokes(state.Keystrokes)
+
+	if i := stats.Chars["i"]; i == nil || i.Count != 1 || i.Errors != 0 || i.LatencyCount != 1 {
+		t.Errorf("i: 期待 1回/ミス0/所要時間1件, 実際 %+v", i)
+	}
+	if h := stats.Chars["h"]; h != nil {
+		t.Errorf("h: 押していないキーが計上されています: %+v", h)
+	}
+	if si := stats.Bigrams["si"]; si == nil || si.Count != 1 {
+		t.Errorf("si: 期待 1回, 実際 %+v", si)
+	}
+	if sh := stats.Bigrams["sh"]; sh != nil {
+		t.Errorf("sh: 押していないバイグラムが計上されています: %+v", sh)
+	}
+}
+
+// TestKeyStats_WordWeakness は単語の苦手度評価をテストします。
+func TestKeyStats_WordWeakness(t *testing.T) {
+	stats := NewKeyStats()
+	stats.Chars["q"] = &KeyStat{Count: 10, Errors: 5}
+	stats.Chars["a"] = &KeyStat{Count: 10}
+	stats.Chars["z"] = &KeyStat{Count: 1, Errors: 1} // 入力回数不足で評価対象外
+
+	if w := stats.WordWeakness("aaa"); w != 0 {
+		t.Errorf("苦手キーを含まない単語: 期待 0, 実際 %f", w)
+	}
+	if w := stats.WordWeakness("zzz"); w != 0 {
+		t.Errorf("入力回数不足のキー: 期待 0, 実際 %f", w)
+	}
+	if stats.WordWeakness("qaa") <= stats.WordWeakness("aaa") {
+		t.Error("苦手キーを含む単語の苦手度が高くなっていない")
+	}
+}
+
+// TestGenerateChallenge_Adaptive はアダプティブ出題で苦手キーを含む単語が優先されることをテストします。
+func TestGenerateChallenge_Adaptive(t *testing.T) {
+	dict := &Dictionary{
+		Easy: []string{"quiz", "abba", "baba", "dada"},
+	}
+	stats := NewKeyStats()
+	stats.Chars["q"] = &KeyStat{Count: 20, Errors: 20}
+	stats.Chars["z"] = &KeyStat{Count: 20, Errors: 20}
+
+	generator := NewChallengeGenerator(dict)
+	generator.SetKeyStats(stats)
+	generator.SetAdaptive(true)
+
+	quizCount := 0
+	for i := 0; i < 200; i++ {
+		generator.lastText = "" // 連続出題回避の影響を除外
+		challenge := generator.Generate(DifficultyEasy, 5*time.Second)
+		if challenge.Text == "quiz" {
+			quizCount++
+		}
+	}
+
+	// 一様ランダムなら約50回、重み付きでは明確に多くなる
+	if quizCount < 80 {
+		t.Errorf("苦手キーを含む単語の出題回数が少なすぎます: %d/200", quizCount)
+	}
+}
+
+// TestGenerateChallenge_AdaptiveRespectsLength はアダプティブ出題でも文字数の範囲を守ることをテストします。
+func TestGenerateChallenge_AdaptiveRespectsLength(t *testing.T) {
+	dict := &Dictionary{
+		Easy: []string{"cat", "quizzical"},
+	}
+	stats := NewKeyStats()
+	stats.Chars["q"] = &KeyStat{Count: 20, Errors: 20}
+
+	generator := NewChallengeGenerator(dict)
+	generator.SetKeyStats(stats)
+	generator.SetAdaptive(true)
+
+	for i := 0; i < 20; i++ {
+		challenge := generator.Generate(DifficultyEasy, 5*time.Second)
+		if challenge.Text != "cat" {
+			t.Fatalf("Easyの文字数範囲外の単語が出題されました: %s", challenge.Text)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package typing はタイピングシステムを提供します。
+// keystroke.go は1打ごとの入力記録と、それに基づく詳細指標の計算を担当します。
+
+package typing
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// slowestBigramCount はTypingResultに含める遅いバイグラムの最大数です。
+const slowestBigramCount = 3
+
+// KeystrokeEvent は1打の入力記録を表す構造体です。
+type KeystrokeEvent struct {
+	// Timestamp は入力時刻です。
+	Timestamp time.Time
+
+	// Expected は期待された文字です（ローマ字モードでは入力ガイドの次の文字）。
+	Expected rune
+
+	// Typed は実際に入力された文字です。
+	Typed rune
+
+	// Correct は正しい入力だったかどうかです。
+	Correct bool
+
+	// Interval は直前の入力（最初の1打はチャレンジ開始）からの経過時間です。
+	Interval time.Duration
+}
+
+// BigramTiming は2文字連続入力（バイグラム）の平均所要時間を表す構造体です。
+type BigramTiming struct {
+	// Bigram は連続して入力された2文字です。
+	Bigram string
+
+	// Average は2文字目の入力にかかった平均時間です。
+	Average time.Duration
+
+	// Count は出現回数です。
+	Count int
+}
+
+// KeystrokeMetrics は入力記録から算出した詳細指標です。
+type KeystrokeMetrics struct {
+	// RawWPM はミスを含む全打鍵数から算出したWPMです。
+	RawWPM float64
+
+	// NetWPM はRawWPMから1分あたりのミス数を差し引いたWPMです（0未満にはなりません）。
+	NetWPM float64
+
+	// Consistency は2打目以降の打鍵間隔の標準偏差です（小さいほどリズムが安定）。
+	Consistency time.Duration
+
+	// SlowestBigrams は平均所要時間の長い順のバイグラムです。
+	SlowestBigrams []BigramTiming
+}
+
+// AnalyzeKeystrokes は入力記録と経過時間から詳細指標を算出します。
+func AnalyzeKeystrokes(events []KeystrokeEvent, elapsed time.Duration) KeystrokeMetrics {
+	metrics := KeystrokeMetrics{
+		Consistency:    intervalStdDev(events),
+		SlowestBigrams: slowestBigrams(events, slowestBigramCount),
+	}
+
+	minutes := elapsed.Minutes()
+	if minutes <= 0 {
+		return metrics
+	}
+
+	mistakes := 0
+	for _, ev := range events {
+		if !ev.Correct {
+			mistakes++
+		}
+	}
+
+	metrics.RawWPM = float64(len(events)) / charsPerWord / minutes
+	metrics.NetWPM = metrics.RawWPM - float64(mistakes)/minutes
+	if metrics.NetWPM < 0 {
+		metrics.NetWPM = 0
+	}
+	return metrics
+}
+
+// intervalStdDev は打鍵間隔の標準偏差を返します。
+// 最初の1打の間隔はチャレンジ開始からの反応時間のため除外し、2打目以降の打鍵間隔のみで計算します。
+func intervalStdDev(events []KeystrokeEvent) time.Duration {
+	if len(events) < 3 {
+		return 0
+	}
+	intervals := events[1:]
+
+	var sum float64
+	for _, ev := range intervals {
+		sum += float64(ev.Interval)
+	}
+	mean := sum / float64(len(intervals))
+
+	var variance float64
+	for _, ev := range intervals {
+		diff := float64(ev.Interval) - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(intervals))
+
+	return time.Duration(math.Sqrt(variance))
+}
+
+// slowestBigrams は正しく連続入力されたバイグラムを平均所要時間の長い順に最大limit件返します。
+func slowestBigrams(events []KeystrokeEvent, limit int) []BigramTiming {
+	type bigramTotal struct {
+		total time.Duration
+		count int
+	}
+	totals := make(map[string]*bigramTotal)
+
+	for i := 1; i < len(events); i++ {
+		prev, cur := events[i-1], events[i]
+		if !prev.Correct || !cur.Correct {
+			continue
+		}
+		key := string(prev.Typed) + string(cur.Typed)
+		if totals[key] == nil {
+			totals[key] = &bigramTotal{}
+		}
+		totals[key].total += cur.Interval
+		totals[key].count++
+	}
+
+	result := make([]BigramTiming, 0, len(totals))
+	for bigram, t := range totals {
+		result = append(result, BigramTiming{
+			Bigram:  bigram,
+			Average: t.total / time.Duration(t.count),
+			Count:   t.count,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Average != result[j].Average {
+			return result[i].Average > result[j].Average
+		}
+		return result[i].Bigram < result[j].Bigram
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+// Package typing はタイピングシステムを提供します。
+// keystroke_test.go は1打ごとの入力記録と詳細指標のテストです。
+
+package typing
+
+import (
+	"testing"
+	"time"
+)
+
+// ==================== 入力記録テスト ====================
+
+// TestEvaluator_RecordsKeystrokes は1打ごとの入力記録をテストします。
+func TestEvaluator_RecordsKeystrokes(t *testing.T) {
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(&Challenge{Text: "ab", TimeLimit: 10 * time.Second})
+
+	for _, r := range "axb" {
+		evaluator.ProcessInput(state, r)
+	}
+
+	if len(state.Keystrokes) != 3 {
+		t.Fatalf("記録数: 期待 3, 実際 %d", len(state.Keystrokes))
+	}
+
+	expected := []struct {
+		expected, typed rune
+		correct         bool
+	}{
+		{'a', 'a', true},
+		{'b', 'x', false},
+		{'b', 'b', true},
+	}
+	for i, want := range expected {
+		ev := state.Keystrokes[i]
+		if ev.Expected != want.expected || ev.Typed != want.typed || ev.Correct != want.correct {
+			t.Errorf("記録[%d]: 期待 %c/%c/%v, 実際 %c/%c/%v",
+				i, want.expected, want.typed, want.correct, ev.Expected, ev.Typed, ev.Correct)
+		}
+		if ev.Interval < 0 {
+			t.Errorf("記録[%d]: 打鍵間隔が負です: %v", i, ev.Interval)
+		}
+	}
+
+	result := evaluator.CompleteChallenge(state)
+	if len(result.Keystrokes) != 3 {
+		t.Errorf("結果の記録数: 期待 3, 実際 %d", len(result.Keystrokes))
+	}
+}
+
+// TestEvaluator_RomajiKeystrokeExpected はローマ字モードの期待文字がガイドに従うことをテストします。
+func TestEvaluator_RomajiKeystrokeExpected(t *testing.T) {
+	evaluator := NewEvaluator()
+	state := evaluator.StartChallenge(&Challenge{Text: "寿司", Reading: "すし", Mode: InputModeRomaji, TimeLimit: 10 * time.Second})
+
+	for _, r := range "sus" {
+		evaluator.ProcessInput(state, r)
+	}
+	if state.Keystrokes[2].Expected != 's' || !state.Keystrokes[2].Correct {
+		t.Errorf("3打目: 期待 s/正解, 実際 %c/%v", state.Keystrokes[2].Expected, state.Keystrokes[2].Correct)
+	}
+}
+
+// ==================== 詳細指標テスト ====================
+
+// makeKeystrokes は打鍵間隔と正誤から入力記録を生成します。
+func makeKeystrokes(typed string, intervals []time.Duration, correct []bool) []KeystrokeEvent {
+	events := make([]KeystrokeEvent, 0, len(intervals))
+	now := time.Now()
+	for i, r := range []rune(typed) {
+		now = now.Add(intervals[i])
+		events = append(events, KeystrokeEvent{
+			Timestamp: now,
+			Expected:  r,
+			Typed:     r,
+			Correct:   correct[i],
+			Interval:  intervals[i],
+		})
+	}
+	return events
+}
+
+// TestAnalyzeKeystrokes_WPM は生WPMとネットWPMの計算をテストします。
+func TestAnalyzeKeystrokes_WPM(t *testing.T) {
+	// 10打（うちミス2打）を30秒で入力
+	intervals := make([]time.Duration, 10)
+	correct := make([]bool, 10)
+	for i := range intervals {
+		intervals[i] = 3 * time.Second
+		correct[i] = i%5 != 0
+	}
+	events := makeKeystrokes("abcdefghij", intervals, correct)
+
+	metrics := AnalyzeKeystrokes(events, 30*time.Second)
+
+	// Raw: 10打 / 5 / 0.5分 = 4 WPM
+	if metrics.RawWPM != 4 {
+		t.Errorf("RawWPM: 期待 4, 実際 %f", metrics.RawWPM)
+	}
+	// Net: 4 - 2ミス / 0.5分 = 0 WPM（0未満にはならない）
+	if metrics.NetWPM != 0 {
+		t.Errorf("NetWPM: 期待 0, 実際 %f", metrics.NetWPM)
+	}
+	// 打鍵間隔が一定なので標準偏差は0
+	if metrics.Consistency != 0 {
+		t.Errorf("Consistency: 期待 0, 実際 %v", metrics.Consistency)
+	}
+}
+
+// TestAnalyzeKeystrokes_Consistency は打鍵間隔の標準偏差をテストします。
+// 最初の1打の反応時間は標準偏差に含めません。
+func TestAnalyzeKeystrokes_Consistency(t *testing.T) {
+	ms := time.Millisecond
+	events := makeKeystrokes("abc", []time.Duration{2000 * ms, 100 * ms, 300 * ms}, []bool{true, true, true})
+
+	metrics := AnalyzeKeystrokes(events, 3*time.Second)
+	if metrics.Consistency != 100*ms {
+		t.Errorf("Consistency: 期待 100ms, 実際 %v", metrics.Consistency)
+	}
+
+	// 反応時間だけが長くても、以降の打鍵間隔が一定なら標準偏差は0
+	steady := makeKeystrokes("abcd", []time.Duration{3000 * ms, 200 * ms, 200 * ms, 200 * ms}, []bool{true, true, true, true})
+	if got := AnalyzeKeystrokes(steady, 4*time.Second).Consistency; got != 0 {
+		t.Errorf("反応時間を除いたConsistency: 期待 0, 実際 %v", got)
+	}
+
+	// 打鍵間隔が1つしかない場合は0
+	if got := AnalyzeKeystrokes(events[:2], time.Second).Consistency; got != 0 {
+		t.Errorf("打鍵間隔1つのConsistency: 期待 0, 実際 %v", got)
+	}
+}
+
+// TestAnalyzeKeystrokes_SlowestBigrams は遅いバイグラムの抽出をテストします。
+func TestAnalyzeKeystrokes_SlowestBigrams(t *testing.T) {
+	ms := time.Millisecond
+	// "thth" + "e": t→h が遅く、h→e はミスを挟むため対象外
+	events := makeKeystrokes("ththxe",
+		[]time.Duration{100 * ms, 400 * ms, 100 * ms, 200 * ms, 100 * ms, 500 * ms},
+		[]bool{true, true, true, true, false, true})
+
+	metrics := AnalyzeKeystrokes(events, time.Second)
+	if len(metrics.SlowestBigrams) != 2 {
+		t.Fatalf("バイグラム数: 期待 2, 実際 %d (%v)", len(metrics.SlowestBigrams), metrics.SlowestBigrams)
+	}
+
+	top := metrics.SlowestBigrams[0]
+	if top.Bigram != "th" || top.Count != 2 || top.Average != 300*ms {
+		t.Errorf("最も遅いバイグラム: 期待 th/2回/300ms, 実際 %+v", top)
+	}
+	if metrics.SlowestBigrams[1].Bigram != "ht" {
+		t.Errorf("2番目のバイグラム: 期待 ht, 実際 %s", metrics.SlowestBigrams[1].Bigram)
+	}
+}
@@ -0,0 +1,327 @@
+// Package typing はタイピングシステムを提供します。
+// romaji.go はかな読みに対するローマ字入力の判定を担当します。
+
+package typing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ==================== かな→ローマ字テーブル ====================
+
+// kanaRomaji は単独のかなに対する入力候補です。
+// 先頭の候補を入力ガイドの表記として使用します。
+var kanaRomaji = map[string][]string{
+	"あ": {"a"}, "い": {"i", "yi"}, "う": {"u", "wu", "whu"}, "え": {"e"}, "お": {"o"},
+	"か": {"ka", "ca"}, "き": {"ki"}, "く": {"ku", "cu", "qu"}, "け": {"ke"}, "こ": {"ko", "co"},
+	"さ": {"sa"}, "し": {"shi", "si", "ci"}, "す": {"su"}, "せ": {"se", "ce"}, "そ": {"so"},
+	"た": {"ta"}, "ち": {"chi", "ti"}, "つ": {"tsu", "tu"}, "て": {"te"}, "と": {"to"},
+	"な": {"na"}, "に": {"ni"}, "ぬ": {"nu"}, "ね": {"ne"}, "の": {"no"},
+	"は": {"ha"}, "ひ": {"hi"}, "ふ": {"fu", "hu"}, "へ": {"he"}, "ほ": {"ho"},
+	"ま": {"ma"}, "み": {"mi"}, "む": {"mu"}, "め": {"me"}, "も": {"mo"},
+	"や": {"ya"}, "ゆ": {"yu"}, "よ": {"yo"},
+	"ら": {"ra"}, "り": {"ri"}, "る": {"ru"}, "れ": {"re"}, "ろ": {"ro"},
+	"わ": {"wa"}, "を": {"wo"}, "ん": {"nn", "xn"},
+	"が": {"ga"}, "ぎ": {"gi"}, "ぐ": {"gu"}, "げ": {"ge"}, "ご": {"go"},
+	"ざ": {"za"}, "じ": {"ji", "zi"}, "ず": {"zu"}, "ぜ": {"ze"}, "ぞ": {"zo"},
+	"だ": {"da"}, "ぢ": {"di"}, "づ": {"du"}, "で": {"de"}, "ど": {"do"},
+	"ば": {"ba"}, "び": {"bi"}, "ぶ": {"bu"}, "べ": {"be"}, "ぼ": {"bo"},
+	"ぱ": {"pa"}, "ぴ": {"pi"}, "ぷ": {"pu"}, "ぺ": {"pe"}, "ぽ": {"po"},
+	"ゔ": {"vu"},
+	"ぁ": {"xa", "la"}, "ぃ": {"xi", "li"}, "ぅ": {"xu", "lu"}, "ぇ": {"xe", "le"}, "ぉ": {"xo", "lo"},
+	"ゃ": {"xya", "lya"}, "ゅ": {"xyu", "lyu"}, "ょ": {"xyo", "lyo"}, "ゎ": {"xwa", "lwa"},
+	"っ": {"xtu", "ltu", "xtsu", "ltsu"},
+	"ー": {"-"}, "、": {","}, "。": {"."}, "・": {"/"}, "！": {"!"}, "？": {"?"}, "　": {" "}, " ": {" "},
+}
+
+// kanaRomajiCombined は拗音など2文字で1単位となるかなの入力候補です。
+// 分割入力（例: "kixya"）の候補は実行時に合成されます。
+var kanaRomajiCombined = map[string][]string{
+	"きゃ": {"kya"}, "きゅ": {"kyu"}, "きょ": {"kyo"},
+	"しゃ": {"sha", "sya"}, "しゅ": {"shu", "syu"}, "しょ": {"sho", "syo"}, "しぇ": {"she", "sye"},
+	"ちゃ": {"cha", "tya", "cya"}, "ちゅ": {"chu", "tyu", "cyu"}, "ちょ": {"cho", "tyo", "cyo"}, "ちぇ": {"che", "tye", "cye"},
+	"にゃ": {"nya"}, "にゅ": {"nyu"}, "にょ": {"nyo"},
+	"ひゃ": {"hya"}, "ひゅ": {"hyu"}, "ひょ": {"hyo"},
+	"みゃ": {"mya"}, "みゅ": {"myu"}, "みょ": {"myo"},
+	"りゃ": {"rya"}, "りゅ": {"ryu"}, "りょ": {"ryo"},
+	"ぎゃ": {"gya"}, "ぎゅ": {"gyu"}, "ぎょ": {"gyo"},
+	"じゃ": {"ja", "zya", "jya"}, "じゅ": {"ju", "zyu", "jyu"}, "じょ": {"jo", "zyo", "jyo"}, "じぇ": {"je", "zye", "jye"},
+	"ぢゃ": {"dya"}, "ぢゅ": {"dyu"}, "ぢょ": {"dyo"},
+	"びゃ": {"bya"}, "びゅ": {"byu"}, "びょ": {"byo"},
+	"ぴゃ": {"pya"}, "ぴゅ": {"pyu"}, "ぴょ": {"pyo"},
+	"ふぁ": {"fa"}, "ふぃ": {"fi"}, "ふぇ": {"fe"}, "ふぉ": {"fo"},
+	"てぃ": {"thi"}, "でぃ": {"dhi"}, "でゅ": {"dhu"},
+	"うぃ": {"wi"}, "うぇ": {"we"},
+	"ゔぁ": {"va"}, "ゔぃ": {"vi"}, "ゔぇ": {"ve"}, "ゔぉ": {"vo"},
+}
+
+// smallKana は直前のかなと結合して1単位になる小書きかなです。
+const smallKana = "ぁぃぅぇぉゃゅょゎ"
+
+// romajiVowels は撥音「ん」を"n"1打で確定できない後続文字です。
+const romajiVowels = "aiueoyn"
+
+// smallKanaHeads は小書きかなの単独入力の先頭文字です。
+// 「っ」自身の単独入力（"xtu"など）と区別できないため、促音の子音重ねには使いません。
+const smallKanaHeads = "xl"
+
+// toHiragana はカタカナをひらがなに変換します。
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
+
+// SplitKanaUnits は読みをかな単位（拗音は1単位）に分割します。
+// カタカナはひらがなに正規化されます。
+func SplitKanaUnits(reading string) []string {
+	runes := make([]rune, 0, len(reading))
+	for _, r := range reading {
+		runes = append(runes, toHiragana(r))
+	}
+
+	units := make([]string, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		unit := string(runes[i])
+		if i+1 < len(runes) && strings.ContainsRune(smallKana, runes[i+1]) &&
+			!strings.ContainsRune(smallKana, runes[i]) && runes[i] != 'っ' && runes[i] != 'ん' {
+			unit += string(runes[i+1])
+			i++
+		}
+		units = append(units, unit)
+	}
+	return units
+}
+
+// unitCandidates はかな単位単独の入力候補を返します（促音・撥音の文脈依存分を除く）。
+func unitCandidates(unit string) []string {
+	if cands, ok := kanaRomaji[unit]; ok {
+		return cands
+	}
+
+	runes := []rune(unit)
+	if len(runes) == 2 {
+		var result []string
+		result = append(result, kanaRomajiCombined[unit]...)
+		// 分割入力（例: き + ゃ → "kixya"）も受け付ける
+		for _, base := range kanaRomaji[string(runes[0])] {
+			for _, small := range kanaRomaji[string(runes[1])] {
+				result = append(result, base+small)
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+	}
+
+	// テーブルにない文字（英数字など）はそのまま入力する
+	return []string{strings.ToLower(unit)}
+}
+
+// ==================== ローマ字マッチャー ====================
+
+// RomajiMatcher はかな読みに対するローマ字入力を1打ずつ判定する構造体です。
+// "shi"/"si"、"tsu"/"tu"、"n"/"nn"、促音の子音重ねなど複数の表記を受け付けます。
+type RomajiMatcher struct {
+	units  []string
+	index  int
+	buffer string
+	typed  strings.Builder
+
+	// caseSensitive がtrueの場合、大文字の入力を小文字として扱いません。
+	caseSensitive bool
+}
+
+// NewRomajiMatcher は読みから新しいRomajiMatcherを作成します。
+func NewRomajiMatcher(reading string) *RomajiMatcher {
+	return &RomajiMatcher{
+		units: SplitKanaUnits(reading),
+	}
+}
+
+// SetCaseSensitive は大文字小文字を区別して判定するかを設定します。
+func (m *RomajiMatcher) SetCaseSensitive(enabled bool) {
+	m.caseSensitive = enabled
+}
+
+// normalize は入力文字を判定用に正規化します（大文字小文字を区別しない場合は小文字化）。
+func (m *RomajiMatcher) normalize(r rune) rune {
+	if m.caseSensitive {
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+// candidatesAt は指定位置のかな単位の入力候補を返します。
+// 促音「っ」は後続単位の子音1打も候補に含みます（母音・"n"・小書きかなの"x"/"l"は除く）。
+func (m *RomajiMatcher) candidatesAt(i int) []string {
+	if i < 0 || i >= len(m.units) {
+		return nil
+	}
+	cands := unitCandidates(m.units[i])
+	if m.units[i] != "っ" || i+1 >= len(m.units) {
+		return cands
+	}
+
+	doubled := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, next := range unitCandidates(m.units[i+1]) {
+		head := next[:1]
+		if strings.Contains(romajiVowels, head) || strings.Contains(smallKanaHeads, head) || seen[head] {
+			continue
+		}
+		seen[head] = true
+		doubled = append(doubled, head)
+	}
+	return append(doubled, cands...)
+}
+
+// canStartUnit は指定位置のかな単位がprefixで始められるかを判定します。
+func (m *RomajiMatcher) canStartUnit(i int, prefix string) bool {
+	for _, c := range m.candidatesAt(i) {
+		if strings.HasPrefix(c, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts は入力を処理した場合に受理されるかを判定します（状態は変更しません）。
+func (m *RomajiMatcher) Accepts(r rune) bool {
+	if m.IsComplete() {
+		return false
+	}
+	r = m.normalize(r)
+	if m.canAcceptSingleN(r) {
+		return true
+	}
+	return m.canStartUnit(m.index, m.buffer+string(r))
+}
+
+// canAcceptSingleN は「ん」を"n"1打で確定し、rを次の単位に回せるかを判定します。
+func (m *RomajiMatcher) canAcceptSingleN(r rune) bool {
+	if m.units[m.index] != "ん" || m.buffer != "n" || m.index+1 >= len(m.units) {
+		return false
+	}
+	if strings.ContainsRune(romajiVowels, r) {
+		return false
+	}
+	return m.canStartUnit(m.index+1, string(r))
+}
+
+// Input は1打の入力を処理します。
+// 受理されたかどうかと、この入力で確定したかな単位数を返します。
+func (m *RomajiMatcher) Input(r rune) (accepted bool, completed int) {
+	if m.IsComplete() {
+		return false, 0
+	}
+	r = m.normalize(r)
+
+	// 撥音の"n"1打確定（後続が子音の場合）
+	if m.canAcceptSingleN(r) {
+		m.confirm("n")
+		ok, n := m.Input(r)
+		return ok, n + 1
+	}
+
+	next := m.buffer + string(r)
+	cands := m.candidatesAt(m.index)
+	exact := false
+	longer := false
+	for _, c := range cands {
+		if c == next {
+			exact = true
+		} else if strings.HasPrefix(c, next) {
+			longer = true
+		}
+	}
+	if !exact && !longer {
+		return false, 0
+	}
+
+	m.buffer = next
+	if exact && !longer {
+		m.confirm(next)
+		return true, 1
+	}
+	return true, 0
+}
+
+// confirm は現在のかな単位を確定し、次の単位に進みます。
+func (m *RomajiMatcher) confirm(romaji string) {
+	m.typed.WriteString(romaji)
+	m.buffer = ""
+	m.index++
+}
+
+// Expected は入力ガイド上で次に入力すべき文字を返します（完了時は0）。
+func (m *RomajiMatcher) Expected() rune {
+	for _, r := range m.Guide() {
+		return r
+	}
+	return 0
+}
+
+// Index は確定済みのかな単位数を返します。
+func (m *RomajiMatcher) Index() int {
+	return m.index
+}
+
+// Len はかな単位の総数を返します。
+func (m *RomajiMatcher) Len() int {
+	return len(m.units)
+}
+
+// Units はかな単位のリストを返します。
+func (m *RomajiMatcher) Units() []string {
+	return m.units
+}
+
+// IsComplete は全てのかな単位が確定したかを判定します。
+func (m *RomajiMatcher) IsComplete() bool {
+	return m.index >= len(m.units)
+}
+
+// Typed は入力済みのローマ字（確定分＋入力途中）を返します。
+func (m *RomajiMatcher) Typed() string {
+	return m.typed.String() + m.buffer
+}
+
+// Guide は未入力部分のローマ字表記例を返します。
+// 入力途中の単位は、入力済み部分に一致する候補の残りを表示します。
+func (m *RomajiMatcher) Guide() string {
+	var builder strings.Builder
+	for i := m.index; i < len(m.units); i++ {
+		romaji := m.guideFor(i)
+		if i == m.index && !strings.HasPrefix(romaji, m.buffer) {
+			for _, c := range m.candidatesAt(i) {
+				if strings.HasPrefix(c, m.buffer) {
+					romaji = c
+					break
+				}
+			}
+		}
+		if i == m.index {
+			romaji = strings.TrimPrefix(romaji, m.buffer)
+		}
+		builder.WriteString(romaji)
+	}
+	return builder.String()
+}
+
+// guideFor は指定位置のかな単位の代表表記を返します。
+func (m *RomajiMatcher) guideFor(i int) string {
+	if m.units[i] == "ん" && i+1 < len(m.units) {
+		head := m.guideFor(i + 1)[:1]
+		if !strings.Contains(romajiVowels, head) {
+			return "n"
+		}
+	}
+	cands := m.candidatesAt(i)
+	if len(cands) == 0 {
+		return ""
+	}
+	return cands[0]
+}
@@ -0,0 +1,232 @@
+// Package typing はタイピングシステムを提供します。
+// romaji_test.go はローマ字入力判定のテストです。
+
+package typing
+
+import (
+	"testing"
+	"time"
+)
+
+// ==================== ローマ字入力判定テスト ====================
+
+// typeRomaji は文字列を1打ずつ入力し、全て受理されたかを返します。
+func typeRomaji(m *RomajiMatcher, input string) bool {
+	for _, r := range input {
+		if ok, _ := m.Input(r); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// TestSplitKanaUnits は拗音を1単位とするかな分割をテストします。
+func TestSplitKanaUnits(t *testing.T) {
+	tests := []struct {
+		reading  string
+		expected []string
+	}{
+		{"ねこ", []string{"ね", "こ"}},
+		{"きょう", []string{"きょ", "う"}},
+		{"がっこう", []string{"が", "っ", "こ", "う"}},
+		{"カタカナ", []string{"か", "た", "か", "な"}},
+		{"しゃしん", []string{"しゃ", "し", "ん"}},
+	}
+
+	for _, tt := range tests {
+		units := SplitKanaUnits(tt.reading)
+		if len(units) != len(tt.expected) {
+			t.Errorf("%s: 単位数 期待 %d, 実際 %d (%v)", tt.reading, len(tt.expected), len(units), units)
+			continue
+		}
+		for i := range units {
+			if units[i] != tt.expected[i] {
+				t.Errorf("%s: 単位[%d] 期待 %s, 実際 %s", tt.reading, i, tt.expected[i], units[i])
+			}
+		}
+	}
+}
+
+// TestRomajiMatcher_AlternativeSpellings は複数のローマ字表記の受理をテストします。
+func TestRomajiMatcher_AlternativeSpellings(t *testing.T) {
+	tests := []struct {
+		reading string
+		inputs  []string
+	}{
+		{"すし", []string{"sushi", "susi", "suci"}},
+		{"つき", []string{"tsuki", "tuki"}},
+		{"ふじ", []string{"fuji", "huzi"}},
+		{"しゃしん", []string{"shashinn", "syasinn", "sixyasinn", "shasinn"}},
+		{"ちゃ", []string{"cha", "tya", "cya", "chixya"}},
+		{"かんじ", []string{"kanji", "kannji", "kanzi"}},
+	}
+
+	for _, tt := range tests {
+		for _, input := range tt.inputs {
+			m := NewRomajiMatcher(tt.reading)
+			if !typeRomaji(m, input) {
+				t.Errorf("%s: %q が受理されなかった", tt.reading, input)
+				continue
+			}
+			if !m.IsComplete() {
+				t.Errorf("%s: %q 入力後に完了していない (index=%d)", tt.reading, input, m.Index())
+			}
+		}
+	}
+}
+
+// TestRomajiMatcher_SmallTsu は促音の子音重ねと単独入力をテストします。
+func TestRomajiMatcher_SmallTsu(t *testing.T) {
+	for _, input := range []string{"gakkou", "gaxtukou", "galtsukou"} {
+		m := NewRomajiMatcher("がっこう")
+		if !typeRomaji(m, input) || !m.IsComplete() {
+			t.Errorf("がっこう: %q が受理されなかった", input)
+		}
+	}
+
+	m := NewRomajiMatcher("まっちゃ")
+	if !typeRomaji(m, "maccha") || !m.IsComplete() {
+		t.Error("まっちゃ: \"maccha\" が受理されなかった")
+	}
+
+	// 小書きかなの前の促音は"x"/"l"を重ねず、ガイドどおりの単独入力で確定する
+	m = NewRomajiMatcher("あっぁ")
+	if guide := m.Guide(); guide != "axtuxa" {
+		t.Errorf("あっぁ: ガイド 期待 \"axtuxa\", 実際 %q", guide)
+	}
+	if !typeRomaji(m, m.Guide()) || !m.IsComplete() {
+		t.Error("あっぁ: ガイドどおりの入力が受理されなかった")
+	}
+	for _, input := range []string{"axtuxa", "altula", "axtsuxa"} {
+		m = NewRomajiMatcher("あっぁ")
+		if !typeRomaji(m, input) || !m.IsComplete() {
+			t.Errorf("あっぁ: %q が受理されなかった", input)
+		}
+	}
+}
+
+// TestRomajiMatcher_N は撥音の"n"1打確定の条件をテストします。
+func TestRomajiMatcher_N(t *testing.T) {
+	// 母音が続く場合は"nn"が必要（"kani"は「かに」になる）
+	m := NewRomajiMatcher("かんい")
+	if !typeRomaji(m, "kan") {
+		t.Fatal("\"kan\" が受理されなかった")
+	}
+	if m.Accepts('i') {
+		t.Error("「ん」の後の母音で\"n\"1打確定してはいけない")
+	}
+	if !typeRomaji(m, "ni") || !m.IsComplete() {
+		t.Error("\"kanni\" で完了しなかった")
+	}
+
+	// 末尾の「ん」は"nn"が必要
+	m = NewRomajiMatcher("ほん")
+	typeRomaji(m, "hon")
+	if m.IsComplete() {
+		t.Error("末尾の「ん」が\"n\"1打で確定してはいけない")
+	}
+}
+
+// TestRomajiMatcher_RejectsWrongInput は誤入力を状態を変えずに拒否することをテストします。
+func TestRomajiMatcher_RejectsWrongInput(t *testing.T) {
+	m := NewRomajiMatcher("ねこ")
+	if ok, _ := m.Input('x'); ok {
+		t.Error("誤入力が受理された")
+	}
+	if m.Typed() != "" || m.Index() != 0 {
+		t.Errorf("誤入力で状態が変化した: typed=%q index=%d", m.Typed(), m.Index())
+	}
+	if ok, n := m.Input('N'); !ok || n != 0 {
+		t.Errorf("大文字入力: 期待 受理・確定0, 実際 %v/%d", ok, n)
+	}
+	if ok, n := m.Input('e'); !ok || n != 1 {
+		t.Errorf("\"ne\": 期待 受理・確定1, 実際 %v/%d", ok, n)
+	}
+}
+
+// TestRomajiMatcher_Guide は入力ガイドの表示をテストします。
+func TestRomajiMatcher_Guide(t *testing.T) {
+	m := NewRomajiMatcher("しんぶん")
+	if m.Guide() != "shinbunn" {
+		t.Errorf("初期ガイド: 期待 shinbunn, 実際 %s", m.Guide())
+	}
+
+	typeRomaji(m, "si")
+	if m.Typed() != "si" {
+		t.Errorf("入力済み: 期待 si, 実際 %s", m.Typed())
+	}
+	if m.Guide() != "nbunn" {
+		t.Errorf("入力後ガイド: 期待 nbunn, 実際 %s", m.Guide())
+	}
+}
+
+// ==================== ローマ字モード評価テスト ====================
+
+// TestEvaluator_RomajiCountsPerKana はかな単位での進捗・正解数カウントをテストします。
+func TestEvaluator_RomajiCountsPerKana(t *testing.T) {
+	evaluator := NewEvaluator()
+	challenge := &Challenge{Text: "寿司", Reading: "すし", Mode: InputModeRomaji, TimeLimit: 10 * time.Second}
+	state := evaluator.StartChallenge(challenge)
+
+	for _, r := range "suq" {
+		evaluator.ProcessInput(state, r)
+	}
+	if state.CurrentIndex != 1 {
+		t.Errorf("CurrentIndex: 期待 1, 実際 %d", state.CurrentIndex)
+	}
+	if len(state.Mistakes) != 1 || state.Mistakes[0] != 1 {
+		t.Errorf("Mistakes: 期待 [1], 実際 %v", state.Mistakes)
+	}
+	if evaluator.GetProgress(state) != 0.5 {
+		t.Errorf("進捗: 期待 0.5, 実際 %f", evaluator.GetProgress(state))
+	}
+
+	for _, r := range "si" {
+		evaluator.ProcessInput(state, r)
+	}
+	if !evaluator.IsCompleted(state) {
+		t.Error("チャレンジが完了していない")
+	}
+	if state.CorrectCount != 2 || state.TotalInputCount != 3 {
+		t.Errorf("正解数/総入力数: 期待 2/3, 実際 %d/%d", state.CorrectCount, state.TotalInputCount)
+	}
+
+	state.StartTime = time.Now().Add(-2 * time.Second)
+	result := evaluator.CompleteChallenge(state)
+	if !result.Completed {
+		t.Error("結果がCompletedになっていない")
+	}
+	// 2かな / 2秒 * 60 / 2.5 = 24 WPM
+	if result.WPM < 23 || result.WPM > 25 {
+		t.Errorf("WPM: 期待 約24, 実際 %f", result.WPM)
+	}
+}
+
+// TestGenerateChallenge_Romaji はローマ字モードのチャレンジ生成をテストします。
+func TestGenerateChallenge_Romaji(t *testing.T) {
+	dict := &Dictionary{
+		Easy: []string{"cat"},
+		Japanese: &JapaneseDictionary{
+			Easy: []JapaneseWord{{Text: "猫", Reading: "ねこ"}},
+		},
+	}
+	generator := NewChallengeGenerator(dict)
+	generator.SetInputMode(InputModeRomaji)
+
+	challenge := generator.Generate(DifficultyEasy, 5*time.Second)
+	if challenge == nil {
+		t.Fatal("チャレンジ生成に失敗")
+	}
+	if challenge.Mode != InputModeRomaji || challenge.Text != "猫" || challenge.Reading != "ねこ" {
+		t.Errorf("ローマ字チャレンジ: 期待 猫/ねこ, 実際 %+v", challenge)
+	}
+	if challenge.Length() != 2 {
+		t.Errorf("Length: 期待 2, 実際 %d", challenge.Length())
+	}
+
+	// 日本語辞書に該当難易度がない場合は英単語にフォールバック
+	challenge = generator.Generate(DifficultyMedium, 5*time.Second)
+	if challenge != nil && challenge.Mode != InputModeDirect {
+		t.Errorf("フォールバック時のモード: 期待 Direct, 実際 %d", challenge.Mode)
+	}
+}
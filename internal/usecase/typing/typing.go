@@ -6,6 +6,8 @@ package typing
 import (
 	"math/rand"
 	"time"
+
+	"hirorocky/type-battle/internal/usecase/clock"
 )
 
 // 難易度定数
@@ -29,6 +31,23 @@ const (
 
 const SpeedFactorMax = 2.0
 
+// InputMode はチャレンジの入力方式を表す型です。
+type InputMode int
+
+const (
+	// InputModeDirect はテキストをそのまま1文字ずつ入力する方式です。
+	InputModeDirect InputMode = iota
+
+	// InputModeRomaji は読み（かな）をローマ字で入力する方式です。
+	InputModeRomaji
+)
+
+// charsPerWord はWPM計算における1単語あたりの文字数です。
+const charsPerWord = 5.0
+
+// kanaPerWord はローマ字モードのWPM計算における1単語あたりのかな数です。
+const kanaPerWord = 2.5
+
 // ==================== チャレンジ生成（Task 6.1） ====================
 
 // Dictionary はタイピング辞書を表す構造体です。
@@ -37,6 +56,29 @@ type Dictionary struct {
 	Easy   []string
 	Medium []string
 	Hard   []string
+
+	// Parry はパリィ用の短い単語です（空の場合はEasyの単語を使用）。
+	// パリィは入力方式によらず直接入力で判定します。
+	Parry []string
+
+	// Japanese はローマ字モード用の日本語辞書です（nilの場合は英単語を使用）。
+	Japanese *JapaneseDictionary
+}
+
+// JapaneseWord は表示テキストと読みの組です。
+type JapaneseWord struct {
+	// Text は表示テキスト（漢字かな混じり）です。
+	Text string
+
+	// Reading は読み（ひらがなまたはカタカナ）です。
+	Reading string
+}
+
+// JapaneseDictionary はローマ字モード用の難易度別日本語辞書です。
+type JapaneseDictionary struct {
+	Easy   []JapaneseWord
+	Medium []JapaneseWord
+	Hard   []JapaneseWord
 }
 
 // Challenge はタイピングチャレンジを表す構造体です。
@@ -50,6 +92,24 @@ type Challenge struct {
 
 	// Difficulty は難易度です。
 	Difficulty Difficulty
+
+	// Reading はローマ字モードの読みです。
+	Reading string
+
+	// Mode は入力方式です。
+	Mode InputMode
+
+	// Disruption は敵のデバフによる表示・判定の妨害です。
+	Disruption Disruption
+}
+
+// Length はチャレンジの入力単位数を返します。
+// ローマ字モードではかな単位数、それ以外では書記素クラスタ数です。
+func (c *Challenge) Length() int {
+	if c.Mode == InputModeRomaji {
+		return len(SplitKanaUnits(c.Reading))
+	}
+	return GraphemeCount(c.Text)
 }
 
 // ChallengeGenerator はタイピングチャレンジを生成する構造体です。
@@ -58,6 +118,13 @@ type ChallengeGenerator struct {
 	dictionary *Dictionary
 	lastText   string
 	rng        *rand.Rand
+	mode       InputMode
+
+	// keyStats はプレイヤーの苦手キー統計です（アダプティブ出題で使用）。
+	keyStats *KeyStats
+
+	// adaptive は苦手キーを含む単語を優先して出題するかどうかです。
+	adaptive bool
 }
 
 // NewChallengeGenerator は新しいChallengeGeneratorを作成します。
@@ -69,9 +136,43 @@ func NewChallengeGenerator(dict *Dictionary) *ChallengeGenerator {
 	}
 }
 
+// SetInputMode は生成するチャレンジの入力方式を設定します。
+func (g *ChallengeGenerator) SetInputMode(mode InputMode) {
+	g.mode = mode
+}
+
+// InputMode は現在の入力方式を返します。
+func (g *ChallengeGenerator) InputMode() InputMode {
+	return g.mode
+}
+
+// SetKeyStats はアダプティブ出題に使用する苦手キー統計を設定します。
+func (g *ChallengeGenerator) SetKeyStats(stats *KeyStats) {
+	g.keyStats = stats
+}
+
+// SetRng は出題に使う乱数生成器を設定します。
+// シード固定のシミュレーションやテストで出題を再現するために使用します。
+func (g *ChallengeGenerator) SetRng(rng *rand.Rand) {
+	g.rng = rng
+}
+
+// SetAdaptive はアダプティブ出題（苦手キーを含む単語を優先）の有効/無効を設定します。
+// 難易度ごとの文字数の範囲は変わりません。
+func (g *ChallengeGenerator) SetAdaptive(enabled bool) {
+	g.adaptive = enabled
+}
+
 // Generate はチャレンジを生成します。
+// ローマ字モードで日本語辞書が使えない場合は英単語チャレンジにフォールバックします。
 
 func (g *ChallengeGenerator) Generate(difficulty Difficulty, timeLimit time.Duration) *Challenge {
+	if g.mode == InputModeRomaji {
+		if challenge := g.generateJapanese(difficulty, timeLimit); challenge != nil {
+			return challenge
+		}
+	}
+
 	var candidates []string
 
 	switch difficulty {
@@ -92,21 +193,96 @@ func (g *ChallengeGenerator) Generate(difficulty Difficulty, timeLimit time.Dura
 		return nil
 	}
 
+	text := g.selectCandidate(candidates, func(text string) string { return text })
+	g.lastText = text
+
+	return &Challenge{
+		Text:       text,
+		TimeLimit:  timeLimit,
+		Difficulty: difficulty,
+	}
+}
+
+// GenerateParry はパリィ用のチャレンジを生成します。
+// パリィ用の単語が辞書にない場合はEasyの単語から選びます。
+// パリィは素早い反応を問うため、入力方式によらず直接入力のチャレンジを返します。
+func (g *ChallengeGenerator) GenerateParry(timeLimit time.Duration) *Challenge {
+	candidates := g.dictionary.Parry
+	if len(candidates) == 0 {
+		candidates = g.dictionary.Easy
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
 	text := g.selectWithoutDuplication(candidates)
 	g.lastText = text
 
+	return &Challenge{
+		Text:       text,
+		TimeLimit:  timeLimit,
+		Difficulty: DifficultyEasy,
+	}
+}
+
+// generateJapanese はローマ字モード用のチャレンジを生成します。
+func (g *ChallengeGenerator) generateJapanese(difficulty Difficulty, timeLimit time.Duration) *Challenge {
+	dict := g.dictionary.Japanese
+	if dict == nil {
+		return nil
+	}
+
+	var words []JapaneseWord
+	var minLen, maxLen int
+	switch difficulty {
+	case DifficultyEasy:
+		words, minLen, maxLen = dict.Easy, 2, 4
+	case DifficultyMedium:
+		words, minLen, maxLen = dict.Medium, 5, 8
+	case DifficultyHard:
+		words, minLen, maxLen = dict.Hard, 9, 16
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	candidates := make([]string, 0, len(words))
+	readings := make(map[string]string, len(words))
+	for _, word := range words {
+		kanaLen := len(SplitKanaUnits(word.Reading))
+		if kanaLen >= minLen && kanaLen <= maxLen {
+			candidates = append(candidates, word.Text)
+			readings[word.Text] = word.Reading
+		}
+	}
+	if len(candidates) == 0 {
+		// フォールバック：長さ条件に合う単語がない場合は全単語から選ぶ
+		for _, word := range words {
+			candidates = append(candidates, word.Text)
+			readings[word.Text] = word.Reading
+		}
+	}
+
+	text := g.selectCandidate(candidates, func(text string) string {
+		return NewRomajiMatcher(readings[text]).Guide()
+	})
+	g.lastText = text
+
 	return &Challenge{
 		Text:       text,
 		TimeLimit:  timeLimit,
 		Difficulty: difficulty,
+		Reading:    readings[text],
+		Mode:       InputModeRomaji,
 	}
 }
 
-// filterByLength は指定された長さ範囲の単語をフィルタリングします。
+// filterByLength は指定された長さ範囲（書記素クラスタ数）の単語をフィルタリングします。
 func (g *ChallengeGenerator) filterByLength(words []string, minLen, maxLen int) []string {
 	result := make([]string, 0)
 	for _, word := range words {
-		if len(word) >= minLen && len(word) <= maxLen {
+		length := GraphemeCount(word)
+		if length >= minLen && length <= maxLen {
 			result = append(result, word)
 		}
 	}
@@ -126,6 +302,44 @@ func (g *ChallengeGenerator) getAllCandidates(difficulty Difficulty) []string {
 	return nil
 }
 
+// selectCandidate は出題方式に応じて候補からテキストを選択します。
+// keysOfは単語を入力する際のキー列（ローマ字モードではローマ字表記）を返します。
+func (g *ChallengeGenerator) selectCandidate(candidates []string, keysOf func(string) string) string {
+	if g.adaptive && g.keyStats != nil {
+		return g.selectWeighted(candidates, keysOf)
+	}
+	return g.selectWithoutDuplication(candidates)
+}
+
+// selectWeighted は苦手キーを含む単語ほど選ばれやすい重み付きで、前回と異なるテキストを選択します。
+func (g *ChallengeGenerator) selectWeighted(candidates []string, keysOf func(string) string) string {
+	pool := make([]string, 0, len(candidates))
+	for _, text := range candidates {
+		if text != g.lastText {
+			pool = append(pool, text)
+		}
+	}
+	if len(pool) == 0 {
+		return candidates[0]
+	}
+
+	weights := make([]float64, len(pool))
+	total := 0.0
+	for i, text := range pool {
+		weights[i] = 1 + g.keyStats.WordWeakness(keysOf(text))*adaptiveWeightScale
+		total += weights[i]
+	}
+
+	pick := g.rng.Float64() * total
+	for i, w := range weights {
+		pick -= w
+		if pick < 0 {
+			return pool[i]
+		}
+	}
+	return pool[len(pool)-1]
+}
+
 // selectWithoutDuplication は前回と異なるテキストを選択します。
 
 func (g *ChallengeGenerator) selectWithoutDuplication(candidates []string) string {
@@ -191,7 +405,7 @@ type ChallengeState struct {
 
 	StartTime time.Time
 
-	// CurrentIndex は現在の入力位置です。
+	// CurrentIndex は現在の入力位置（入力単位のインデックス）です。
 	CurrentIndex int
 
 	// CorrectCount は正解入力数です。
@@ -202,6 +416,20 @@ type ChallengeState struct {
 
 	// Mistakes は誤入力の位置リストです。
 	Mistakes []int
+
+	// Keystrokes は1打ごとの入力記録です。
+	Keystrokes []KeystrokeEvent
+
+	// matcher は入力方式に応じた1打判定です。
+	matcher inputMatcher
+
+	// romaji はローマ字モードの入力判定です。
+	romaji *RomajiMatcher
+}
+
+// Romaji はローマ字モードの入力判定を返します（ローマ字モード以外ではnil）。
+func (s *ChallengeState) Romaji() *RomajiMatcher {
+	return s.romaji
 }
 
 // TypingResult はタイピング評価結果を表す構造体です。
@@ -229,60 +457,129 @@ type TypingResult struct {
 
 	// Timeout はタイムアウトしたかどうかです。
 	Timeout bool
+
+	// Keystrokes は1打ごとの入力記録です。
+	Keystrokes []KeystrokeEvent
+
+	// RawWPM はミスを含む全打鍵数から算出したWPMです。
+	RawWPM float64
+
+	// NetWPM はRawWPMから1分あたりのミス数を差し引いたWPMです。
+	NetWPM float64
+
+	// Consistency は2打目以降の打鍵間隔の標準偏差です（小さいほどリズムが安定）。
+	Consistency time.Duration
+
+	// SlowestBigrams は平均所要時間の長いバイグラムです（長い順）。
+	SlowestBigrams []BigramTiming
 }
 
 // Evaluator はタイピング評価を担当する構造体です。
 
-type Evaluator struct{}
+type Evaluator struct {
+	// clock は経過時間の計測に使う時計です。
+	clock clock.Clock
+}
 
 // NewEvaluator は新しいEvaluatorを作成します。
 func NewEvaluator() *Evaluator {
-	return &Evaluator{}
+	return &Evaluator{
+		clock: clock.NewSystemClock(),
+	}
+}
+
+// SetClock は経過時間の計測に使う時計を設定します。
+func (e *Evaluator) SetClock(c clock.Clock) {
+	e.clock = c
 }
 
 // StartChallenge はチャレンジを開始します。
 
 func (e *Evaluator) StartChallenge(challenge *Challenge) *ChallengeState {
-	return &ChallengeState{
+	state := &ChallengeState{
 		Challenge:       challenge,
-		StartTime:       time.Now(),
+		StartTime:       e.clock.Now(),
 		CurrentIndex:    0,
 		CorrectCount:    0,
 		TotalInputCount: 0,
 		Mistakes:        make([]int, 0),
+		Keystrokes:      make([]KeystrokeEvent, 0),
 	}
+	if challenge.Mode == InputModeRomaji {
+		state.romaji = NewRomajiMatcher(challenge.Reading)
+		state.romaji.SetCaseSensitive(challenge.Disruption.CaseSensitive)
+		state.matcher = state.romaji
+	} else {
+		state.matcher = newGraphemeMatcher(challenge.Text)
+	}
+	return state
 }
 
 // ProcessInput は入力を処理します。
+// 正解数・総入力数・誤入力位置は入力単位（書記素クラスタまたはかな）で数えます。
 
 func (e *Evaluator) ProcessInput(state *ChallengeState, input rune) *ChallengeState {
-	if state.CurrentIndex >= len(state.Challenge.Text) {
+	matcher := e.matcherFor(state)
+	if matcher.IsComplete() {
 		return state // 既に完了
 	}
 
-	expectedChar := rune(state.Challenge.Text[state.CurrentIndex])
-	state.TotalInputCount++
-
-	if input == expectedChar {
-
-		state.CorrectCount++
-		state.CurrentIndex++
-	} else {
-
+	expected := matcher.Expected()
+	accepted, completed := matcher.Input(input)
+	state.recordKeystroke(expected, input, accepted, e.clock.Now())
+	if !accepted {
+		state.TotalInputCount++
 		state.Mistakes = append(state.Mistakes, state.CurrentIndex)
+		return state
 	}
 
+	state.CorrectCount += completed
+	state.TotalInputCount += completed
+	state.CurrentIndex = matcher.Index()
 	return state
 }
 
+// recordKeystroke は1打の入力記録を追加します。
+func (s *ChallengeState) recordKeystroke(expected, typed rune, correct bool, now time.Time) {
+	previous := s.StartTime
+	if n := len(s.Keystrokes); n > 0 {
+		previous = s.Keystrokes[n-1].Timestamp
+	}
+	s.Keystrokes = append(s.Keystrokes, KeystrokeEvent{
+		Timestamp: now,
+		Expected:  expected,
+		Typed:     typed,
+		Correct:   correct,
+		Interval:  now.Sub(previous),
+	})
+}
+
+// Accepts は入力が正解として受理されるかを判定します（状態は変更しません）。
+func (e *Evaluator) Accepts(state *ChallengeState, input rune) bool {
+	return e.matcherFor(state).Accepts(input)
+}
+
+// matcherFor はチャレンジ状態の1打判定を返します。
+// StartChallengeを経由せずに作成された状態では直接入力の判定を生成します。
+func (e *Evaluator) matcherFor(state *ChallengeState) inputMatcher {
+	if state.matcher == nil {
+		state.matcher = newGraphemeMatcher(state.Challenge.Text)
+	}
+	return state.matcher
+}
+
 // CompleteChallenge はチャレンジを完了し、結果を計算します。
 
 func (e *Evaluator) CompleteChallenge(state *ChallengeState) *TypingResult {
-	completionTime := time.Since(state.StartTime)
+	completionTime := clock.Since(e.clock, state.StartTime)
 
 	wpm := 0.0
 	if completionTime.Seconds() > 0 {
-		wpm = (float64(state.CorrectCount) / completionTime.Seconds() * 60) / 5
+		perWord := charsPerWord
+		if state.Challenge.Mode == InputModeRomaji {
+			perWord = kanaPerWord
+		}
+		wpm = (float64(state.CorrectCount) / completionTime.Seconds() * 60) / perWord
 	}
 
 	accuracy := 1.0
@@ -298,8 +595,8 @@ func (e *Evaluator) CompleteChallenge(state *ChallengeState) *TypingResult {
 		speedFactor = SpeedFactorMax
 	}
 
-	return &TypingResult{
-		Completed:      state.CurrentIndex >= len(state.Challenge.Text),
+	result := &TypingResult{
+		Completed:      state.CurrentIndex >= state.Challenge.Length(),
 		WPM:            wpm,
 		Accuracy:       accuracy,
 		SpeedFactor:    speedFactor,
@@ -307,32 +604,45 @@ func (e *Evaluator) CompleteChallenge(state *ChallengeState) *TypingResult {
 		CompletionTime: completionTime,
 		Timeout:        false,
 	}
+	applyKeystrokeMetrics(result, state.Keystrokes, completionTime)
+	return result
+}
+
+// applyKeystrokeMetrics は入力記録と詳細指標を結果に設定します。
+func applyKeystrokeMetrics(result *TypingResult, keystrokes []KeystrokeEvent, elapsed time.Duration) {
+	metrics := AnalyzeKeystrokes(keystrokes, elapsed)
+	result.Keystrokes = keystrokes
+	result.RawWPM = metrics.RawWPM
+	result.NetWPM = metrics.NetWPM
+	result.Consistency = metrics.Consistency
+	result.SlowestBigrams = metrics.SlowestBigrams
 }
 
 // IsTimeout は制限時間を超過したかを判定します。
 
 func (e *Evaluator) IsTimeout(state *ChallengeState) bool {
-	elapsed := time.Since(state.StartTime)
+	elapsed := clock.Since(e.clock, state.StartTime)
 	return elapsed >= state.Challenge.TimeLimit
 }
 
 // IsCompleted はチャレンジが完了したかを判定します。
 func (e *Evaluator) IsCompleted(state *ChallengeState) bool {
-	return state.CurrentIndex >= len(state.Challenge.Text)
+	return state.CurrentIndex >= state.Challenge.Length()
 }
 
 // GetProgress は入力進捗（0.0〜1.0）を返します。
 func (e *Evaluator) GetProgress(state *ChallengeState) float64 {
-	if len(state.Challenge.Text) == 0 {
+	length := state.Challenge.Length()
+	if length == 0 {
 		return 1.0
 	}
-	return float64(state.CurrentIndex) / float64(len(state.Challenge.Text))
+	return float64(state.CurrentIndex) / float64(length)
 }
 
 // GetRemainingTime は残り時間を返します。
 
 func (e *Evaluator) GetRemainingTime(state *ChallengeState) time.Duration {
-	elapsed := time.Since(state.StartTime)
+	elapsed := clock.Since(e.clock, state.StartTime)
 	remaining := state.Challenge.TimeLimit - elapsed
 	if remaining < 0 {
 		return 0
@@ -343,7 +653,7 @@ func (e *Evaluator) GetRemainingTime(state *ChallengeState) time.Duration {
 // GetTimeoutResult はタイムアウト時の結果を返します。
 
 func (e *Evaluator) GetTimeoutResult(state *ChallengeState) *TypingResult {
-	return &TypingResult{
+	result := &TypingResult{
 		Completed:      false,
 		WPM:            0,
 		Accuracy:       0,
@@ -352,4 +662,6 @@ func (e *Evaluator) GetTimeoutResult(state *ChallengeState) *TypingResult {
 		CompletionTime: state.Challenge.TimeLimit,
 		Timeout:        true,
 	}
+	applyKeystrokeMetrics(result, state.Keystrokes, state.Challenge.TimeLimit)
+	return result
 }
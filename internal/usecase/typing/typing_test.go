@@ -6,6 +6,8 @@ package typing
 import (
 	"testing"
 	"time"
+
+	"hirorocky/type-battle/internal/usecase/clock"
 )
 
 // ==================== タイピングチャレンジ生成テスト（Task 6.1） ====================
@@ -106,6 +108,33 @@ func TestGenerateChallenge_NoDuplication(t *testing.T) {
 	_ = challenge2
 }
 
+// TestGenerateParry はパリィ用の単語からチャレンジが生成されることをテストします。
+func TestGenerateParry(t *testing.T) {
+	dict := &Dictionary{
+		Easy:  []string{"cat"},
+		Parry: []string{"block"},
+	}
+	generator := NewChallengeGenerator(dict)
+	generator.SetInputMode(InputModeRomaji)
+
+	challenge := generator.GenerateParry(2 * time.Second)
+	if challenge == nil {
+		t.Fatal("チャレンジ生成に失敗")
+	}
+	if challenge.Text != "block" || challenge.TimeLimit != 2*time.Second {
+		t.Errorf("パリィ用の単語と制限時間で出題されるべき: %+v", challenge)
+	}
+	if challenge.Mode != InputModeDirect {
+		t.Error("パリィはローマ字モードでも直接入力で出題されるべき")
+	}
+
+	// パリィ用の単語がなければEasyの単語を使う
+	generator = NewChallengeGenerator(&Dictionary{Easy: []string{"cat"}})
+	if challenge := generator.GenerateParry(time.Second); challenge == nil || challenge.Text != "cat" {
+		t.Errorf("Easyの単語にフォールバックするべき: %+v", challenge)
+	}
+}
+
 // TestGenerateChallenge_TimeLimit はモジュール別制限時間設定をテストします。
 
 func TestGenerateChallenge_TimeLimit(t *testing.T) {
@@ -221,6 +250,40 @@ func TestEvaluator_CalculateWPM(t *testing.T) {
 	}
 }
 
+// TestEvaluator_ManualClock は注入した時計で経過時間が決定的に計測されることをテストします。
+func TestEvaluator_ManualClock(t *testing.T) {
+	c := clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	evaluator := NewEvaluator()
+	evaluator.SetClock(c)
+
+	state := evaluator.StartChallenge(&Challenge{Text: "hello", TimeLimit: 4 * time.Second})
+	for _, r := range "hello" {
+		c.Advance(400 * time.Millisecond)
+		state = evaluator.ProcessInput(state, r)
+	}
+
+	if remaining := evaluator.GetRemainingTime(state); remaining != 2*time.Second {
+		t.Errorf("残り時間: 期待 2s, 実際 %v", remaining)
+	}
+	if state.Keystrokes[1].Interval != 400*time.Millisecond {
+		t.Errorf("打鍵間隔: 期待 400ms, 実際 %v", state.Keystrokes[1].Interval)
+	}
+
+	result := evaluator.CompleteChallenge(state)
+	// WPM = (5文字 / 2秒 * 60) / 5 = 30 WPM
+	if result.WPM != 30 {
+		t.Errorf("WPM: 期待 30, 実際 %f", result.WPM)
+	}
+	if result.SpeedFactor != 2.0 {
+		t.Errorf("速度係数: 期待 2.0, 実際 %f", result.SpeedFactor)
+	}
+
+	c.Advance(2 * time.Second)
+	if !evaluator.IsTimeout(state) {
+		t.Error("制限時間ちょうどでタイムアウト判定されなかった")
+	}
+}
+
 // TestEvaluator_CalculateAccuracy は正確性計算をテストします。
 
 func TestEvaluator_CalculateAccuracy(t *testing.T) {